@@ -16,6 +16,8 @@ type appConfig struct {
 	LogLevel  string `mapstructure:"log_level"`
 	// the server port. Defaults to 8080
 	ServerPort int `mapstructure:"server_port"`
+	// the port of the gRPC server. The gRPC API is disabled if it is not set, it requires api_auth_key
+	GrpcPort int `mapstructure:"grpc_port"`
 	// the data source name (MongoURL) for connecting to the database. required.
 	MongoURL        string `mapstructure:"mongo_url"`
 	MongoDBPassword string `mapstructure:"mongo_password"`
@@ -34,6 +36,39 @@ type appConfig struct {
 
 	Tomochain map[string]string `mapstructure:"tomochain"`
 
+	// FiatCurrencies lists the fiat currencies values can be requested in. Defaults to USD only
+	FiatCurrencies []string `mapstructure:"fiat_currencies"`
+
+	// FxSource describes where exchange rates are loaded from (type: static or http, url)
+	FxSource map[string]string `mapstructure:"fx_source"`
+
+	// FxRates are the USD based rates used by the static fx source
+	FxRates map[string]float64 `mapstructure:"fx_rates"`
+
+	// Cluster enables the clustered mode (enabled: true), where the instance elected leader with a lease
+	// (lease_ttl in seconds, defaults to 15) runs the change streams and crons and the websocket
+	// messages are relayed to all the instances. instance_id defaults to the hostname and pid.
+	Cluster map[string]string `mapstructure:"cluster"`
+
+	// Fix enables the FIX 4.4 gateway on port, sender_comp_id is the SenderCompID of the gateway
+	// and defaults to TOMOX. The gateway is served over TLS with the PEM files cert_file and
+	// key_file, and the delegated keys of the accounts are encrypted with keystore_password.
+	Fix map[string]string `mapstructure:"fix"`
+
+	// Notifications configures the delivery of the notifications: the email channel is enabled by
+	// smtp_host (smtp_port, smtp_username, smtp_password, smtp_from), the Telegram channel by
+	// telegram_token (telegram_api_url). templates_dir overrides the builtin templates.
+	Notifications map[string]string `mapstructure:"notifications"`
+
+	// Auth configures the Sign-In with Ethereum sessions: jwt_secret signs the session tokens and
+	// is required in the clustered mode, domain is the domain of the login messages
+	// (localhost:<server_port> by default), nonce_ttl and session_ttl are in seconds (300 and 3600)
+	Auth map[string]string `mapstructure:"auth"`
+
+	// L3OrderBook configures the level 3 orderbook: id_secret anonymizes the order ids and is
+	// required in the clustered mode
+	L3OrderBook map[string]string `mapstructure:"l3_orderbook"`
+
 	Env string `mapstructure:"env"`
 }
 
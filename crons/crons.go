@@ -16,6 +16,10 @@ type CronService struct {
 	lendingPriceBoardService *services.LendingPriceBoardService
 	lendingPairService       *services.LendingPairService
 	lendingOhlcvService      *services.LendingOhlcvService
+	FiatService              *services.FiatService
+	MarketsService           *services.MarketsService
+	NotificationService      *services.NotificationService
+	PortfolioService         *services.PortfolioService
 }
 
 // NewCronService returns a new instance of CronService
@@ -28,6 +32,10 @@ func NewCronService(
 	lendingPriceBoardService *services.LendingPriceBoardService,
 	lendingPairService *services.LendingPairService,
 	lendingOhlcvService *services.LendingOhlcvService,
+	fiatService *services.FiatService,
+	marketsService *services.MarketsService,
+	notificationService *services.NotificationService,
+	portfolioService *services.PortfolioService,
 ) *CronService {
 	return &CronService{
 		OHLCVService:             ohlcvService,
@@ -38,6 +46,10 @@ func NewCronService(
 		lendingPriceBoardService: lendingPriceBoardService,
 		lendingPairService:       lendingPairService,
 		lendingOhlcvService:      lendingOhlcvService,
+		FiatService:              fiatService,
+		MarketsService:           marketsService,
+		NotificationService:      notificationService,
+		PortfolioService:         portfolioService,
 	}
 }
 
@@ -47,10 +59,13 @@ func (s *CronService) InitCrons() {
 	s.RelayService.UpdateRelayers()
 	c := cron.New()
 	s.startRelayerUpdate(c)
+	s.startFiatRateCron(c)
 	// s.tickStreamingCron(c)   // Cron to fetch OHLCV data
 	s.startPriceBoardCron(c) // Cron to fetch data for top price board
 	s.startMarketsCron(c)    // Cron to fetch markets data
 	s.startLendingPriceBoardCron(c)
 	s.startLendingMarketsCron(c)
+	s.startNotificationDigestCron(c) // Cron to send the notification digests
+	s.startPortfolioSnapshotCron(c)  // Cron to record the values of the portfolios
 	c.Start()
 }
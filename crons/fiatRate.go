@@ -0,0 +1,17 @@
+package crons
+
+import (
+	"github.com/robfig/cron"
+)
+
+// startFiatRateCron refreshes the exchange rates used to value prices and balances in fiat currencies
+func (s *CronService) startFiatRateCron(c *cron.Cron) {
+	s.FiatService.Refresh()
+	c.AddFunc("0 */10 * * * *", s.updateFiatRates())
+}
+
+func (s *CronService) updateFiatRates() func() {
+	return func() {
+		s.FiatService.Refresh()
+	}
+}
@@ -36,5 +36,19 @@ func (s *CronService) getMarketsData() func() {
 		id := utils.GetMarketsChannelID(ws.MarketsChannel)
 
 		ws.GetMarketSocket().BroadcastMessage(id, res)
+
+		for _, currency := range s.FiatService.Currencies() {
+			if currency == s.FiatService.DefaultCurrency() {
+				continue
+			}
+
+			res, err := s.MarketsService.GetMarketData(currency)
+			if err != nil {
+				log.Printf("%s", err)
+				continue
+			}
+
+			ws.GetMarketSocket().BroadcastMessage(utils.GetFiatChannelID(id, currency), res)
+		}
 	}
 }
@@ -0,0 +1,10 @@
+package crons
+
+import (
+	"github.com/robfig/cron"
+)
+
+// startNotificationDigestCron sends every minute the notification digests which are due
+func (s *CronService) startNotificationDigestCron(c *cron.Cron) {
+	c.AddFunc("0 * * * * *", s.NotificationService.FlushDigests)
+}
@@ -0,0 +1,10 @@
+package crons
+
+import (
+	"github.com/robfig/cron"
+)
+
+// startPortfolioSnapshotCron records every hour the values of the portfolios for the equity charts
+func (s *CronService) startPortfolioSnapshotCron(c *cron.Cron) {
+	c.AddFunc("0 0 * * * *", s.PortfolioService.TakeSnapshots)
+}
@@ -72,13 +72,16 @@ func (s *CronService) getPriceBoardData() func() {
 			if e != nil || usd == nil {
 				usd = big.NewFloat(0)
 			}
-			result := types.PriceBoardData{
-				Ticks:          ticks,
-				PriceUSD:       usd.String(),
-				LastTradePrice: lastTradePrice,
-			}
 
-			ws.GetPriceBoardSocket().BroadcastMessage(id, result)
+			for _, currency := range s.FiatService.Currencies() {
+				result, err := s.PriceBoardService.NewPriceBoardData(ticks, usd, lastTradePrice, currency)
+				if err != nil {
+					log.Printf("%s", err)
+					continue
+				}
+
+				ws.GetPriceBoardSocket().BroadcastMessage(utils.GetFiatChannelID(id, currency), result)
+			}
 		}
 	}
 }
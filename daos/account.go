@@ -268,27 +268,15 @@ func (dao *AccountDao) GetFavoriteTokens(owner common.Address) (map[common.Addre
 	return res[0].FavoriteTokens, nil
 }
 
-func (dao *AccountDao) AddFavoriteToken(owner, token common.Address) error {
+// ClearFavoriteTokens removes the favorite tokens of an account, they are migrated to the
+// watchlists of its preferences
+func (dao *AccountDao) ClearFavoriteTokens(owner common.Address) error {
 	q := bson.M{
 		"address": owner.Hex(),
 	}
 
 	updateQuery := bson.M{
-		"$set": bson.M{"favoriteTokens." + token.Hex(): true},
-	}
-
-	err := db.Update(dao.dbName, dao.collectionName, q, updateQuery)
-
-	return err
-}
-
-func (dao *AccountDao) DeleteFavoriteToken(owner, token common.Address) error {
-	q := bson.M{
-		"address": owner.Hex(),
-	}
-
-	updateQuery := bson.M{
-		"$set": bson.M{"favoriteTokens." + token.Hex(): false},
+		"$unset": bson.M{"favoriteTokens": ""},
 	}
 
 	err := db.Update(dao.dbName, dao.collectionName, q, updateQuery)
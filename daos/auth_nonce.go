@@ -0,0 +1,69 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// AuthNonceDao stores the nonces issued for the Sign-In with Ethereum logins until they are
+// used or expire
+type AuthNonceDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewAuthNonceDao returns a new instance of AuthNonceDao
+func NewAuthNonceDao() *AuthNonceDao {
+	dbName := app.Config.DBName
+	collection := "auth_nonces"
+
+	i := mgo.Index{
+		Key:         []string{"expiresAt"},
+		Background:  true,
+		ExpireAfter: time.Second,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i)
+	if err != nil {
+		panic(err)
+	}
+
+	return &AuthNonceDao{collection, dbName}
+}
+
+// Create stores a new nonce
+func (dao *AuthNonceDao) Create(n *types.AuthNonce) error {
+	err := db.Create(dao.dbName, dao.collectionName, n)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Consume removes a nonce, it returns false if the nonce does not exist or is expired
+func (dao *AuthNonceDao) Consume(nonce string) (bool, error) {
+	sc := db.Session.Copy()
+	defer sc.Close()
+
+	query := bson.M{"_id": nonce, "expiresAt": bson.M{"$gt": time.Now()}}
+	change := mgo.Change{Remove: true}
+
+	n := &types.AuthNonce{}
+	_, err := sc.DB(dao.dbName).C(dao.collectionName).Find(query).Apply(change, n)
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,215 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// ComplianceDao stores the blocked and restricted addresses, the expired entries are removed
+// by the compliance service so that their expiry is audited
+type ComplianceDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewComplianceDao returns a new instance of ComplianceDao
+func NewComplianceDao() *ComplianceDao {
+	dbName := app.Config.DBName
+	collection := "compliance_entries"
+
+	indexes := []mgo.Index{
+		{
+			Key:    []string{"address"},
+			Unique: true,
+		},
+		{
+			Key:        []string{"expiresAt"},
+			Background: true,
+		},
+	}
+
+	// the expiry of the entries removed by the former TTL index would not be audited
+	current, err := db.Session.DB(dbName).C(collection).Indexes()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, i := range current {
+		if i.ExpireAfter > 0 {
+			err := db.Session.DB(dbName).C(collection).DropIndexName(i.Name)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	for _, i := range indexes {
+		err := db.Session.DB(dbName).C(collection).EnsureIndex(i)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return &ComplianceDao{collection, dbName}
+}
+
+// Upsert replaces the entry of the address of e
+func (dao *ComplianceDao) Upsert(e *types.ComplianceEntry) error {
+	e.UpdatedAt = time.Now()
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = e.UpdatedAt
+	}
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, bson.M{"address": e.Address.Hex()}, e)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByAddress returns the entry of an address, nil if it does not have one
+func (dao *ComplianceDao) GetByAddress(addr common.Address) (*types.ComplianceEntry, error) {
+	res := &types.ComplianceEntry{}
+
+	err := db.GetOne(dao.dbName, dao.collectionName, bson.M{"address": addr.Hex()}, res)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetAll returns the entries, the latest updated first
+func (dao *ComplianceDao) GetAll() ([]*types.ComplianceEntry, error) {
+	res := []*types.ComplianceEntry{}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, bson.M{}, []string{"-updatedAt"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Delete removes the entry of an address, it returns false if the address does not have one
+func (dao *ComplianceDao) Delete(addr common.Address) (bool, error) {
+	sc := db.Session.Copy()
+	defer sc.Close()
+
+	err := sc.DB(dao.dbName).C(dao.collectionName).Remove(bson.M{"address": addr.Hex()})
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetExpired returns the entries expired at now
+func (dao *ComplianceDao) GetExpired(now time.Time) ([]*types.ComplianceEntry, error) {
+	res := []*types.ComplianceEntry{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{"expiresAt": bson.M{"$lte": now}}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteExpired removes an expired entry, it returns false if the entry was already removed
+// or replaced
+func (dao *ComplianceDao) DeleteExpired(e *types.ComplianceEntry) (bool, error) {
+	sc := db.Session.Copy()
+	defer sc.Close()
+
+	err := sc.DB(dao.dbName).C(dao.collectionName).Remove(bson.M{
+		"address":   e.Address.Hex(),
+		"expiresAt": e.ExpiresAt,
+	})
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ComplianceAuditDao stores the audit log of the blocks, unblocks and expiries of the addresses
+type ComplianceAuditDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewComplianceAuditDao returns a new instance of ComplianceAuditDao
+func NewComplianceAuditDao() *ComplianceAuditDao {
+	dbName := app.Config.DBName
+	collection := "compliance_audit_log"
+
+	index := mgo.Index{
+		Key: []string{"address", "-createdAt"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &ComplianceAuditDao{collection, dbName}
+}
+
+// Create inserts a new entry of the audit log
+func (dao *ComplianceAuditDao) Create(a *types.ComplianceAuditEntry) error {
+	a.ID = bson.NewObjectId()
+	a.CreatedAt = time.Now()
+
+	err := db.Create(dao.dbName, dao.collectionName, a)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Query returns the entries of the audit log of an address, of all addresses if addr is the
+// zero address, the latest first
+func (dao *ComplianceAuditDao) Query(addr common.Address, offset, size int) (*types.ComplianceAuditRes, error) {
+	q := bson.M{}
+	if (addr != common.Address{}) {
+		q["address"] = addr.Hex()
+	}
+
+	res := &types.ComplianceAuditRes{Entries: []*types.ComplianceAuditEntry{}}
+
+	total, err := db.GetEx(dao.dbName, dao.collectionName, q, []string{"-createdAt"}, offset, size, &res.Entries)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	res.Total = total
+	return res, nil
+}
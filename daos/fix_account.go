@@ -0,0 +1,86 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// FixAccountDao stores the accounts of the FIX sessions
+type FixAccountDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewFixAccountDao returns a new instance of FixAccountDao
+func NewFixAccountDao() *FixAccountDao {
+	dbName := app.Config.DBName
+	collection := "fix_accounts"
+
+	index := mgo.Index{
+		Key:    []string{"senderCompId"},
+		Unique: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &FixAccountDao{collection, dbName}
+}
+
+// Create inserts a new account, the SenderCompID must be unique
+func (dao *FixAccountDao) Create(a *types.FixAccount) error {
+	a.ID = bson.NewObjectId()
+	a.CreatedAt = time.Now()
+
+	err := db.Create(dao.dbName, dao.collectionName, a)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetAll returns all the accounts
+func (dao *FixAccountDao) GetAll() ([]*types.FixAccount, error) {
+	res := []*types.FixAccount{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetBySenderCompID returns the account of a session, nil if it does not exist
+func (dao *FixAccountDao) GetBySenderCompID(senderCompID string) (*types.FixAccount, error) {
+	res := []*types.FixAccount{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{"senderCompId": senderCompID}, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
+
+// Delete removes the account of a session
+func (dao *FixAccountDao) Delete(senderCompID string) error {
+	sc := db.Session.Copy()
+	defer sc.Close()
+
+	return sc.DB(dao.dbName).C(dao.collectionName).Remove(bson.M{"senderCompId": senderCompID})
+}
@@ -0,0 +1,71 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// LeaseDao stores the leases used to elect the leader of a cluster of SDK instances
+type LeaseDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewLeaseDao returns a new instance of LeaseDao
+func NewLeaseDao() *LeaseDao {
+	dbName := app.Config.DBName
+	collection := "leases"
+	return &LeaseDao{collection, dbName}
+}
+
+// Acquire takes the lease for owner until now + ttl if it is free or expired, or renews it if owner
+// already holds it. It returns false if the lease is held by another owner.
+func (dao *LeaseDao) Acquire(name, owner string, ttl time.Duration) (bool, error) {
+	sc := db.Session.Copy()
+	defer sc.Close()
+
+	now := time.Now()
+	query := bson.M{
+		"_id": name,
+		"$or": []bson.M{
+			{"owner": owner},
+			{"expiresAt": bson.M{"$lt": now}},
+		},
+	}
+
+	change := mgo.Change{
+		Update:    bson.M{"$set": bson.M{"owner": owner, "expiresAt": now.Add(ttl)}},
+		Upsert:    true,
+		ReturnNew: true,
+	}
+
+	lease := &types.Lease{}
+	_, err := sc.DB(dao.dbName).C(dao.collectionName).Find(query).Apply(change, lease)
+	if mgo.IsDup(err) {
+		// the lease exists and is held by another owner, the upsert conflicts on _id
+		return false, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return lease.Owner == owner, nil
+}
+
+// Release frees the lease if owner holds it
+func (dao *LeaseDao) Release(name, owner string) error {
+	query := bson.M{"_id": name, "owner": owner}
+	err := db.RemoveItem(dao.dbName, dao.collectionName, query)
+	if err != nil && err != mgo.ErrNotFound {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -343,6 +343,8 @@ type LendingOrderMsg struct {
 	LendingID       hexutil.Uint64 `json:"lendingID,omitempty"`
 	LendingTradeID  hexutil.Uint64 `json:"tradeId,omitempty"`
 	AutoTopUp       bool           `json:"autoTopUp,omitempty"`
+	// SignatureType is EIP712 when the order or the action is signed as typed data
+	SignatureType string `json:"signatureType,omitempty"`
 	// Signature values
 	V hexutil.Big `json:"v" gencodec:"required"`
 	R hexutil.Big `json:"r" gencodec:"required"`
@@ -381,6 +383,7 @@ func (dao *LendingOrderDao) AddNewLendingOrder(o *types.LendingOrder) error {
 		Side:            o.Side,
 		Type:            o.Type,
 		Hash:            o.Hash,
+		SignatureType:   o.SignatureType,
 		V:               hexutil.Big(*V),
 		R:               hexutil.Big(*R),
 		S:               hexutil.Big(*S),
@@ -427,6 +430,7 @@ func (dao *LendingOrderDao) CancelLendingOrder(o *types.LendingOrder) error {
 		Term:            hexutil.Uint64(o.Term),
 		Interest:        hexutil.Uint64(o.Interest),
 		RelayerAddress:  o.RelayerAddress,
+		SignatureType:   o.SignatureType,
 		V:               hexutil.Big(*V),
 		R:               hexutil.Big(*R),
 		S:               hexutil.Big(*S),
@@ -470,6 +474,7 @@ func (dao *LendingOrderDao) RepayLendingOrder(o *types.LendingOrder) error {
 		LendingTradeID: hexutil.Uint64(o.LendingTradeID),
 		RelayerAddress: o.RelayerAddress,
 		Type:           o.Type,
+		SignatureType:  o.SignatureType,
 		V:              hexutil.Big(*V),
 		R:              hexutil.Big(*R),
 		S:              hexutil.Big(*S),
@@ -514,6 +519,7 @@ func (dao *LendingOrderDao) TopupLendingOrder(o *types.LendingOrder) error {
 		RelayerAddress: o.RelayerAddress,
 		Quantity:       hexutil.Big(*o.Quantity),
 		Type:           o.Type,
+		SignatureType:  o.SignatureType,
 		V:              hexutil.Big(*V),
 		R:              hexutil.Big(*R),
 		S:              hexutil.Big(*S),
@@ -688,12 +694,22 @@ func (dao *LendingOrderDao) GetLendingOrders(lendingSpec types.LendingSpec, sort
 	}
 	var res types.LendingRes
 	lendings := []*types.LendingOrder{}
-	c, err := db.GetEx(dao.dbName, dao.collectionName, q, sort, offset, size, &lendings)
+	var c int
+	var err error
+	if lendingSpec.Cursor != nil {
+		c, err = db.GetPage(dao.dbName, dao.collectionName, q, lendingSpec.Cursor, size, &lendings)
+	} else {
+		c, err = db.GetEx(dao.dbName, dao.collectionName, q, sort, offset, size, &lendings)
+	}
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 	res.Total = c
+	if lendingSpec.Cursor != nil && len(lendings) > 0 {
+		last := lendings[len(lendings)-1]
+		res.NextCursor = lendingSpec.Cursor.Next(len(lendings), size, last.CreatedAt, last.ID)
+	}
 	for i := range lendings {
 		lendings[i].Signature = nil
 	}
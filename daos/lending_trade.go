@@ -339,6 +339,40 @@ func (dao *LendingTradeDao) GetLendingTradesUserHistory(a common.Address, lendin
 	return &res, nil
 }
 
+// StreamUserLendingTrades calls fn on each lending trade of a user and a relayer open before to
+// and updated since from, unix timestamps in seconds, from the oldest to the most recent
+func (dao *LendingTradeDao) StreamUserLendingTrades(a, relayer common.Address, from, to int64, fn func(*types.LendingTrade) error) error {
+	q := bson.M{
+		"createdAt": bson.M{"$lt": time.Unix(to, 0)},
+		"updatedAt": bson.M{"$gte": time.Unix(from, 0)},
+		"$and": []bson.M{
+			{
+				"$or": []bson.M{
+					{"investor": a.Hex()},
+					{"borrower": a.Hex()},
+				},
+			},
+			{
+				"$or": []bson.M{
+					{"investingRelayer": relayer.Hex()},
+					{"borrowingRelayer": relayer.Hex()},
+				},
+			},
+		},
+	}
+
+	trade := &types.LendingTrade{}
+	err := db.Iterate(dao.dbName, dao.collectionName, q, []string{"+createdAt", "+_id"}, trade, func() error {
+		return fn(trade)
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 // GetLendingTrades get user lending trade
 func (dao *LendingTradeDao) GetLendingTrades(lendingtradeSpec *types.LendingTradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.LendingTradeRes, error) {
 	q := bson.M{}
@@ -364,12 +398,22 @@ func (dao *LendingTradeDao) GetLendingTrades(lendingtradeSpec *types.LendingTrad
 	}
 	var res types.LendingTradeRes
 	trades := []*types.LendingTrade{}
-	c, err := db.GetEx(dao.dbName, dao.collectionName, q, sortedBy, pageOffset, pageSize, &trades)
+	var c int
+	var err error
+	if lendingtradeSpec.Cursor != nil {
+		c, err = db.GetPage(dao.dbName, dao.collectionName, q, lendingtradeSpec.Cursor, pageSize, &trades)
+	} else {
+		c, err = db.GetEx(dao.dbName, dao.collectionName, q, sortedBy, pageOffset, pageSize, &trades)
+	}
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 	res.Total = c
 	res.LendingTrades = trades
+	if lendingtradeSpec.Cursor != nil && len(trades) > 0 {
+		last := trades[len(trades)-1]
+		res.NextCursor = lendingtradeSpec.Cursor.Next(len(trades), pageSize, last.CreatedAt, last.ID)
+	}
 	return &res, nil
 }
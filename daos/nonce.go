@@ -0,0 +1,139 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// NonceReservationDao stores the nonces reserved for the addresses, so that the instances of a
+// cluster hand out each nonce once. The expired nonces are removed by a TTL index.
+type NonceReservationDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewNonceReservationDao returns a new instance of NonceReservationDao
+func NewNonceReservationDao() *NonceReservationDao {
+	dbName := app.Config.DBName
+	collection := "nonce_reservations"
+
+	indexes := []mgo.Index{
+		{
+			Key:         []string{"expiresAt"},
+			Background:  true,
+			ExpireAfter: time.Second,
+		},
+		{
+			Key: []string{"kind", "address", "nonce"},
+		},
+	}
+
+	for _, i := range indexes {
+		err := db.Session.DB(dbName).C(collection).EnsureIndex(i)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return &NonceReservationDao{collection, dbName}
+}
+
+// GetTaken returns the nonces of a kind of an address from count which are not expired
+func (dao *NonceReservationDao) GetTaken(kind string, addr common.Address, count uint64) (map[uint64]bool, error) {
+	query := bson.M{
+		"kind":      kind,
+		"address":   addr.Hex(),
+		"nonce":     bson.M{"$gte": int64(count)},
+		"expiresAt": bson.M{"$gt": time.Now()},
+	}
+
+	res := []types.ReservedNonce{}
+	err := db.Get(dao.dbName, dao.collectionName, query, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	taken := make(map[uint64]bool, len(res))
+	for _, n := range res {
+		taken[uint64(n.Nonce)] = true
+	}
+
+	return taken, nil
+}
+
+// Claim reserves a nonce of a kind of an address until expiresAt if it is free or expired. It
+// returns false if the nonce is held by another reservation or order.
+func (dao *NonceReservationDao) Claim(kind string, addr common.Address, nonce uint64, expiresAt time.Time) (bool, error) {
+	sc := db.Session.Copy()
+	defer sc.Close()
+
+	id := types.ReservedNonceID(kind, addr, nonce)
+	query := bson.M{"_id": id, "expiresAt": bson.M{"$lte": time.Now()}}
+
+	change := mgo.Change{
+		Update: bson.M{"$set": bson.M{
+			"kind":      kind,
+			"address":   addr.Hex(),
+			"nonce":     int64(nonce),
+			"status":    types.ReservedNoncePending,
+			"expiresAt": expiresAt,
+		}},
+		Upsert: true,
+	}
+
+	n := &types.ReservedNonce{}
+	_, err := sc.DB(dao.dbName).C(dao.collectionName).Find(query).Apply(change, n)
+	if mgo.IsDup(err) {
+		// the nonce is held and not expired, the upsert conflicts on _id
+		return false, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Release frees a pending nonce of a kind of an address
+func (dao *NonceReservationDao) Release(kind string, addr common.Address, nonce uint64) error {
+	sc := db.Session.Copy()
+	defer sc.Close()
+
+	query := bson.M{"_id": types.ReservedNonceID(kind, addr, nonce), "status": types.ReservedNoncePending}
+	err := sc.DB(dao.dbName).C(dao.collectionName).Remove(query)
+	if err != nil && err != mgo.ErrNotFound {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Confirm marks a nonce of a kind of an address as used by an order accepted by the node, it
+// stays taken until expiresAt even if its reservation expired meanwhile
+func (dao *NonceReservationDao) Confirm(kind string, addr common.Address, nonce uint64, expiresAt time.Time) error {
+	query := bson.M{"_id": types.ReservedNonceID(kind, addr, nonce)}
+	update := bson.M{"$set": bson.M{
+		"kind":      kind,
+		"address":   addr.Hex(),
+		"nonce":     int64(nonce),
+		"status":    types.ReservedNonceConfirmed,
+		"expiresAt": expiresAt,
+	}}
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -46,7 +46,7 @@ func NewNotificationDao() *NotificationDao {
 // It accepts 1 or more notifications as input.
 // All the notifications are inserted in one query itself.
 func (dao *NotificationDao) Create(notifications ...*types.Notification) ([]*types.Notification, error) {
-	y := make([]interface{}, len(notifications))
+	y := make([]interface{}, 0, len(notifications))
 
 	for _, notification := range notifications {
 		notification.ID = bson.NewObjectId()
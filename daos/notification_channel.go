@@ -0,0 +1,211 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// NotificationChannelDao stores the delivery channels registered by the users
+type NotificationChannelDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewNotificationChannelDao returns a new instance of NotificationChannelDao
+func NewNotificationChannelDao() *NotificationChannelDao {
+	dbName := app.Config.DBName
+	collection := "notification_channels"
+
+	index := mgo.Index{
+		Key:    []string{"userAddress", "kind", "target"},
+		Unique: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &NotificationChannelDao{collection, dbName}
+}
+
+// Create inserts a new channel
+func (dao *NotificationChannelDao) Create(c *types.NotificationChannel) error {
+	c.ID = bson.NewObjectId()
+	c.CreatedAt = time.Now()
+
+	err := db.Create(dao.dbName, dao.collectionName, c)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByUserAddress returns the channels of a user
+func (dao *NotificationChannelDao) GetByUserAddress(addr common.Address) ([]*types.NotificationChannel, error) {
+	res := []*types.NotificationChannel{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{"userAddress": addr.Hex()}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByID returns a channel of a user, nil if the user has no such channel
+func (dao *NotificationChannelDao) GetByID(id bson.ObjectId, addr common.Address) (*types.NotificationChannel, error) {
+	res := &types.NotificationChannel{}
+
+	err := db.GetOne(dao.dbName, dao.collectionName, bson.M{"_id": id, "userAddress": addr.Hex()}, res)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// MarkVerified marks a channel as verified and removes its verification code
+func (dao *NotificationChannelDao) MarkVerified(id bson.ObjectId) error {
+	return db.Update(dao.dbName, dao.collectionName, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"verified": true},
+		"$unset": bson.M{"verificationCode": "", "verificationExpiresAt": ""},
+	})
+}
+
+// Delete removes a channel of a user, it returns mgo.ErrNotFound if the user has no such channel
+func (dao *NotificationChannelDao) Delete(id bson.ObjectId, addr common.Address) error {
+	return db.RemoveItem(dao.dbName, dao.collectionName, bson.M{"_id": id, "userAddress": addr.Hex()})
+}
+
+// NotificationPreferencesDao stores the delivery preferences of the users
+type NotificationPreferencesDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewNotificationPreferencesDao returns a new instance of NotificationPreferencesDao
+func NewNotificationPreferencesDao() *NotificationPreferencesDao {
+	dbName := app.Config.DBName
+	collection := "notification_preferences"
+
+	index := mgo.Index{
+		Key:    []string{"userAddress"},
+		Unique: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &NotificationPreferencesDao{collection, dbName}
+}
+
+// GetByUserAddress returns the preferences of a user, nil if the user has no preferences
+func (dao *NotificationPreferencesDao) GetByUserAddress(addr common.Address) (*types.NotificationPreferences, error) {
+	res := &types.NotificationPreferences{}
+
+	err := db.GetOne(dao.dbName, dao.collectionName, bson.M{"userAddress": addr.Hex()}, res)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Upsert replaces the preferences of a user
+func (dao *NotificationPreferencesDao) Upsert(p *types.NotificationPreferences) error {
+	p.UpdatedAt = time.Now()
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, bson.M{"userAddress": p.UserAddress.Hex()}, p)
+	return err
+}
+
+// NotificationDigestDao stores the notifications waiting for the digests of their channels
+type NotificationDigestDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewNotificationDigestDao returns a new instance of NotificationDigestDao
+func NewNotificationDigestDao() *NotificationDigestDao {
+	dbName := app.Config.DBName
+	collection := "notification_digest_items"
+
+	index := mgo.Index{
+		Key: []string{"channelId", "createdAt"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &NotificationDigestDao{collection, dbName}
+}
+
+// Create inserts the items of a digest
+func (dao *NotificationDigestDao) Create(items ...*types.NotificationDigestItem) error {
+	docs := make([]interface{}, 0, len(items))
+	for _, i := range items {
+		i.ID = bson.NewObjectId()
+		if i.CreatedAt.IsZero() {
+			i.CreatedAt = time.Now()
+		}
+
+		docs = append(docs, i)
+	}
+
+	if len(docs) == 0 {
+		return nil
+	}
+
+	err := db.Create(dao.dbName, dao.collectionName, docs...)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetAll returns the pending items, the oldest first
+func (dao *NotificationDigestDao) GetAll() ([]*types.NotificationDigestItem, error) {
+	res := []*types.NotificationDigestItem{}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, bson.M{}, []string{"createdAt"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteByIds removes the items of a sent digest
+func (dao *NotificationDigestDao) DeleteByIds(ids ...bson.ObjectId) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return db.RemoveAll(dao.dbName, dao.collectionName, bson.M{"_id": bson.M{"$in": ids}})
+}
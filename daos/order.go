@@ -539,7 +539,50 @@ func (dao *OrderDao) removeSignature(order *types.Order) {
 
 // GetOrders filter order
 func (dao *OrderDao) GetOrders(orderSpec types.OrderSpec, sort []string, offset int, size int) (*types.OrderRes, error) {
+	q := orderSpecQuery(orderSpec)
+	var res types.OrderRes
+	orders := []*types.Order{}
+	var c int
+	var err error
+	if orderSpec.Cursor != nil {
+		c, err = db.GetPage(dao.dbName, dao.collectionName, q, orderSpec.Cursor, size, &orders)
+	} else {
+		c, err = db.GetEx(dao.dbName, dao.collectionName, q, sort, offset, size, &orders)
+	}
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	res.Total = c
+	if orderSpec.Cursor != nil && len(orders) > 0 {
+		last := orders[len(orders)-1]
+		res.NextCursor = orderSpec.Cursor.Next(len(orders), size, last.CreatedAt, last.ID)
+	}
+	for i := range orders {
+		dao.removeSignature(orders[i])
+	}
+	res.Orders = orders
+	return &res, nil
+}
+
+// StreamOrders calls fn on each order matching the spec from the oldest to the most recent
+// without loading them all in memory, it stops at the first error returned by fn
+func (dao *OrderDao) StreamOrders(orderSpec types.OrderSpec, fn func(*types.Order) error) error {
+	q := orderSpecQuery(orderSpec)
+	order := &types.Order{}
+	err := db.Iterate(dao.dbName, dao.collectionName, q, []string{"+createdAt", "+_id"}, order, func() error {
+		dao.removeSignature(order)
+		return fn(order)
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
 
+func orderSpecQuery(orderSpec types.OrderSpec) bson.M {
 	q := bson.M{}
 	q["exchangeAddress"] = orderSpec.RelayerAddress.Hex()
 	if orderSpec.UserAddress != "" {
@@ -575,19 +618,8 @@ func (dao *OrderDao) GetOrders(orderSpec types.OrderSpec, sort []string, offset
 	if orderSpec.OrderHash != "" {
 		q["hash"] = orderSpec.OrderHash
 	}
-	var res types.OrderRes
-	orders := []*types.Order{}
-	c, err := db.GetEx(dao.dbName, dao.collectionName, q, sort, offset, size, &orders)
-	if err != nil {
-		logger.Error(err)
-		return nil, err
-	}
-	res.Total = c
-	for i := range orders {
-		dao.removeSignature(orders[i])
-	}
-	res.Orders = orders
-	return &res, nil
+
+	return q
 }
 
 // GetOpenOrdersByUserAddress function fetches list of open/partial filled orders from order collection based on user address.
@@ -964,6 +996,8 @@ type OrderMsg struct {
 	Side            string         `json:"side,omitempty"`
 	Type            string         `json:"type,omitempty"`
 	OrderID         hexutil.Uint64 `json:"orderid,omitempty"`
+	// SignatureType is EIP712 when the order or the cancel is signed as typed data
+	SignatureType string `json:"signatureType,omitempty"`
 	// Signature values
 	V hexutil.Big `json:"v" gencodec:"required"`
 	R hexutil.Big `json:"r" gencodec:"required"`
@@ -1002,6 +1036,7 @@ func (dao *OrderDao) AddNewOrder(o *types.Order, topic string) error {
 		Side:            o.Side,
 		Type:            o.Type,
 		Hash:            o.Hash,
+		SignatureType:   o.SignatureType,
 		V:               hexutil.Big(*V),
 		R:               hexutil.Big(*R),
 		S:               hexutil.Big(*S),
@@ -1045,6 +1080,7 @@ func (dao *OrderDao) CancelOrder(o *types.Order, topic string) error {
 		QuoteToken:      o.QuoteToken,
 		BaseToken:       o.BaseToken,
 		ExchangeAddress: o.ExchangeAddress,
+		SignatureType:   o.SignatureType,
 		V:               hexutil.Big(*V),
 		R:               hexutil.Big(*R),
 		S:               hexutil.Big(*S),
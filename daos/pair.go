@@ -214,6 +214,31 @@ func (dao *PairDao) GetByTokenAddress(baseToken, quoteToken common.Address) (*ty
 	return res[0], nil
 }
 
+// GetByTokenAddresses returns the pairs of the given token addresses, the pairs which are
+// not found are missing from the result
+func (dao *PairDao) GetByTokenAddresses(pairs []types.PairAddresses) ([]types.Pair, error) {
+	var res []types.Pair
+
+	if len(pairs) == 0 {
+		return res, nil
+	}
+
+	or := []bson.M{}
+	for _, p := range pairs {
+		or = append(or, bson.M{
+			"baseTokenAddress":  p.BaseToken.Hex(),
+			"quoteTokenAddress": p.QuoteToken.Hex(),
+		})
+	}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{"$or": or}, 0, 0, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // DeleteByToken delete token by contract address
 func (dao *PairDao) DeleteByToken(baseAddress common.Address, quoteAddress common.Address) error {
 	query := bson.M{"baseTokenAddress": baseAddress.Hex(), "quoteTokenAddress": quoteAddress.Hex()}
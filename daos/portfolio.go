@@ -0,0 +1,155 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// PortfolioDao stores the portfolios of the users, one per cost basis method
+type PortfolioDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewPortfolioDao returns a new instance of PortfolioDao
+func NewPortfolioDao() *PortfolioDao {
+	dbName := app.Config.DBName
+	collection := "portfolios"
+
+	index := mgo.Index{
+		Key:    []string{"userAddress", "method"},
+		Unique: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &PortfolioDao{collection, dbName}
+}
+
+// GetByUserAddress returns the portfolio of a user for a cost basis method, nil if it was not
+// computed yet
+func (dao *PortfolioDao) GetByUserAddress(addr common.Address, method string) (*types.Portfolio, error) {
+	res := &types.Portfolio{}
+	q := bson.M{"userAddress": addr.Hex(), "method": method}
+
+	err := db.GetOne(dao.dbName, dao.collectionName, q, res)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetAllByUserAddress returns the portfolios of a user for all the cost basis methods
+func (dao *PortfolioDao) GetAllByUserAddress(addr common.Address) ([]*types.Portfolio, error) {
+	res := []*types.Portfolio{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{"userAddress": addr.Hex()}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetAll returns all the portfolios
+func (dao *PortfolioDao) GetAll() ([]*types.Portfolio, error) {
+	res := []*types.Portfolio{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Upsert replaces the portfolio of a user for its cost basis method
+func (dao *PortfolioDao) Upsert(p *types.Portfolio) error {
+	p.UpdatedAt = time.Now()
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = p.UpdatedAt
+	}
+
+	q := bson.M{"userAddress": p.UserAddress.Hex(), "method": p.Method}
+
+	_, err := db.Upsert(dao.dbName, dao.collectionName, q, p)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// PortfolioSnapshotDao stores the time series of the values of the portfolios
+type PortfolioSnapshotDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewPortfolioSnapshotDao returns a new instance of PortfolioSnapshotDao
+func NewPortfolioSnapshotDao() *PortfolioSnapshotDao {
+	dbName := app.Config.DBName
+	collection := "portfolio_snapshots"
+
+	index := mgo.Index{
+		Key: []string{"userAddress", "method", "timestamp"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &PortfolioSnapshotDao{collection, dbName}
+}
+
+// Create inserts a new snapshot
+func (dao *PortfolioSnapshotDao) Create(s *types.PortfolioSnapshot) error {
+	s.ID = bson.NewObjectId()
+
+	err := db.Create(dao.dbName, dao.collectionName, s)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByUserAddress returns the snapshots of the portfolio of a user between from and to, unix
+// timestamps in seconds, oldest first. A zero to means until now.
+func (dao *PortfolioSnapshotDao) GetByUserAddress(addr common.Address, method string, from, to int64) ([]*types.PortfolioSnapshot, error) {
+	res := []*types.PortfolioSnapshot{}
+
+	timestamp := bson.M{"$gte": from}
+	if to != 0 {
+		timestamp["$lt"] = to
+	}
+
+	q := bson.M{"userAddress": addr.Hex(), "method": method, "timestamp": timestamp}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"timestamp"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
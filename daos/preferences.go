@@ -0,0 +1,85 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// UserPreferencesDao stores the versioned preferences of the users
+type UserPreferencesDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewUserPreferencesDao returns a new instance of UserPreferencesDao
+func NewUserPreferencesDao() *UserPreferencesDao {
+	dbName := app.Config.DBName
+	collection := "user_preferences"
+
+	index := mgo.Index{
+		Key:    []string{"userAddress"},
+		Unique: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &UserPreferencesDao{collection, dbName}
+}
+
+// GetByUserAddress returns the preferences of a user, nil if the user has no preferences
+func (dao *UserPreferencesDao) GetByUserAddress(addr common.Address) (*types.UserPreferences, error) {
+	res := &types.UserPreferences{}
+
+	err := db.GetOne(dao.dbName, dao.collectionName, bson.M{"userAddress": addr.Hex()}, res)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Save replaces the preferences of a user if they are still at version, the preferences are
+// inserted if version is 0. It returns types.ErrPreferencesVersion if the stored preferences
+// are at another version, otherwise the version of p is incremented.
+func (dao *UserPreferencesDao) Save(p *types.UserPreferences, version int64) error {
+	sc := db.Session.Copy()
+	defer sc.Close()
+
+	saved := *p
+	saved.Version = version + 1
+	saved.UpdatedAt = time.Now()
+
+	c := sc.DB(dao.dbName).C(dao.collectionName)
+
+	var err error
+	if version == 0 {
+		err = c.Insert(&saved)
+	} else {
+		err = c.Update(bson.M{"userAddress": p.UserAddress.Hex(), "version": version}, &saved)
+	}
+
+	if mgo.IsDup(err) || err == mgo.ErrNotFound {
+		return types.ErrPreferencesVersion
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	*p = saved
+	return nil
+}
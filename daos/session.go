@@ -6,6 +6,7 @@ import (
 	"github.com/globalsign/mgo"
 	"github.com/globalsign/mgo/bson"
 	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils"
 )
 
@@ -136,6 +137,40 @@ func (d *Database) GetEx(dbName, collection string, query interface{}, sort []st
 	return c, err
 }
 
+// GetPage is a wrapper for mgo.Find function paginated with a cursor on createdAt and _id
+// instead of an offset. It returns the total number of documents matching the query.
+func (d *Database) GetPage(dbName, collection string, query bson.M, cursor *types.Cursor, limit int, response interface{}) (count int, err error) {
+	sc := d.Session.Copy()
+	defer sc.Close()
+
+	c := sc.DB(dbName).C(collection)
+	count, err = c.Find(query).Count()
+	if err != nil {
+		return 0, err
+	}
+
+	q := bson.M{"$and": []bson.M{query, cursor.Query()}}
+	err = c.Find(q).Sort(cursor.Sort()...).Limit(limit).All(response)
+	return count, err
+}
+
+// Iterate is a wrapper for mgo.Iter, it calls fn after decoding each document matching the query
+// into result so that large results can be streamed without being held in memory
+func (d *Database) Iterate(dbName, collection string, query interface{}, sort []string, result interface{}, fn func() error) error {
+	sc := d.Session.Copy()
+	defer sc.Close()
+
+	iter := sc.DB(dbName).C(collection).Find(query).Sort(sort...).Iter()
+	for iter.Next(result) {
+		if err := fn(); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+
+	return iter.Close()
+}
+
 // GetSortOne is a wrapper for mgo.Find function with SORT function in pipeline.
 // It creates a copy of session initialized, sends query over this session
 // and returns the session to connection pool
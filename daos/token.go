@@ -184,6 +184,24 @@ func (dao *TokenDao) GetByAddress(addr common.Address) (*types.Token, error) {
 	return &resp[0], nil
 }
 
+// GetByAddresses returns the tokens with the given contract addresses
+func (dao *TokenDao) GetByAddresses(addrs []common.Address) ([]types.Token, error) {
+	hexes := []string{}
+	for _, a := range addrs {
+		hexes = append(hexes, a.Hex())
+	}
+
+	var res []types.Token
+	q := bson.M{"contractAddress": bson.M{"$in": hexes}}
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (dao *TokenDao) UpdateFiatPriceBySymbol(symbol string, price float64) error {
 	q := bson.M{"symbol": symbol}
 	update := bson.M{"$set": bson.M{"usd": fmt.Sprintf("%f", price)}}
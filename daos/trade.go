@@ -401,6 +401,33 @@ func (dao *TradeDao) GetSortedTradesByUserAddress(a, bt, qt common.Address, from
 	return res, nil
 }
 
+// GetUserTradesFrom returns the trades of a user created from a time, oldest first, except the
+// trades whose hashes are excluded
+func (dao *TradeDao) GetUserTradesFrom(a common.Address, from time.Time, excluded []common.Hash, limit int) ([]*types.Trade, error) {
+	hashes := make([]string, len(excluded))
+	for i, h := range excluded {
+		hashes[i] = h.Hex()
+	}
+
+	q := bson.M{
+		"createdAt": bson.M{"$gte": from},
+		"hash":      bson.M{"$nin": hashes},
+		"$or": []bson.M{
+			{"maker": a.Hex()},
+			{"taker": a.Hex()},
+		},
+	}
+
+	var res []*types.Trade
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"createdAt"}, 0, limit, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // GetByUserAddress fetches all the trades corresponding to a particular user address.
 func (dao *TradeDao) GetByUserAddress(a common.Address) ([]*types.Trade, error) {
 	var res []*types.Trade
@@ -540,35 +567,8 @@ func (dao *TradeDao) Drop() {
 
 // GetTrades filter trade
 func (dao *TradeDao) GetTrades(tradeSpec *types.TradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.TradeRes, error) {
-	q := bson.M{}
-
-	if tradeSpec.DateFrom != 0 || tradeSpec.DateTo != 0 {
-		dateFilter := bson.M{}
-		if tradeSpec.DateFrom != 0 {
-			dateFilter["$gte"] = time.Unix(tradeSpec.DateFrom, 0)
-		}
-		if tradeSpec.DateTo != 0 {
-			dateFilter["$lt"] = time.Unix(tradeSpec.DateTo, 0)
-		}
-		q["createdAt"] = dateFilter
-	}
-	if tradeSpec.BaseToken != "" {
-		q["baseToken"] = tradeSpec.BaseToken
-	}
-	if tradeSpec.QuoteToken != "" {
-		q["quoteToken"] = tradeSpec.QuoteToken
-	}
-
-	var res types.TradeRes
-	trades := []*types.Trade{}
-	c, err := db.GetEx(dao.dbName, dao.collectionName, q, sortedBy, pageOffset, pageSize, &trades)
-	if err != nil {
-		logger.Error(err)
-		return nil, err
-	}
-	res.Total = c
-	res.Trades = trades
-	return &res, nil
+	q := tradeSpecQuery(tradeSpec)
+	return dao.getTradeRes(q, tradeSpec.Cursor, sortedBy, pageOffset, pageSize)
 }
 
 // GetTradeByTime get range trade
@@ -591,8 +591,56 @@ func (dao *TradeDao) GetTradeByTime(dateFrom, dateTo int64, pageOffset int, page
 
 // GetTradesUserHistory get trade by user address
 func (dao *TradeDao) GetTradesUserHistory(a common.Address, tradeSpec *types.TradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.TradeRes, error) {
+	q := userTradeSpecQuery(a, tradeSpec)
+	return dao.getTradeRes(q, tradeSpec.Cursor, sortedBy, pageOffset, pageSize)
+}
+
+// StreamTrades calls fn on each trade matching the spec from the oldest to the most recent without
+// loading them all in memory. The trades are restricted to the ones of a user unless a is the zero address.
+func (dao *TradeDao) StreamTrades(a common.Address, tradeSpec *types.TradeSpec, fn func(*types.Trade) error) error {
+	q := tradeSpecQuery(tradeSpec)
+	if (a != common.Address{}) {
+		q = userTradeSpecQuery(a, tradeSpec)
+	}
+
+	trade := &types.Trade{}
+	err := db.Iterate(dao.dbName, dao.collectionName, q, []string{"+createdAt", "+_id"}, trade, func() error {
+		return fn(trade)
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
 
+func (dao *TradeDao) getTradeRes(q bson.M, cursor *types.Cursor, sortedBy []string, pageOffset int, pageSize int) (*types.TradeRes, error) {
+	var res types.TradeRes
+	trades := []*types.Trade{}
+	var c int
+	var err error
+	if cursor != nil {
+		c, err = db.GetPage(dao.dbName, dao.collectionName, q, cursor, pageSize, &trades)
+	} else {
+		c, err = db.GetEx(dao.dbName, dao.collectionName, q, sortedBy, pageOffset, pageSize, &trades)
+	}
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	res.Total = c
+	res.Trades = trades
+	if cursor != nil && len(trades) > 0 {
+		last := trades[len(trades)-1]
+		res.NextCursor = cursor.Next(len(trades), pageSize, last.CreatedAt, last.ID)
+	}
+	return &res, nil
+}
+
+func tradeSpecQuery(tradeSpec *types.TradeSpec) bson.M {
 	q := bson.M{}
+
 	if tradeSpec.DateFrom != 0 || tradeSpec.DateTo != 0 {
 		dateFilter := bson.M{}
 		if tradeSpec.DateFrom != 0 {
@@ -603,6 +651,18 @@ func (dao *TradeDao) GetTradesUserHistory(a common.Address, tradeSpec *types.Tra
 		}
 		q["createdAt"] = dateFilter
 	}
+	if tradeSpec.BaseToken != "" {
+		q["baseToken"] = tradeSpec.BaseToken
+	}
+	if tradeSpec.QuoteToken != "" {
+		q["quoteToken"] = tradeSpec.QuoteToken
+	}
+
+	return q
+}
+
+func userTradeSpecQuery(a common.Address, tradeSpec *types.TradeSpec) bson.M {
+	q := tradeSpecQuery(tradeSpec)
 	q["$and"] = []bson.M{
 		{
 			"$or": []bson.M{
@@ -617,20 +677,6 @@ func (dao *TradeDao) GetTradesUserHistory(a common.Address, tradeSpec *types.Tra
 			},
 		},
 	}
-	if tradeSpec.BaseToken != "" {
-		q["baseToken"] = tradeSpec.BaseToken
-	}
-	if tradeSpec.QuoteToken != "" {
-		q["quoteToken"] = tradeSpec.QuoteToken
-	}
-	var res types.TradeRes
-	trades := []*types.Trade{}
-	c, err := db.GetEx(dao.dbName, dao.collectionName, q, sortedBy, pageOffset, pageSize, &trades)
-	if err != nil {
-		logger.Error(err)
-		return nil, err
-	}
-	res.Total = c
-	res.Trades = trades
-	return &res, nil
+
+	return q
 }
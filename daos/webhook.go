@@ -0,0 +1,207 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// WebhookDao stores the webhooks registered by the relayers and their partners
+type WebhookDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewWebhookDao returns a new instance of WebhookDao
+func NewWebhookDao() *WebhookDao {
+	return &WebhookDao{"webhooks", app.Config.DBName}
+}
+
+// Create inserts a new webhook
+func (dao *WebhookDao) Create(w *types.Webhook) error {
+	w.ID = bson.NewObjectId()
+	w.CreatedAt = time.Now()
+	w.UpdatedAt = w.CreatedAt
+
+	err := db.Create(dao.dbName, dao.collectionName, w)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Update replaces the url, the filters and the status of a webhook
+func (dao *WebhookDao) Update(w *types.Webhook) error {
+	w.UpdatedAt = time.Now()
+
+	addresses := make([]string, len(w.Addresses))
+	for i, a := range w.Addresses {
+		addresses[i] = a.Hex()
+	}
+
+	update := bson.M{"$set": bson.M{
+		"url":       w.URL,
+		"events":    w.Events,
+		"addresses": addresses,
+		"active":    w.Active,
+		"updatedAt": w.UpdatedAt,
+	}}
+
+	return db.Update(dao.dbName, dao.collectionName, bson.M{"_id": w.ID}, update)
+}
+
+// GetAll returns all the webhooks
+func (dao *WebhookDao) GetAll() ([]*types.Webhook, error) {
+	res := []*types.Webhook{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByOwner returns the webhooks registered by a user
+func (dao *WebhookDao) GetByOwner(owner common.Address) ([]*types.Webhook, error) {
+	res := []*types.Webhook{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{"owner": owner.Hex()}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByID returns a webhook, nil if it does not exist
+func (dao *WebhookDao) GetByID(id bson.ObjectId) (*types.Webhook, error) {
+	res := &types.Webhook{}
+
+	err := db.GetByID(dao.dbName, dao.collectionName, id, res)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Delete removes a webhook
+func (dao *WebhookDao) Delete(id bson.ObjectId) error {
+	return db.RemoveItem(dao.dbName, dao.collectionName, bson.M{"_id": id})
+}
+
+// WebhookDeliveryDao stores the log of the webhook deliveries
+type WebhookDeliveryDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewWebhookDeliveryDao returns a new instance of WebhookDeliveryDao
+func NewWebhookDeliveryDao() *WebhookDeliveryDao {
+	dbName := app.Config.DBName
+	collection := "webhook_deliveries"
+
+	index := mgo.Index{
+		Key: []string{"webhookId", "-createdAt"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(index)
+	if err != nil {
+		panic(err)
+	}
+
+	return &WebhookDeliveryDao{collection, dbName}
+}
+
+// Create inserts a new delivery
+func (dao *WebhookDeliveryDao) Create(d *types.WebhookDelivery) error {
+	d.ID = bson.NewObjectId()
+	d.CreatedAt = time.Now()
+	d.UpdatedAt = d.CreatedAt
+
+	err := db.Create(dao.dbName, dao.collectionName, d)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStatus records the result of an attempt of a delivery
+func (dao *WebhookDeliveryDao) UpdateStatus(d *types.WebhookDelivery) error {
+	d.UpdatedAt = time.Now()
+
+	update := bson.M{"$set": bson.M{
+		"status":       d.Status,
+		"attempts":     d.Attempts,
+		"responseCode": d.ResponseCode,
+		"error":        d.Error,
+		"nextRetryAt":  d.NextRetryAt,
+		"updatedAt":    d.UpdatedAt,
+	}}
+
+	return db.Update(dao.dbName, dao.collectionName, bson.M{"_id": d.ID}, update)
+}
+
+// GetByID returns a delivery, nil if it does not exist
+func (dao *WebhookDeliveryDao) GetByID(id bson.ObjectId) (*types.WebhookDelivery, error) {
+	res := &types.WebhookDelivery{}
+
+	err := db.GetByID(dao.dbName, dao.collectionName, id, res)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Query returns the deliveries of a webhook matching the spec, the latest first
+func (dao *WebhookDeliveryDao) Query(spec *types.WebhookDeliverySpec, offset, size int) (*types.WebhookDeliveryRes, error) {
+	q := bson.M{"webhookId": spec.WebhookID}
+
+	if spec.Status != "" {
+		q["status"] = spec.Status
+	}
+
+	createdAt := bson.M{}
+	if spec.DateFrom != 0 {
+		createdAt["$gte"] = time.Unix(spec.DateFrom, 0)
+	}
+	if spec.DateTo != 0 {
+		createdAt["$lt"] = time.Unix(spec.DateTo, 0)
+	}
+	if len(createdAt) > 0 {
+		q["createdAt"] = createdAt
+	}
+
+	res := &types.WebhookDeliveryRes{Deliveries: []*types.WebhookDelivery{}}
+
+	total, err := db.GetEx(dao.dbName, dao.collectionName, q, []string{"-createdAt"}, offset, size, &res.Deliveries)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	res.Total = total
+	return res, nil
+}
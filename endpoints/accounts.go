@@ -3,27 +3,45 @@ package endpoints
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
 	"github.com/justinas/alice"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/middlewares"
 	"github.com/tomochain/tomox-sdk/types"
-	"github.com/tomochain/tomox-sdk/utils"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
+	"github.com/tomochain/tomox-sdk/ws"
 )
 
+// statementDefaultRange is the range of the statements without a from date, in seconds
+const statementDefaultRange = 365 * 24 * 60 * 60
+
 type AccountEndpoint struct {
-	AccountService interfaces.AccountService
+	AccountService   interfaces.AccountService
+	FiatService      interfaces.FiatService
+	StatementService interfaces.StatementService
+	RelayerService   interfaces.RelayerService
 }
 
 func ServeAccountResource(
 	r *mux.Router,
 	accountService interfaces.AccountService,
+	fiatService interfaces.FiatService,
+	statementService interfaces.StatementService,
+	relayerService interfaces.RelayerService,
+	authService interfaces.AuthService,
 ) {
 
-	e := &AccountEndpoint{AccountService: accountService}
+	e := &AccountEndpoint{
+		AccountService:   accountService,
+		FiatService:      fiatService,
+		StatementService: statementService,
+		RelayerService:   relayerService,
+	}
 
 	/*
 		r.Handle(
@@ -31,30 +49,22 @@ func ServeAccountResource(
 		).Methods("POST")
 	*/
 
+	signed := alice.New(middlewares.Authenticate(authService))
+
 	r.Handle(
-		"/api/account/favorite/{address}",
-		alice.New(middlewares.VerifySignature).Then(http.HandlerFunc(e.handleGetFavoriteTokens)),
+		"/api/account/{address}", http.HandlerFunc(e.handleGetAccount),
 	).Methods("GET")
 
-	/*
-		r.Handle(
-			"/api/account/favorite/add",
-			alice.New(middlewares.VerifySignature).Then(http.HandlerFunc(e.handleAddFavoriteToken)),
-		).Methods("POST")
-
-			r.Handle(
-				"/api/account/favorite/remove",
-				alice.New(middlewares.VerifySignature).Then(http.HandlerFunc(e.handleRemoveFavoriteToken)),
-			).Methods("POST")
-	*/
-
 	r.Handle(
-		"/api/account/{address}", http.HandlerFunc(e.handleGetAccount),
+		"/api/account/{address}/statement",
+		signed.Then(http.HandlerFunc(e.handleGetStatement)),
 	).Methods("GET")
 
 	r.Handle(
 		"/api/account/{address}/{token}", http.HandlerFunc(e.handleGetAccountTokenBalance),
 	).Methods("GET")
+
+	ws.RegisterChannel(ws.BalanceChannel, e.handleBalancesWebSocket)
 }
 
 func (e *AccountEndpoint) handleCreateAccount(w http.ResponseWriter, r *http.Request) {
@@ -99,6 +109,12 @@ func (e *AccountEndpoint) handleGetAccount(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	currency := r.URL.Query().Get("currency")
+	if currency != "" && !e.FiatService.IsSupported(currency) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid currency")
+		return
+	}
+
 	address := common.HexToAddress(addr)
 	a, err := e.AccountService.GetByAddress(address)
 	if err != nil {
@@ -111,6 +127,15 @@ func (e *AccountEndpoint) handleGetAccount(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if currency != "" {
+		err = e.FiatService.ConvertTokenBalances(a.TokenBalances, currency)
+		if err != nil {
+			logger.Error(err)
+			httputils.WriteError(w, fiatStatus(err), err.Error())
+			return
+		}
+	}
+
 	httputils.WriteJSON(w, http.StatusOK, a)
 }
 
@@ -127,6 +152,12 @@ func (e *AccountEndpoint) handleGetAccountTokenBalance(w http.ResponseWriter, r
 		httputils.WriteError(w, http.StatusBadRequest, "Invalid Token Address")
 	}
 
+	currency := r.URL.Query().Get("currency")
+	if currency != "" && !e.FiatService.IsSupported(currency) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid currency")
+		return
+	}
+
 	addr := common.HexToAddress(a)
 	tokenAddr := common.HexToAddress(t)
 
@@ -137,124 +168,127 @@ func (e *AccountEndpoint) handleGetAccountTokenBalance(w http.ResponseWriter, r
 		return
 	}
 
+	if currency != "" && b != nil {
+		err = e.FiatService.ConvertTokenBalances(map[common.Address]*types.TokenBalance{tokenAddr: b}, currency)
+		if err != nil {
+			logger.Error(err)
+			httputils.WriteError(w, fiatStatus(err), err.Error())
+			return
+		}
+	}
+
 	httputils.WriteJSON(w, http.StatusOK, b)
 }
 
-func (e *AccountEndpoint) handleGetFavoriteTokens(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-
-	addr := vars["address"]
-	if !common.IsHexAddress(addr) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+// handleGetStatement returns the statement of the signer between from and to, unix timestamps
+// in seconds, as JSON or as CSV with format=csv. The range defaults to the last year.
+func (e *AccountEndpoint) handleGetStatement(w http.ResponseWriter, r *http.Request) {
+	addr, ok := signerAddress(w, r)
+	if !ok {
 		return
 	}
 
-	address := common.HexToAddress(addr)
-
-	publicKeyBytes := common.Hex2Bytes(r.Header["Pubkey"][0])
-	publicAddress := utils.GetAddressFromPublicKey(publicKeyBytes)
+	v := r.URL.Query()
+	to := time.Now().Unix()
+	if v.Get("to") != "" {
+		t, err := strconv.ParseInt(v.Get("to"), 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid time to value")
+			return
+		}
+		to = t
+	}
 
-	if address != publicAddress {
-		httputils.WriteError(w, http.StatusUnauthorized, "Request is not sent from address's owner")
-		return
+	from := to - statementDefaultRange
+	if v.Get("from") != "" {
+		t, err := strconv.ParseInt(v.Get("from"), 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid time from value")
+			return
+		}
+		from = t
 	}
 
-	a, err := e.AccountService.GetFavoriteTokens(address)
-	if err != nil {
-		logger.Error(err)
-		httputils.WriteError(w, http.StatusInternalServerError, "")
+	if from >= to {
+		httputils.WriteError(w, http.StatusBadRequest, "from must be before to")
 		return
 	}
 
-	httputils.WriteJSON(w, http.StatusOK, a)
-}
-
-func (e *AccountEndpoint) handleAddFavoriteToken(w http.ResponseWriter, r *http.Request) {
-	var tr *types.FavoriteTokenRequest
-	decoder := json.NewDecoder(r.Body)
-
-	defer r.Body.Close()
-
-	err := decoder.Decode(&tr)
-	if err != nil {
-		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+	currency := v.Get("currency")
+	if currency != "" && !e.FiatService.IsSupported(currency) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid currency")
 		return
 	}
 
-	if !common.IsHexAddress(tr.Address) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+	format := v.Get("format")
+	if format != "" && format != "json" && format != types.ExportFormatCSV {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid format")
 		return
 	}
 
-	if !common.IsHexAddress(tr.Token) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid Token Address")
+	st, err := e.StatementService.GetStatement(addr, e.RelayerService.GetRelayerAddress(r), from, to, currency)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	address := common.HexToAddress(tr.Address)
-	tokenAddr := common.HexToAddress(tr.Token)
-
-	publicKeyBytes := common.Hex2Bytes(r.Header["Pubkey"][0])
-	publicAddress := utils.GetAddressFromPublicKey(publicKeyBytes)
-
-	if address != publicAddress {
-		httputils.WriteError(w, http.StatusUnauthorized, "Request is not sent from address's owner")
+	if format != types.ExportFormatCSV {
+		httputils.WriteJSON(w, http.StatusOK, st)
 		return
 	}
 
-	err = e.AccountService.AddFavoriteToken(address, tokenAddr)
-
+	exp, err := newExportWriter(w, format, "statement", types.StatementCSVHeader)
 	if err != nil {
 		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	httputils.WriteJSON(w, http.StatusOK, tokenAddr)
-}
-
-func (e *AccountEndpoint) handleRemoveFavoriteToken(w http.ResponseWriter, r *http.Request) {
-	var tr *types.FavoriteTokenRequest
-	decoder := json.NewDecoder(r.Body)
+	for _, l := range st.Lines {
+		if err := exp.Write(l, l.CSVRecord(st.Currency)); err != nil {
+			logger.Error(err)
+			break
+		}
+	}
 
-	defer r.Body.Close()
+	exp.Flush()
+}
 
-	err := decoder.Decode(&tr)
+// handleBalancesWebSocket subscribes the connection to the balances of an address, the
+// connection must be logged in with the address
+func (e *AccountEndpoint) handleBalancesWebSocket(input interface{}, c *ws.Client) error {
+	b, _ := json.Marshal(input)
+	var ev *types.WebsocketEvent
+	err := json.Unmarshal(b, &ev)
 	if err != nil {
-		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
-		return
+		return errors.InvalidPayload(err.Error())
 	}
-
-	if !common.IsHexAddress(tr.Address) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
-		return
-	}
-
-	if !common.IsHexAddress(tr.Token) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid Token Address")
-		return
+	if ev == nil {
+		return errors.InvalidPayload("empty payload")
 	}
 
-	address := common.HexToAddress(tr.Address)
-	tokenAddr := common.HexToAddress(tr.Token)
+	b, _ = json.Marshal(ev.Payload)
+	var addr string
 
-	publicKeyBytes := common.Hex2Bytes(r.Header["Pubkey"][0])
-	publicAddress := utils.GetAddressFromPublicKey(publicKeyBytes)
+	err = json.Unmarshal(b, &addr)
+	if err != nil {
+		return errors.InvalidPayload(err.Error())
+	}
 
-	if address != publicAddress {
-		httputils.WriteError(w, http.StatusUnauthorized, "Request is not sent from address's owner")
-		return
+	if !common.IsHexAddress(addr) {
+		return errors.InvalidPayload("Invalid address")
 	}
 
-	err = e.AccountService.DeleteFavoriteToken(address, tokenAddr)
+	a := common.HexToAddress(addr)
 
-	if err != nil {
-		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, err.Error())
-		return
+	switch ev.Type {
+	case types.SUBSCRIBE:
+		return e.AccountService.SubscribeBalances(c, a)
+	case types.UNSUBSCRIBE:
+		e.AccountService.UnsubscribeBalances(c, a)
+		return nil
+	default:
+		return errors.InvalidEvent(ws.BalanceChannel, ev.Type)
 	}
-
-	httputils.WriteJSON(w, http.StatusOK, tokenAddr)
 }
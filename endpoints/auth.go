@@ -0,0 +1,96 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/middlewares"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+)
+
+type authEndpoint struct {
+	authService interfaces.AuthService
+}
+
+// ServeAuthResource sets up the routing of the Sign-In with Ethereum endpoints
+func ServeAuthResource(
+	r *mux.Router,
+	authService interfaces.AuthService,
+) {
+	e := &authEndpoint{authService}
+	r.HandleFunc("/api/auth/nonce", e.handleGetNonce).Methods("GET")
+	r.HandleFunc("/api/auth/login", e.handleLogin).Methods("POST")
+}
+
+func (e *authEndpoint) handleGetNonce(w http.ResponseWriter, r *http.Request) {
+	n, err := e.authService.NewNonce()
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, n)
+}
+
+// handleLogin opens a session for the signer of a Sign-In with Ethereum message
+func (e *authEndpoint) handleLogin(w http.ResponseWriter, r *http.Request) {
+	req := &types.LoginRequest{}
+
+	defer r.Body.Close()
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	s, err := e.authService.Login(req.Message, common.FromHex(req.Signature))
+	switch err {
+	case nil:
+	case types.ErrInvalidSiweMessage:
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	case types.ErrInvalidSiweDomain, types.ErrSiweExpired, types.ErrInvalidLoginSignature, types.ErrInvalidAuthNonce:
+		httputils.WriteError(w, http.StatusUnauthorized, err.Error())
+		return
+	default:
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, s)
+}
+
+// signerAddress returns the address of the session of a request authenticated by
+// middlewares.Authenticate, which must be the address of the route if it has one
+func signerAddress(w http.ResponseWriter, r *http.Request) (common.Address, bool) {
+	a, ok := middlewares.AuthAddress(r)
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "Missing session token")
+		return common.Address{}, false
+	}
+
+	addr, ok := mux.Vars(r)["address"]
+	if !ok {
+		return a, true
+	}
+
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return common.Address{}, false
+	}
+
+	if common.HexToAddress(addr) != a {
+		httputils.WriteError(w, http.StatusForbidden, "The session is not opened for the address")
+		return common.Address{}, false
+	}
+
+	return a, true
+}
@@ -0,0 +1,205 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/middlewares"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+)
+
+// maxComplianceImportSize is the maximum size of the imported CSV files
+const maxComplianceImportSize = 10 << 20
+
+type complianceEndpoint struct {
+	complianceService interfaces.ComplianceService
+}
+
+// ServeComplianceResource sets up the routing of the compliance endpoints, they require the api auth key
+func ServeComplianceResource(
+	r *mux.Router,
+	complianceService interfaces.ComplianceService,
+) {
+	e := &complianceEndpoint{complianceService}
+	r.HandleFunc("/api/compliance/entries", middlewares.RequireAuthKey(e.handleGetEntries)).Methods("GET")
+	r.HandleFunc("/api/compliance/entries/{address}", middlewares.RequireAuthKey(e.handleGetEntry)).Methods("GET")
+	r.HandleFunc("/api/compliance/entries/{address}", middlewares.RequireAuthKey(e.handleBlock)).Methods("PUT")
+	r.HandleFunc("/api/compliance/entries/{address}", middlewares.RequireAuthKey(e.handleUnblock)).Methods("DELETE")
+	r.HandleFunc("/api/compliance/import", middlewares.RequireAuthKey(e.handleImport)).Methods("POST")
+	r.HandleFunc("/api/compliance/audit", middlewares.RequireAuthKey(e.handleGetAuditLog)).Methods("GET")
+}
+
+func (e *complianceEndpoint) handleGetEntries(w http.ResponseWriter, r *http.Request) {
+	res, err := e.complianceService.GetAll()
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *complianceEndpoint) handleGetEntry(w http.ResponseWriter, r *http.Request) {
+	addr, ok := routeAddress(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := e.complianceService.GetByAddress(addr)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if res == nil {
+		httputils.WriteError(w, http.StatusNotFound, "Address is neither blocked nor restricted")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handleBlock blocks or restricts the address of the route with the status, the reason and
+// the optional expiration time of the payload
+func (e *complianceEndpoint) handleBlock(w http.ResponseWriter, r *http.Request) {
+	addr, ok := routeAddress(w, r)
+	if !ok {
+		return
+	}
+
+	entry := &types.ComplianceEntry{}
+
+	defer r.Body.Close()
+
+	err := json.NewDecoder(r.Body).Decode(entry)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	entry.Address = addr
+
+	err = e.complianceService.Block(entry, types.ComplianceSourceAPI)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, entry)
+}
+
+// handleUnblock removes the entry of the address of the route, the reason is recorded in the audit log
+func (e *complianceEndpoint) handleUnblock(w http.ResponseWriter, r *http.Request) {
+	addr, ok := routeAddress(w, r)
+	if !ok {
+		return
+	}
+
+	reason := r.URL.Query().Get("reason")
+	if reason == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "reason Parameter Missing")
+		return
+	}
+
+	ok, err := e.complianceService.Unblock(addr, reason, types.ComplianceSourceAPI)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !ok {
+		httputils.WriteError(w, http.StatusNotFound, "Address is neither blocked nor restricted")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, "OK")
+}
+
+// handleImport blocks or restricts the addresses of the CSV file of the body
+func (e *complianceEndpoint) handleImport(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	res, err := e.complianceService.Import(http.MaxBytesReader(w, r.Body, maxComplianceImportSize))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *complianceEndpoint) handleGetAuditLog(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+
+	var addr common.Address
+	if a := v.Get("address"); a != "" {
+		if !common.IsHexAddress(a) {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+			return
+		}
+
+		addr = common.HexToAddress(a)
+	}
+
+	offset := 0
+	size := types.DefaultLimit
+
+	if pageOffset := v.Get("pageOffset"); pageOffset != "" {
+		t, err := strconv.Atoi(pageOffset)
+		if err != nil || t < 0 {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid page offset")
+			return
+		}
+		offset = t
+	}
+
+	if pageSize := v.Get("pageSize"); pageSize != "" {
+		t, err := strconv.Atoi(pageSize)
+		if err != nil || t <= 0 || t > 1000 {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid page size")
+			return
+		}
+		size = t
+	}
+
+	res, err := e.complianceService.GetAuditLog(addr, offset*size, size)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// complianceStatus returns the http status of an error of the services, http.StatusForbidden
+// for the orders refused by the compliance checks and status otherwise
+func complianceStatus(err error, status int) int {
+	if err == types.ErrAddressBlocked || err == types.ErrAddressRestricted {
+		return http.StatusForbidden
+	}
+
+	return status
+}
+
+// routeAddress returns the address of the route
+func routeAddress(w http.ResponseWriter, r *http.Request) (common.Address, bool) {
+	addr := mux.Vars(r)["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return common.Address{}, false
+	}
+
+	return common.HexToAddress(addr), true
+}
@@ -0,0 +1,149 @@
+package endpoints
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// exportFlushInterval is the number of records written between two flushes of an export
+const exportFlushInterval = 100
+
+// exportWriter streams records to the response as NDJSON or CSV, the response is flushed
+// regularly so that an export of a full history is never held in memory
+type exportWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	csv     *csv.Writer
+	json    *json.Encoder
+	count   int
+}
+
+func newExportWriter(w http.ResponseWriter, format string, filename string, header []string) (*exportWriter, error) {
+	e := &exportWriter{w: w}
+	e.flusher, _ = w.(http.Flusher)
+
+	switch format {
+	case types.ExportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+".csv\"")
+		e.csv = csv.NewWriter(w)
+		if err := e.csv.Write(header); err != nil {
+			return nil, err
+		}
+	default:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+".ndjson\"")
+		e.json = json.NewEncoder(w)
+	}
+
+	return e, nil
+}
+
+// Write writes a record, v is encoded in NDJSON exports and record in CSV exports
+func (e *exportWriter) Write(v interface{}, record []string) error {
+	var err error
+	if e.csv != nil {
+		err = e.csv.Write(record)
+	} else {
+		err = e.json.Encode(v)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	e.count++
+	if e.count%exportFlushInterval == 0 {
+		e.Flush()
+	}
+
+	return nil
+}
+
+// Flush sends the buffered records to the client
+func (e *exportWriter) Flush() {
+	if e.csv != nil {
+		e.csv.Flush()
+	}
+
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+}
+
+// exportParams are the filters shared by the order and trade exports
+type exportParams struct {
+	format     string
+	address    common.Address
+	baseToken  common.Address
+	quoteToken common.Address
+	from       int64
+	to         int64
+}
+
+// parseExportParams validates the query of an export. Exports are restricted to the history
+// of a user (address) or of a pair (baseToken and quoteToken), or both.
+func parseExportParams(v url.Values) (*exportParams, error) {
+	p := &exportParams{format: v.Get("format")}
+	if p.format == "" {
+		p.format = types.ExportFormatNDJSON
+	}
+
+	if p.format != types.ExportFormatNDJSON && p.format != types.ExportFormatCSV {
+		return nil, errors.New("Invalid format")
+	}
+
+	addr := v.Get("address")
+	bt := v.Get("baseToken")
+	qt := v.Get("quoteToken")
+
+	if addr == "" && (bt == "" || qt == "") {
+		return nil, errors.New("address or baseToken and quoteToken Parameters missing")
+	}
+
+	if addr != "" {
+		if !common.IsHexAddress(addr) {
+			return nil, errors.New("Invalid Address")
+		}
+		p.address = common.HexToAddress(addr)
+	}
+
+	if bt != "" {
+		if !common.IsHexAddress(bt) {
+			return nil, errors.New("Invalid Base Token Address")
+		}
+		p.baseToken = common.HexToAddress(bt)
+	}
+
+	if qt != "" {
+		if !common.IsHexAddress(qt) {
+			return nil, errors.New("Invalid Quote Token Address")
+		}
+		p.quoteToken = common.HexToAddress(qt)
+	}
+
+	if from := v.Get("from"); from != "" {
+		t, err := strconv.ParseInt(from, 10, 64)
+		if err != nil {
+			return nil, errors.New("Invalid time from value")
+		}
+		p.from = t
+	}
+
+	if to := v.Get("to"); to != "" {
+		t, err := strconv.ParseInt(to, 10, 64)
+		if err != nil {
+			return nil, errors.New("Invalid time to value")
+		}
+		p.to = t
+	}
+
+	return p, nil
+}
@@ -0,0 +1,73 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/middlewares"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+)
+
+type fixAccountEndpoint struct {
+	fixAccountService interfaces.FixAccountService
+}
+
+// ServeFixAccountResource sets up the routing of the FIX account endpoints, they require the api auth key
+func ServeFixAccountResource(
+	r *mux.Router,
+	fixAccountService interfaces.FixAccountService,
+) {
+	e := &fixAccountEndpoint{fixAccountService}
+	r.HandleFunc("/api/fix/accounts", middlewares.RequireAuthKey(e.handleGetFixAccounts)).Methods("GET")
+	r.HandleFunc("/api/fix/accounts", middlewares.RequireAuthKey(e.handleCreateFixAccount)).Methods("POST")
+	r.HandleFunc("/api/fix/accounts/{senderCompId}", middlewares.RequireAuthKey(e.handleDeleteFixAccount)).Methods("DELETE")
+}
+
+func (e *fixAccountEndpoint) handleGetFixAccounts(w http.ResponseWriter, r *http.Request) {
+	res, err := e.fixAccountService.GetAll()
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *fixAccountEndpoint) handleCreateFixAccount(w http.ResponseWriter, r *http.Request) {
+	params := &struct {
+		SenderCompID string `json:"senderCompId"`
+		Password     string `json:"password"`
+	}{}
+
+	defer r.Body.Close()
+
+	err := json.NewDecoder(r.Body).Decode(params)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	a, err := e.fixAccountService.Create(params.SenderCompID, params.Password)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, a)
+}
+
+func (e *fixAccountEndpoint) handleDeleteFixAccount(w http.ResponseWriter, r *http.Request) {
+	err := e.fixAccountService.Delete(mux.Vars(r)["senderCompId"])
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, nil)
+}
@@ -0,0 +1,177 @@
+package endpoints
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+)
+
+// IndicatorEndpoint struct for technical indicators endpoint
+type IndicatorEndpoint struct {
+	indicatorService interfaces.IndicatorService
+}
+
+// ServeIndicatorResource handle technical indicators api
+func ServeIndicatorResource(
+	r *mux.Router,
+	indicatorService interfaces.IndicatorService,
+) {
+	e := &IndicatorEndpoint{indicatorService}
+	r.HandleFunc("/api/indicators", e.handleGetIndicators).Methods("GET")
+	r.HandleFunc("/api/lending-indicators", e.handleGetLendingIndicators).Methods("GET")
+}
+
+func (e *IndicatorEndpoint) handleGetIndicators(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	bt := v.Get("baseToken")
+	qt := v.Get("quoteToken")
+
+	p, specs, ok := parseIndicatorParams(w, r)
+	if !ok {
+		return
+	}
+
+	if bt == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "baseToken Parameter is missing")
+		return
+	}
+
+	if qt == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "quoteToken Parameter is missing")
+		return
+	}
+
+	if !common.IsHexAddress(bt) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid base token address")
+		return
+	}
+
+	if !common.IsHexAddress(qt) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid quote token address")
+		return
+	}
+
+	res, err := e.indicatorService.GetIndicators(common.HexToAddress(bt), common.HexToAddress(qt), p.Duration, p.Units, p.From, p.To, specs)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *IndicatorEndpoint) handleGetLendingIndicators(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	t := v.Get("term")
+	lendingToken := v.Get("lendingToken")
+
+	p, specs, ok := parseIndicatorParams(w, r)
+	if !ok {
+		return
+	}
+
+	if t == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "term Parameter is missing")
+		return
+	}
+
+	if lendingToken == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "lendingToken Parameter is missing")
+		return
+	}
+
+	if !common.IsHexAddress(lendingToken) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid lending token address")
+		return
+	}
+
+	term, err := strconv.ParseUint(t, 10, 64)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid term")
+		return
+	}
+
+	res, err := e.indicatorService.GetLendingIndicators(term, common.HexToAddress(lendingToken), p.Duration, p.Units, p.From, p.To, specs)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// parseIndicatorParams parses the time range and indicators shared by the indicator apis,
+// an error response is written when the parameters are invalid
+func parseIndicatorParams(w http.ResponseWriter, r *http.Request) (*types.OHLCVParams, []types.IndicatorSpec, bool) {
+	var p types.OHLCVParams
+
+	v := r.URL.Query()
+	from := v.Get("from")
+	to := v.Get("to")
+	timeInterval := v.Get("timeInterval")
+	indicators := v.Get("indicators")
+
+	if timeInterval == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "timeInterval Parameter is missing")
+		return nil, nil, false
+	}
+
+	if indicators == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "indicators Parameter is missing")
+		return nil, nil, false
+	}
+
+	specs, err := types.ParseIndicatorSpecs(indicators)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return nil, nil, false
+	}
+
+	unit, duration := processTimeInterval(timeInterval)
+	p.Units = unit
+	p.Duration = int64(duration)
+
+	now := time.Now()
+
+	if to == "" {
+		p.To = now.Unix()
+	} else {
+		t, err := strconv.ParseInt(to, 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid to")
+			return nil, nil, false
+		}
+
+		p.To = t
+	}
+
+	if from == "" {
+		p.From = now.AddDate(0, 0, -7).Unix()
+	} else {
+		f, err := strconv.ParseInt(from, 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid from")
+			return nil, nil, false
+		}
+
+		p.From = f
+	}
+
+	_, interval := utils.GetModTime(p.To, p.Duration, p.Units)
+	err = types.CheckIndicatorRange(p.From, p.To, interval, specs)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return nil, nil, false
+	}
+
+	return &p, specs, true
+}
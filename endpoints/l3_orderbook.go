@@ -0,0 +1,115 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+	"github.com/tomochain/tomox-sdk/ws"
+)
+
+// L3OrderBookEndpoint struct for the per order orderbook endpoint
+type L3OrderBookEndpoint struct {
+	l3OrderBookService interfaces.L3OrderBookService
+}
+
+// ServeL3OrderBookResource sets up the routing of the per order orderbook endpoints
+func ServeL3OrderBookResource(
+	r *mux.Router,
+	l3OrderBookService interfaces.L3OrderBookService,
+) {
+	e := &L3OrderBookEndpoint{l3OrderBookService}
+	r.HandleFunc("/api/orderbook/l3", e.handleGetL3OrderBook).Methods("GET")
+	ws.RegisterChannel(ws.L3OrderBookChannel, e.l3OrderBookWebSocket)
+}
+
+func (e *L3OrderBookEndpoint) handleGetL3OrderBook(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	bt := v.Get("baseToken")
+	qt := v.Get("quoteToken")
+
+	if bt == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "baseToken Parameter missing")
+		return
+	}
+
+	if qt == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "quoteToken Parameter missing")
+		return
+	}
+
+	if !common.IsHexAddress(bt) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Base Token Address")
+		return
+	}
+
+	if !common.IsHexAddress(qt) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Quote Token Address")
+		return
+	}
+
+	ob, err := e.l3OrderBookService.GetL3OrderBook(common.HexToAddress(bt), common.HexToAddress(qt))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, ob)
+}
+
+func (e *L3OrderBookEndpoint) l3OrderBookWebSocket(input interface{}, c *ws.Client) error {
+	b, _ := json.Marshal(input)
+	var ev *types.WebsocketEvent
+	err := json.Unmarshal(b, &ev)
+	if err != nil {
+		return errors.InvalidPayload(err.Error())
+	}
+	if ev == nil {
+		return errors.InvalidPayload("empty payload")
+	}
+	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
+		return errors.InvalidEvent(ws.L3OrderBookChannel, ev.Type)
+	}
+
+	b, _ = json.Marshal(ev.Payload)
+	var p *types.SubscriptionPayload
+
+	err = json.Unmarshal(b, &p)
+	if err != nil {
+		logger.Error(err)
+		return errors.InvalidPayload("empty payload")
+	}
+
+	if ev.Type == types.SUBSCRIBE {
+		if p == nil {
+			return errors.InvalidPayload("empty payload")
+		}
+
+		if (p.BaseToken == common.Address{}) {
+			return errors.InvalidPayload("Invalid base token")
+		}
+
+		if (p.QuoteToken == common.Address{}) {
+			return errors.InvalidPayload("Invalid quote token")
+		}
+
+		return e.l3OrderBookService.SubscribeL3OrderBook(c, p.BaseToken, p.QuoteToken)
+	}
+
+	if ev.Type == types.UNSUBSCRIBE {
+		if p == nil {
+			e.l3OrderBookService.UnsubscribeL3OrderBook(c)
+			return nil
+		}
+
+		e.l3OrderBookService.UnsubscribeL3OrderBookChannel(c, p.BaseToken, p.QuoteToken)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
@@ -87,7 +88,7 @@ func (e *LendingMarketsEndpoint) handleGetLendingMarketStats(w http.ResponseWrit
 	httputils.WriteJSON(w, http.StatusOK, res)
 }
 
-func (e *LendingMarketsEndpoint) handleLendingMarketsWebSocket(input interface{}, c *ws.Client) {
+func (e *LendingMarketsEndpoint) handleLendingMarketsWebSocket(input interface{}, c *ws.Client) error {
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
 
@@ -96,20 +97,17 @@ func (e *LendingMarketsEndpoint) handleLendingMarketsWebSocket(input interface{}
 		logger.Error(err)
 	}
 
-	socket := ws.GetLendingMarketSocket()
-
 	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
-		logger.Info("Event Type", ev.Type)
-		err := map[string]string{"Message": "Invalid payload"}
-		socket.SendErrorMessage(c, err)
-		return
+		return errors.InvalidEvent(ws.LendingMarketsChannel, ev.Type)
 	}
 
 	if ev.Type == types.SUBSCRIBE {
-		e.LendingMarketsService.Subscribe(c)
+		return e.LendingMarketsService.Subscribe(c)
 	}
 
 	if ev.Type == types.UNSUBSCRIBE {
 		e.LendingMarketsService.Unsubscribe(c)
 	}
+
+	return nil
 }
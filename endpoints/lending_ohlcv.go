@@ -8,8 +8,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
 	"github.com/tomochain/tomox-sdk/ws"
 )
@@ -17,14 +19,16 @@ import (
 // LendingOhlcvEndpoint struct for lending ohlcv endpoint
 type LendingOhlcvEndpoint struct {
 	lendingOhlcvService interfaces.LendingOhlcvService
+	indicatorService    interfaces.IndicatorService
 }
 
 // ServeLendingOhlcvResource handle lending ohlcv api
 func ServeLendingOhlcvResource(
 	r *mux.Router,
 	lendingOhlcvService interfaces.LendingOhlcvService,
+	indicatorService interfaces.IndicatorService,
 ) {
-	e := &LendingOhlcvEndpoint{lendingOhlcvService}
+	e := &LendingOhlcvEndpoint{lendingOhlcvService, indicatorService}
 	r.HandleFunc("/api/lending-ohlcv", e.handleGetLendingOhlcv).Methods("GET")
 	ws.RegisterChannel(ws.LendingOhlcvChannel, e.ohlcvWebSocket)
 }
@@ -98,24 +102,20 @@ func (e *LendingOhlcvEndpoint) handleGetLendingOhlcv(w http.ResponseWriter, r *h
 	httputils.WriteJSON(w, http.StatusOK, res)
 }
 
-func (e *LendingOhlcvEndpoint) ohlcvWebSocket(input interface{}, c *ws.Client) {
+func (e *LendingOhlcvEndpoint) ohlcvWebSocket(input interface{}, c *ws.Client) error {
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
 	socket := ws.GetLendingOhlcvSocket()
 	err := json.Unmarshal(b, &ev)
 	if err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 	if ev == nil {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 
 	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidEvent(ws.LendingOhlcvChannel, ev.Type)
 	}
 
 	if ev.Type == types.SUBSCRIBE {
@@ -124,21 +124,19 @@ func (e *LendingOhlcvEndpoint) ohlcvWebSocket(input interface{}, c *ws.Client) {
 
 		err = json.Unmarshal(b, &p)
 		if err != nil {
-			logger.Error(err)
-			return
+			return errors.InvalidPayload(err.Error())
 		}
 		if p == nil {
-			socket.SendErrorMessage(c, errInvalidPayload)
-			return
+			return errors.InvalidPayload("empty payload")
 		}
 		if p.Term == 0 {
 			socket.SendErrorMessage(c, "Invalid term")
-			return
+			return nil
 		}
 
 		if (p.LendingToken == common.Address{}) {
 			socket.SendErrorMessage(c, "Invalid Lending Token")
-			return
+			return nil
 		}
 
 		now := time.Now()
@@ -159,10 +157,29 @@ func (e *LendingOhlcvEndpoint) ohlcvWebSocket(input interface{}, c *ws.Client) {
 			p.Units = "hour"
 		}
 
-		e.lendingOhlcvService.Subscribe(c, p)
+		if p.Indicator != "" {
+			specs, err := types.ParseIndicatorSpecs(p.Indicator)
+			if err != nil {
+				socket.SendErrorMessage(c, err.Error())
+				return nil
+			}
+
+			_, interval := utils.GetModTime(p.To, p.Duration, p.Units)
+			err = types.CheckIndicatorRange(p.From, p.To, interval, specs)
+			if err != nil {
+				socket.SendErrorMessage(c, err.Error())
+				return nil
+			}
+
+			return e.indicatorService.SubscribeLending(c, p, specs)
+		}
+
+		return e.lendingOhlcvService.Subscribe(c, p)
 	}
 
 	if ev.Type == types.UNSUBSCRIBE {
 		e.lendingOhlcvService.Unsubscribe(c)
 	}
+
+	return nil
 }
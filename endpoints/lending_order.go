@@ -2,13 +2,13 @@ package endpoints
 
 import (
 	"encoding/json"
-	"log"
 	"math/big"
 	"net/http"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
@@ -22,6 +22,7 @@ const (
 type lendingorderEndpoint struct {
 	lendingorderService interfaces.LendingOrderService
 	relayerService      interfaces.RelayerService
+	reserveNonce        http.Handler
 }
 
 // ServeLendingOrderResource sets up the routing of order endpoints and the corresponding handlers.
@@ -29,8 +30,10 @@ func ServeLendingOrderResource(
 	r *mux.Router,
 	lendingorderService interfaces.LendingOrderService,
 	relayerService interfaces.RelayerService,
+	nonceService interfaces.NonceService,
+	authService interfaces.AuthService,
 ) {
-	e := &lendingorderEndpoint{lendingorderService, relayerService}
+	e := &lendingorderEndpoint{lendingorderService, relayerService, reserveNonceHandler(authService, nonceService)}
 	r.HandleFunc("/api/lending/orders", e.handleGetLendingOrders).Methods("GET")
 	r.HandleFunc("/api/lending/repay", e.handleGetRepay).Methods("GET")
 	r.HandleFunc("/api/lending/topup", e.handleGetTopup).Methods("GET")
@@ -130,6 +133,15 @@ func (e *lendingorderEndpoint) handleGetLendingOrders(w http.ResponseWriter, r *
 		sortType = "dec"
 	}
 
+	if _, ok := v["cursor"]; ok {
+		cursor, err := types.DecodeCursor(v.Get("cursor"), sortType == "asc")
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		lendingSpec.Cursor = cursor
+	}
+
 	if sortBy == "" {
 		sortBy = "time"
 	}
@@ -222,7 +234,7 @@ func (e *lendingorderEndpoint) handleNewLendingOrder(w http.ResponseWriter, r *h
 	err = e.lendingorderService.NewLendingOrder(o)
 	if err != nil {
 		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		httputils.WriteError(w, complianceStatus(err, http.StatusBadRequest), err.Error())
 		return
 	}
 	httputils.WriteJSON(w, http.StatusCreated, o)
@@ -241,7 +253,7 @@ func (e *lendingorderEndpoint) handleCancelLendingOrder(w http.ResponseWriter, r
 	err = e.lendingorderService.CancelLendingOrder(o)
 	if err != nil {
 		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		httputils.WriteError(w, complianceStatus(err, http.StatusBadRequest), err.Error())
 		return
 	}
 	httputils.WriteJSON(w, http.StatusOK, o.Hash)
@@ -262,7 +274,7 @@ func (e *lendingorderEndpoint) handleRepayLendingOrder(w http.ResponseWriter, r
 	err = e.lendingorderService.RepayLendingOrder(o)
 	if err != nil {
 		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		httputils.WriteError(w, complianceStatus(err, http.StatusBadRequest), err.Error())
 		return
 	}
 	httputils.WriteJSON(w, http.StatusOK, o.Hash)
@@ -282,111 +294,110 @@ func (e *lendingorderEndpoint) handleTopupLendingOrder(w http.ResponseWriter, r
 	err = e.lendingorderService.TopupLendingOrder(o)
 	if err != nil {
 		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		httputils.WriteError(w, complianceStatus(err, http.StatusBadRequest), err.Error())
 		return
 	}
 	httputils.WriteJSON(w, http.StatusOK, o.Hash)
 }
 
 // ws function handles incoming websocket messages on the order channel
-func (e *lendingorderEndpoint) ws(input interface{}, c *ws.Client) {
+func (e *lendingorderEndpoint) ws(input interface{}, c *ws.Client) error {
 	msg := &types.WebsocketEvent{}
 
 	bytes, _ := json.Marshal(input)
 	if err := json.Unmarshal(bytes, &msg); err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.LendingOrderChannel, types.ERROR, err.Error())
+		return errors.InvalidPayload(err.Error())
 	}
 
 	switch msg.Type {
 	case "NEW_LENDING_ORDER":
-		e.handleWSNewLendingOrder(msg, c)
+		return e.handleWSNewLendingOrder(msg, c)
 	case "CANCEL_LENDING_ORDER":
-		e.handleWSCancelLendingOrder(msg, c)
+		return e.handleWSCancelLendingOrder(msg, c)
 	case "SUBSCRIBE":
-		e.handleWSSubLendingOrder(msg, c)
+		return e.handleWSSubLendingOrder(msg, c)
 	default:
-		log.Print("Response with error")
+		return errors.InvalidEvent(ws.LendingOrderChannel, msg.Type)
 	}
 }
 
-func (e *lendingorderEndpoint) handleWSSubLendingOrder(ev *types.WebsocketEvent, c *ws.Client) {
+func (e *lendingorderEndpoint) handleWSSubLendingOrder(ev *types.WebsocketEvent, c *ws.Client) error {
 	var addr string
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
 	bytes, err := json.Marshal(ev.Payload)
 	if err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.LendingOrderChannel, types.ERROR, err.Error())
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	logger.Debugf("Payload: %v#", ev.Payload)
 
 	err = json.Unmarshal(bytes, &addr)
 	if err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.LendingOrderChannel, types.ERROR, err.Error())
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	if !common.IsHexAddress(addr) {
-		c.SendMessage(ws.LendingOrderChannel, types.ERROR, errInvalidPayload)
-		return
+		return errors.InvalidPayload("Invalid address")
 	}
 
 	a := common.HexToAddress(addr)
+	if err := c.Authorize(ws.LendingOrderChannel, a); err != nil {
+		return err
+	}
+
 	ws.RegisterLendingOrderConnection(a, c)
 	ws.SendLendingOrderMessage(types.INIT, a, nil)
+	return nil
 }
 
 // handleWSNewLendingOrder handles NewOrder message. New order messages are transmitted to the order service after being unmarshalled
-func (e *lendingorderEndpoint) handleWSNewLendingOrder(ev *types.WebsocketEvent, c *ws.Client) {
+func (e *lendingorderEndpoint) handleWSNewLendingOrder(ev *types.WebsocketEvent, c *ws.Client) error {
 	o := &types.LendingOrder{}
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
 	bytes, err := json.Marshal(ev.Payload)
 	if err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.LendingOrderChannel, types.ERROR, err.Error())
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	logger.Debugf("Payload: %v#", ev.Payload)
 
 	err = json.Unmarshal(bytes, &o)
 	if err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.LendingOrderChannel, types.ERROR, err.Error())
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 	if o == nil {
-		c.SendMessage(ws.LendingOrderChannel, types.ERROR, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 	if err := o.Validate(); err != nil {
-		c.SendMessage(ws.LendingOrderChannel, types.ERROR, err.Error())
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	o.Hash = o.ComputeHash()
+	if err := c.Authorize(ws.LendingOrderChannel, o.UserAddress); err != nil {
+		return err
+	}
+
 	ws.RegisterLendingOrderConnection(o.UserAddress, c)
 
 	err = e.lendingorderService.NewLendingOrder(o)
 	if err != nil {
 		logger.Error(err)
 		c.SendLendingOrderErrorMessage(err, o.Hash)
-		return
 	}
+
+	return nil
 }
 
 // handleCancelLendingOrder handles CancelLendingOrder message.
-func (e *lendingorderEndpoint) handleWSCancelLendingOrder(ev *types.WebsocketEvent, c *ws.Client) {
-	bytes, err := json.Marshal(ev.Payload)
+func (e *lendingorderEndpoint) handleWSCancelLendingOrder(ev *types.WebsocketEvent, c *ws.Client) error {
+	bytes, _ := json.Marshal(ev.Payload)
 	o := &types.LendingOrder{}
 
-	err = json.Unmarshal(bytes, &o)
+	err := json.Unmarshal(bytes, &o)
 	if err != nil {
-		logger.Error(err)
-		c.SendLendingOrderErrorMessage(err, o.Hash)
+		return errors.InvalidPayload(err.Error())
+	}
+
+	if err := c.Authorize(ws.LendingOrderChannel, o.UserAddress); err != nil {
+		return err
 	}
 
 	ws.RegisterLendingOrderConnection(o.UserAddress, c)
@@ -395,12 +406,18 @@ func (e *lendingorderEndpoint) handleWSCancelLendingOrder(ev *types.WebsocketEve
 	if orderErr != nil {
 		logger.Error(orderErr)
 		c.SendLendingOrderErrorMessage(orderErr, o.Hash)
-		return
 	}
+
+	return nil
 }
 
 func (e *lendingorderEndpoint) handleGetLendingOrderNonce(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
+	if v.Get("reserve") != "" {
+		e.reserveNonce.ServeHTTP(w, r)
+		return
+	}
+
 	addr := v.Get("address")
 
 	if addr == "" {
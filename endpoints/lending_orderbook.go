@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
@@ -26,6 +27,7 @@ func ServeLendingOrderBookResource(
 	e := &LendingOrderBookEndpoint{lendingOrderBookService}
 	r.HandleFunc("/api/lending/orderbook", e.HandleGetLendingOrderBook).Methods("GET")
 	r.HandleFunc("/api/lending/orderbook/db", e.HandleGetLendingOrderBookInDb).Methods("GET")
+	r.HandleFunc("/api/lending/orderbook/depth", e.HandleGetLendingOrderBookDepth).Methods("GET")
 	ws.RegisterChannel(ws.LendingOrderBookChannel, e.lendingOrderBookWebSocket)
 }
 
@@ -91,24 +93,60 @@ func (e *LendingOrderBookEndpoint) HandleGetLendingOrderBookInDb(w http.Response
 	httputils.WriteJSON(w, http.StatusOK, ob)
 }
 
-func (e *LendingOrderBookEndpoint) lendingOrderBookWebSocket(input interface{}, c *ws.Client) {
+// HandleGetLendingOrderBookDepth returns the lending orderbook aggregated by interest tick size (in percent)
+func (e *LendingOrderBookEndpoint) HandleGetLendingOrderBookDepth(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	t := v.Get("term")
+	lendingToken := v.Get("lendingToken")
+	term, err := strconv.ParseUint(t, 10, 32)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Term parameter is incorect")
+		return
+	}
+
+	if lendingToken == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "lendingToken Parameter missing")
+		return
+	}
+
+	if !common.IsHexAddress(lendingToken) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Lending Token Address")
+		return
+	}
+
+	levels, cumulative, ok := parseDepthParams(w, r)
+	if !ok {
+		return
+	}
+
+	lendingTokenAddress := common.HexToAddress(lendingToken)
+	ob, err := e.lendingOrderBookService.GetLendingOrderBookDepth(term, lendingTokenAddress, v.Get("tickSize"), levels, cumulative)
+	if err == types.ErrInvalidTickSize || err == types.ErrInvalidDepthLevels {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, ob)
+}
+
+func (e *LendingOrderBookEndpoint) lendingOrderBookWebSocket(input interface{}, c *ws.Client) error {
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
 	err := json.Unmarshal(b, &ev)
 	if err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
-	socket := ws.GetLendingOrderBookSocket()
 	if ev == nil {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
-		logger.Info("Event Type", ev.Type)
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidEvent(ws.LendingOrderBookChannel, ev.Type)
 	}
 
 	b, _ = json.Marshal(ev.Payload)
@@ -117,32 +155,33 @@ func (e *LendingOrderBookEndpoint) lendingOrderBookWebSocket(input interface{},
 	err = json.Unmarshal(b, &p)
 	if err != nil {
 		logger.Error(err)
-		msg := map[string]string{"Message": "Internal server error"}
-		socket.SendErrorMessage(c, msg)
-		return
+		return errors.InvalidPayload("Internal server error")
 	}
 
 	if ev.Type == types.SUBSCRIBE {
 		if p == nil {
-			socket.SendErrorMessage(c, errInvalidPayload)
-			return
+			return errors.InvalidPayload("empty payload")
 		}
 
 		if (p.LendingToken == common.Address{}) {
-			msg := map[string]string{"Message": "Invalid lending token"}
-			socket.SendErrorMessage(c, msg)
-			return
+			return errors.InvalidPayload("Invalid lending token")
 		}
 
-		e.lendingOrderBookService.SubscribeLendingOrderBook(c, p.Term, p.LendingToken)
+		if p.TickSize != "" || p.Levels > 0 {
+			return e.lendingOrderBookService.SubscribeLendingOrderBookDepth(c, p.Term, p.LendingToken, p.TickSize, p.Levels, p.Cumulative)
+		}
+
+		return e.lendingOrderBookService.SubscribeLendingOrderBook(c, p.Term, p.LendingToken)
 	}
 
 	if ev.Type == types.UNSUBSCRIBE {
 		if p == nil {
 			e.lendingOrderBookService.UnsubscribeLendingOrderBook(c)
-			return
+			return nil
 		}
 
 		e.lendingOrderBookService.UnsubscribeLendingOrderBookChannel(c, p.Term, p.LendingToken)
 	}
+
+	return nil
 }
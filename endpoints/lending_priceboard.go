@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/ws"
@@ -24,30 +25,23 @@ func ServeLendingPriceBoardResource(
 	ws.RegisterChannel(ws.LendingPriceBoardChannel, e.handleLendingPriceBoardWebSocket)
 }
 
-func (e *LendingPriceBoardEndpoint) handleLendingPriceBoardWebSocket(input interface{}, c *ws.Client) {
-	socket := ws.GetLendingPriceBoardSocket()
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
+func (e *LendingPriceBoardEndpoint) handleLendingPriceBoardWebSocket(input interface{}, c *ws.Client) error {
 	if input == nil {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
 
 	err := json.Unmarshal(b, &ev)
 	if err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 	if ev == nil {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 
 	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
-		logger.Info("Event Type", ev.Type)
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidEvent(ws.LendingPriceBoardChannel, ev.Type)
 	}
 
 	b, _ = json.Marshal(ev.Payload)
@@ -55,38 +49,32 @@ func (e *LendingPriceBoardEndpoint) handleLendingPriceBoardWebSocket(input inter
 
 	err = json.Unmarshal(b, &p)
 	if err != nil {
-		logger.Error(err)
-		msg := map[string]string{"Message": "Internal server error"}
-		socket.SendErrorMessage(c, msg)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	if ev.Type == types.SUBSCRIBE {
 		if p == nil {
-			socket.SendErrorMessage(c, errInvalidPayload)
-			return
+			return errors.InvalidPayload("empty payload")
 		}
 		if (p.LendingToken == common.Address{}) {
-			msg := map[string]string{"Message": "Invalid lending token"}
-			socket.SendErrorMessage(c, msg)
-			return
+			return errors.InvalidPayload("Invalid lending token")
 		}
 
 		if p.Term == 0 {
-			msg := map[string]string{"Message": "Invalid term"}
-			socket.SendErrorMessage(c, msg)
-			return
+			return errors.InvalidPayload("Invalid term")
 		}
 
-		e.lendingPriceBoardService.Subscribe(c, p.Term, p.LendingToken)
+		return e.lendingPriceBoardService.Subscribe(c, p.Term, p.LendingToken)
 	}
 
 	if ev.Type == types.UNSUBSCRIBE {
 		if p == nil {
 			e.lendingPriceBoardService.Unsubscribe(c)
-			return
+			return nil
 		}
 
 		e.lendingPriceBoardService.UnsubscribeChannel(c, p.Term, p.LendingToken)
 	}
+
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
@@ -30,62 +31,51 @@ func ServeLendingTradeResource(
 	r.HandleFunc("/api/lending/trades/history", e.handleGetLendingTradesHistory).Methods("GET")
 	ws.RegisterChannel(ws.LendingTradeChannel, e.lendingTradeWebsocket)
 }
-func (e *lendingTradeEndpoint) lendingTradeWebsocket(input interface{}, c *ws.Client) {
+func (e *lendingTradeEndpoint) lendingTradeWebsocket(input interface{}, c *ws.Client) error {
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
 	if err := json.Unmarshal(b, &ev); err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
-	socket := ws.GetLendingTradeSocket()
 	if ev == nil {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
-		logger.Info("Event Type", ev.Type)
-		err := map[string]string{"Message": "Invalid payload"}
-		socket.SendErrorMessage(c, err)
-		return
+		return errors.InvalidEvent(ws.LendingTradeChannel, ev.Type)
 	}
 
 	b, _ = json.Marshal(ev.Payload)
 	var p *types.SubscriptionPayload
 	err := json.Unmarshal(b, &p)
 	if err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	if ev.Type == types.SUBSCRIBE {
 		if p == nil {
-			socket.SendErrorMessage(c, errInvalidPayload)
-			return
+			return errors.InvalidPayload("empty payload")
 		}
 		if p.Term == 0 {
-			err := map[string]string{"Message": "Invalid base token"}
-			socket.SendErrorMessage(c, err)
-			return
+			return errors.InvalidPayload("Invalid base token")
 		}
 
 		if (p.LendingToken == common.Address{}) {
-			err := map[string]string{"Message": "Invalid lending token"}
-			socket.SendErrorMessage(c, err)
-			return
+			return errors.InvalidPayload("Invalid lending token")
 		}
 
-		e.lendingTradeService.Subscribe(c, p.Term, p.LendingToken)
+		return e.lendingTradeService.Subscribe(c, p.Term, p.LendingToken)
 	}
 
 	if ev.Type == types.UNSUBSCRIBE {
 		if p == nil {
 			e.lendingTradeService.Unsubscribe(c)
-			return
+			return nil
 		}
 
 		e.lendingTradeService.UnsubscribeChannel(c, p.Term, p.LendingToken)
 	}
+
+	return nil
 }
 
 // handleGetLendingTradesHistory is responsible for handling user's trade history requests
@@ -251,6 +241,15 @@ func (e *lendingTradeEndpoint) handleGetLendingTrades(w http.ResponseWriter, r *
 	if sortType != "asc" && sortType != "dec" {
 		sortType = "dec"
 	}
+
+	if _, ok := v["cursor"]; ok {
+		cursor, err := types.DecodeCursor(v.Get("cursor"), sortType == "asc")
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		lendingTradeSpec.Cursor = cursor
+	}
 	if sortBy != "" {
 		if val, ok := sortedList[sortBy]; ok {
 			if sortType == "asc" {
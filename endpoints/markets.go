@@ -6,7 +6,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/services"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
 	"github.com/tomochain/tomox-sdk/ws"
@@ -16,6 +18,7 @@ type MarketsEndpoint struct {
 	marketsService interfaces.MarketsService
 	pairService    interfaces.PairService
 	relayerService interfaces.RelayerService
+	fiatService    interfaces.FiatService
 }
 
 // ServeTokenResource sets up the routing of token endpoints and the corresponding handlers.
@@ -24,8 +27,9 @@ func ServeMarketsResource(
 	marketsService interfaces.MarketsService,
 	pairService interfaces.PairService,
 	relayerService interfaces.RelayerService,
+	fiatService interfaces.FiatService,
 ) {
-	e := &MarketsEndpoint{marketsService, pairService, relayerService}
+	e := &MarketsEndpoint{marketsService, pairService, relayerService, fiatService}
 	r.HandleFunc("/api/market/stats/all", e.HandleGetAllMarketStats).Methods("GET")
 	r.HandleFunc("/api/market/stats", e.HandleGetMarketStats).Methods("GET")
 
@@ -34,6 +38,11 @@ func ServeMarketsResource(
 
 // HandleGetAllMarketStats get all market token data
 func (e *MarketsEndpoint) HandleGetAllMarketStats(w http.ResponseWriter, r *http.Request) {
+	currency := r.URL.Query().Get("currency")
+	if currency != "" && !e.fiatService.IsSupported(currency) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid currency")
+		return
+	}
 
 	ex := e.relayerService.GetRelayerAddress(r)
 	res, err := e.pairService.GetAllTokenPairDataByCoinbase(ex)
@@ -48,6 +57,15 @@ func (e *MarketsEndpoint) HandleGetAllMarketStats(w http.ResponseWriter, r *http
 		return
 	}
 
+	if currency != "" {
+		err = e.fiatService.ConvertPairData(res, currency)
+		if err != nil {
+			logger.Error(err)
+			httputils.WriteError(w, fiatStatus(err), err.Error())
+			return
+		}
+	}
+
 	httputils.WriteJSON(w, http.StatusOK, res)
 	return
 
@@ -58,6 +76,7 @@ func (e *MarketsEndpoint) HandleGetMarketStats(w http.ResponseWriter, r *http.Re
 	v := r.URL.Query()
 	baseToken := v.Get("baseToken")
 	quoteToken := v.Get("quoteToken")
+	currency := v.Get("currency")
 
 	if quoteToken == "" {
 		httputils.WriteError(w, http.StatusBadRequest, "quoteToken Parameter missing")
@@ -79,6 +98,11 @@ func (e *MarketsEndpoint) HandleGetMarketStats(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if currency != "" && !e.fiatService.IsSupported(currency) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid currency")
+		return
+	}
+
 	baseTokenAddress := common.HexToAddress(baseToken)
 	quoteTokenAddress := common.HexToAddress(quoteToken)
 
@@ -92,10 +116,20 @@ func (e *MarketsEndpoint) HandleGetMarketStats(w http.ResponseWriter, r *http.Re
 		httputils.WriteJSON(w, http.StatusOK, []types.Pair{})
 		return
 	}
+
+	if currency != "" {
+		err := e.fiatService.ConvertPairData([]*types.PairData{res}, currency)
+		if err != nil {
+			logger.Error(err)
+			httputils.WriteError(w, fiatStatus(err), err.Error())
+			return
+		}
+	}
+
 	httputils.WriteJSON(w, http.StatusOK, res)
 }
 
-func (e *MarketsEndpoint) handleMarketsWebSocket(input interface{}, c *ws.Client) {
+func (e *MarketsEndpoint) handleMarketsWebSocket(input interface{}, c *ws.Client) error {
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
 
@@ -104,20 +138,45 @@ func (e *MarketsEndpoint) handleMarketsWebSocket(input interface{}, c *ws.Client
 		logger.Error(err)
 	}
 
-	socket := ws.GetMarketSocket()
-
 	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
-		logger.Info("Event Type", ev.Type)
-		err := map[string]string{"Message": "Invalid payload"}
-		socket.SendErrorMessage(c, err)
-		return
+		return errors.InvalidEvent(ws.MarketsChannel, ev.Type)
+	}
+
+	var p *types.SubscriptionPayload
+	b, _ = json.Marshal(ev.Payload)
+	json.Unmarshal(b, &p)
+
+	currency := ""
+	if p != nil {
+		currency = p.Currency
 	}
 
 	if ev.Type == types.SUBSCRIBE {
-		e.marketsService.Subscribe(c)
+		if currency != "" && !e.fiatService.IsSupported(currency) {
+			return errors.InvalidPayload("Invalid currency")
+		}
+
+		return e.marketsService.Subscribe(c, currency)
 	}
 
 	if ev.Type == types.UNSUBSCRIBE {
-		e.marketsService.Unsubscribe(c)
+		if p == nil {
+			e.marketsService.Unsubscribe(c)
+			return nil
+		}
+
+		e.marketsService.UnsubscribeChannel(c, currency)
 	}
+
+	return nil
+}
+
+// fiatStatus returns the http status of the errors of the fiat conversions, the conversions to
+// a currency whose rate is not loaded yet are unavailable
+func fiatStatus(err error) int {
+	if err == services.ErrRateUnavailable {
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusInternalServerError
 }
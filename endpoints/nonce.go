@@ -0,0 +1,47 @@
+package endpoints
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/middlewares"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+)
+
+// maxNonceReservation is the maximum number of nonces reserved by a request
+const maxNonceReservation = 100
+
+// reserveNonceHandler returns the handler of the nonce requests with a reserve parameter, which
+// reserve nonces for the address of the session of the request
+func reserveNonceHandler(authService interfaces.AuthService, nonceService interfaces.NonceService) http.Handler {
+	return middlewares.Authenticate(authService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a, ok := signerAddress(w, r)
+		if !ok {
+			return
+		}
+
+		v := r.URL.Query()
+		addr := v.Get("address")
+		if addr != "" && (!common.IsHexAddress(addr) || common.HexToAddress(addr) != a) {
+			httputils.WriteError(w, http.StatusForbidden, "The session is not opened for the address")
+			return
+		}
+
+		n, err := strconv.Atoi(v.Get("reserve"))
+		if err != nil || n < 1 || n > maxNonceReservation {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid reserve Parameter")
+			return
+		}
+
+		res, err := nonceService.Reserve(a, n)
+		if err != nil {
+			logger.Error(err)
+			httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		httputils.WriteJSON(w, http.StatusOK, res)
+	}))
+}
@@ -7,9 +7,13 @@ import (
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
 	"github.com/gorilla/mux"
+	"github.com/justinas/alice"
 	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/middlewares"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
 	"github.com/tomochain/tomox-sdk/ws"
@@ -23,30 +27,35 @@ type NotificationEndpoint struct {
 func ServeNotificationResource(
 	r *mux.Router,
 	notificationService interfaces.NotificationService,
+	authService interfaces.AuthService,
 ) {
 	e := &NotificationEndpoint{notificationService}
 
 	r.HandleFunc("/api/notifications", e.HandleGetNotifications).Methods("GET")
 
-	r.HandleFunc("/api/notification/mark/read", e.HandleMarkReadNotification).Methods("PUT")
-	r.HandleFunc("/api/notification/mark/unread", e.HandleMarkUnReadNotification).Methods("PUT")
-	r.HandleFunc("/api/notification/mark/readall", e.HandleMarkReadAllNotification).Methods("PUT")
+	signed := alice.New(middlewares.Authenticate(authService))
+	r.Handle("/api/notification/mark/read", signed.Then(http.HandlerFunc(e.HandleMarkReadNotification))).Methods("PUT")
+	r.Handle("/api/notification/mark/unread", signed.Then(http.HandlerFunc(e.HandleMarkUnReadNotification))).Methods("PUT")
+	r.Handle("/api/notification/mark/readall", signed.Then(http.HandlerFunc(e.HandleMarkReadAllNotification))).Methods("PUT")
+
+	r.Handle("/api/notification/channels/{address}", signed.Then(http.HandlerFunc(e.handleGetChannels))).Methods("GET")
+	r.Handle("/api/notification/channels/{address}", signed.Then(http.HandlerFunc(e.handleAddChannel))).Methods("POST")
+	r.Handle("/api/notification/channels/{address}/{id}", signed.Then(http.HandlerFunc(e.handleRemoveChannel))).Methods("DELETE")
+	r.Handle("/api/notification/channels/{address}/{id}/verify", signed.Then(http.HandlerFunc(e.handleVerifyChannel))).Methods("POST")
+	r.Handle("/api/notification/preferences/{address}", signed.Then(http.HandlerFunc(e.handleGetPreferences))).Methods("GET")
+	r.Handle("/api/notification/preferences/{address}", signed.Then(http.HandlerFunc(e.handleSavePreferences))).Methods("PUT")
 
 	ws.RegisterChannel(ws.NotificationChannel, e.handleNotificationWebSocket)
 }
 
-// HandleMarkReadAllNotification mark all read status
+// HandleMarkReadAllNotification mark all read status of the notifications of the session address
 func (e *NotificationEndpoint) HandleMarkReadAllNotification(w http.ResponseWriter, r *http.Request) {
-	var n types.Notification
-	decoder := json.NewDecoder(r.Body)
-	err := decoder.Decode(&n)
-	if err != nil {
-		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+	a, ok := signerAddress(w, r)
+	if !ok {
 		return
 	}
-	defer r.Body.Close()
-	err = e.NotificationService.MarkAllRead(n.Recipient)
+
+	err := e.NotificationService.MarkAllRead(a)
 
 	if err != nil {
 		logger.Error(err)
@@ -67,6 +76,11 @@ func (e *NotificationEndpoint) HandleMarkReadNotification(w http.ResponseWriter,
 		return
 	}
 	defer r.Body.Close()
+
+	if !e.ownNotification(w, r, n.ID) {
+		return
+	}
+
 	err = e.NotificationService.MarkRead(n.ID)
 
 	if err != nil {
@@ -88,6 +102,11 @@ func (e *NotificationEndpoint) HandleMarkUnReadNotification(w http.ResponseWrite
 		return
 	}
 	defer r.Body.Close()
+
+	if !e.ownNotification(w, r, n.ID) {
+		return
+	}
+
 	err = e.NotificationService.MarkUnRead(n.ID)
 
 	if err != nil {
@@ -98,6 +117,33 @@ func (e *NotificationEndpoint) HandleMarkUnReadNotification(w http.ResponseWrite
 	httputils.WriteMessage(w, http.StatusOK, "Mark unread status successfully")
 }
 
+// ownNotification returns true if the notification is sent to the session address
+func (e *NotificationEndpoint) ownNotification(w http.ResponseWriter, r *http.Request, id bson.ObjectId) bool {
+	a, ok := signerAddress(w, r)
+	if !ok {
+		return false
+	}
+
+	if !id.Valid() {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid notification id")
+		return false
+	}
+
+	n, err := e.NotificationService.GetByID(id)
+	if err != nil && err != mgo.ErrNotFound {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	if n == nil || n.Recipient != a {
+		httputils.WriteError(w, http.StatusNotFound, "Notification not found")
+		return false
+	}
+
+	return true
+}
+
 // HandleGetNotifications get notifications user address
 func (e *NotificationEndpoint) HandleGetNotifications(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
@@ -177,52 +223,191 @@ func (e *NotificationEndpoint) HandleUpdateNotification(w http.ResponseWriter, r
 	httputils.WriteJSON(w, http.StatusOK, updated)
 }
 
-func (e *NotificationEndpoint) handleNotificationWebSocket(input interface{}, c *ws.Client) {
-	b, _ := json.Marshal(input)
-	var ev *types.WebsocketEvent
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
-	err := json.Unmarshal(b, &ev)
+func (e *NotificationEndpoint) handleGetChannels(w http.ResponseWriter, r *http.Request) {
+	a, ok := signerAddress(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := e.NotificationService.GetChannels(a)
 	if err != nil {
 		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
 	}
-	if ev == nil {
-		ws.SendNotificationErrorMessage(c, errInvalidPayload)
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *NotificationEndpoint) handleAddChannel(w http.ResponseWriter, r *http.Request) {
+	a, ok := signerAddress(w, r)
+	if !ok {
 		return
 	}
-	if ev.Type != types.SUBSCRIBE {
-		logger.Info("Event Type", ev.Type)
-		ws.SendNotificationErrorMessage(c, errInvalidPayload)
+
+	c := &types.NotificationChannel{}
+
+	defer r.Body.Close()
+
+	err := json.NewDecoder(r.Body).Decode(c)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
 		return
 	}
 
-	if ev.Type == types.SUBSCRIBE {
-		b, _ = json.Marshal(ev.Payload)
-		var addr string
+	c.UserAddress = a
+	res, err := e.NotificationService.AddChannel(c)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-		err = json.Unmarshal(b, &addr)
-		if err != nil {
-			logger.Error(err)
-			ws.SendNotificationErrorMessage(c, err)
-			return
-		}
+	httputils.WriteJSON(w, http.StatusCreated, res)
+}
 
-		if !common.IsHexAddress(addr) {
-			err := map[string]string{"Message": "Invalid address"}
-			ws.SendNotificationErrorMessage(c, err)
-			return
-		}
+// handleVerifyChannel verifies a channel with the code sent to its target, the notifications
+// are only sent to the verified channels
+func (e *NotificationEndpoint) handleVerifyChannel(w http.ResponseWriter, r *http.Request) {
+	a, ok := signerAddress(w, r)
+	if !ok {
+		return
+	}
 
-		a := common.HexToAddress(addr)
+	id, ok := objectID(w, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
 
-		ws.RegisterNotificationConnection(a, c)
-		notifications, err := e.NotificationService.GetByUserAddress(a, 0, 0)
+	var v struct {
+		Code string `json:"code"`
+	}
 
-		if err != nil {
-			logger.Error(err)
-			ws.SendNotificationErrorMessage(c, err)
-			return
-		}
+	defer r.Body.Close()
 
-		ws.SendNotificationMessage(types.INIT, a, notifications)
+	err := json.NewDecoder(r.Body).Decode(&v)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	err = e.NotificationService.VerifyChannel(id, a, v.Code)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
 	}
+
+	httputils.WriteJSON(w, http.StatusOK, nil)
+}
+
+func (e *NotificationEndpoint) handleRemoveChannel(w http.ResponseWriter, r *http.Request) {
+	a, ok := signerAddress(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := objectID(w, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	err := e.NotificationService.RemoveChannel(id, a)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, nil)
+}
+
+func (e *NotificationEndpoint) handleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	a, ok := signerAddress(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := e.NotificationService.GetPreferences(a)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handleSavePreferences replaces the preferences of the user, the missing locale and digest
+// interval are set to their defaults
+func (e *NotificationEndpoint) handleSavePreferences(w http.ResponseWriter, r *http.Request) {
+	a, ok := signerAddress(w, r)
+	if !ok {
+		return
+	}
+
+	p := &types.NotificationPreferences{}
+
+	defer r.Body.Close()
+
+	err := json.NewDecoder(r.Body).Decode(p)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	p.UserAddress = a
+	res, err := e.NotificationService.SavePreferences(p)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *NotificationEndpoint) handleNotificationWebSocket(input interface{}, c *ws.Client) error {
+	b, _ := json.Marshal(input)
+	var ev *types.WebsocketEvent
+	err := json.Unmarshal(b, &ev)
+	if err != nil {
+		return errors.InvalidPayload(err.Error())
+	}
+	if ev == nil {
+		return errors.InvalidPayload("empty payload")
+	}
+	if ev.Type != types.SUBSCRIBE {
+		return errors.InvalidEvent(ws.NotificationChannel, ev.Type)
+	}
+
+	b, _ = json.Marshal(ev.Payload)
+	var addr string
+
+	err = json.Unmarshal(b, &addr)
+	if err != nil {
+		return errors.InvalidPayload(err.Error())
+	}
+
+	if !common.IsHexAddress(addr) {
+		return errors.InvalidPayload("Invalid address")
+	}
+
+	a := common.HexToAddress(addr)
+	if err := c.Authorize(ws.NotificationChannel, a); err != nil {
+		return err
+	}
+
+	ws.RegisterNotificationConnection(a, c)
+	notifications, err := e.NotificationService.GetByUserAddress(a, 0, 0)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	ws.SendNotificationMessage(types.INIT, a, notifications)
+	return nil
 }
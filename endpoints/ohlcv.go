@@ -8,21 +8,25 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
 	"github.com/tomochain/tomox-sdk/ws"
 )
 
 type OHLCVEndpoint struct {
-	ohlcvService interfaces.OHLCVService
+	ohlcvService     interfaces.OHLCVService
+	indicatorService interfaces.IndicatorService
 }
 
 func ServeOHLCVResource(
 	r *mux.Router,
 	ohlcvService interfaces.OHLCVService,
+	indicatorService interfaces.IndicatorService,
 ) {
-	e := &OHLCVEndpoint{ohlcvService}
+	e := &OHLCVEndpoint{ohlcvService, indicatorService}
 	r.HandleFunc("/api/ohlcv", e.handleGetOHLCV).Methods("GET")
 	ws.RegisterChannel(ws.OHLCVChannel, e.ohlcvWebSocket)
 }
@@ -173,24 +177,20 @@ func (e *OHLCVEndpoint) handleGetOHLCV(w http.ResponseWriter, r *http.Request) {
 	httputils.WriteJSON(w, http.StatusOK, res)
 }
 
-func (e *OHLCVEndpoint) ohlcvWebSocket(input interface{}, c *ws.Client) {
+func (e *OHLCVEndpoint) ohlcvWebSocket(input interface{}, c *ws.Client) error {
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
 	socket := ws.GetOHLCVSocket()
 	err := json.Unmarshal(b, &ev)
 	if err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 	if ev == nil {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 
 	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidEvent(ws.OHLCVChannel, ev.Type)
 	}
 
 	if ev.Type == types.SUBSCRIBE {
@@ -199,21 +199,19 @@ func (e *OHLCVEndpoint) ohlcvWebSocket(input interface{}, c *ws.Client) {
 
 		err = json.Unmarshal(b, &p)
 		if err != nil {
-			logger.Error(err)
-			return
+			return errors.InvalidPayload(err.Error())
 		}
 		if p == nil {
-			socket.SendErrorMessage(c, errInvalidPayload)
-			return
+			return errors.InvalidPayload("empty payload")
 		}
 		if (p.BaseToken == common.Address{}) {
 			socket.SendErrorMessage(c, "Invalid base token")
-			return
+			return nil
 		}
 
 		if (p.QuoteToken == common.Address{}) {
 			socket.SendErrorMessage(c, "Invalid Quote Token")
-			return
+			return nil
 		}
 
 		now := time.Now()
@@ -234,10 +232,29 @@ func (e *OHLCVEndpoint) ohlcvWebSocket(input interface{}, c *ws.Client) {
 			p.Units = "hour"
 		}
 
-		e.ohlcvService.Subscribe(c, p)
+		if p.Indicator != "" {
+			specs, err := types.ParseIndicatorSpecs(p.Indicator)
+			if err != nil {
+				socket.SendErrorMessage(c, err.Error())
+				return nil
+			}
+
+			_, interval := utils.GetModTime(p.To, p.Duration, p.Units)
+			err = types.CheckIndicatorRange(p.From, p.To, interval, specs)
+			if err != nil {
+				socket.SendErrorMessage(c, err.Error())
+				return nil
+			}
+
+			return e.indicatorService.Subscribe(c, p, specs)
+		}
+
+		return e.ohlcvService.Subscribe(c, p)
 	}
 
 	if ev.Type == types.UNSUBSCRIBE {
 		e.ohlcvService.Unsubscribe(c)
 	}
+
+	return nil
 }
@@ -2,7 +2,6 @@ package endpoints
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -18,27 +17,30 @@ import (
 
 type orderEndpoint struct {
 	orderService   interfaces.OrderService
-	accountService interfaces.AccountService
 	relayerService interfaces.RelayerService
+	reserveNonce   http.Handler
 }
 
 // ServeOrderResource sets up the routing of order endpoints and the corresponding handlers.
 func ServeOrderResource(
 	r *mux.Router,
 	orderService interfaces.OrderService,
-	accountService interfaces.AccountService,
 	relayerService interfaces.RelayerService,
+	nonceService interfaces.NonceService,
+	authService interfaces.AuthService,
 ) {
-	e := &orderEndpoint{orderService, accountService, relayerService}
+	e := &orderEndpoint{orderService, relayerService, reserveNonceHandler(authService, nonceService)}
 
 	r.HandleFunc("/api/orders/count", e.handleGetCountOrder).Methods("GET")
 	r.HandleFunc("/api/orders/nonce", e.handleGetOrderNonce).Methods("GET")
 	r.HandleFunc("/api/orders/history", e.handleGetOrderHistory).Methods("GET")
 	r.HandleFunc("/api/orders/positions", e.handleGetPositions).Methods("GET")
+	r.HandleFunc("/api/orders/export", e.handleExportOrders).Methods("GET")
 	r.HandleFunc("/api/orders", e.handleGetOrders).Methods("GET")
 	r.HandleFunc("/api/orders", e.handleNewOrder).Methods("POST")
 	r.HandleFunc("/api/orders/cancel", e.handleCancelOrder).Methods("POST")
 	r.HandleFunc("/api/orders/cancelAll", e.handleCancelAllOrders).Methods("POST")
+	r.HandleFunc("/api/orders/typed-data", e.handleGetTypedData).Methods("POST")
 	r.HandleFunc("/api/orders/balance/lock", e.handleGetLockedBalanceInOrder).Methods("GET")
 	r.HandleFunc("/api/orders/{hash}", e.handleGetOrderByHash).Methods("GET")
 	ws.RegisterChannel(ws.OrderChannel, e.ws)
@@ -186,6 +188,15 @@ func (e *orderEndpoint) handleGetOrders(w http.ResponseWriter, r *http.Request)
 		sortType = "dec"
 	}
 
+	if _, ok := v["cursor"]; ok {
+		cursor, err := types.DecodeCursor(v.Get("cursor"), sortType == "asc")
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		orderSpec.Cursor = cursor
+	}
+
 	if sortBy == "" {
 		sortBy = "time"
 	}
@@ -250,6 +261,49 @@ func (e *orderEndpoint) handleGetOrders(w http.ResponseWriter, r *http.Request)
 	httputils.WriteJSON(w, http.StatusOK, orders)
 }
 
+func (e *orderEndpoint) handleExportOrders(w http.ResponseWriter, r *http.Request) {
+	p, err := parseExportParams(r.URL.Query())
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	orderSpec := types.OrderSpec{
+		RelayerAddress: e.relayerService.GetRelayerAddress(r),
+		DateFrom:       p.from,
+		DateTo:         p.to,
+	}
+
+	if (p.address != common.Address{}) {
+		orderSpec.UserAddress = p.address.Hex()
+	}
+
+	if (p.baseToken != common.Address{}) {
+		orderSpec.BaseToken = p.baseToken.Hex()
+	}
+
+	if (p.quoteToken != common.Address{}) {
+		orderSpec.QuoteToken = p.quoteToken.Hex()
+	}
+
+	exp, err := newExportWriter(w, p.format, "orders", types.OrderCSVHeader)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// the status is already sent once the first record is written, errors can only be logged
+	err = e.orderService.StreamOrders(orderSpec, func(o *types.Order) error {
+		return exp.Write(o, o.CSVRecord())
+	})
+	if err != nil {
+		logger.Error(err)
+	}
+
+	exp.Flush()
+}
+
 func (e *orderEndpoint) handleGetPositions(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
 	addr := v.Get("address")
@@ -442,22 +496,10 @@ func (e *orderEndpoint) handleNewOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	acc, err := e.accountService.GetByAddress(o.UserAddress)
-	if err != nil {
-		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	if acc.IsBlocked {
-		httputils.WriteError(w, http.StatusForbidden, "Account is blocked")
-		return
-	}
-
 	err = e.orderService.NewOrder(o)
 	if err != nil {
 		logger.Error(err)
-		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		httputils.WriteError(w, complianceStatus(err, http.StatusBadRequest), err.Error())
 		return
 	}
 
@@ -481,13 +523,50 @@ func (e *orderEndpoint) handleCancelOrder(w http.ResponseWriter, r *http.Request
 	err = e.orderService.CancelOrder(oc)
 	if err != nil {
 		logger.Error(err)
-		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		httputils.WriteError(w, complianceStatus(err, http.StatusInternalServerError), err.Error())
 		return
 	}
 
 	httputils.WriteJSON(w, http.StatusOK, oc.Hash)
 }
 
+// handleGetTypedData returns the EIP-712 typed data to sign, with eth_signTypedData_v4, for an
+// order, a cancel or a lending order or action
+func (e *orderEndpoint) handleGetTypedData(w http.ResponseWriter, r *http.Request) {
+	if !types.TypedDataSignatures() {
+		httputils.WriteError(w, http.StatusNotImplemented, types.ErrTypedDataDisabled.Error())
+		return
+	}
+
+	req := &types.TypedDataRequest{}
+
+	defer r.Body.Close()
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	td, err := req.TypedData()
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	hash, err := td.SignedHash()
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"typedData": td,
+		"hash":      hash,
+	})
+}
+
 // handleCancelAllOrder cancels all open/partial filled orders of an user address
 func (e *orderEndpoint) handleCancelAllOrders(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
@@ -509,7 +588,7 @@ func (e *orderEndpoint) handleCancelAllOrders(w http.ResponseWriter, r *http.Req
 
 	if err != nil {
 		logger.Error(err)
-		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		httputils.WriteError(w, complianceStatus(err, http.StatusInternalServerError), err.Error())
 		return
 	}
 
@@ -517,119 +596,108 @@ func (e *orderEndpoint) handleCancelAllOrders(w http.ResponseWriter, r *http.Req
 }
 
 // ws function handles incoming websocket messages on the order channel
-func (e *orderEndpoint) ws(input interface{}, c *ws.Client) {
+func (e *orderEndpoint) ws(input interface{}, c *ws.Client) error {
 	msg := &types.WebsocketEvent{}
 
 	bytes, _ := json.Marshal(input)
 	if err := json.Unmarshal(bytes, &msg); err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.OrderChannel, types.ERROR, err.Error())
+		return errors.InvalidPayload(err.Error())
 	}
 
 	switch msg.Type {
 	case "NEW_ORDER":
-		e.handleWSNewOrder(msg, c)
+		return e.handleWSNewOrder(msg, c)
 	case "CANCEL_ORDER":
-		e.handleWSCancelOrder(msg, c)
+		return e.handleWSCancelOrder(msg, c)
 	case "SUBSCRIBE":
-		e.handleWSSubOrder(msg, c)
+		return e.handleWSSubOrder(msg, c)
 	default:
-		log.Print("Response with error")
+		return errors.InvalidEvent(ws.OrderChannel, msg.Type)
 	}
 }
 
-func (e *orderEndpoint) handleWSSubOrder(ev *types.WebsocketEvent, c *ws.Client) {
+func (e *orderEndpoint) handleWSSubOrder(ev *types.WebsocketEvent, c *ws.Client) error {
 	var addr string
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
 	bytes, err := json.Marshal(ev.Payload)
 	if err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.OrderChannel, types.ERROR, err.Error())
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	logger.Debugf("Payload: %v#", ev.Payload)
 
 	err = json.Unmarshal(bytes, &addr)
 	if err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.LendingOrderChannel, types.ERROR, err.Error())
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	if !common.IsHexAddress(addr) {
-		c.SendMessage(ws.LendingOrderChannel, types.ERROR, errInvalidPayload)
-		return
+		return errors.InvalidPayload("Invalid address")
 	}
 
 	a := common.HexToAddress(addr)
+	if err := c.Authorize(ws.OrderChannel, a); err != nil {
+		return err
+	}
+
 	ws.RegisterOrderConnection(a, c)
 	ws.SendOrderMessage(types.INIT, a, nil)
+	return nil
 }
 
-// handleNewOrder handles NewOrder message. New order messages are transmitted to the order service after being unmarshalled
-func (e *orderEndpoint) handleWSNewOrder(ev *types.WebsocketEvent, c *ws.Client) {
+// handleNewOrder handles NewOrder message. New order messages are transmitted to the order service after being unmarshalled.
+// The errors of the order service are sent with the hash of the order like the engine responses.
+func (e *orderEndpoint) handleWSNewOrder(ev *types.WebsocketEvent, c *ws.Client) error {
 	o := &types.Order{}
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
 	bytes, err := json.Marshal(ev.Payload)
 	if err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.OrderChannel, types.ERROR, err.Error())
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	logger.Debugf("Payload: %v#", ev.Payload)
 
 	err = json.Unmarshal(bytes, &o)
 	if err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.OrderChannel, types.ERROR, err.Error())
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 	if o == nil {
-		c.SendMessage(ws.OrderChannel, types.ERROR, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 	if err := o.Validate(); err != nil {
-		c.SendMessage(ws.OrderChannel, types.ERROR, err.Error())
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
-	ws.RegisterOrderConnection(o.UserAddress, c)
-
-	acc, err := e.accountService.GetByAddress(o.UserAddress)
-	if err != nil {
-		logger.Error(err)
-		c.SendOrderErrorMessage(err, o.Hash)
+	if err := c.Authorize(ws.OrderChannel, o.UserAddress); err != nil {
+		return err
 	}
 
-	if acc.IsBlocked {
-		c.SendMessage(ws.OrderChannel, types.ERROR, errors.New("Account is blocked"))
-	}
+	ws.RegisterOrderConnection(o.UserAddress, c)
 
 	err = e.orderService.NewOrder(o)
 	if err != nil {
 		logger.Error(err)
 		c.SendOrderErrorMessage(err, o.Hash)
-		return
 	}
+
+	return nil
 }
 
 // handleCancelOrder handles CancelOrder message.
-func (e *orderEndpoint) handleWSCancelOrder(ev *types.WebsocketEvent, c *ws.Client) {
-	bytes, err := json.Marshal(ev.Payload)
+func (e *orderEndpoint) handleWSCancelOrder(ev *types.WebsocketEvent, c *ws.Client) error {
+	bytes, _ := json.Marshal(ev.Payload)
 	oc := &types.OrderCancel{}
 
-	err = json.Unmarshal(bytes, &oc)
+	err := json.Unmarshal(bytes, &oc)
 	if err != nil {
-		logger.Error(err)
-		c.SendOrderErrorMessage(err, oc.Hash)
+		return errors.InvalidPayload(err.Error())
 	}
 
 	addr, err := oc.GetSenderAddress()
 	if err != nil {
-		logger.Error(err)
-		c.SendOrderErrorMessage(err, oc.Hash)
+		return errors.InvalidPayload(err.Error())
+	}
+
+	if err := c.Authorize(ws.OrderChannel, addr); err != nil {
+		return err
 	}
 
 	ws.RegisterOrderConnection(addr, c)
@@ -638,12 +706,18 @@ func (e *orderEndpoint) handleWSCancelOrder(ev *types.WebsocketEvent, c *ws.Clie
 	if orderErr != nil {
 		logger.Error(orderErr)
 		c.SendOrderErrorMessage(orderErr, oc.Hash)
-		return
 	}
+
+	return nil
 }
 
 func (e *orderEndpoint) handleGetOrderNonce(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
+	if v.Get("reserve") != "" {
+		e.reserveNonce.ServeHTTP(w, r)
+		return
+	}
+
 	addr := v.Get("address")
 
 	if addr == "" {
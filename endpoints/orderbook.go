@@ -3,9 +3,11 @@ package endpoints
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
@@ -24,6 +26,7 @@ func ServeOrderBookResource(
 	e := &OrderBookEndpoint{orderBookService}
 	r.HandleFunc("/api/orderbook/raw", e.handleGetRawOrderBook)
 	r.HandleFunc("/api/orderbook/db", e.handleGetDbOrderBook)
+	r.HandleFunc("/api/orderbook/depth", e.handleGetOrderBookDepth)
 	r.HandleFunc("/api/orderbook", e.handleGetOrderBook)
 	ws.RegisterChannel(ws.OrderBookChannel, e.orderBookWebSocket)
 	ws.RegisterChannel(ws.RawOrderBookChannel, e.rawOrderBookWebSocket)
@@ -67,6 +70,84 @@ func (e *OrderBookEndpoint) handleGetOrderBook(w http.ResponseWriter, r *http.Re
 	httputils.WriteJSON(w, http.StatusOK, ob)
 }
 
+// handleGetOrderBookDepth returns the orderbook aggregated by tick size (in quote token units)
+func (e *OrderBookEndpoint) handleGetOrderBookDepth(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	bt := v.Get("baseToken")
+	qt := v.Get("quoteToken")
+
+	if bt == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "baseToken Parameter missing")
+		return
+	}
+
+	if qt == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "quoteToken Parameter missing")
+		return
+	}
+
+	if !common.IsHexAddress(bt) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Base Token Address")
+		return
+	}
+
+	if !common.IsHexAddress(qt) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Quote Token Address")
+		return
+	}
+
+	levels, cumulative, ok := parseDepthParams(w, r)
+	if !ok {
+		return
+	}
+
+	baseTokenAddress := common.HexToAddress(bt)
+	quoteTokenAddress := common.HexToAddress(qt)
+	ob, err := e.orderBookService.GetOrderBookDepth(baseTokenAddress, quoteTokenAddress, v.Get("tickSize"), levels, cumulative)
+	if err == types.ErrInvalidTickSize || err == types.ErrInvalidDepthLevels {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, ob)
+}
+
+// parseDepthParams parses the levels and cumulative params of the depth apis,
+// an error response is written when they are invalid
+func parseDepthParams(w http.ResponseWriter, r *http.Request) (int, bool, bool) {
+	v := r.URL.Query()
+	levels := 0
+	cumulative := false
+
+	if l := v.Get("levels"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 0 || n > types.MaxDepthLevels {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid levels")
+			return 0, false, false
+		}
+
+		levels = n
+	}
+
+	if c := v.Get("cumulative"); c != "" {
+		b, err := strconv.ParseBool(c)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid cumulative")
+			return 0, false, false
+		}
+
+		cumulative = b
+	}
+
+	return levels, cumulative, true
+}
+
 // orderBookEndpoint
 func (e *OrderBookEndpoint) handleGetRawOrderBook(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
@@ -141,18 +222,15 @@ func (e *OrderBookEndpoint) handleGetDbOrderBook(w http.ResponseWriter, r *http.
 }
 
 // liteOrderBookWebSocket
-func (e *OrderBookEndpoint) rawOrderBookWebSocket(input interface{}, c *ws.Client) {
+func (e *OrderBookEndpoint) rawOrderBookWebSocket(input interface{}, c *ws.Client) error {
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
 
 	err := json.Unmarshal(b, &ev)
 	if err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
-	socket := ws.GetRawOrderBookSocket()
-
 	b, _ = json.Marshal(ev.Payload)
 	var p *types.SubscriptionPayload
 
@@ -163,44 +241,37 @@ func (e *OrderBookEndpoint) rawOrderBookWebSocket(input interface{}, c *ws.Clien
 
 	if ev.Type == types.UNSUBSCRIBE {
 		e.orderBookService.UnsubscribeRawOrderBook(c)
-		return
+		return nil
 	}
 
 	if (p.BaseToken == common.Address{}) {
-		msg := map[string]string{"Message": "Invalid Base Token"}
-		socket.SendErrorMessage(c, msg)
-		return
+		return errors.InvalidPayload("Invalid Base Token")
 	}
 
 	if (p.QuoteToken == common.Address{}) {
-		msg := map[string]string{"Message": "Invalid Quote Token"}
-		socket.SendErrorMessage(c, msg)
-		return
+		return errors.InvalidPayload("Invalid Quote Token")
 	}
 
 	if ev.Type == types.SUBSCRIBE {
-		e.orderBookService.SubscribeRawOrderBook(c, p.BaseToken, p.QuoteToken)
+		return e.orderBookService.SubscribeRawOrderBook(c, p.BaseToken, p.QuoteToken)
 	}
+
+	return nil
 }
 
-func (e *OrderBookEndpoint) orderBookWebSocket(input interface{}, c *ws.Client) {
+func (e *OrderBookEndpoint) orderBookWebSocket(input interface{}, c *ws.Client) error {
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
 	err := json.Unmarshal(b, &ev)
 	if err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 	socket := ws.GetOrderBookSocket()
 	if ev == nil {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
-		logger.Info("Event Type", ev.Type)
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidEvent(ws.OrderBookChannel, ev.Type)
 	}
 
 	b, _ = json.Marshal(ev.Payload)
@@ -215,30 +286,31 @@ func (e *OrderBookEndpoint) orderBookWebSocket(input interface{}, c *ws.Client)
 
 	if ev.Type == types.SUBSCRIBE {
 		if p == nil {
-			socket.SendErrorMessage(c, errInvalidPayload)
-			return
+			return errors.InvalidPayload("empty payload")
 		}
 		if (p.BaseToken == common.Address{}) {
-			msg := map[string]string{"Message": "Invalid base token"}
-			socket.SendErrorMessage(c, msg)
-			return
+			return errors.InvalidPayload("Invalid base token")
 		}
 
 		if (p.QuoteToken == common.Address{}) {
-			msg := map[string]string{"Message": "Invalid quote token"}
-			socket.SendErrorMessage(c, msg)
-			return
+			return errors.InvalidPayload("Invalid quote token")
+		}
+
+		if p.TickSize != "" || p.Levels > 0 {
+			return e.orderBookService.SubscribeOrderBookDepth(c, p.BaseToken, p.QuoteToken, p.TickSize, p.Levels, p.Cumulative)
 		}
 
-		e.orderBookService.SubscribeOrderBook(c, p.BaseToken, p.QuoteToken)
+		return e.orderBookService.SubscribeOrderBook(c, p.BaseToken, p.QuoteToken)
 	}
 
 	if ev.Type == types.UNSUBSCRIBE {
 		if p == nil {
 			e.orderBookService.UnsubscribeOrderBook(c)
-			return
+			return nil
 		}
 
 		e.orderBookService.UnsubscribeOrderBookChannel(c, p.BaseToken, p.QuoteToken)
 	}
+
+	return nil
 }
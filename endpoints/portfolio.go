@@ -0,0 +1,103 @@
+package endpoints
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+)
+
+type portfolioEndpoint struct {
+	portfolioService interfaces.PortfolioService
+}
+
+// ServePortfolioResource sets up the routing of the portfolio endpoints
+func ServePortfolioResource(
+	r *mux.Router,
+	portfolioService interfaces.PortfolioService,
+) {
+	e := &portfolioEndpoint{portfolioService}
+	r.HandleFunc("/api/portfolio/{address}", e.handleGetPortfolio).Methods("GET")
+	r.HandleFunc("/api/portfolio/{address}/snapshots", e.handleGetSnapshots).Methods("GET")
+}
+
+// portfolioParams returns the address of the route and the cost basis method of the query,
+// fifo by default
+func portfolioParams(w http.ResponseWriter, r *http.Request) (common.Address, string, bool) {
+	addr := mux.Vars(r)["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return common.Address{}, "", false
+	}
+
+	method := r.URL.Query().Get("method")
+	if method == "" {
+		method = types.CostBasisFIFO
+	}
+
+	if method != types.CostBasisFIFO && method != types.CostBasisAverage {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid method, it must be fifo or average")
+		return common.Address{}, "", false
+	}
+
+	return common.HexToAddress(addr), method, true
+}
+
+func (e *portfolioEndpoint) handleGetPortfolio(w http.ResponseWriter, r *http.Request) {
+	addr, method, ok := portfolioParams(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := e.portfolioService.GetPortfolio(addr, method)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *portfolioEndpoint) handleGetSnapshots(w http.ResponseWriter, r *http.Request) {
+	addr, method, ok := portfolioParams(w, r)
+	if !ok {
+		return
+	}
+
+	v := r.URL.Query()
+	var from, to int64
+
+	if v.Get("from") != "" {
+		t, err := strconv.ParseInt(v.Get("from"), 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid from")
+			return
+		}
+
+		from = t
+	}
+
+	if v.Get("to") != "" {
+		t, err := strconv.ParseInt(v.Get("to"), 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid to")
+			return
+		}
+
+		to = t
+	}
+
+	res, err := e.portfolioService.GetSnapshots(addr, method, from, to)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,137 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/justinas/alice"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/middlewares"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+	"github.com/tomochain/tomox-sdk/ws"
+)
+
+// maxPreferencesSize is the maximum size of the saved preferences
+const maxPreferencesSize = 256 << 10
+
+type preferencesEndpoint struct {
+	preferencesService interfaces.PreferencesService
+}
+
+// ServePreferencesResource sets up the routing of the preferences endpoints, they require a
+// session opened for the address
+func ServePreferencesResource(
+	r *mux.Router,
+	preferencesService interfaces.PreferencesService,
+	authService interfaces.AuthService,
+) {
+	e := &preferencesEndpoint{preferencesService}
+	signed := alice.New(middlewares.Authenticate(authService))
+
+	r.Handle("/api/preferences/{address}", signed.Then(http.HandlerFunc(e.handleGetPreferences))).Methods("GET")
+	r.Handle("/api/preferences/{address}", signed.Then(http.HandlerFunc(e.handleSavePreferences))).Methods("PUT")
+
+	ws.RegisterChannel(ws.PreferencesChannel, e.handlePreferencesWebSocket)
+}
+
+func (e *preferencesEndpoint) handleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	addr, ok := signerAddress(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := e.preferencesService.Get(addr)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handleSavePreferences replaces the preferences of the address, the payload must carry the
+// version they were read at. The current preferences are returned with http.StatusConflict if
+// they were saved by another device since.
+func (e *preferencesEndpoint) handleSavePreferences(w http.ResponseWriter, r *http.Request) {
+	addr, ok := signerAddress(w, r)
+	if !ok {
+		return
+	}
+
+	p := &types.UserPreferences{}
+
+	defer r.Body.Close()
+
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPreferencesSize)).Decode(p)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	p.UserAddress = addr
+
+	err = e.preferencesService.Save(p)
+	if err == types.ErrPreferencesVersion {
+		current, err := e.preferencesService.Get(addr)
+		if err != nil {
+			logger.Error(err)
+			httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		httputils.WriteJSON(w, http.StatusConflict, current)
+		return
+	}
+
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, p)
+}
+
+// handlePreferencesWebSocket subscribes the connection to the preferences of an address, the
+// connection must be logged in with the address
+func (e *preferencesEndpoint) handlePreferencesWebSocket(input interface{}, c *ws.Client) error {
+	b, _ := json.Marshal(input)
+	var ev *types.WebsocketEvent
+	err := json.Unmarshal(b, &ev)
+	if err != nil {
+		return errors.InvalidPayload(err.Error())
+	}
+	if ev == nil {
+		return errors.InvalidPayload("empty payload")
+	}
+
+	b, _ = json.Marshal(ev.Payload)
+	var addr string
+
+	err = json.Unmarshal(b, &addr)
+	if err != nil {
+		return errors.InvalidPayload(err.Error())
+	}
+
+	if !common.IsHexAddress(addr) {
+		return errors.InvalidPayload("Invalid address")
+	}
+
+	a := common.HexToAddress(addr)
+
+	switch ev.Type {
+	case types.SUBSCRIBE:
+		return e.preferencesService.Subscribe(c, a)
+	case types.UNSUBSCRIBE:
+		e.preferencesService.Unsubscribe(c, a)
+		return nil
+	default:
+		return errors.InvalidEvent(ws.PreferencesChannel, ev.Type)
+	}
+}
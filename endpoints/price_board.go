@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/ws"
@@ -12,42 +13,37 @@ import (
 
 type PriceBoardEndpoint struct {
 	priceBoardService interfaces.PriceBoardService
+	fiatService       interfaces.FiatService
 }
 
 // ServePriceBoardResource sets up the routing of token endpoints and the corresponding handlers.
 func ServePriceBoardResource(
 	r *mux.Router,
 	priceBoardService interfaces.PriceBoardService,
+	fiatService interfaces.FiatService,
 ) {
-	e := &PriceBoardEndpoint{priceBoardService}
+	e := &PriceBoardEndpoint{priceBoardService, fiatService}
 
 	ws.RegisterChannel(ws.PriceBoardChannel, e.handlePriceBoardWebSocket)
 }
 
-func (e *PriceBoardEndpoint) handlePriceBoardWebSocket(input interface{}, c *ws.Client) {
-	socket := ws.GetPriceBoardSocket()
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
+func (e *PriceBoardEndpoint) handlePriceBoardWebSocket(input interface{}, c *ws.Client) error {
 	if input == nil {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
 
 	err := json.Unmarshal(b, &ev)
 	if err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 	if ev == nil {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 
 	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
-		logger.Info("Event Type", ev.Type)
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidEvent(ws.PriceBoardChannel, ev.Type)
 	}
 
 	b, _ = json.Marshal(ev.Payload)
@@ -55,38 +51,36 @@ func (e *PriceBoardEndpoint) handlePriceBoardWebSocket(input interface{}, c *ws.
 
 	err = json.Unmarshal(b, &p)
 	if err != nil {
-		logger.Error(err)
-		msg := map[string]string{"Message": "Internal server error"}
-		socket.SendErrorMessage(c, msg)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	if ev.Type == types.SUBSCRIBE {
 		if p == nil {
-			socket.SendErrorMessage(c, errInvalidPayload)
-			return
+			return errors.InvalidPayload("empty payload")
 		}
 		if (p.BaseToken == common.Address{}) {
-			msg := map[string]string{"Message": "Invalid base token"}
-			socket.SendErrorMessage(c, msg)
-			return
+			return errors.InvalidPayload("Invalid base token")
 		}
 
 		if (p.QuoteToken == common.Address{}) {
-			msg := map[string]string{"Message": "Invalid quote token"}
-			socket.SendErrorMessage(c, msg)
-			return
+			return errors.InvalidPayload("Invalid quote token")
 		}
 
-		e.priceBoardService.Subscribe(c, p.BaseToken, p.QuoteToken)
+		if p.Currency != "" && !e.fiatService.IsSupported(p.Currency) {
+			return errors.InvalidPayload("Invalid currency")
+		}
+
+		return e.priceBoardService.Subscribe(c, p.BaseToken, p.QuoteToken, p.Currency)
 	}
 
 	if ev.Type == types.UNSUBSCRIBE {
 		if p == nil {
 			e.priceBoardService.Unsubscribe(c)
-			return
+			return nil
 		}
 
-		e.priceBoardService.UnsubscribeChannel(c, p.BaseToken, p.QuoteToken)
+		e.priceBoardService.UnsubscribeChannel(c, p.BaseToken, p.QuoteToken, p.Currency)
 	}
+
+	return nil
 }
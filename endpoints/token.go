@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
 	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/services"
 	"github.com/tomochain/tomox-sdk/types"
@@ -124,13 +125,12 @@ func (e *tokenEndpoint) HandleGetToken(w http.ResponseWriter, r *http.Request) {
 }
 
 // ws function handles incoming websocket messages on the order channel
-func (e *tokenEndpoint) ws(input interface{}, c *ws.Client) {
+func (e *tokenEndpoint) ws(input interface{}, c *ws.Client) error {
 	// it means that we can handle not only WebSocketPayload but other Payloads as well
 	msg := &types.WebsocketEvent{}
 	bytes, _ := json.Marshal(input)
 	if err := json.Unmarshal(bytes, &msg); err != nil {
-		logger.Error(err)
-		c.SendMessage(ws.TokenChannel, types.ERROR, err.Error())
+		return errors.InvalidPayload(err.Error())
 	}
 
 	switch msg.Type {
@@ -138,9 +138,10 @@ func (e *tokenEndpoint) ws(input interface{}, c *ws.Client) {
 		e.handleGetTokensWS(msg, c)
 		log.Printf("Data: %+v", msg)
 	default:
-		log.Print("Response with error")
+		return errors.InvalidEvent(ws.TokenChannel, msg.Type)
 	}
 
+	return nil
 }
 
 // handleSubmitSignatures handles NewTrade messages. New trade messages are transmitted to the corresponding order channel
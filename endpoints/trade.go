@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils/httputils"
@@ -28,6 +29,7 @@ func ServeTradeResource(
 	e := &tradeEndpoint{tradeService, relayerService}
 	r.HandleFunc("/api/trades", e.HandleGetTrades)
 	r.HandleFunc("/api/trades/history", e.HandleGetTradesHistory)
+	r.HandleFunc("/api/trades/export", e.HandleExportTrades)
 	ws.RegisterChannel(ws.TradeChannel, e.tradeWebsocket)
 }
 
@@ -84,6 +86,15 @@ func (e *tradeEndpoint) HandleGetTrades(w http.ResponseWriter, r *http.Request)
 	if sortType != "asc" && sortType != "dec" {
 		sortType = "dec"
 	}
+
+	if _, ok := v["cursor"]; ok {
+		cursor, err := types.DecodeCursor(v.Get("cursor"), sortType == "asc")
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		tradeSpec.Cursor = cursor
+	}
 	if sortBy != "" {
 		if val, ok := sortedList[sortBy]; ok {
 			if sortType == "asc" {
@@ -196,6 +207,15 @@ func (e *tradeEndpoint) HandleGetTradesHistory(w http.ResponseWriter, r *http.Re
 	if sortType != "asc" && sortType != "dec" {
 		sortType = "dec"
 	}
+
+	if _, ok := v["cursor"]; ok {
+		cursor, err := types.DecodeCursor(v.Get("cursor"), sortType == "asc")
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		tradeSpec.Cursor = cursor
+	}
 	if sortBy != "" {
 		if val, ok := sortedList[sortBy]; ok {
 			if sortType == "asc" {
@@ -239,60 +259,89 @@ func (e *tradeEndpoint) HandleGetTradesHistory(w http.ResponseWriter, r *http.Re
 
 }
 
-func (e *tradeEndpoint) tradeWebsocket(input interface{}, c *ws.Client) {
+// HandleExportTrades streams the full trade history of a user or a pair as NDJSON or CSV
+func (e *tradeEndpoint) HandleExportTrades(w http.ResponseWriter, r *http.Request) {
+	p, err := parseExportParams(r.URL.Query())
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tradeSpec := types.TradeSpec{
+		RelayerAddress: e.relayerService.GetRelayerAddress(r),
+		DateFrom:       p.from,
+		DateTo:         p.to,
+	}
+
+	if (p.baseToken != common.Address{}) {
+		tradeSpec.BaseToken = p.baseToken.Hex()
+	}
+
+	if (p.quoteToken != common.Address{}) {
+		tradeSpec.QuoteToken = p.quoteToken.Hex()
+	}
+
+	exp, err := newExportWriter(w, p.format, "trades", types.TradeCSVHeader)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// the status is already sent once the first record is written, errors can only be logged
+	err = e.tradeService.StreamTrades(p.address, &tradeSpec, func(t *types.Trade) error {
+		return exp.Write(t, t.CSVRecord())
+	})
+	if err != nil {
+		logger.Error(err)
+	}
+
+	exp.Flush()
+}
+
+func (e *tradeEndpoint) tradeWebsocket(input interface{}, c *ws.Client) error {
 	b, _ := json.Marshal(input)
 	var ev *types.WebsocketEvent
-	errInvalidPayload := map[string]string{"Message": "Invalid payload"}
 	if err := json.Unmarshal(b, &ev); err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
-	socket := ws.GetTradeSocket()
 	if ev == nil {
-		socket.SendErrorMessage(c, errInvalidPayload)
-		return
+		return errors.InvalidPayload("empty payload")
 	}
 	if ev.Type != types.SUBSCRIBE && ev.Type != types.UNSUBSCRIBE {
-		logger.Info("Event Type", ev.Type)
-		err := map[string]string{"Message": "Invalid payload"}
-		socket.SendErrorMessage(c, err)
-		return
+		return errors.InvalidEvent(ws.TradeChannel, ev.Type)
 	}
 
 	b, _ = json.Marshal(ev.Payload)
 	var p *types.SubscriptionPayload
 	err := json.Unmarshal(b, &p)
 	if err != nil {
-		logger.Error(err)
-		return
+		return errors.InvalidPayload(err.Error())
 	}
 
 	if ev.Type == types.SUBSCRIBE {
 		if p == nil {
-			socket.SendErrorMessage(c, errInvalidPayload)
-			return
+			return errors.InvalidPayload("empty payload")
 		}
 		if (p.BaseToken == common.Address{}) {
-			err := map[string]string{"Message": "Invalid base token"}
-			socket.SendErrorMessage(c, err)
-			return
+			return errors.InvalidPayload("Invalid base token")
 		}
 
 		if (p.QuoteToken == common.Address{}) {
-			err := map[string]string{"Message": "Invalid quote token"}
-			socket.SendErrorMessage(c, err)
-			return
+			return errors.InvalidPayload("Invalid quote token")
 		}
 
-		e.tradeService.Subscribe(c, p.BaseToken, p.QuoteToken)
+		return e.tradeService.Subscribe(c, p.BaseToken, p.QuoteToken)
 	}
 
 	if ev.Type == types.UNSUBSCRIBE {
 		if p == nil {
 			e.tradeService.Unsubscribe(c)
-			return
+			return nil
 		}
 
 		e.tradeService.UnsubscribeChannel(c, p.BaseToken, p.QuoteToken)
 	}
+
+	return nil
 }
@@ -0,0 +1,375 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/gorilla/mux"
+	"github.com/justinas/alice"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/middlewares"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+)
+
+type webhookEndpoint struct {
+	webhookService interfaces.WebhookService
+}
+
+// ServeWebhookResource sets up the routing of the webhook endpoints. The users manage the
+// webhooks of their own events with a session, the relayer-wide webhooks require the api auth key.
+func ServeWebhookResource(
+	r *mux.Router,
+	webhookService interfaces.WebhookService,
+	authService interfaces.AuthService,
+) {
+	e := &webhookEndpoint{webhookService}
+
+	signed := alice.New(middlewares.Authenticate(authService))
+	r.Handle("/api/webhooks/users/{address}", signed.Then(http.HandlerFunc(e.handleGetUserWebhooks))).Methods("GET")
+	r.Handle("/api/webhooks/users/{address}", signed.Then(http.HandlerFunc(e.handleCreateUserWebhook))).Methods("POST")
+	r.Handle("/api/webhooks/users/{address}/{id}", signed.Then(http.HandlerFunc(e.handleDeleteUserWebhook))).Methods("DELETE")
+	r.Handle("/api/webhooks/users/{address}/{id}/deliveries", signed.Then(http.HandlerFunc(e.handleGetUserDeliveries))).Methods("GET")
+	r.Handle("/api/webhooks/users/{address}/{id}/replay", signed.Then(http.HandlerFunc(e.handleReplayUserDeliveries))).Methods("POST")
+
+	r.HandleFunc("/api/webhooks", middlewares.RequireAuthKey(e.handleGetWebhooks)).Methods("GET")
+	r.HandleFunc("/api/webhooks", middlewares.RequireAuthKey(e.handleCreateWebhook)).Methods("POST")
+	r.HandleFunc("/api/webhooks/deliveries/{id}/replay", middlewares.RequireAuthKey(e.handleReplayDelivery)).Methods("POST")
+	r.HandleFunc("/api/webhooks/{id}", middlewares.RequireAuthKey(e.handleGetWebhook)).Methods("GET")
+	r.HandleFunc("/api/webhooks/{id}", middlewares.RequireAuthKey(e.handleUpdateWebhook)).Methods("PUT")
+	r.HandleFunc("/api/webhooks/{id}", middlewares.RequireAuthKey(e.handleDeleteWebhook)).Methods("DELETE")
+	r.HandleFunc("/api/webhooks/{id}/deliveries", middlewares.RequireAuthKey(e.handleGetDeliveries)).Methods("GET")
+	r.HandleFunc("/api/webhooks/{id}/replay", middlewares.RequireAuthKey(e.handleReplayDeliveries)).Methods("POST")
+}
+
+func (e *webhookEndpoint) handleGetWebhooks(w http.ResponseWriter, r *http.Request) {
+	res, err := e.webhookService.GetAll()
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *webhookEndpoint) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	hook := &types.Webhook{}
+
+	defer r.Body.Close()
+
+	err := json.NewDecoder(r.Body).Decode(hook)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	res, err := e.webhookService.Create(hook)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, res)
+}
+
+func (e *webhookEndpoint) handleGetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := objectID(w, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	res, err := e.webhookService.GetByID(id)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if res == nil {
+		httputils.WriteError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handleUpdateWebhook updates the fields of the payload, the other fields are unchanged
+func (e *webhookEndpoint) handleUpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := objectID(w, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	hook, err := e.webhookService.GetByID(id)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if hook == nil {
+		httputils.WriteError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	defer r.Body.Close()
+
+	err = json.NewDecoder(r.Body).Decode(hook)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	hook.ID = id
+	res, err := e.webhookService.Update(hook)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *webhookEndpoint) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := objectID(w, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	err := e.webhookService.Delete(id)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, nil)
+}
+
+func (e *webhookEndpoint) handleGetDeliveries(w http.ResponseWriter, r *http.Request) {
+	spec, ok := deliverySpec(w, r)
+	if !ok {
+		return
+	}
+
+	v := r.URL.Query()
+	offset := 0
+	size := types.DefaultLimit
+
+	if pageOffset := v.Get("pageOffset"); pageOffset != "" {
+		t, err := strconv.Atoi(pageOffset)
+		if err != nil || t < 0 {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid page offset")
+			return
+		}
+		offset = t
+	}
+
+	if pageSize := v.Get("pageSize"); pageSize != "" {
+		t, err := strconv.Atoi(pageSize)
+		if err != nil || t <= 0 || t > 1000 {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid page size")
+			return
+		}
+		size = t
+	}
+
+	res, err := e.webhookService.GetDeliveries(spec, offset*size, size)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handleReplayDeliveries replays the deliveries of a webhook matching the status and date filters
+func (e *webhookEndpoint) handleReplayDeliveries(w http.ResponseWriter, r *http.Request) {
+	spec, ok := deliverySpec(w, r)
+	if !ok {
+		return
+	}
+
+	n, err := e.webhookService.ReplayAll(spec)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]int{"replayed": n})
+}
+
+func (e *webhookEndpoint) handleReplayDelivery(w http.ResponseWriter, r *http.Request) {
+	id, ok := objectID(w, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	res, err := e.webhookService.Replay(id)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+func (e *webhookEndpoint) handleGetUserWebhooks(w http.ResponseWriter, r *http.Request) {
+	a, ok := signerAddress(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := e.webhookService.GetByOwner(a)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// handleCreateUserWebhook registers a webhook receiving the events of the session address only
+func (e *webhookEndpoint) handleCreateUserWebhook(w http.ResponseWriter, r *http.Request) {
+	a, ok := signerAddress(w, r)
+	if !ok {
+		return
+	}
+
+	hook := &types.Webhook{}
+
+	defer r.Body.Close()
+
+	err := json.NewDecoder(r.Body).Decode(hook)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	hook.Owner = a
+	hook.Addresses = []common.Address{a}
+
+	res, err := e.webhookService.Create(hook)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, res)
+}
+
+func (e *webhookEndpoint) handleDeleteUserWebhook(w http.ResponseWriter, r *http.Request) {
+	if !e.ownedWebhook(w, r) {
+		return
+	}
+
+	e.handleDeleteWebhook(w, r)
+}
+
+func (e *webhookEndpoint) handleGetUserDeliveries(w http.ResponseWriter, r *http.Request) {
+	if !e.ownedWebhook(w, r) {
+		return
+	}
+
+	e.handleGetDeliveries(w, r)
+}
+
+func (e *webhookEndpoint) handleReplayUserDeliveries(w http.ResponseWriter, r *http.Request) {
+	if !e.ownedWebhook(w, r) {
+		return
+	}
+
+	e.handleReplayDeliveries(w, r)
+}
+
+// ownedWebhook checks that the webhook of the route is registered by the session address, the
+// webhooks of the other users and the relayer-wide ones are not found
+func (e *webhookEndpoint) ownedWebhook(w http.ResponseWriter, r *http.Request) bool {
+	a, ok := signerAddress(w, r)
+	if !ok {
+		return false
+	}
+
+	id, ok := objectID(w, mux.Vars(r)["id"])
+	if !ok {
+		return false
+	}
+
+	hook, err := e.webhookService.GetByID(id)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	if hook == nil || hook.Owner != a {
+		httputils.WriteError(w, http.StatusNotFound, "Webhook not found")
+		return false
+	}
+
+	return true
+}
+
+func objectID(w http.ResponseWriter, s string) (bson.ObjectId, bool) {
+	if !bson.IsObjectIdHex(s) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid id")
+		return "", false
+	}
+
+	return bson.ObjectIdHex(s), true
+}
+
+// deliverySpec parses the status, from and to filters of the deliveries of a webhook
+func deliverySpec(w http.ResponseWriter, r *http.Request) (*types.WebhookDeliverySpec, bool) {
+	id, ok := objectID(w, mux.Vars(r)["id"])
+	if !ok {
+		return nil, false
+	}
+
+	v := r.URL.Query()
+	spec := &types.WebhookDeliverySpec{
+		WebhookID: id,
+		Status:    v.Get("status"),
+	}
+
+	switch spec.Status {
+	case "", types.DeliveryStatusPending, types.DeliveryStatusSuccess, types.DeliveryStatusFailed:
+	default:
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid status")
+		return nil, false
+	}
+
+	if from := v.Get("from"); from != "" {
+		t, err := strconv.ParseInt(from, 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid from")
+			return nil, false
+		}
+		spec.DateFrom = t
+	}
+
+	if to := v.Get("to"); to != "" {
+		t, err := strconv.ParseInt(to, 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid to")
+			return nil, false
+		}
+		spec.DateTo = t
+	}
+
+	return spec, true
+}
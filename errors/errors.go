@@ -27,6 +27,32 @@ func Unauthorized(err string) *APIError {
 	return NewHTTPError(http.StatusUnauthorized, "UNAUTHORIZED", Params{"error": err})
 }
 
+// InvalidMessage creates a new API error representing a websocket message that can not be parsed
+func InvalidMessage(err string) *APIError {
+	return NewHTTPError(http.StatusBadRequest, "INVALID_MESSAGE", Params{"error": err})
+}
+
+// InvalidChannel creates a new API error representing a websocket message sent to an unknown channel
+func InvalidChannel(channel string) *APIError {
+	return NewHTTPError(http.StatusBadRequest, "INVALID_CHANNEL", Params{"channel": channel})
+}
+
+// InvalidEvent creates a new API error representing a websocket event not supported by a channel
+func InvalidEvent(channel string, event interface{}) *APIError {
+	return NewHTTPError(http.StatusBadRequest, "INVALID_EVENT", Params{"channel": channel, "event": event})
+}
+
+// InvalidPayload creates a new API error representing a websocket message with an invalid payload
+func InvalidPayload(err string) *APIError {
+	return NewHTTPError(http.StatusBadRequest, "INVALID_PAYLOAD", Params{"error": err})
+}
+
+// LoginRequired creates a new API error representing a private websocket channel accessed without
+// being logged in with the address (HTTP 403)
+func LoginRequired(channel, address string) *APIError {
+	return NewHTTPError(http.StatusForbidden, "LOGIN_REQUIRED", Params{"channel": channel, "address": address})
+}
+
 // InvalidData converts a data validation error into an API error (HTTP 400)
 func InvalidData(errs validation.Errors) *APIError {
 	result := []validationError{}
@@ -28,3 +28,11 @@ func TestInvalidData(t *testing.T) {
 func TestNotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, NotFound("abc").Status)
 }
+
+func TestWebsocketErrors(t *testing.T) {
+	assert.Equal(t, "INVALID_MESSAGE", InvalidMessage("t").ErrorCode)
+	assert.Equal(t, "INVALID_CHANNEL", InvalidChannel("abc").ErrorCode)
+	assert.Equal(t, "INVALID_EVENT", InvalidEvent("abc", "xyz").ErrorCode)
+	assert.Equal(t, "INVALID_PAYLOAD", InvalidPayload("t").ErrorCode)
+	assert.Equal(t, http.StatusForbidden, LoginRequired("abc", "0x1").Status)
+}
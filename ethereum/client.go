@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type SimulatedClient struct {
@@ -20,6 +21,10 @@ func (b *SimulatedClient) PendingBalanceAt(ctx context.Context, acc common.Addre
 	return nil, errors.New("PendingBalanceAt is not implemented on the simulated backend")
 }
 
+func (b *SimulatedClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return nil, errors.New("HeaderByNumber is not implemented on the simulated backend")
+}
+
 func NewSimulatedClientWithGasLimit(accs []common.Address, gasLimit uint64) *SimulatedClient {
 	weiBalance := &big.Int{}
 	ether := big.NewInt(1e18)
@@ -0,0 +1,54 @@
+package fix
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// parseDecimal converts a decimal quantity or price to an amount of the token with the
+// given decimals. The value must be positive and have at most decimals digits after the point.
+func parseDecimal(s string, decimals int) (*big.Int, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || strings.ContainsAny(s, "eE/") {
+		return nil, fmt.Errorf("invalid decimal value %s", s)
+	}
+
+	if r.Sign() <= 0 {
+		return nil, fmt.Errorf("the value %s is not strictly positive", s)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(pow10(decimals)))
+	if !r.IsInt() {
+		return nil, fmt.Errorf("the value %s has more than %d decimals", s, decimals)
+	}
+
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// formatDecimal converts an amount of the token with the given decimals to a decimal value
+func formatDecimal(n *big.Int, decimals int) string {
+	if n == nil {
+		return "0"
+	}
+
+	return trimZeros(new(big.Rat).SetFrac(n, pow10(decimals)).FloatString(decimals))
+}
+
+// formatRat formats a rational value rounded to the given decimals
+func formatRat(r *big.Rat, decimals int) string {
+	return trimZeros(r.FloatString(decimals))
+}
+
+func trimZeros(s string) string {
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(s, "0")
+		s = strings.TrimSuffix(s, ".")
+	}
+
+	return s
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,533 @@
+// Package fix implements a FIX 4.4 acceptor for order entry and market data. The orders of a
+// session are signed by the gateway with the delegated key of its account.
+package fix
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils"
+)
+
+var logger = utils.Logger
+
+// order is an order placed by a session, it is tracked to send the execution reports
+type order struct {
+	compID      string
+	clOrdID     string
+	origClOrdID string
+	ordType     string
+	pair        *types.Pair
+	order       *types.Order
+
+	cumQty   *big.Int
+	notional *big.Int
+	trades   map[common.Hash]bool
+
+	// the ClOrdID of the pending cancel request, and the order replacing this one once cancelled
+	cancelClOrdID string
+	replacement   *order
+}
+
+// Gateway accepts the FIX sessions, places their orders and sends the execution reports and
+// the market data from the hooks of the services
+type Gateway struct {
+	compID            string
+	fixAccountService interfaces.FixAccountService
+	orderService      interfaces.OrderService
+	orderBookService  interfaces.OrderBookService
+	pairDao           interfaces.PairDao
+
+	sessions map[string]*session
+	orders   map[common.Hash]*order
+	execID   uint64
+	mutex    sync.RWMutex
+}
+
+// NewGateway returns a new instance of Gateway, compID is the SenderCompID of the gateway
+func NewGateway(
+	compID string,
+	fixAccountService interfaces.FixAccountService,
+	orderService interfaces.OrderService,
+	orderBookService interfaces.OrderBookService,
+	pairDao interfaces.PairDao,
+) *Gateway {
+	return &Gateway{
+		compID:            compID,
+		fixAccountService: fixAccountService,
+		orderService:      orderService,
+		orderBookService:  orderBookService,
+		pairDao:           pairDao,
+		sessions:          make(map[string]*session),
+		orders:            make(map[common.Hash]*order),
+	}
+}
+
+// ListenAndServe accepts the FIX sessions over TLS on the tcp address, with the certificate and
+// private key of the PEM files
+func (g *Gateway) ListenAndServe(address, certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+
+	lis, err := tls.Listen("tcp", address, &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		return err
+	}
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			return err
+		}
+
+		go newSession(g, conn).serve()
+	}
+}
+
+func (g *Gateway) register(s *session) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.sessions[s.senderCompID] != nil {
+		return false
+	}
+
+	g.sessions[s.senderCompID] = s
+	return true
+}
+
+func (g *Gateway) unregister(s *session) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.sessions[s.senderCompID] == s {
+		delete(g.sessions, s.senderCompID)
+	}
+}
+
+func (g *Gateway) getSession(compID string) *session {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return g.sessions[compID]
+}
+
+func (g *Gateway) getOrder(hash common.Hash) *order {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return g.orders[hash]
+}
+
+// findOrder returns the open order of a session with the ClOrdID
+func (g *Gateway) findOrder(compID, clOrdID string) *order {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	for _, o := range g.orders {
+		if o.compID == compID && o.clOrdID == clOrdID {
+			return o
+		}
+	}
+
+	return nil
+}
+
+func (g *Gateway) nextExecID() string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.execID++
+	return fmt.Sprintf("%d-%d", time.Now().Unix(), g.execID)
+}
+
+func (g *Gateway) handleNewOrderSingle(s *session, m *Message) {
+	o, err := g.newOrder(s, m, m.Get(tagClOrdID))
+	if err != nil {
+		g.sendRejected(s, m, err.Error())
+		return
+	}
+
+	err = g.placeOrder(s, o)
+	if err != nil {
+		g.sendReport(o, "8", "8", err.Error())
+		return
+	}
+
+	g.sendReport(o, "A", "A", "")
+}
+
+// newOrder builds the order of a NewOrderSingle or an OrderCancelReplaceRequest
+func (g *Gateway) newOrder(s *session, m *Message, clOrdID string) (*order, error) {
+	if clOrdID == "" {
+		return nil, fmt.Errorf("ClOrdID is required")
+	}
+
+	if g.findOrder(s.senderCompID, clOrdID) != nil {
+		return nil, fmt.Errorf("Duplicate ClOrdID %s", clOrdID)
+	}
+
+	pair, err := g.pairDao.GetByName(m.Get(tagSymbol))
+	if err != nil || pair == nil {
+		return nil, fmt.Errorf("Unknown symbol %s", m.Get(tagSymbol))
+	}
+
+	o := &types.Order{
+		UserAddress:     s.wallet.Address,
+		ExchangeAddress: common.HexToAddress(app.Config.Tomochain["exchange_address"]),
+		BaseToken:       pair.BaseTokenAddress,
+		QuoteToken:      pair.QuoteTokenAddress,
+		Status:          types.OrderStatusOpen,
+	}
+
+	switch m.Get(tagSide) {
+	case "1":
+		o.Side = types.BUY
+	case "2":
+		o.Side = types.SELL
+	default:
+		return nil, fmt.Errorf("Unsupported Side %s", m.Get(tagSide))
+	}
+
+	ordType := m.Get(tagOrdType)
+	switch ordType {
+	case "1":
+		o.Type = types.TypeMarketOrder
+	case "2":
+		o.Type = types.TypeLimitOrder
+		o.PricePoint, err = parseDecimal(m.Get(tagPrice), pair.QuoteTokenDecimals)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid Price: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported OrdType %s", ordType)
+	}
+
+	o.Amount, err = parseDecimal(m.Get(tagOrderQty), pair.BaseTokenDecimals)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid OrderQty: %v", err)
+	}
+
+	if o.PricePoint == nil {
+		o.PricePoint = big.NewInt(0)
+	}
+
+	return &order{
+		compID:   s.senderCompID,
+		clOrdID:  clOrdID,
+		ordType:  ordType,
+		pair:     pair,
+		order:    o,
+		cumQty:   big.NewInt(0),
+		notional: big.NewInt(0),
+		trades:   make(map[common.Hash]bool),
+	}, nil
+}
+
+// placeOrder signs the order with the delegated key of the session and sends it to the engine
+func (g *Gateway) placeOrder(s *session, o *order) error {
+	nonce, err := s.nextNonce()
+	if err != nil {
+		return err
+	}
+
+	o.order.Nonce = nonce
+	err = o.order.Sign(s.wallet)
+	if err != nil {
+		return err
+	}
+
+	g.mutex.Lock()
+	g.orders[o.order.Hash] = o
+	g.mutex.Unlock()
+
+	err = g.orderService.NewOrder(o.order)
+	if err != nil {
+		g.mutex.Lock()
+		delete(g.orders, o.order.Hash)
+		g.mutex.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+func (g *Gateway) handleOrderCancelRequest(s *session, m *Message) {
+	o := g.findOrder(s.senderCompID, m.Get(tagOrigClOrdID))
+	if o == nil {
+		g.sendCancelReject(s, m, "1", "1", "Unknown order")
+		return
+	}
+
+	err := g.cancelOrder(s, o, m.Get(tagClOrdID))
+	if err != nil {
+		g.sendCancelReject(s, m, "1", "0", err.Error())
+	}
+}
+
+// handleOrderCancelReplaceRequest cancels the order and places the replacing order once the
+// cancellation is confirmed by the engine, the orders can not be amended in place
+func (g *Gateway) handleOrderCancelReplaceRequest(s *session, m *Message) {
+	o := g.findOrder(s.senderCompID, m.Get(tagOrigClOrdID))
+	if o == nil {
+		g.sendCancelReject(s, m, "2", "1", "Unknown order")
+		return
+	}
+
+	replacement, err := g.newOrder(s, m, m.Get(tagClOrdID))
+	if err != nil {
+		g.sendCancelReject(s, m, "2", "0", err.Error())
+		return
+	}
+
+	if replacement.pair.Code() != o.pair.Code() || replacement.order.Side != o.order.Side {
+		g.sendCancelReject(s, m, "2", "0", "The symbol and side of an order can not be replaced")
+		return
+	}
+
+	replacement.origClOrdID = o.clOrdID
+
+	g.mutex.Lock()
+	o.replacement = replacement
+	g.mutex.Unlock()
+
+	err = g.cancelOrder(s, o, m.Get(tagClOrdID))
+	if err != nil {
+		g.mutex.Lock()
+		o.replacement = nil
+		g.mutex.Unlock()
+		g.sendCancelReject(s, m, "2", "0", err.Error())
+	}
+}
+
+func (g *Gateway) cancelOrder(s *session, o *order, clOrdID string) error {
+	nonce, err := s.nextNonce()
+	if err != nil {
+		return err
+	}
+
+	oc := &types.OrderCancel{
+		OrderHash:       o.order.Hash,
+		Nonce:           nonce,
+		OrderID:         o.order.OrderID,
+		Status:          types.OrderStatusCancelled,
+		UserAddress:     o.order.UserAddress,
+		ExchangeAddress: o.order.ExchangeAddress,
+	}
+
+	err = oc.Sign(s.wallet)
+	if err != nil {
+		return err
+	}
+
+	g.mutex.Lock()
+	o.cancelClOrdID = clOrdID
+	g.mutex.Unlock()
+
+	return g.orderService.CancelOrder(oc)
+}
+
+// NotifyEngineResponse sends the execution reports of the orders added, cancelled or rejected
+// by the engine. The fills are reported from the trades.
+func (g *Gateway) NotifyEngineResponse(res *types.EngineResponse) {
+	if res.Order == nil {
+		return
+	}
+
+	o := g.getOrder(res.Order.Hash)
+	if o == nil {
+		return
+	}
+
+	g.mutex.Lock()
+	if res.Order.OrderID != 0 {
+		o.order.OrderID = res.Order.OrderID
+	}
+	g.mutex.Unlock()
+
+	switch res.Status {
+	case types.ORDER_ADDED:
+		g.sendReport(o, "0", "0", "")
+	case types.ORDER_CANCELLED:
+		g.removeOrder(o)
+		g.handleCancelled(o)
+	case types.ORDER_REJECTED, types.ERROR_STATUS:
+		g.removeOrder(o)
+		g.sendReport(o, "8", "8", "Rejected by the engine")
+	}
+}
+
+func (g *Gateway) handleCancelled(o *order) {
+	if o.replacement == nil {
+		g.sendReport(o, "4", "4", "")
+		return
+	}
+
+	s := g.getSession(o.compID)
+	if s == nil {
+		return
+	}
+
+	r := o.replacement
+	err := g.placeOrder(s, r)
+	if err != nil {
+		g.sendReport(r, "8", "8", err.Error())
+		return
+	}
+
+	g.sendReport(r, "5", "0", "")
+}
+
+// NotifyTrades sends the execution reports of the fills of the orders of the sessions
+func (g *Gateway) NotifyTrades(trades []*types.Trade) {
+	for _, t := range trades {
+		for _, hash := range []common.Hash{t.MakerOrderHash, t.TakerOrderHash} {
+			o := g.getOrder(hash)
+			if o == nil {
+				continue
+			}
+
+			g.mutex.Lock()
+			if o.trades[t.Hash] {
+				g.mutex.Unlock()
+				continue
+			}
+
+			o.trades[t.Hash] = true
+			o.cumQty.Add(o.cumQty, t.Amount)
+			o.notional.Add(o.notional, new(big.Int).Mul(t.Amount, t.PricePoint))
+			filled := o.cumQty.Cmp(o.order.Amount) >= 0
+			g.mutex.Unlock()
+
+			status := "1"
+			if filled {
+				status = "2"
+				g.removeOrder(o)
+			}
+
+			g.sendFill(o, t, status)
+		}
+	}
+}
+
+func (g *Gateway) removeOrder(o *order) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	delete(g.orders, o.order.Hash)
+}
+
+// sendReport sends an ExecutionReport of the order to its session
+func (g *Gateway) sendReport(o *order, execType, ordStatus, text string) {
+	g.sendExecutionReport(o, execType, ordStatus, text, nil)
+}
+
+func (g *Gateway) sendFill(o *order, t *types.Trade, ordStatus string) {
+	g.sendExecutionReport(o, "F", ordStatus, "", t)
+}
+
+func (g *Gateway) sendExecutionReport(o *order, execType, ordStatus, text string, t *types.Trade) {
+	s := g.getSession(o.compID)
+	if s == nil {
+		return
+	}
+
+	g.mutex.RLock()
+	clOrdID, origClOrdID := o.clOrdID, o.origClOrdID
+	if execType == "4" {
+		clOrdID, origClOrdID = o.cancelClOrdID, o.clOrdID
+	}
+
+	cumQty := new(big.Int).Set(o.cumQty)
+	avgPx := "0"
+	if cumQty.Sign() > 0 {
+		avgPx = formatRat(new(big.Rat).SetFrac(o.notional, new(big.Int).Mul(cumQty, o.pair.QuoteTokenMultiplier())), o.pair.QuoteTokenDecimals)
+	}
+	g.mutex.RUnlock()
+
+	leavesQty := new(big.Int).Sub(o.order.Amount, cumQty)
+	if ordStatus == "2" || ordStatus == "4" || ordStatus == "8" || leavesQty.Sign() < 0 {
+		leavesQty = big.NewInt(0)
+	}
+
+	m := NewMessage(msgTypeExecutionReport).
+		Add(tagOrderID, o.order.Hash.Hex()).
+		Add(tagClOrdID, clOrdID)
+
+	if origClOrdID != "" {
+		m.Add(tagOrigClOrdID, origClOrdID)
+	}
+
+	m.Add(tagExecID, g.nextExecID()).
+		Add(tagExecType, execType).
+		Add(tagOrdStatus, ordStatus).
+		Add(tagSymbol, o.pair.Name()).
+		Add(tagSide, fixSide(o.order.Side)).
+		Add(tagOrderQty, formatDecimal(o.order.Amount, o.pair.BaseTokenDecimals)).
+		Add(tagOrdType, o.ordType)
+
+	if o.order.Type == types.TypeLimitOrder {
+		m.Add(tagPrice, formatDecimal(o.order.PricePoint, o.pair.QuoteTokenDecimals))
+	}
+
+	if t != nil {
+		m.Add(tagLastQty, formatDecimal(t.Amount, o.pair.BaseTokenDecimals)).
+			Add(tagLastPx, formatDecimal(t.PricePoint, o.pair.QuoteTokenDecimals))
+	}
+
+	m.Add(tagLeavesQty, formatDecimal(leavesQty, o.pair.BaseTokenDecimals)).
+		Add(tagCumQty, formatDecimal(cumQty, o.pair.BaseTokenDecimals)).
+		Add(tagAvgPx, avgPx).
+		Add(tagTransactTime, time.Now().UTC().Format(sendingTimeFormat))
+
+	if text != "" {
+		m.Add(tagText, text)
+	}
+
+	s.send(m)
+}
+
+// sendRejected rejects a NewOrderSingle which could not be converted to an order
+func (g *Gateway) sendRejected(s *session, m *Message, text string) {
+	s.send(NewMessage(msgTypeExecutionReport).
+		Add(tagOrderID, "NONE").
+		Add(tagClOrdID, m.Get(tagClOrdID)).
+		Add(tagExecID, g.nextExecID()).
+		Add(tagExecType, "8").
+		Add(tagOrdStatus, "8").
+		Add(tagOrdRejReason, "99").
+		Add(tagSymbol, m.Get(tagSymbol)).
+		Add(tagSide, m.Get(tagSide)).
+		Add(tagLeavesQty, "0").
+		Add(tagCumQty, "0").
+		Add(tagAvgPx, "0").
+		Add(tagTransactTime, time.Now().UTC().Format(sendingTimeFormat)).
+		Add(tagText, text))
+}
+
+// sendCancelReject sends an OrderCancelReject, responseTo is 1 for a cancel and 2 for a
+// replace, reason is 1 for an unknown order and 0 otherwise
+func (g *Gateway) sendCancelReject(s *session, m *Message, responseTo, reason, text string) {
+	s.send(NewMessage(msgTypeOrderCancelReject).
+		Add(tagOrderID, "NONE").
+		Add(tagClOrdID, m.Get(tagClOrdID)).
+		Add(tagOrigClOrdID, m.Get(tagOrigClOrdID)).
+		Add(tagOrdStatus, "8").
+		Add(tagCxlRejResponseTo, responseTo).
+		Add(tagCxlRejReason, reason).
+		Add(tagText, text))
+}
+
+func fixSide(side string) string {
+	if side == types.BUY {
+		return "1"
+	}
+
+	return "2"
+}
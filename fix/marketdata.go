@@ -0,0 +1,262 @@
+package fix
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// Types of the market data entries
+const (
+	mdEntryBid   = "0"
+	mdEntryOffer = "1"
+)
+
+// mdSubscription is a MarketDataRequest subscribed to the incremental refreshes. The price
+// levels sent to the client are tracked to tell the new levels from the changed ones.
+type mdSubscription struct {
+	mdReqID    string
+	pairs      map[string]*types.Pair
+	entryTypes map[string]bool
+	levels     map[string]map[string]map[string]bool
+}
+
+func (g *Gateway) handleMarketDataRequest(s *session, m *Message) {
+	mdReqID := m.Get(tagMDReqID)
+	requestType := m.Get(tagSubscriptionRequestType)
+
+	if requestType == "2" {
+		g.mutex.Lock()
+		delete(s.subscriptions, mdReqID)
+		g.mutex.Unlock()
+		return
+	}
+
+	if requestType != "0" && requestType != "1" {
+		g.sendMarketDataReject(s, mdReqID, "4", "Unsupported SubscriptionRequestType")
+		return
+	}
+
+	// a MarketDepth of 0 requests the full book
+	depth := 0
+	if v := m.Get(tagMarketDepth); v != "" {
+		var err error
+		depth, err = strconv.Atoi(v)
+		if err != nil || depth < 0 {
+			g.sendMarketDataReject(s, mdReqID, "5", "Invalid MarketDepth")
+			return
+		}
+	}
+
+	entryTypes := map[string]bool{}
+	for _, t := range m.GetAll(tagMDEntryType) {
+		if t != mdEntryBid && t != mdEntryOffer {
+			g.sendMarketDataReject(s, mdReqID, "8", "Unsupported MDEntryType "+t)
+			return
+		}
+
+		entryTypes[t] = true
+	}
+
+	if len(entryTypes) == 0 {
+		entryTypes[mdEntryBid] = true
+		entryTypes[mdEntryOffer] = true
+	}
+
+	sub := &mdSubscription{
+		mdReqID:    mdReqID,
+		pairs:      map[string]*types.Pair{},
+		entryTypes: entryTypes,
+		levels:     map[string]map[string]map[string]bool{},
+	}
+
+	symbols := m.GetAll(tagSymbol)
+	if len(symbols) == 0 {
+		g.sendMarketDataReject(s, mdReqID, "0", "No symbol requested")
+		return
+	}
+
+	for _, symbol := range symbols {
+		pair, err := g.pairDao.GetByName(symbol)
+		if err != nil || pair == nil {
+			g.sendMarketDataReject(s, mdReqID, "0", "Unknown symbol "+symbol)
+			return
+		}
+
+		sub.pairs[pair.Code()] = pair
+	}
+
+	for _, pair := range sub.pairs {
+		ob, err := g.orderBookService.GetOrderBook(pair.BaseTokenAddress, pair.QuoteTokenAddress)
+		if err != nil {
+			g.sendMarketDataReject(s, mdReqID, "0", err.Error())
+			return
+		}
+
+		snapshot := NewMessage(msgTypeMarketDataSnapshot).
+			Add(tagMDReqID, mdReqID).
+			Add(tagSymbol, pair.Name())
+
+		levels := map[string]map[string]bool{mdEntryBid: {}, mdEntryOffer: {}}
+		entries := []Field{}
+		for _, entryType := range []string{mdEntryBid, mdEntryOffer} {
+			side := ob.Bids
+			if entryType == mdEntryOffer {
+				side = ob.Asks
+			}
+
+			if !entryTypes[entryType] {
+				continue
+			}
+
+			for i, level := range sortLevels(side, entryType) {
+				if depth > 0 && i >= depth {
+					break
+				}
+
+				levels[entryType][level["pricepoint"]] = true
+				entries = append(entries, mdEntry(pair, entryType, level, false)...)
+			}
+		}
+
+		snapshot.Add(tagNoMDEntries, strconv.Itoa(countEntries(entries)))
+		snapshot.Fields = append(snapshot.Fields, entries...)
+		s.send(snapshot)
+
+		sub.levels[pair.Code()] = levels
+	}
+
+	if requestType == "1" {
+		g.mutex.Lock()
+		s.subscriptions[mdReqID] = sub
+		g.mutex.Unlock()
+	}
+}
+
+// NotifyOrderBookUpdate sends the updated price levels to the sessions subscribed to the pair.
+// MarketDepth only limits the snapshot, the refreshes carry every updated level.
+func (g *Gateway) NotifyOrderBookUpdate(bt, qt common.Address, bids, asks []map[string]string) {
+	code := bt.Hex() + "::" + qt.Hex()
+
+	type refresh struct {
+		s *session
+		m *Message
+	}
+	refreshes := []refresh{}
+
+	g.mutex.Lock()
+	for _, s := range g.sessions {
+		for _, sub := range s.subscriptions {
+			pair := sub.pairs[code]
+			if pair == nil {
+				continue
+			}
+
+			entries := []Field{}
+			for _, entryType := range []string{mdEntryBid, mdEntryOffer} {
+				side := bids
+				if entryType == mdEntryOffer {
+					side = asks
+				}
+
+				if !sub.entryTypes[entryType] {
+					continue
+				}
+
+				known := sub.levels[code][entryType]
+				for _, level := range side {
+					pp := level["pricepoint"]
+					amount, _ := new(big.Int).SetString(level["amount"], 10)
+
+					action := "1"
+					switch {
+					case amount == nil || amount.Sign() == 0:
+						if !known[pp] {
+							continue
+						}
+						action = "2"
+						delete(known, pp)
+					case !known[pp]:
+						action = "0"
+						known[pp] = true
+					}
+
+					entries = append(entries, Field{tagMDUpdateAction, action})
+					entries = append(entries, mdEntry(pair, entryType, level, true)...)
+				}
+			}
+
+			if len(entries) == 0 {
+				continue
+			}
+
+			m := NewMessage(msgTypeMarketDataIncremental).
+				Add(tagMDReqID, sub.mdReqID).
+				Add(tagNoMDEntries, strconv.Itoa(countEntries(entries)))
+			m.Fields = append(m.Fields, entries...)
+			refreshes = append(refreshes, refresh{s, m})
+		}
+	}
+	g.mutex.Unlock()
+
+	for _, r := range refreshes {
+		r.s.send(r.m)
+	}
+}
+
+// mdEntry returns the fields of a price level, the symbol is in the entries of the refreshes
+func mdEntry(pair *types.Pair, entryType string, level map[string]string, refresh bool) []Field {
+	pp, _ := new(big.Int).SetString(level["pricepoint"], 10)
+	amount, _ := new(big.Int).SetString(level["amount"], 10)
+
+	fields := []Field{{tagMDEntryType, entryType}}
+	if refresh {
+		fields = append(fields, Field{tagSymbol, pair.Name()})
+	}
+
+	return append(fields,
+		Field{tagMDEntryPx, formatDecimal(pp, pair.QuoteTokenDecimals)},
+		Field{tagMDEntrySize, formatDecimal(amount, pair.BaseTokenDecimals)},
+	)
+}
+
+func (g *Gateway) sendMarketDataReject(s *session, mdReqID, reason, text string) {
+	s.send(NewMessage(msgTypeMarketDataRequestReject).
+		Add(tagMDReqID, mdReqID).
+		Add(tagMDReqRejReason, reason).
+		Add(tagText, text))
+}
+
+// sortLevels sorts the bids by decreasing price and the offers by increasing price
+func sortLevels(levels []map[string]string, entryType string) []map[string]string {
+	price := func(i int) *big.Int {
+		pp, ok := new(big.Int).SetString(levels[i]["pricepoint"], 10)
+		if !ok {
+			return big.NewInt(0)
+		}
+		return pp
+	}
+
+	sort.SliceStable(levels, func(i, j int) bool {
+		if entryType == mdEntryBid {
+			return price(i).Cmp(price(j)) > 0
+		}
+		return price(i).Cmp(price(j)) < 0
+	})
+
+	return levels
+}
+
+func countEntries(entries []Field) int {
+	n := 0
+	for _, f := range entries {
+		if f.Tag == tagMDEntryType {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,274 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// BeginString is the version of the protocol supported by the gateway
+const BeginString = "FIX.4.4"
+
+const soh = '\x01'
+
+// Tags of the fields used by the gateway
+const (
+	tagAvgPx                   = 6
+	tagBeginSeqNo              = 7
+	tagBeginString             = 8
+	tagBodyLength              = 9
+	tagCheckSum                = 10
+	tagClOrdID                 = 11
+	tagCumQty                  = 14
+	tagEndSeqNo                = 16
+	tagExecID                  = 17
+	tagLastPx                  = 31
+	tagLastQty                 = 32
+	tagMsgSeqNum               = 34
+	tagMsgType                 = 35
+	tagNewSeqNo                = 36
+	tagOrderID                 = 37
+	tagOrderQty                = 38
+	tagOrdStatus               = 39
+	tagOrdType                 = 40
+	tagOrigClOrdID             = 41
+	tagPossDupFlag             = 43
+	tagPrice                   = 44
+	tagRefSeqNum               = 45
+	tagSenderCompID            = 49
+	tagSendingTime             = 52
+	tagSide                    = 54
+	tagSymbol                  = 55
+	tagTargetCompID            = 56
+	tagText                    = 58
+	tagTransactTime            = 60
+	tagEncryptMethod           = 98
+	tagCxlRejReason            = 102
+	tagOrdRejReason            = 103
+	tagHeartBtInt              = 108
+	tagTestReqID               = 112
+	tagGapFillFlag             = 123
+	tagResetSeqNumFlag         = 141
+	tagExecType                = 150
+	tagLeavesQty               = 151
+	tagMDReqID                 = 262
+	tagSubscriptionRequestType = 263
+	tagMarketDepth             = 264
+	tagNoMDEntries             = 268
+	tagMDEntryType             = 269
+	tagMDEntryPx               = 270
+	tagMDEntrySize             = 271
+	tagMDUpdateAction          = 279
+	tagMDReqRejReason          = 281
+	tagRefMsgType              = 372
+	tagSessionRejectReason     = 373
+	tagBusinessRejectReason    = 380
+	tagCxlRejResponseTo        = 434
+	tagPassword                = 554
+)
+
+// Types of the messages handled by the gateway
+const (
+	msgTypeHeartbeat                 = "0"
+	msgTypeTestRequest               = "1"
+	msgTypeResendRequest             = "2"
+	msgTypeReject                    = "3"
+	msgTypeSequenceReset             = "4"
+	msgTypeLogout                    = "5"
+	msgTypeExecutionReport           = "8"
+	msgTypeOrderCancelReject         = "9"
+	msgTypeLogon                     = "A"
+	msgTypeNewOrderSingle            = "D"
+	msgTypeOrderCancelRequest        = "F"
+	msgTypeOrderCancelReplaceRequest = "G"
+	msgTypeMarketDataRequest         = "V"
+	msgTypeMarketDataSnapshot        = "W"
+	msgTypeMarketDataIncremental     = "X"
+	msgTypeMarketDataRequestReject   = "Y"
+	msgTypeBusinessMessageReject     = "j"
+)
+
+// maxBodyLength limits the size of the messages read from the clients
+const maxBodyLength = 1 << 16
+
+// Field is a tag=value pair of a message
+type Field struct {
+	Tag   int
+	Value string
+}
+
+// Message is a FIX message. The fields are kept in order since the repeating groups are
+// identified by the order of their fields. BeginString, BodyLength and CheckSum are not
+// stored, they are added when the message is encoded.
+type Message struct {
+	Fields []Field
+}
+
+// NewMessage returns a message of the given type
+func NewMessage(msgType string) *Message {
+	return &Message{Fields: []Field{{tagMsgType, msgType}}}
+}
+
+// Type returns the MsgType of the message
+func (m *Message) Type() string {
+	return m.Get(tagMsgType)
+}
+
+// Add appends a field to the message
+func (m *Message) Add(tag int, value string) *Message {
+	m.Fields = append(m.Fields, Field{tag, value})
+	return m
+}
+
+// Get returns the value of the first field with the tag, an empty string if there is none
+func (m *Message) Get(tag int) string {
+	for _, f := range m.Fields {
+		if f.Tag == tag {
+			return f.Value
+		}
+	}
+
+	return ""
+}
+
+// Has returns true if the message contains the tag
+func (m *Message) Has(tag int) bool {
+	for _, f := range m.Fields {
+		if f.Tag == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetAll returns the values of all the fields with the tag, the fields of a repeating group
+func (m *Message) GetAll(tag int) []string {
+	values := []string{}
+	for _, f := range m.Fields {
+		if f.Tag == tag {
+			values = append(values, f.Value)
+		}
+	}
+
+	return values
+}
+
+// Bytes encodes the message with its BeginString, BodyLength and CheckSum
+func (m *Message) Bytes() []byte {
+	body := &bytes.Buffer{}
+	for _, f := range m.Fields {
+		body.WriteString(strconv.Itoa(f.Tag))
+		body.WriteByte('=')
+		body.WriteString(f.Value)
+		body.WriteByte(soh)
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%d=%s%c%d=%d%c", tagBeginString, BeginString, soh, tagBodyLength, body.Len(), soh)
+	buf.Write(body.Bytes())
+	fmt.Fprintf(buf, "%d=%03d%c", tagCheckSum, checksum(buf.Bytes()), soh)
+
+	return buf.Bytes()
+}
+
+// String returns the message with the field separators replaced by |, for the logs
+func (m *Message) String() string {
+	return string(bytes.Replace(m.Bytes(), []byte{soh}, []byte{'|'}, -1))
+}
+
+func checksum(b []byte) int {
+	sum := 0
+	for _, c := range b {
+		sum += int(c)
+	}
+
+	return sum % 256
+}
+
+// ReadMessage reads a message, the BeginString, BodyLength and CheckSum fields are checked
+func ReadMessage(r *bufio.Reader) (*Message, error) {
+	raw := &bytes.Buffer{}
+
+	beginString, err := readField(r, raw, tagBeginString)
+	if err != nil {
+		return nil, err
+	}
+
+	if beginString != BeginString {
+		return nil, fmt.Errorf("unsupported BeginString %s", beginString)
+	}
+
+	bodyLength, err := readField(r, raw, tagBodyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := strconv.Atoi(bodyLength)
+	if err != nil || n <= 0 || n > maxBodyLength {
+		return nil, fmt.Errorf("invalid BodyLength %s", bodyLength)
+	}
+
+	body := make([]byte, n)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	raw.Write(body)
+
+	sum := checksum(raw.Bytes())
+	value, err := readField(r, &bytes.Buffer{}, tagCheckSum)
+	if err != nil {
+		return nil, err
+	}
+
+	if expected, err := strconv.Atoi(value); err != nil || expected != sum {
+		return nil, fmt.Errorf("invalid CheckSum %s, expected %03d", value, sum)
+	}
+
+	return parseBody(body)
+}
+
+// readField reads the next field, which must have the tag, and writes it to raw
+func readField(r *bufio.Reader, raw *bytes.Buffer, tag int) (string, error) {
+	b, err := r.ReadBytes(soh)
+	if err != nil {
+		return "", err
+	}
+	raw.Write(b)
+
+	prefix := strconv.Itoa(tag) + "="
+	if !bytes.HasPrefix(b, []byte(prefix)) {
+		return "", fmt.Errorf("expected tag %d, got %q", tag, b)
+	}
+
+	return string(b[len(prefix) : len(b)-1]), nil
+}
+
+func parseBody(body []byte) (*Message, error) {
+	if body[len(body)-1] != soh {
+		return nil, fmt.Errorf("the body does not end with a field separator")
+	}
+
+	m := &Message{}
+	for _, f := range bytes.Split(body[:len(body)-1], []byte{soh}) {
+		i := bytes.IndexByte(f, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid field %q", f)
+		}
+
+		tag, err := strconv.Atoi(string(f[:i]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag %q", f[:i])
+		}
+
+		m.Fields = append(m.Fields, Field{tag, string(f[i+1:])})
+	}
+
+	if len(m.Fields) == 0 || m.Fields[0].Tag != tagMsgType {
+		return nil, fmt.Errorf("MsgType must be the first field of the body")
+	}
+
+	return m, nil
+}
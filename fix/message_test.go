@@ -0,0 +1,56 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageRoundTrip(t *testing.T) {
+	m := NewMessage(msgTypeNewOrderSingle).
+		Add(tagClOrdID, "1").
+		Add(tagSymbol, "TOMO/USDT").
+		Add(tagMDEntryType, "0").
+		Add(tagMDEntryType, "1")
+
+	decoded, err := ReadMessage(bufio.NewReader(bytes.NewReader(m.Bytes())))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, msgTypeNewOrderSingle, decoded.Type())
+	assert.Equal(t, "TOMO/USDT", decoded.Get(tagSymbol))
+	assert.Equal(t, []string{"0", "1"}, decoded.GetAll(tagMDEntryType))
+	assert.False(t, decoded.Has(tagPrice))
+}
+
+func TestReadMessageInvalidCheckSum(t *testing.T) {
+	raw := NewMessage(msgTypeHeartbeat).Bytes()
+	raw[len(raw)-2]++
+
+	_, err := ReadMessage(bufio.NewReader(bytes.NewReader(raw)))
+	assert.Error(t, err)
+
+	_, err = ReadMessage(bufio.NewReader(bytes.NewBufferString("8=FIX.4.2\x019=5\x0135=0\x0110=000\x01")))
+	assert.Error(t, err)
+}
+
+func TestDecimal(t *testing.T) {
+	n, err := parseDecimal("1.25", 18)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _ := new(big.Int).SetString("1250000000000000000", 10)
+	assert.Equal(t, expected, n)
+	assert.Equal(t, "1.25", formatDecimal(n, 18))
+	assert.Equal(t, "100", formatDecimal(big.NewInt(100), 0))
+
+	for _, s := range []string{"0", "-1", "1e18", "1/2", "0.001", "abc"} {
+		_, err := parseDecimal(s, 2)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,334 @@
+package fix
+
+import (
+	"bufio"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// sendingTimeFormat is the UTCTimestamp format of the SendingTime and TransactTime fields
+const sendingTimeFormat = "20060102-15:04:05.000"
+
+// logonTimeout is the time given to a client to send its Logon message
+const logonTimeout = 10 * time.Second
+
+// session is the connection of a FIX client. The sequence numbers start at 1 on each logon,
+// the messages are not stored so a ResendRequest is answered with a gap fill.
+type session struct {
+	gateway *Gateway
+	conn    net.Conn
+	reader  *bufio.Reader
+
+	senderCompID string
+	account      *types.FixAccount
+	wallet       *types.Wallet
+	heartbeat    time.Duration
+
+	inSeq        int
+	outSeq       int
+	lastReceived time.Time
+	nonce        *big.Int
+
+	// the market data subscriptions by MDReqID
+	subscriptions map[string]*mdSubscription
+
+	mutex sync.Mutex
+	done  chan struct{}
+}
+
+func newSession(g *Gateway, conn net.Conn) *session {
+	return &session{
+		gateway:       g,
+		conn:          conn,
+		reader:        bufio.NewReader(conn),
+		inSeq:         1,
+		subscriptions: make(map[string]*mdSubscription),
+		done:          make(chan struct{}),
+	}
+}
+
+// serve runs the session until the connection is closed
+func (s *session) serve() {
+	defer s.conn.Close()
+
+	if err := s.logon(); err != nil {
+		logger.Warningf("FIX logon from %v failed: %v", s.conn.RemoteAddr(), err)
+		return
+	}
+
+	logger.Infof("FIX session %v logged on", s.senderCompID)
+	defer s.gateway.unregister(s)
+	defer close(s.done)
+
+	go s.heartbeats()
+
+	for {
+		s.conn.SetReadDeadline(time.Now().Add(2*s.heartbeat + logonTimeout))
+		m, err := ReadMessage(s.reader)
+		if err != nil {
+			logger.Infof("FIX session %v closed: %v", s.senderCompID, err)
+			return
+		}
+
+		if !s.receive(m) {
+			return
+		}
+	}
+}
+
+func (s *session) logon() error {
+	s.conn.SetReadDeadline(time.Now().Add(logonTimeout))
+	m, err := ReadMessage(s.reader)
+	if err != nil {
+		return err
+	}
+
+	if m.Type() != msgTypeLogon {
+		return fmt.Errorf("the first message is not a Logon")
+	}
+
+	s.senderCompID = m.Get(tagSenderCompID)
+	if m.Get(tagTargetCompID) != s.gateway.compID {
+		return s.logout(fmt.Sprintf("Invalid TargetCompID, expected %s", s.gateway.compID))
+	}
+
+	if m.Get(tagMsgSeqNum) != "1" {
+		return s.logout("MsgSeqNum of the Logon must be 1, the sequence numbers are reset on each logon")
+	}
+	s.inSeq = 2
+
+	hb, err := strconv.Atoi(m.Get(tagHeartBtInt))
+	if err != nil || hb <= 0 {
+		return s.logout("Invalid HeartBtInt")
+	}
+	s.heartbeat = time.Duration(hb) * time.Second
+
+	s.account, s.wallet, err = s.gateway.fixAccountService.Authenticate(s.senderCompID, m.Get(tagPassword))
+	if err != nil {
+		return s.logout(err.Error())
+	}
+
+	if !s.gateway.register(s) {
+		return s.logout("The session is already logged on")
+	}
+
+	s.mutex.Lock()
+	s.lastReceived = time.Now()
+	s.mutex.Unlock()
+
+	reply := NewMessage(msgTypeLogon).
+		Add(tagEncryptMethod, "0").
+		Add(tagHeartBtInt, strconv.Itoa(hb))
+	if m.Get(tagResetSeqNumFlag) == "Y" {
+		reply.Add(tagResetSeqNumFlag, "Y")
+	}
+
+	return s.send(reply)
+}
+
+// logout sends a Logout with the reason and returns it as an error
+func (s *session) logout(text string) error {
+	s.send(NewMessage(msgTypeLogout).Add(tagText, text))
+	return fmt.Errorf(text)
+}
+
+// receive handles a message, it returns false if the session has to be closed
+func (s *session) receive(m *Message) bool {
+	s.mutex.Lock()
+	s.lastReceived = time.Now()
+	s.mutex.Unlock()
+
+	seq, err := strconv.Atoi(m.Get(tagMsgSeqNum))
+	if err != nil {
+		s.reject(m, 1, "Invalid MsgSeqNum")
+		return true
+	}
+
+	if m.Type() == msgTypeSequenceReset {
+		n, err := strconv.Atoi(m.Get(tagNewSeqNo))
+		if err != nil || n < s.inSeq {
+			s.reject(m, 5, "Invalid NewSeqNo")
+			return true
+		}
+
+		s.inSeq = n
+		return true
+	}
+
+	switch {
+	case seq < s.inSeq && m.Get(tagPossDupFlag) != "Y":
+		s.logout(fmt.Sprintf("MsgSeqNum too low, expecting %d but received %d", s.inSeq, seq))
+		return false
+	case seq < s.inSeq:
+		// duplicates are ignored
+		return true
+	case seq > s.inSeq:
+		s.send(NewMessage(msgTypeResendRequest).
+			Add(tagBeginSeqNo, strconv.Itoa(s.inSeq)).
+			Add(tagEndSeqNo, "0"))
+	}
+	s.inSeq = seq + 1
+
+	switch m.Type() {
+	case msgTypeHeartbeat, msgTypeReject:
+	case msgTypeTestRequest:
+		s.send(NewMessage(msgTypeHeartbeat).Add(tagTestReqID, m.Get(tagTestReqID)))
+	case msgTypeResendRequest:
+		s.gapFill(m)
+	case msgTypeLogout:
+		s.send(NewMessage(msgTypeLogout))
+		return false
+	case msgTypeNewOrderSingle:
+		s.gateway.handleNewOrderSingle(s, m)
+	case msgTypeOrderCancelRequest:
+		s.gateway.handleOrderCancelRequest(s, m)
+	case msgTypeOrderCancelReplaceRequest:
+		s.gateway.handleOrderCancelReplaceRequest(s, m)
+	case msgTypeMarketDataRequest:
+		s.gateway.handleMarketDataRequest(s, m)
+	default:
+		s.send(NewMessage(msgTypeBusinessMessageReject).
+			Add(tagRefSeqNum, strconv.Itoa(seq)).
+			Add(tagRefMsgType, m.Type()).
+			Add(tagBusinessRejectReason, "3").
+			Add(tagText, "Unsupported message type"))
+	}
+
+	return true
+}
+
+// gapFill answers a ResendRequest with a SequenceReset, the messages are not resent
+func (s *session) gapFill(m *Message) {
+	begin, err := strconv.Atoi(m.Get(tagBeginSeqNo))
+	if err != nil || begin <= 0 {
+		s.reject(m, 5, "Invalid BeginSeqNo")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if begin > s.outSeq {
+		return
+	}
+
+	reset := NewMessage(msgTypeSequenceReset).
+		Add(tagGapFillFlag, "Y").
+		Add(tagNewSeqNo, strconv.Itoa(s.outSeq+1))
+
+	s.write(reset, begin, true)
+}
+
+// reject sends a session level Reject of the message
+func (s *session) reject(m *Message, reason int, text string) {
+	s.send(NewMessage(msgTypeReject).
+		Add(tagRefSeqNum, m.Get(tagMsgSeqNum)).
+		Add(tagRefMsgType, m.Type()).
+		Add(tagSessionRejectReason, strconv.Itoa(reason)).
+		Add(tagText, text))
+}
+
+// heartbeats sends a Heartbeat every interval, a TestRequest when nothing was received during
+// an interval and closes the connection when nothing was received during two intervals
+func (s *session) heartbeats() {
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		s.mutex.Lock()
+		idle := time.Since(s.lastReceived)
+		s.mutex.Unlock()
+
+		switch {
+		case idle > 2*s.heartbeat+time.Second:
+			logger.Warningf("FIX session %v timed out", s.senderCompID)
+			s.conn.Close()
+			return
+		case idle > s.heartbeat+time.Second:
+			s.send(NewMessage(msgTypeTestRequest).Add(tagTestReqID, strconv.FormatInt(time.Now().Unix(), 10)))
+		default:
+			s.send(NewMessage(msgTypeHeartbeat))
+		}
+	}
+}
+
+// send adds the header to the message and writes it with the next sequence number
+func (s *session) send(m *Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.outSeq++
+	return s.write(m, s.outSeq, false)
+}
+
+func (s *session) write(m *Message, seq int, possDup bool) error {
+	header := []Field{
+		m.Fields[0],
+		{tagSenderCompID, s.gateway.compID},
+		{tagTargetCompID, s.senderCompID},
+		{tagMsgSeqNum, strconv.Itoa(seq)},
+	}
+
+	if possDup {
+		header = append(header, Field{tagPossDupFlag, "Y"})
+	}
+
+	header = append(header, Field{tagSendingTime, time.Now().UTC().Format(sendingTimeFormat)})
+	msg := &Message{Fields: append(header, m.Fields[1:]...)}
+
+	s.conn.SetWriteDeadline(time.Now().Add(logonTimeout))
+	_, err := s.conn.Write(msg.Bytes())
+	if err != nil {
+		logger.Error(err)
+	}
+
+	return err
+}
+
+// nextNonce returns the nonce of the next order or cancel of the delegated key. The first nonce
+// is the order count of the key on the chain, the next ones are counted by the session.
+func (s *session) nextNonce() (*big.Int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.nonce == nil {
+		count, err := s.gateway.orderService.GetOrderNonceByUserAddress(s.wallet.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		s.nonce, err = parseNonce(count)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		s.nonce = new(big.Int).Add(s.nonce, big.NewInt(1))
+	}
+
+	return new(big.Int).Set(s.nonce), nil
+}
+
+// parseNonce parses the order count returned by the node
+func parseNonce(v interface{}) (*big.Int, error) {
+	switch v := v.(type) {
+	case string:
+		return hexutil.DecodeBig(v)
+	case float64:
+		return big.NewInt(int64(v)), nil
+	}
+
+	return nil, fmt.Errorf("invalid order nonce %v", v)
+}
@@ -23,13 +23,14 @@ require (
 	github.com/go-ozzo/ozzo-validation v3.4.0+incompatible
 	github.com/go-stack/stack v1.8.0 // indirect
 	github.com/go-test/deep v1.0.1
-	github.com/golang/protobuf v1.3.1 // indirect
+	github.com/golang/protobuf v1.3.1
 	github.com/golang/snappy v0.0.0-20180518054509-2e65f85255db // indirect
 	github.com/google/uuid v1.0.0 // indirect
 	github.com/gorilla/context v1.1.1 // indirect
 	github.com/gorilla/handlers v1.4.0
 	github.com/gorilla/mux v1.6.2
 	github.com/gorilla/websocket v1.4.0
+	github.com/graph-gophers/graphql-go v1.0.0
 	github.com/hashicorp/golang-lru v0.5.0
 	github.com/hashicorp/hcl v1.0.0
 	github.com/huin/goupnp v1.0.0 // indirect
@@ -42,7 +43,7 @@ require (
 	github.com/onsi/ginkgo v1.10.1 // indirect
 	github.com/onsi/gomega v1.7.0 // indirect
 	github.com/op/go-logging v0.0.0-20160315200505-970db520ece7
-	github.com/pborman/uuid v0.0.0-20180906182336-adf5a7427709 // indirect
+	github.com/pborman/uuid v0.0.0-20180906182336-adf5a7427709
 	github.com/pkg/errors v0.8.1
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/posener/wstest v0.0.0-20180216222922-04b166ca0bf1
@@ -61,8 +62,8 @@ require (
 	github.com/tomochain/tomochain v1.5.6
 	github.com/tyler-smith/go-bip32 v0.0.0-20170922074101-2c9cfd177564
 	github.com/valyala/fasttemplate v1.0.0 // indirect
-	golang.org/x/crypto v0.0.0-20180910181607-0e37d006457b // indirect
-	golang.org/x/tools v0.0.0-20180911133044-677d2ff680c1 // indirect
+	golang.org/x/crypto v0.0.0-20190308221718-c2843e01d9a2
+	google.golang.org/grpc v1.21.1
 	gopkg.in/fatih/set.v0 v0.2.1 // indirect
 	gopkg.in/karalabe/cookiejar.v2 v2.0.0-20150724131613-8dcd6a7f4951 // indirect
 	gopkg.in/natefinch/npipe.v2 v2.0.0-20160621034901-c1b8fa8bdcce // indirect
@@ -0,0 +1,143 @@
+// Package gql serves the GraphQL API of the exchange. The resolvers call the services used by
+// the HTTP endpoints, the nested objects are batched by request with loaders and the
+// subscriptions are fed by the channels of the websocket sockets.
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/utils"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+)
+
+var logger = utils.Logger
+
+// maxDepth limits the nesting of the queries
+const maxDepth = 8
+
+type contextKey int
+
+const (
+	relayerKey contextKey = iota
+	loadersKey
+)
+
+// Handler serves the GraphQL queries on POST and GET requests and the subscriptions on
+// websocket connections
+type Handler struct {
+	schema *graphql.Schema
+
+	relayerService interfaces.RelayerService
+	pairService    interfaces.PairService
+	tradeService   interfaces.TradeService
+	pairDao        interfaces.PairDao
+	tokenDao       interfaces.TokenDao
+	orderDao       interfaces.OrderDao
+}
+
+// NewHandler returns a new instance of Handler
+func NewHandler(
+	relayerService interfaces.RelayerService,
+	pairService interfaces.PairService,
+	tokenService interfaces.TokenService,
+	orderService interfaces.OrderService,
+	tradeService interfaces.TradeService,
+	accountService interfaces.AccountService,
+	notificationService interfaces.NotificationService,
+	lendingOrderService interfaces.LendingOrderService,
+	lendingTradeService interfaces.LendingTradeService,
+	orderBookService interfaces.OrderBookService,
+	pairDao interfaces.PairDao,
+	tokenDao interfaces.TokenDao,
+	orderDao interfaces.OrderDao,
+) *Handler {
+	r := &resolver{
+		pairService:         pairService,
+		tokenService:        tokenService,
+		orderService:        orderService,
+		tradeService:        tradeService,
+		accountService:      accountService,
+		notificationService: notificationService,
+		lendingOrderService: lendingOrderService,
+		lendingTradeService: lendingTradeService,
+		orderBookService:    orderBookService,
+	}
+
+	return &Handler{
+		schema:         graphql.MustParseSchema(schema, r, graphql.MaxDepth(maxDepth)),
+		relayerService: relayerService,
+		pairService:    pairService,
+		tradeService:   tradeService,
+		pairDao:        pairDao,
+		tokenDao:       tokenDao,
+		orderDao:       orderDao,
+	}
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if isWebsocketUpgrade(r) {
+		h.serveSubscriptions(w, r)
+		return
+	}
+
+	req := request{}
+	switch r.Method {
+	case http.MethodGet:
+		v := r.URL.Query()
+		req.Query = v.Get("query")
+		req.OperationName = v.Get("operationName")
+		if vars := v.Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				httputils.WriteError(w, http.StatusBadRequest, "Invalid variables")
+				return
+			}
+		}
+	case http.MethodPost:
+		defer r.Body.Close()
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+			return
+		}
+	default:
+		httputils.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if req.Query == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	ctx := h.context(r)
+	res := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// context returns the context of a request with the relayer the request is scoped to, as
+// in the HTTP endpoints, and the loaders of the request
+func (h *Handler) context(r *http.Request) context.Context {
+	relayer := h.relayerService.GetRelayerAddress(r)
+
+	ctx := context.WithValue(r.Context(), relayerKey, relayer)
+	return context.WithValue(ctx, loadersKey, h.newLoaders(relayer))
+}
+
+func relayerAddress(ctx context.Context) common.Address {
+	addr, _ := ctx.Value(relayerKey).(common.Address)
+	return addr
+}
+
+func getLoaders(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey).(*loaders)
+}
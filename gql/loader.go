@@ -0,0 +1,108 @@
+package gql
+
+import (
+	"sync"
+	"time"
+)
+
+// batchWait is the time a batch collects the keys requested by the resolvers running in
+// parallel before it is fetched
+const batchWait = 2 * time.Millisecond
+
+// maxBatchSize limits the number of keys fetched by a query
+const maxBatchSize = 100
+
+// cacheTTL bounds the time a value is cached, the subscriptions keep their loaders for the
+// lifetime of the operation
+const cacheTTL = time.Second
+
+// fetchFunc returns the values of the keys, the keys which are not found are missing
+type fetchFunc func(keys []string) (map[string]interface{}, error)
+
+type result struct {
+	done      chan struct{}
+	value     interface{}
+	err       error
+	fetchedAt time.Time
+}
+
+// expired is only read once the result is done
+func (r *result) expired() bool {
+	select {
+	case <-r.done:
+		return time.Since(r.fetchedAt) > cacheTTL
+	default:
+		return false
+	}
+}
+
+type batch struct {
+	keys    []string
+	results []*result
+}
+
+// loader batches the loads of the resolvers of a request so that the fields of a list are
+// fetched with a single query. The values are cached for the request, or for cacheTTL.
+type loader struct {
+	fetch fetchFunc
+	cache map[string]*result
+	batch *batch
+	mutex sync.Mutex
+}
+
+func newLoader(fetch fetchFunc) *loader {
+	return &loader{
+		fetch: fetch,
+		cache: make(map[string]*result),
+	}
+}
+
+// load returns the value of the key, nil if it is not found
+func (l *loader) load(key string) (interface{}, error) {
+	l.mutex.Lock()
+	res, ok := l.cache[key]
+	if !ok || res.expired() {
+		res = &result{done: make(chan struct{})}
+		l.cache[key] = res
+
+		if l.batch == nil {
+			l.batch = &batch{}
+			go l.run(l.batch)
+		}
+
+		l.batch.keys = append(l.batch.keys, key)
+		l.batch.results = append(l.batch.results, res)
+
+		// a full batch is closed, the next keys start a new one
+		if len(l.batch.keys) == maxBatchSize {
+			l.batch = nil
+		}
+	}
+	l.mutex.Unlock()
+
+	<-res.done
+	return res.value, res.err
+}
+
+func (l *loader) run(b *batch) {
+	time.Sleep(batchWait)
+
+	l.mutex.Lock()
+	if l.batch == b {
+		l.batch = nil
+	}
+	l.mutex.Unlock()
+
+	values, err := l.fetch(b.keys)
+	now := time.Now()
+	for i, key := range b.keys {
+		res := b.results[i]
+		res.fetchedAt = now
+		res.err = err
+		if err == nil {
+			res.value = values[key]
+		}
+
+		close(res.done)
+	}
+}
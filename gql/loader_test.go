@@ -0,0 +1,86 @@
+package gql
+
+import (
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoaderBatchesLoads(t *testing.T) {
+	var calls int32
+	l := newLoader(func(keys []string) (map[string]interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+
+		values := make(map[string]interface{})
+		for _, k := range keys {
+			if k != "missing" {
+				values[k] = k + "-value"
+			}
+		}
+		return values, nil
+	})
+
+	keys := []string{"a", "b", "c", "a", "missing"}
+	values := make([]interface{}, len(keys))
+
+	wg := sync.WaitGroup{}
+	for i, k := range keys {
+		wg.Add(1)
+		go func(i int, k string) {
+			defer wg.Done()
+			v, err := l.load(k)
+			assert.Nil(t, err)
+			values[i] = v
+		}(i, k)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, []interface{}{"a-value", "b-value", "c-value", "a-value", nil}, values)
+
+	v, err := l.load("b")
+	assert.Nil(t, err)
+	assert.Equal(t, "b-value", v)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestParsePage(t *testing.T) {
+	p, err := parsePage(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"-createdAt"}, p.sort)
+
+	size := int32(maxPageSize + 1)
+	_, err = parsePage(&pageInput{Size: &size})
+	assert.NotNil(t, err)
+
+	offset, size, ascending := int32(20), int32(10), true
+	p, err = parsePage(&pageInput{Offset: &offset, Size: &size, Ascending: &ascending})
+	assert.Nil(t, err)
+	assert.Equal(t, 20, p.offset)
+	assert.Equal(t, 10, p.size)
+	assert.Equal(t, []string{"+createdAt"}, p.sort)
+}
+
+func TestSchemaScalars(t *testing.T) {
+	n := &bigInt{}
+	assert.Nil(t, n.UnmarshalGraphQL("1000000000000000000000"))
+	b, err := n.MarshalJSON()
+	assert.Nil(t, err)
+	assert.Equal(t, `"1000000000000000000000"`, string(b))
+	assert.NotNil(t, n.UnmarshalGraphQL("1e18"))
+	assert.Nil(t, newBigInt((*big.Int)(nil)))
+
+	u := uint64Scalar(0)
+	assert.Nil(t, u.UnmarshalGraphQL("18446744073709551615"))
+	assert.Equal(t, uint64Scalar(18446744073709551615), u)
+	assert.NotNil(t, u.UnmarshalGraphQL(int32(-1)))
+
+	// the resolvers must match the schema
+	assert.NotPanics(t, func() {
+		graphql.MustParseSchema(schema, &resolver{})
+	})
+}
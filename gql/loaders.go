@@ -0,0 +1,180 @@
+package gql
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// loaders are the loaders of a request
+type loaders struct {
+	pairs       *loader
+	pairData    *loader
+	tokens      *loader
+	orders      *loader
+	orderTrades *loader
+}
+
+func (h *Handler) newLoaders(relayer common.Address) *loaders {
+	return &loaders{
+		pairs:       newLoader(h.fetchPairs),
+		pairData:    newLoader(h.fetchPairData(relayer)),
+		tokens:      newLoader(h.fetchTokens),
+		orders:      newLoader(h.fetchOrders),
+		orderTrades: newLoader(h.fetchOrderTrades),
+	}
+}
+
+func pairKey(bt, qt common.Address) string {
+	return bt.Hex() + "::" + qt.Hex()
+}
+
+func (h *Handler) fetchPairs(keys []string) (map[string]interface{}, error) {
+	addrs := []types.PairAddresses{}
+	for _, key := range keys {
+		addrs = append(addrs, types.PairAddresses{
+			BaseToken:  common.HexToAddress(key[:42]),
+			QuoteToken: common.HexToAddress(key[44:]),
+		})
+	}
+
+	pairs, err := h.pairDao.GetByTokenAddresses(addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]interface{}{}
+	for i := range pairs {
+		res[pairKey(pairs[i].BaseTokenAddress, pairs[i].QuoteTokenAddress)] = &pairs[i]
+	}
+
+	return res, nil
+}
+
+// fetchPairData loads the data of all the pairs of the relayer at once, like the
+// /api/pairs/data endpoint
+func (h *Handler) fetchPairData(relayer common.Address) fetchFunc {
+	return func(keys []string) (map[string]interface{}, error) {
+		data, err := h.pairService.GetAllTokenPairDataByCoinbase(relayer)
+		if err != nil {
+			return nil, err
+		}
+
+		res := map[string]interface{}{}
+		for _, d := range data {
+			res[pairKey(d.Pair.BaseToken, d.Pair.QuoteToken)] = d
+		}
+
+		return res, nil
+	}
+}
+
+func (h *Handler) fetchTokens(keys []string) (map[string]interface{}, error) {
+	addrs := []common.Address{}
+	for _, key := range keys {
+		addrs = append(addrs, common.HexToAddress(key))
+	}
+
+	tokens, err := h.tokenDao.GetByAddresses(addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]interface{}{}
+	for i := range tokens {
+		res[tokens[i].ContractAddress.Hex()] = &tokens[i]
+	}
+
+	return res, nil
+}
+
+func (h *Handler) fetchOrders(keys []string) (map[string]interface{}, error) {
+	hashes := []common.Hash{}
+	for _, key := range keys {
+		hashes = append(hashes, common.HexToHash(key))
+	}
+
+	orders, err := h.orderDao.GetByHashes(hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]interface{}{}
+	for _, o := range orders {
+		res[o.Hash.Hex()] = o
+	}
+
+	return res, nil
+}
+
+// fetchOrderTrades loads the trades of the orders, as maker or taker
+func (h *Handler) fetchOrderTrades(keys []string) (map[string]interface{}, error) {
+	hashes := []common.Hash{}
+	for _, key := range keys {
+		hashes = append(hashes, common.HexToHash(key))
+	}
+
+	trades, err := h.tradeService.GetByOrderHashes(hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]interface{}{}
+	for _, key := range keys {
+		res[key] = []*types.Trade{}
+	}
+
+	for _, t := range trades {
+		for _, hash := range []common.Hash{t.MakerOrderHash, t.TakerOrderHash} {
+			if trades, ok := res[hash.Hex()]; ok {
+				res[hash.Hex()] = append(trades.([]*types.Trade), t)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (l *loaders) pair(bt, qt common.Address) (*types.Pair, error) {
+	v, err := l.pairs.load(pairKey(bt, qt))
+	if v == nil {
+		return nil, err
+	}
+
+	return v.(*types.Pair), nil
+}
+
+func (l *loaders) data(bt, qt common.Address) (*types.PairData, error) {
+	v, err := l.pairData.load(pairKey(bt, qt))
+	if v == nil {
+		return nil, err
+	}
+
+	return v.(*types.PairData), nil
+}
+
+func (l *loaders) token(addr common.Address) (*types.Token, error) {
+	v, err := l.tokens.load(addr.Hex())
+	if v == nil {
+		return nil, err
+	}
+
+	return v.(*types.Token), nil
+}
+
+func (l *loaders) order(hash common.Hash) (*types.Order, error) {
+	v, err := l.orders.load(hash.Hex())
+	if v == nil {
+		return nil, err
+	}
+
+	return v.(*types.Order), nil
+}
+
+func (l *loaders) trades(hash common.Hash) ([]*types.Trade, error) {
+	v, err := l.orderTrades.load(hash.Hex())
+	if v == nil {
+		return nil, err
+	}
+
+	return v.([]*types.Trade), nil
+}
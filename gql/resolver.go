@@ -0,0 +1,541 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// maxPageSize is the largest page of the queries, as in the HTTP endpoints
+const maxPageSize = 500
+
+// resolver is the root resolver of the queries and subscriptions. The lists are scoped to the
+// relayer of the request like in the HTTP endpoints.
+type resolver struct {
+	pairService         interfaces.PairService
+	tokenService        interfaces.TokenService
+	orderService        interfaces.OrderService
+	tradeService        interfaces.TradeService
+	accountService      interfaces.AccountService
+	notificationService interfaces.NotificationService
+	lendingOrderService interfaces.LendingOrderService
+	lendingTradeService interfaces.LendingTradeService
+	orderBookService    interfaces.OrderBookService
+}
+
+type pageInput struct {
+	Offset    *int32
+	Size      *int32
+	Cursor    *string
+	Ascending *bool
+}
+
+type orderFilter struct {
+	UserAddress *string
+	BaseToken   *string
+	QuoteToken  *string
+	Status      *string
+	Side        *string
+	Type        *string
+	Hash        *string
+	From        *int32
+	To          *int32
+}
+
+type tradeFilter struct {
+	BaseToken  *string
+	QuoteToken *string
+	From       *int32
+	To         *int32
+}
+
+type lendingOrderFilter struct {
+	UserAddress     *string
+	LendingToken    *string
+	CollateralToken *string
+	Term            *string
+	Status          *string
+	Side            *string
+	Type            *string
+	Hash            *string
+	From            *int32
+	To              *int32
+}
+
+type lendingTradeFilter struct {
+	LendingToken    *string
+	CollateralToken *string
+	Term            *string
+	Status          *string
+	From            *int32
+	To              *int32
+}
+
+type pairArgs struct {
+	BaseToken  string
+	QuoteToken string
+}
+
+type pageArgs struct {
+	Filter *orderFilter
+	Page   *pageInput
+}
+
+type tradePageArgs struct {
+	Filter *tradeFilter
+	Page   *pageInput
+}
+
+type notificationArgs struct {
+	Limit  *int32
+	Offset *int32
+}
+
+// page is a validated page of a query
+type page struct {
+	offset int
+	size   int
+	sort   []string
+	cursor *types.Cursor
+}
+
+// parsePage validates the page of a query, the size defaults to types.DefaultLimit and the
+// documents are sorted by decreasing creation date unless ascending is set
+func parsePage(p *pageInput) (*page, error) {
+	res := &page{size: types.DefaultLimit, sort: []string{"-createdAt"}}
+	if p == nil {
+		return res, nil
+	}
+
+	if p.Offset != nil {
+		if *p.Offset < 0 {
+			return nil, fmt.Errorf("Invalid page offset")
+		}
+		res.offset = int(*p.Offset)
+	}
+
+	if p.Size != nil {
+		if *p.Size <= 0 || *p.Size > maxPageSize {
+			return nil, fmt.Errorf("Invalid page size")
+		}
+		res.size = int(*p.Size)
+	}
+
+	ascending := p.Ascending != nil && *p.Ascending
+	if ascending {
+		res.sort = []string{"+createdAt"}
+	}
+
+	if p.Cursor != nil {
+		cursor, err := types.DecodeCursor(*p.Cursor, ascending)
+		if err != nil {
+			return nil, err
+		}
+		res.cursor = cursor
+	}
+
+	return res, nil
+}
+
+func optional(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func date(t *int32) int64 {
+	if t == nil {
+		return 0
+	}
+
+	return int64(*t)
+}
+
+func (r *resolver) Pairs(ctx context.Context) ([]*pairResolver, error) {
+	pairs, err := r.pairService.GetAllByCoinbase(relayerAddress(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*pairResolver{}
+	for i := range pairs {
+		res = append(res, &pairResolver{&pairs[i]})
+	}
+
+	return res, nil
+}
+
+func (r *resolver) Pair(args pairArgs) (*pairResolver, error) {
+	bt, err := requireAddress("baseToken", args.BaseToken)
+	if err != nil {
+		return nil, err
+	}
+
+	qt, err := requireAddress("quoteToken", args.QuoteToken)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := r.pairService.GetByTokenAddress(bt, qt)
+	if err != nil || p == nil {
+		return nil, err
+	}
+
+	return &pairResolver{p}, nil
+}
+
+func (r *resolver) Tokens(ctx context.Context) ([]*tokenResolver, error) {
+	tokens, err := r.tokenService.GetAllByCoinbase(relayerAddress(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*tokenResolver{}
+	for i := range tokens {
+		res = append(res, &tokenResolver{&tokens[i]})
+	}
+
+	return res, nil
+}
+
+func (r *resolver) Token(args struct{ Address string }) (*tokenResolver, error) {
+	addr, err := requireAddress("address", args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := r.tokenService.GetByAddress(addr)
+	if err != nil || t == nil {
+		return nil, err
+	}
+
+	return &tokenResolver{t}, nil
+}
+
+func (r *resolver) Order(args struct{ Hash string }) (*orderResolver, error) {
+	o, err := r.orderService.GetByHash(common.HexToHash(args.Hash))
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	return &orderResolver{o}, nil
+}
+
+func (r *resolver) Orders(ctx context.Context, args pageArgs) (*orderPageResolver, error) {
+	return r.orders(ctx, "", args)
+}
+
+// orders returns the orders of the filter, restricted to userAddress if it is set
+func (r *resolver) orders(ctx context.Context, userAddress string, args pageArgs) (*orderPageResolver, error) {
+	p, err := parsePage(args.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := types.OrderSpec{RelayerAddress: relayerAddress(ctx), Cursor: p.cursor}
+	if f := args.Filter; f != nil {
+		for _, a := range []struct {
+			name  string
+			value *string
+			spec  *string
+		}{
+			{"userAddress", f.UserAddress, &spec.UserAddress},
+			{"baseToken", f.BaseToken, &spec.BaseToken},
+			{"quoteToken", f.QuoteToken, &spec.QuoteToken},
+		} {
+			if *a.spec, err = parseAddress(a.name, a.value); err != nil {
+				return nil, err
+			}
+		}
+
+		spec.Status = optional(f.Status)
+		spec.Side = optional(f.Side)
+		spec.OrderType = optional(f.Type)
+		spec.OrderHash = optional(f.Hash)
+		spec.DateFrom = date(f.From)
+		spec.DateTo = date(f.To)
+	}
+
+	if userAddress != "" {
+		spec.UserAddress = userAddress
+	}
+
+	res, err := r.orderService.GetOrders(spec, p.sort, p.offset*p.size, p.size)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		res = &types.OrderRes{}
+	}
+
+	return &orderPageResolver{res}, nil
+}
+
+func (r *resolver) tradeSpec(f *tradeFilter) (*types.TradeSpec, error) {
+	spec := &types.TradeSpec{}
+	if f == nil {
+		return spec, nil
+	}
+
+	var err error
+	if spec.BaseToken, err = parseAddress("baseToken", f.BaseToken); err != nil {
+		return nil, err
+	}
+
+	if spec.QuoteToken, err = parseAddress("quoteToken", f.QuoteToken); err != nil {
+		return nil, err
+	}
+
+	spec.DateFrom = date(f.From)
+	spec.DateTo = date(f.To)
+	return spec, nil
+}
+
+func (r *resolver) Trades(args tradePageArgs) (*tradePageResolver, error) {
+	p, err := parsePage(args.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := r.tradeSpec(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	spec.Cursor = p.cursor
+
+	res, err := r.tradeService.GetTrades(spec, p.sort, p.offset*p.size, p.size)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		res = &types.TradeRes{}
+	}
+
+	return &tradePageResolver{res}, nil
+}
+
+func (r *resolver) TradeHistory(ctx context.Context, args struct {
+	Address string
+	Filter  *tradeFilter
+	Page    *pageInput
+}) (*tradePageResolver, error) {
+	addr, err := requireAddress("address", args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.tradeHistory(ctx, addr, tradePageArgs{args.Filter, args.Page})
+}
+
+func (r *resolver) tradeHistory(ctx context.Context, addr common.Address, args tradePageArgs) (*tradePageResolver, error) {
+	p, err := parsePage(args.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := r.tradeSpec(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	spec.Cursor = p.cursor
+	spec.RelayerAddress = relayerAddress(ctx)
+
+	res, err := r.tradeService.GetTradesUserHistory(addr, spec, p.sort, p.offset*p.size, p.size)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		res = &types.TradeRes{}
+	}
+
+	return &tradePageResolver{res}, nil
+}
+
+func (r *resolver) Account(args struct{ Address string }) (*accountResolver, error) {
+	addr, err := requireAddress("address", args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := r.accountService.GetByAddress(addr)
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	return &accountResolver{r, a}, nil
+}
+
+func (r *resolver) Notifications(args struct {
+	Address string
+	Limit   *int32
+	Offset  *int32
+}) ([]*notificationResolver, error) {
+	addr, err := requireAddress("address", args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.notifications(addr, notificationArgs{args.Limit, args.Offset})
+}
+
+// notifications returns the latest notifications of the address, the limit defaults to 10 and
+// can not exceed 50 like in the HTTP endpoint
+func (r *resolver) notifications(addr common.Address, args notificationArgs) ([]*notificationResolver, error) {
+	limit, offset := 10, 0
+	if args.Limit != nil && *args.Limit > 0 && *args.Limit <= 50 {
+		limit = int(*args.Limit)
+	}
+
+	if args.Offset != nil && *args.Offset > 0 {
+		offset = int(*args.Offset)
+	}
+
+	notifications, err := r.notificationService.GetSortDecByUserAddress(addr, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*notificationResolver{}
+	for _, n := range notifications {
+		res = append(res, &notificationResolver{n})
+	}
+
+	return res, nil
+}
+
+func (r *resolver) LendingOrders(ctx context.Context, args struct {
+	Filter *lendingOrderFilter
+	Page   *pageInput
+}) (*lendingOrderPageResolver, error) {
+	p, err := parsePage(args.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := types.LendingSpec{RelayerAddress: relayerAddress(ctx), Cursor: p.cursor}
+	if f := args.Filter; f != nil {
+		for _, a := range []struct {
+			name  string
+			value *string
+			spec  *string
+		}{
+			{"userAddress", f.UserAddress, &spec.UserAddress},
+			{"lendingToken", f.LendingToken, &spec.LendingToken},
+			{"collateralToken", f.CollateralToken, &spec.CollateralToken},
+		} {
+			if *a.spec, err = parseAddress(a.name, a.value); err != nil {
+				return nil, err
+			}
+		}
+
+		spec.Term = optional(f.Term)
+		spec.Status = optional(f.Status)
+		spec.Side = optional(f.Side)
+		spec.Type = optional(f.Type)
+		spec.Hash = optional(f.Hash)
+		spec.DateFrom = date(f.From)
+		spec.DateTo = date(f.To)
+	}
+
+	res, err := r.lendingOrderService.GetLendingOrders(spec, p.sort, p.offset*p.size, p.size)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		res = &types.LendingRes{}
+	}
+
+	return &lendingOrderPageResolver{res}, nil
+}
+
+func (r *resolver) lendingTradeSpec(f *lendingTradeFilter) (*types.LendingTradeSpec, error) {
+	spec := &types.LendingTradeSpec{}
+	if f == nil {
+		return spec, nil
+	}
+
+	var err error
+	if spec.LendingToken, err = parseAddress("lendingToken", f.LendingToken); err != nil {
+		return nil, err
+	}
+
+	if spec.CollateralToken, err = parseAddress("collateralToken", f.CollateralToken); err != nil {
+		return nil, err
+	}
+
+	spec.Term = optional(f.Term)
+	spec.Status = optional(f.Status)
+	spec.DateFrom = date(f.From)
+	spec.DateTo = date(f.To)
+	return spec, nil
+}
+
+func (r *resolver) LendingTrades(args struct {
+	Filter *lendingTradeFilter
+	Page   *pageInput
+}) (*lendingTradePageResolver, error) {
+	p, err := parsePage(args.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := r.lendingTradeSpec(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	spec.Cursor = p.cursor
+
+	res, err := r.lendingTradeService.GetLendingTrades(spec, p.sort, p.offset*p.size, p.size)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		res = &types.LendingTradeRes{}
+	}
+
+	return &lendingTradePageResolver{res}, nil
+}
+
+func (r *resolver) LendingTradeHistory(ctx context.Context, args struct {
+	Address string
+	Filter  *lendingTradeFilter
+	Page    *pageInput
+}) (*lendingTradePageResolver, error) {
+	addr, err := requireAddress("address", args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := parsePage(args.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := r.lendingTradeSpec(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	spec.Cursor = p.cursor
+	spec.RelayerAddress = relayerAddress(ctx)
+
+	res, err := r.lendingTradeService.GetLendingTradesUserHistory(addr, spec, p.sort, p.offset*p.size, p.size)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		res = &types.LendingTradeRes{}
+	}
+
+	return &lendingTradePageResolver{res}, nil
+}
@@ -0,0 +1,114 @@
+package gql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// bigInt is the BigInt scalar, an integer encoded as a decimal string
+type bigInt struct {
+	*big.Int
+}
+
+func newBigInt(n *big.Int) *bigInt {
+	if n == nil {
+		return nil
+	}
+
+	return &bigInt{n}
+}
+
+func (bigInt) ImplementsGraphQLType(name string) bool {
+	return name == "BigInt"
+}
+
+func (n *bigInt) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("BigInt must be a decimal string")
+	}
+
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return fmt.Errorf("invalid BigInt %s", s)
+	}
+
+	n.Int = v
+	return nil
+}
+
+func (n bigInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.String())
+}
+
+// uint64Scalar is the Uint64 scalar, the integers which do not fit the 32 bits of Int
+type uint64Scalar uint64
+
+func (uint64Scalar) ImplementsGraphQLType(name string) bool {
+	return name == "Uint64"
+}
+
+func (n *uint64Scalar) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		if v < 0 {
+			return fmt.Errorf("Uint64 must be positive")
+		}
+		*n = uint64Scalar(v)
+	case float64:
+		if v < 0 || v != float64(uint64(v)) {
+			return fmt.Errorf("invalid Uint64 %v", v)
+		}
+		*n = uint64Scalar(v)
+	case string:
+		u, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Uint64 %s", v)
+		}
+		*n = uint64Scalar(u)
+	default:
+		return fmt.Errorf("invalid Uint64 %v", input)
+	}
+
+	return nil
+}
+
+func (n uint64Scalar) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uint64(n))
+}
+
+// parseAddress parses an optional address argument
+func parseAddress(name string, s *string) (string, error) {
+	if s == nil || *s == "" {
+		return "", nil
+	}
+
+	if !common.IsHexAddress(*s) {
+		return "", fmt.Errorf("Invalid %s", name)
+	}
+
+	return common.HexToAddress(*s).Hex(), nil
+}
+
+// requireAddress parses a required address argument
+func requireAddress(name, s string) (common.Address, error) {
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("Invalid %s", name)
+	}
+
+	return common.HexToAddress(s), nil
+}
+
+// parseLevel parses an amount of an orderbook price level
+func parseLevel(s string) *bigInt {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil
+	}
+
+	return &bigInt{n}
+}
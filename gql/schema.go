@@ -0,0 +1,309 @@
+package gql
+
+// schema is the GraphQL schema of the exchange. The amounts are BigInt decimal strings, the
+// addresses and hashes are hex strings and the dates of the filters are unix timestamps
+// in seconds, as in the HTTP endpoints.
+const schema = `
+schema {
+	query: Query
+	subscription: Subscription
+}
+
+scalar Time
+scalar BigInt
+scalar Uint64
+
+type Query {
+	pairs: [Pair!]!
+	pair(baseToken: String!, quoteToken: String!): Pair
+	tokens: [Token!]!
+	token(address: String!): Token
+	order(hash: String!): Order
+	orders(filter: OrderFilter, page: Page): OrderPage!
+	trades(filter: TradeFilter, page: Page): TradePage!
+	tradeHistory(address: String!, filter: TradeFilter, page: Page): TradePage!
+	account(address: String!): Account
+	notifications(address: String!, limit: Int, offset: Int): [Notification!]!
+	lendingOrders(filter: LendingOrderFilter, page: Page): LendingOrderPage!
+	lendingTrades(filter: LendingTradeFilter, page: Page): LendingTradePage!
+	lendingTradeHistory(address: String!, filter: LendingTradeFilter, page: Page): LendingTradePage!
+}
+
+type Subscription {
+	tradeUpdates(baseToken: String!, quoteToken: String!): TradesEvent!
+	orderBookUpdates(baseToken: String!, quoteToken: String!): OrderBookEvent!
+	lendingTradeUpdates(term: Uint64!, lendingToken: String!): LendingTradesEvent!
+}
+
+# Page selects a page by offset or with the cursor returned by the previous page
+input Page {
+	offset: Int
+	size: Int
+	cursor: String
+	ascending: Boolean
+}
+
+input OrderFilter {
+	userAddress: String
+	baseToken: String
+	quoteToken: String
+	status: String
+	side: String
+	type: String
+	hash: String
+	from: Int
+	to: Int
+}
+
+input TradeFilter {
+	baseToken: String
+	quoteToken: String
+	from: Int
+	to: Int
+}
+
+input LendingOrderFilter {
+	userAddress: String
+	lendingToken: String
+	collateralToken: String
+	term: String
+	status: String
+	side: String
+	type: String
+	hash: String
+	from: Int
+	to: Int
+}
+
+input LendingTradeFilter {
+	lendingToken: String
+	collateralToken: String
+	term: String
+	status: String
+	from: Int
+	to: Int
+}
+
+type Pair {
+	name: String!
+	baseTokenSymbol: String!
+	baseTokenAddress: String!
+	baseTokenDecimals: Int!
+	quoteTokenSymbol: String!
+	quoteTokenAddress: String!
+	quoteTokenDecimals: Int!
+	listed: Boolean!
+	active: Boolean!
+	rank: Int!
+	makeFee: BigInt
+	takeFee: BigInt
+	relayerAddress: String!
+	baseToken: Token
+	quoteToken: Token
+	data: PairData
+}
+
+type PairData {
+	open: BigInt
+	high: BigInt
+	low: BigInt
+	close: BigInt
+	volume: BigInt
+	baseVolume: BigInt
+	count: BigInt
+	change: Float!
+	askPrice: BigInt
+	bidPrice: BigInt
+	price: BigInt
+	timestamp: Float!
+}
+
+type Token {
+	name: String!
+	symbol: String!
+	address: String!
+	decimals: Int!
+	image: String!
+	active: Boolean!
+	listed: Boolean!
+	quote: Boolean!
+	makeFee: BigInt
+	takeFee: BigInt
+	usd: String!
+	relayerAddress: String!
+}
+
+type Order {
+	hash: String!
+	userAddress: String!
+	exchangeAddress: String!
+	baseToken: String!
+	quoteToken: String!
+	pairName: String!
+	status: String!
+	side: String!
+	type: String!
+	pricepoint: BigInt
+	amount: BigInt
+	filledAmount: BigInt
+	nonce: BigInt
+	orderID: Uint64!
+	createdAt: Time!
+	updatedAt: Time!
+	pair: Pair
+	trades: [Trade!]!
+}
+
+type OrderPage {
+	total: Int!
+	nextCursor: String!
+	orders: [Order!]!
+}
+
+type Trade {
+	hash: String!
+	txHash: String!
+	maker: String!
+	taker: String!
+	baseToken: String!
+	quoteToken: String!
+	pairName: String!
+	makerOrderHash: String!
+	takerOrderHash: String!
+	pricepoint: BigInt
+	amount: BigInt
+	makeFee: BigInt
+	takeFee: BigInt
+	status: String!
+	takerOrderSide: String!
+	takerOrderType: String!
+	makerOrderType: String!
+	createdAt: Time!
+	updatedAt: Time!
+	pair: Pair
+	makerOrder: Order
+	takerOrder: Order
+}
+
+type TradePage {
+	total: Int!
+	nextCursor: String!
+	trades: [Trade!]!
+}
+
+type LendingOrder {
+	hash: String!
+	txHash: String!
+	userAddress: String!
+	relayerAddress: String!
+	lendingToken: String!
+	collateralToken: String!
+	quantity: BigInt
+	filledAmount: BigInt
+	interest: Uint64!
+	term: Uint64!
+	side: String!
+	type: String!
+	status: String!
+	nonce: BigInt
+	lendingId: Uint64!
+	tradeId: Uint64!
+	autoTopUp: Uint64!
+	createdAt: Time!
+	updatedAt: Time!
+}
+
+type LendingOrderPage {
+	total: Int!
+	nextCursor: String!
+	lendingOrders: [LendingOrder!]!
+}
+
+type LendingTrade {
+	hash: String!
+	txHash: String!
+	tradeId: String!
+	borrower: String!
+	investor: String!
+	lendingToken: String!
+	collateralToken: String!
+	borrowingOrderHash: String!
+	investingOrderHash: String!
+	term: Uint64!
+	interest: Uint64!
+	amount: BigInt
+	collateralPrice: BigInt
+	liquidationPrice: BigInt
+	collateralLockedAmount: BigInt
+	liquidationTime: Uint64!
+	borrowingFee: BigInt
+	investingFee: BigInt
+	status: String!
+	takerOrderSide: String!
+	autoTopUp: Uint64!
+	createdAt: Time!
+	updatedAt: Time!
+}
+
+type LendingTradePage {
+	total: Int!
+	nextCursor: String!
+	lendingTrades: [LendingTrade!]!
+}
+
+type Account {
+	address: String!
+	isBlocked: Boolean!
+	tokenBalances: [TokenBalance!]!
+	favoriteTokens: [String!]!
+	createdAt: Time!
+	updatedAt: Time!
+	orders(filter: OrderFilter, page: Page): OrderPage!
+	trades(filter: TradeFilter, page: Page): TradePage!
+	notifications(limit: Int, offset: Int): [Notification!]!
+}
+
+type TokenBalance {
+	address: String!
+	symbol: String!
+	decimals: Int!
+	balance: BigInt
+	availableBalance: BigInt
+	inOrderBalance: BigInt
+	token: Token
+}
+
+type Notification {
+	id: ID!
+	recipient: String!
+	type: String!
+	status: String!
+	messageType: String!
+	description: String!
+	createdAt: Time!
+	updatedAt: Time!
+}
+
+type PriceLevel {
+	pricepoint: BigInt
+	amount: BigInt
+}
+
+# the type of the events is INIT for the snapshot sent on subscription, then UPDATE
+type TradesEvent {
+	type: String!
+	trades: [Trade!]!
+}
+
+type OrderBookEvent {
+	type: String!
+	pairName: String!
+	bids: [PriceLevel!]!
+	asks: [PriceLevel!]!
+}
+
+type LendingTradesEvent {
+	type: String!
+	lendingTrades: [LendingTrade!]!
+}
+`
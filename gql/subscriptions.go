@@ -0,0 +1,386 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/ws"
+)
+
+// The subscriptions are served with the graphql-ws protocol of subscriptions-transport-ws
+const (
+	gqlConnectionInit      = "connection_init"
+	gqlConnectionAck       = "connection_ack"
+	gqlConnectionError     = "connection_error"
+	gqlConnectionKeepAlive = "ka"
+	gqlConnectionTerminate = "connection_terminate"
+	gqlStart               = "start"
+	gqlStop                = "stop"
+	gqlData                = "data"
+	gqlError               = "error"
+	gqlComplete            = "complete"
+)
+
+const (
+	keepAlivePeriod = 20 * time.Second
+	writeWait       = 30 * time.Second
+
+	// sendQueueSize is the number of messages queued for a connection before it is closed as
+	// a slow consumer
+	sendQueueSize = 256
+)
+
+var upgrader = websocket.Upgrader{
+	Subprotocols: []string{"graphql-ws"},
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+type operationMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+func isWebsocketUpgrade(r *http.Request) bool {
+	return websocket.IsWebSocketUpgrade(r)
+}
+
+// subscriptionConn is a websocket connection running GraphQL operations
+type subscriptionConn struct {
+	conn       *websocket.Conn
+	send       chan operationMessage
+	done       chan struct{}
+	closeOnce  sync.Once
+	operations map[string]context.CancelFunc
+	mutex      sync.Mutex
+}
+
+func (h *Handler) serveSubscriptions(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	c := &subscriptionConn{
+		conn:       conn,
+		send:       make(chan operationMessage, sendQueueSize),
+		done:       make(chan struct{}),
+		operations: make(map[string]context.CancelFunc),
+	}
+	defer c.close()
+
+	go c.writeHandler()
+
+	for {
+		msg := operationMessage{}
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case gqlConnectionInit:
+			c.write(operationMessage{Type: gqlConnectionAck})
+			c.write(operationMessage{Type: gqlConnectionKeepAlive})
+		case gqlConnectionTerminate:
+			return
+		case gqlStart:
+			req := request{}
+			if err := json.Unmarshal(msg.Payload, &req); err != nil || req.Query == "" {
+				c.writeError(msg.ID, "Invalid payload")
+				continue
+			}
+
+			// each operation has its own loaders so that the events are not resolved from a
+			// cache shared with the other operations
+			ctx, cancel := context.WithCancel(h.context(r))
+			if !c.addOperation(msg.ID, cancel) {
+				cancel()
+				c.writeError(msg.ID, "Duplicate operation id")
+				continue
+			}
+
+			go c.run(ctx, h, msg.ID, req)
+		case gqlStop:
+			c.stopOperation(msg.ID)
+		default:
+			c.write(operationMessage{Type: gqlConnectionError})
+		}
+	}
+}
+
+// run sends the results of an operation until it completes or is stopped
+func (c *subscriptionConn) run(ctx context.Context, h *Handler, id string, req request) {
+	defer c.stopOperation(id)
+
+	responses, err := h.schema.Subscribe(ctx, req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		c.writeError(id, err.Error())
+		return
+	}
+
+	for res := range responses {
+		b, err := json.Marshal(res)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		c.write(operationMessage{ID: id, Type: gqlData, Payload: b})
+	}
+
+	c.write(operationMessage{ID: id, Type: gqlComplete})
+}
+
+func (c *subscriptionConn) addOperation(id string, cancel context.CancelFunc) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.operations[id]; ok {
+		return false
+	}
+
+	c.operations[id] = cancel
+	return true
+}
+
+func (c *subscriptionConn) stopOperation(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cancel, ok := c.operations[id]; ok {
+		cancel()
+		delete(c.operations, id)
+	}
+}
+
+func (c *subscriptionConn) writeError(id, message string) {
+	b, _ := json.Marshal(map[string]string{"message": message})
+	c.write(operationMessage{ID: id, Type: gqlError, Payload: b})
+}
+
+// write queues a message without blocking, a connection which does not read its messages fast
+// enough is closed
+func (c *subscriptionConn) write(m operationMessage) {
+	select {
+	case c.send <- m:
+	case <-c.done:
+	default:
+		logger.Warning("Slow GraphQL consumer, closing connection")
+		c.close()
+	}
+}
+
+func (c *subscriptionConn) writeHandler() {
+	ticker := time.NewTicker(keepAlivePeriod)
+	defer ticker.Stop()
+	defer c.close()
+
+	for {
+		select {
+		case m := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteJSON(m); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteJSON(operationMessage{Type: gqlConnectionKeepAlive}); err != nil {
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// close stops the operations and closes the connection, it can be called several times
+func (c *subscriptionConn) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+
+		c.mutex.Lock()
+		for id, cancel := range c.operations {
+			cancel()
+			delete(c.operations, id)
+		}
+		c.mutex.Unlock()
+
+		c.conn.Close()
+	})
+}
+
+type tradesEventResolver struct {
+	typ    string
+	trades []*types.Trade
+}
+
+func (r *tradesEventResolver) Type() string             { return r.typ }
+func (r *tradesEventResolver) Trades() []*tradeResolver { return toTradeResolvers(r.trades) }
+
+type orderBookEventResolver struct {
+	typ string
+	ob  *types.OrderBook
+}
+
+func (r *orderBookEventResolver) Type() string                { return r.typ }
+func (r *orderBookEventResolver) PairName() string            { return r.ob.PairName }
+func (r *orderBookEventResolver) Bids() []*priceLevelResolver { return toPriceLevels(r.ob.Bids) }
+func (r *orderBookEventResolver) Asks() []*priceLevelResolver { return toPriceLevels(r.ob.Asks) }
+
+type lendingTradesEventResolver struct {
+	typ    string
+	trades []*types.LendingTrade
+}
+
+func (r *lendingTradesEventResolver) Type() string { return r.typ }
+
+func (r *lendingTradesEventResolver) LendingTrades() []*lendingTradeResolver {
+	return toLendingTradeResolvers(r.trades)
+}
+
+// TradeUpdates subscribes to the trades channel of the pair
+func (r *resolver) TradeUpdates(ctx context.Context, args pairArgs) (<-chan *tradesEventResolver, error) {
+	bt, err := requireAddress("baseToken", args.BaseToken)
+	if err != nil {
+		return nil, err
+	}
+
+	qt, err := requireAddress("quoteToken", args.QuoteToken)
+	if err != nil {
+		return nil, err
+	}
+
+	c := ws.NewLocalClient()
+	if err := r.tradeService.Subscribe(c, bt, qt); err != nil {
+		c.Disconnect()
+		return nil, err
+	}
+
+	events := make(chan *tradesEventResolver)
+	go relay(ctx, c, func() { close(events) }, func(m types.WebsocketMessage) error {
+		trades := []*types.Trade{}
+		if err := decodePayload(m.Event.Payload, &trades); err != nil {
+			return err
+		}
+
+		select {
+		case events <- &tradesEventResolver{string(m.Event.Type), trades}:
+		case <-ctx.Done():
+		}
+		return nil
+	})
+
+	return events, nil
+}
+
+// OrderBookUpdates subscribes to the orderbook channel of the pair
+func (r *resolver) OrderBookUpdates(ctx context.Context, args pairArgs) (<-chan *orderBookEventResolver, error) {
+	bt, err := requireAddress("baseToken", args.BaseToken)
+	if err != nil {
+		return nil, err
+	}
+
+	qt, err := requireAddress("quoteToken", args.QuoteToken)
+	if err != nil {
+		return nil, err
+	}
+
+	c := ws.NewLocalClient()
+	if err := r.orderBookService.SubscribeOrderBook(c, bt, qt); err != nil {
+		c.Disconnect()
+		return nil, err
+	}
+
+	events := make(chan *orderBookEventResolver)
+	go relay(ctx, c, func() { close(events) }, func(m types.WebsocketMessage) error {
+		ob := &types.OrderBook{}
+		if err := decodePayload(m.Event.Payload, ob); err != nil {
+			return err
+		}
+
+		select {
+		case events <- &orderBookEventResolver{string(m.Event.Type), ob}:
+		case <-ctx.Done():
+		}
+		return nil
+	})
+
+	return events, nil
+}
+
+// LendingTradeUpdates subscribes to the lending trades channel of the term and lending token
+func (r *resolver) LendingTradeUpdates(ctx context.Context, args struct {
+	Term         uint64Scalar
+	LendingToken string
+}) (<-chan *lendingTradesEventResolver, error) {
+	lendingToken, err := requireAddress("lendingToken", args.LendingToken)
+	if err != nil {
+		return nil, err
+	}
+
+	c := ws.NewLocalClient()
+	if err := r.lendingTradeService.Subscribe(c, uint64(args.Term), lendingToken); err != nil {
+		c.Disconnect()
+		return nil, err
+	}
+
+	events := make(chan *lendingTradesEventResolver)
+	go relay(ctx, c, func() { close(events) }, func(m types.WebsocketMessage) error {
+		trades := []*types.LendingTrade{}
+		if err := decodePayload(m.Event.Payload, &trades); err != nil {
+			return err
+		}
+
+		select {
+		case events <- &lendingTradesEventResolver{string(m.Event.Type), trades}:
+		case <-ctx.Done():
+		}
+		return nil
+	})
+
+	return events, nil
+}
+
+// relay calls fn with the messages of a local client of the sockets until the subscription is
+// stopped or the client is closed as a slow consumer, then it unsubscribes the client
+func relay(ctx context.Context, c *ws.Client, done func(), fn func(types.WebsocketMessage) error) {
+	defer done()
+	defer c.Disconnect()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.Done():
+			return
+		case m := <-c.Messages():
+			if m.Event.Type == types.ERROR {
+				continue
+			}
+
+			if err := fn(m); err != nil {
+				logger.Error(err)
+				return
+			}
+		}
+	}
+}
+
+// decodePayload converts the payload of a socket message, which is relayed as json between
+// the instances of a cluster
+func decodePayload(payload interface{}, v interface{}) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,393 @@
+package gql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+type pairResolver struct {
+	p *types.Pair
+}
+
+func (r *pairResolver) Name() string              { return r.p.Name() }
+func (r *pairResolver) BaseTokenSymbol() string   { return r.p.BaseTokenSymbol }
+func (r *pairResolver) BaseTokenAddress() string  { return r.p.BaseTokenAddress.Hex() }
+func (r *pairResolver) BaseTokenDecimals() int32  { return int32(r.p.BaseTokenDecimals) }
+func (r *pairResolver) QuoteTokenSymbol() string  { return r.p.QuoteTokenSymbol }
+func (r *pairResolver) QuoteTokenAddress() string { return r.p.QuoteTokenAddress.Hex() }
+func (r *pairResolver) QuoteTokenDecimals() int32 { return int32(r.p.QuoteTokenDecimals) }
+func (r *pairResolver) Listed() bool              { return r.p.Listed }
+func (r *pairResolver) Active() bool              { return r.p.Active }
+func (r *pairResolver) Rank() int32               { return int32(r.p.Rank) }
+func (r *pairResolver) MakeFee() *bigInt          { return newBigInt(r.p.MakeFee) }
+func (r *pairResolver) TakeFee() *bigInt          { return newBigInt(r.p.TakeFee) }
+func (r *pairResolver) RelayerAddress() string    { return r.p.RelayerAddress.Hex() }
+
+func (r *pairResolver) BaseToken(ctx context.Context) (*tokenResolver, error) {
+	return resolveToken(ctx, r.p.BaseTokenAddress)
+}
+
+func (r *pairResolver) QuoteToken(ctx context.Context) (*tokenResolver, error) {
+	return resolveToken(ctx, r.p.QuoteTokenAddress)
+}
+
+func (r *pairResolver) Data(ctx context.Context) (*pairDataResolver, error) {
+	d, err := getLoaders(ctx).data(r.p.BaseTokenAddress, r.p.QuoteTokenAddress)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	return &pairDataResolver{d}, nil
+}
+
+func resolvePair(ctx context.Context, bt, qt common.Address) (*pairResolver, error) {
+	p, err := getLoaders(ctx).pair(bt, qt)
+	if err != nil || p == nil {
+		return nil, err
+	}
+
+	return &pairResolver{p}, nil
+}
+
+type pairDataResolver struct {
+	d *types.PairData
+}
+
+func (r *pairDataResolver) Open() *bigInt       { return newBigInt(r.d.Open) }
+func (r *pairDataResolver) High() *bigInt       { return newBigInt(r.d.High) }
+func (r *pairDataResolver) Low() *bigInt        { return newBigInt(r.d.Low) }
+func (r *pairDataResolver) Close() *bigInt      { return newBigInt(r.d.Close) }
+func (r *pairDataResolver) Volume() *bigInt     { return newBigInt(r.d.Volume) }
+func (r *pairDataResolver) BaseVolume() *bigInt { return newBigInt(r.d.BaseVolume) }
+func (r *pairDataResolver) Count() *bigInt      { return newBigInt(r.d.Count) }
+func (r *pairDataResolver) Change() float64     { return float64(r.d.Change) }
+func (r *pairDataResolver) AskPrice() *bigInt   { return newBigInt(r.d.AskPrice) }
+func (r *pairDataResolver) BidPrice() *bigInt   { return newBigInt(r.d.BidPrice) }
+func (r *pairDataResolver) Price() *bigInt      { return newBigInt(r.d.Price) }
+func (r *pairDataResolver) Timestamp() float64  { return float64(r.d.Timestamp) }
+
+type tokenResolver struct {
+	t *types.Token
+}
+
+func (r *tokenResolver) Name() string           { return r.t.Name }
+func (r *tokenResolver) Symbol() string         { return r.t.Symbol }
+func (r *tokenResolver) Address() string        { return r.t.ContractAddress.Hex() }
+func (r *tokenResolver) Decimals() int32        { return int32(r.t.Decimals) }
+func (r *tokenResolver) Image() string          { return r.t.Image.URL }
+func (r *tokenResolver) Active() bool           { return r.t.Active }
+func (r *tokenResolver) Listed() bool           { return r.t.Listed }
+func (r *tokenResolver) Quote() bool            { return r.t.Quote }
+func (r *tokenResolver) MakeFee() *bigInt       { return newBigInt(r.t.MakeFee) }
+func (r *tokenResolver) TakeFee() *bigInt       { return newBigInt(r.t.TakeFee) }
+func (r *tokenResolver) Usd() string            { return r.t.USD }
+func (r *tokenResolver) RelayerAddress() string { return r.t.RelayerAddress.Hex() }
+
+func resolveToken(ctx context.Context, addr common.Address) (*tokenResolver, error) {
+	t, err := getLoaders(ctx).token(addr)
+	if err != nil || t == nil {
+		return nil, err
+	}
+
+	return &tokenResolver{t}, nil
+}
+
+type orderResolver struct {
+	o *types.Order
+}
+
+func (r *orderResolver) Hash() string            { return r.o.Hash.Hex() }
+func (r *orderResolver) UserAddress() string     { return r.o.UserAddress.Hex() }
+func (r *orderResolver) ExchangeAddress() string { return r.o.ExchangeAddress.Hex() }
+func (r *orderResolver) BaseToken() string       { return r.o.BaseToken.Hex() }
+func (r *orderResolver) QuoteToken() string      { return r.o.QuoteToken.Hex() }
+func (r *orderResolver) PairName() string        { return r.o.PairName }
+func (r *orderResolver) Status() string          { return r.o.Status }
+func (r *orderResolver) Side() string            { return r.o.Side }
+func (r *orderResolver) Type() string            { return r.o.Type }
+func (r *orderResolver) Pricepoint() *bigInt     { return newBigInt(r.o.PricePoint) }
+func (r *orderResolver) Amount() *bigInt         { return newBigInt(r.o.Amount) }
+func (r *orderResolver) FilledAmount() *bigInt   { return newBigInt(r.o.FilledAmount) }
+func (r *orderResolver) Nonce() *bigInt          { return newBigInt(r.o.Nonce) }
+func (r *orderResolver) OrderID() uint64Scalar   { return uint64Scalar(r.o.OrderID) }
+func (r *orderResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.o.CreatedAt} }
+func (r *orderResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.o.UpdatedAt} }
+
+func (r *orderResolver) Pair(ctx context.Context) (*pairResolver, error) {
+	return resolvePair(ctx, r.o.BaseToken, r.o.QuoteToken)
+}
+
+func (r *orderResolver) Trades(ctx context.Context) ([]*tradeResolver, error) {
+	trades, err := getLoaders(ctx).trades(r.o.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTradeResolvers(trades), nil
+}
+
+func resolveOrder(ctx context.Context, hash common.Hash) (*orderResolver, error) {
+	o, err := getLoaders(ctx).order(hash)
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	return &orderResolver{o}, nil
+}
+
+type orderPageResolver struct {
+	res *types.OrderRes
+}
+
+func (r *orderPageResolver) Total() int32       { return int32(r.res.Total) }
+func (r *orderPageResolver) NextCursor() string { return r.res.NextCursor }
+
+func (r *orderPageResolver) Orders() []*orderResolver {
+	orders := []*orderResolver{}
+	for _, o := range r.res.Orders {
+		orders = append(orders, &orderResolver{o})
+	}
+
+	return orders
+}
+
+type tradeResolver struct {
+	t *types.Trade
+}
+
+func (r *tradeResolver) Hash() string            { return r.t.Hash.Hex() }
+func (r *tradeResolver) TxHash() string          { return r.t.TxHash.Hex() }
+func (r *tradeResolver) Maker() string           { return r.t.Maker.Hex() }
+func (r *tradeResolver) Taker() string           { return r.t.Taker.Hex() }
+func (r *tradeResolver) BaseToken() string       { return r.t.BaseToken.Hex() }
+func (r *tradeResolver) QuoteToken() string      { return r.t.QuoteToken.Hex() }
+func (r *tradeResolver) PairName() string        { return r.t.PairName }
+func (r *tradeResolver) MakerOrderHash() string  { return r.t.MakerOrderHash.Hex() }
+func (r *tradeResolver) TakerOrderHash() string  { return r.t.TakerOrderHash.Hex() }
+func (r *tradeResolver) Pricepoint() *bigInt     { return newBigInt(r.t.PricePoint) }
+func (r *tradeResolver) Amount() *bigInt         { return newBigInt(r.t.Amount) }
+func (r *tradeResolver) MakeFee() *bigInt        { return newBigInt(r.t.MakeFee) }
+func (r *tradeResolver) TakeFee() *bigInt        { return newBigInt(r.t.TakeFee) }
+func (r *tradeResolver) Status() string          { return r.t.Status }
+func (r *tradeResolver) TakerOrderSide() string  { return r.t.TakerOrderSide }
+func (r *tradeResolver) TakerOrderType() string  { return r.t.TakerOrderType }
+func (r *tradeResolver) MakerOrderType() string  { return r.t.MakerOrderType }
+func (r *tradeResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.t.CreatedAt} }
+func (r *tradeResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.t.UpdatedAt} }
+
+func (r *tradeResolver) Pair(ctx context.Context) (*pairResolver, error) {
+	return resolvePair(ctx, r.t.BaseToken, r.t.QuoteToken)
+}
+
+func (r *tradeResolver) MakerOrder(ctx context.Context) (*orderResolver, error) {
+	return resolveOrder(ctx, r.t.MakerOrderHash)
+}
+
+func (r *tradeResolver) TakerOrder(ctx context.Context) (*orderResolver, error) {
+	return resolveOrder(ctx, r.t.TakerOrderHash)
+}
+
+func toTradeResolvers(trades []*types.Trade) []*tradeResolver {
+	res := []*tradeResolver{}
+	for _, t := range trades {
+		res = append(res, &tradeResolver{t})
+	}
+
+	return res
+}
+
+type tradePageResolver struct {
+	res *types.TradeRes
+}
+
+func (r *tradePageResolver) Total() int32             { return int32(r.res.Total) }
+func (r *tradePageResolver) NextCursor() string       { return r.res.NextCursor }
+func (r *tradePageResolver) Trades() []*tradeResolver { return toTradeResolvers(r.res.Trades) }
+
+type lendingOrderResolver struct {
+	o *types.LendingOrder
+}
+
+func (r *lendingOrderResolver) Hash() string            { return r.o.Hash.Hex() }
+func (r *lendingOrderResolver) TxHash() string          { return r.o.TxHash.Hex() }
+func (r *lendingOrderResolver) UserAddress() string     { return r.o.UserAddress.Hex() }
+func (r *lendingOrderResolver) RelayerAddress() string  { return r.o.RelayerAddress.Hex() }
+func (r *lendingOrderResolver) LendingToken() string    { return r.o.LendingToken.Hex() }
+func (r *lendingOrderResolver) CollateralToken() string { return r.o.CollateralToken.Hex() }
+func (r *lendingOrderResolver) Quantity() *bigInt       { return newBigInt(r.o.Quantity) }
+func (r *lendingOrderResolver) FilledAmount() *bigInt   { return newBigInt(r.o.FilledAmount) }
+func (r *lendingOrderResolver) Interest() uint64Scalar  { return uint64Scalar(r.o.Interest) }
+func (r *lendingOrderResolver) Term() uint64Scalar      { return uint64Scalar(r.o.Term) }
+func (r *lendingOrderResolver) Side() string            { return r.o.Side }
+func (r *lendingOrderResolver) Type() string            { return r.o.Type }
+func (r *lendingOrderResolver) Status() string          { return r.o.Status }
+func (r *lendingOrderResolver) Nonce() *bigInt          { return newBigInt(r.o.Nonce) }
+func (r *lendingOrderResolver) LendingId() uint64Scalar { return uint64Scalar(r.o.LendingID) }
+func (r *lendingOrderResolver) TradeId() uint64Scalar   { return uint64Scalar(r.o.LendingTradeID) }
+func (r *lendingOrderResolver) AutoTopUp() uint64Scalar { return uint64Scalar(r.o.AutoTopUp) }
+func (r *lendingOrderResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.o.CreatedAt} }
+func (r *lendingOrderResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.o.UpdatedAt} }
+
+type lendingOrderPageResolver struct {
+	res *types.LendingRes
+}
+
+func (r *lendingOrderPageResolver) Total() int32       { return int32(r.res.Total) }
+func (r *lendingOrderPageResolver) NextCursor() string { return r.res.NextCursor }
+
+func (r *lendingOrderPageResolver) LendingOrders() []*lendingOrderResolver {
+	orders := []*lendingOrderResolver{}
+	for _, o := range r.res.LendingItems {
+		orders = append(orders, &lendingOrderResolver{o})
+	}
+
+	return orders
+}
+
+type lendingTradeResolver struct {
+	t *types.LendingTrade
+}
+
+func (r *lendingTradeResolver) Hash() string               { return r.t.Hash.Hex() }
+func (r *lendingTradeResolver) TxHash() string             { return r.t.TxHash.Hex() }
+func (r *lendingTradeResolver) TradeId() string            { return r.t.TradeID }
+func (r *lendingTradeResolver) Borrower() string           { return r.t.Borrower.Hex() }
+func (r *lendingTradeResolver) Investor() string           { return r.t.Investor.Hex() }
+func (r *lendingTradeResolver) LendingToken() string       { return r.t.LendingToken.Hex() }
+func (r *lendingTradeResolver) CollateralToken() string    { return r.t.CollateralToken.Hex() }
+func (r *lendingTradeResolver) BorrowingOrderHash() string { return r.t.BorrowingOrderHash.Hex() }
+func (r *lendingTradeResolver) InvestingOrderHash() string { return r.t.InvestingOrderHash.Hex() }
+func (r *lendingTradeResolver) Term() uint64Scalar         { return uint64Scalar(r.t.Term) }
+func (r *lendingTradeResolver) Interest() uint64Scalar     { return uint64Scalar(r.t.Interest) }
+func (r *lendingTradeResolver) Amount() *bigInt            { return newBigInt(r.t.Amount) }
+func (r *lendingTradeResolver) CollateralPrice() *bigInt   { return newBigInt(r.t.CollateralPrice) }
+func (r *lendingTradeResolver) LiquidationPrice() *bigInt  { return newBigInt(r.t.LiquidationPrice) }
+func (r *lendingTradeResolver) CollateralLockedAmount() *bigInt {
+	return newBigInt(r.t.CollateralLockedAmount)
+}
+func (r *lendingTradeResolver) LiquidationTime() uint64Scalar {
+	return uint64Scalar(r.t.LiquidationTime)
+}
+func (r *lendingTradeResolver) BorrowingFee() *bigInt   { return newBigInt(r.t.BorrowingFee) }
+func (r *lendingTradeResolver) InvestingFee() *bigInt   { return newBigInt(r.t.InvestingFee) }
+func (r *lendingTradeResolver) Status() string          { return r.t.Status }
+func (r *lendingTradeResolver) TakerOrderSide() string  { return r.t.TakerOrderSide }
+func (r *lendingTradeResolver) AutoTopUp() uint64Scalar { return uint64Scalar(r.t.AutoTopUp) }
+func (r *lendingTradeResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.t.CreatedAt} }
+func (r *lendingTradeResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.t.UpdatedAt} }
+
+func toLendingTradeResolvers(trades []*types.LendingTrade) []*lendingTradeResolver {
+	res := []*lendingTradeResolver{}
+	for _, t := range trades {
+		res = append(res, &lendingTradeResolver{t})
+	}
+
+	return res
+}
+
+type lendingTradePageResolver struct {
+	res *types.LendingTradeRes
+}
+
+func (r *lendingTradePageResolver) Total() int32       { return int32(r.res.Total) }
+func (r *lendingTradePageResolver) NextCursor() string { return r.res.NextCursor }
+
+func (r *lendingTradePageResolver) LendingTrades() []*lendingTradeResolver {
+	return toLendingTradeResolvers(r.res.LendingTrades)
+}
+
+type accountResolver struct {
+	root *resolver
+	a    *types.Account
+}
+
+func (r *accountResolver) Address() string         { return r.a.Address.Hex() }
+func (r *accountResolver) IsBlocked() bool         { return r.a.IsBlocked }
+func (r *accountResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.a.CreatedAt} }
+func (r *accountResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.a.UpdatedAt} }
+
+// TokenBalances returns the balances sorted by symbol
+func (r *accountResolver) TokenBalances() []*tokenBalanceResolver {
+	balances := []*tokenBalanceResolver{}
+	for _, b := range r.a.TokenBalances {
+		balances = append(balances, &tokenBalanceResolver{b})
+	}
+
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].b.Symbol < balances[j].b.Symbol
+	})
+
+	return balances
+}
+
+func (r *accountResolver) FavoriteTokens() []string {
+	tokens := []string{}
+	for addr, favorite := range r.a.FavoriteTokens {
+		if favorite {
+			tokens = append(tokens, addr.Hex())
+		}
+	}
+
+	sort.Strings(tokens)
+	return tokens
+}
+
+func (r *accountResolver) Orders(ctx context.Context, args pageArgs) (*orderPageResolver, error) {
+	return r.root.orders(ctx, r.a.Address.Hex(), args)
+}
+
+func (r *accountResolver) Trades(ctx context.Context, args tradePageArgs) (*tradePageResolver, error) {
+	return r.root.tradeHistory(ctx, r.a.Address, args)
+}
+
+func (r *accountResolver) Notifications(args notificationArgs) ([]*notificationResolver, error) {
+	return r.root.notifications(r.a.Address, args)
+}
+
+type tokenBalanceResolver struct {
+	b *types.TokenBalance
+}
+
+func (r *tokenBalanceResolver) Address() string           { return r.b.Address.Hex() }
+func (r *tokenBalanceResolver) Symbol() string            { return r.b.Symbol }
+func (r *tokenBalanceResolver) Decimals() int32           { return int32(r.b.Decimals) }
+func (r *tokenBalanceResolver) Balance() *bigInt          { return newBigInt(r.b.Balance) }
+func (r *tokenBalanceResolver) AvailableBalance() *bigInt { return newBigInt(r.b.AvailableBalance) }
+func (r *tokenBalanceResolver) InOrderBalance() *bigInt   { return newBigInt(r.b.InOrderBalance) }
+
+func (r *tokenBalanceResolver) Token(ctx context.Context) (*tokenResolver, error) {
+	return resolveToken(ctx, r.b.Address)
+}
+
+type notificationResolver struct {
+	n *types.Notification
+}
+
+func (r *notificationResolver) ID() graphql.ID          { return graphql.ID(r.n.ID.Hex()) }
+func (r *notificationResolver) Recipient() string       { return r.n.Recipient.Hex() }
+func (r *notificationResolver) Type() string            { return r.n.Type }
+func (r *notificationResolver) Status() string          { return r.n.Status }
+func (r *notificationResolver) MessageType() string     { return r.n.Message.MessageType }
+func (r *notificationResolver) Description() string     { return r.n.Message.Description }
+func (r *notificationResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.n.CreatedAt} }
+func (r *notificationResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.n.UpdatedAt} }
+
+type priceLevelResolver struct {
+	level map[string]string
+}
+
+func (r *priceLevelResolver) Pricepoint() *bigInt { return parseLevel(r.level["pricepoint"]) }
+func (r *priceLevelResolver) Amount() *bigInt     { return parseLevel(r.level["amount"]) }
+
+func toPriceLevels(levels []map[string]string) []*priceLevelResolver {
+	res := []*priceLevelResolver{}
+	for _, l := range levels {
+		res = append(res, &priceLevelResolver{l})
+	}
+
+	return res
+}
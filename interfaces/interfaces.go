@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"io"
 	"math/big"
 	"net/http"
 	"time"
@@ -53,6 +54,7 @@ type OrderDao interface {
 	AddNewOrder(o *types.Order, topic string) error
 	CancelOrder(o *types.Order, topic string) error
 	GetOrders(orderSpec types.OrderSpec, sort []string, offset int, size int) (*types.OrderRes, error)
+	StreamOrders(orderSpec types.OrderSpec, fn func(*types.Order) error) error
 	GetOrderNonce(addr common.Address) (interface{}, error)
 	GetOpenOrders() ([]*types.Order, error)
 	GetBestBid(baseToken, quouteToken common.Address) (*types.PriceVolume, error)
@@ -85,8 +87,7 @@ type AccountDao interface {
 	Transfer(token common.Address, fromAddress common.Address, toAddress common.Address, amount *big.Int) error
 	Drop()
 	GetFavoriteTokens(owner common.Address) (map[common.Address]bool, error)
-	AddFavoriteToken(owner, token common.Address) error
-	DeleteFavoriteToken(owner, token common.Address) error
+	ClearFavoriteTokens(owner common.Address) error
 }
 
 type RelayerDao interface {
@@ -99,6 +100,34 @@ type RelayerDao interface {
 	UpdateNameByAddress(addr common.Address, name string, url string) error
 }
 
+type LeaseDao interface {
+	Acquire(name, owner string, ttl time.Duration) (bool, error)
+	Release(name, owner string) error
+}
+
+type FixAccountDao interface {
+	Create(a *types.FixAccount) error
+	GetAll() ([]*types.FixAccount, error)
+	GetBySenderCompID(senderCompID string) (*types.FixAccount, error)
+	Delete(senderCompID string) error
+}
+
+type WebhookDao interface {
+	Create(w *types.Webhook) error
+	Update(w *types.Webhook) error
+	GetAll() ([]*types.Webhook, error)
+	GetByOwner(owner common.Address) ([]*types.Webhook, error)
+	GetByID(id bson.ObjectId) (*types.Webhook, error)
+	Delete(id bson.ObjectId) error
+}
+
+type WebhookDeliveryDao interface {
+	Create(d *types.WebhookDelivery) error
+	UpdateStatus(d *types.WebhookDelivery) error
+	GetByID(id bson.ObjectId) (*types.WebhookDelivery, error)
+	Query(spec *types.WebhookDeliverySpec, offset, size int) (*types.WebhookDeliveryRes, error)
+}
+
 type ConfigDao interface {
 	GetSchemaVersion() uint64
 	GetAddressIndex(chain types.Chain) (uint64, error)
@@ -138,6 +167,7 @@ type PairDao interface {
 	GetByName(name string) (*types.Pair, error)
 	GetByTokenSymbols(baseTokenSymbol, quoteTokenSymbol string) (*types.Pair, error)
 	GetByTokenAddress(baseToken, quoteToken common.Address) (*types.Pair, error)
+	GetByTokenAddresses(pairs []types.PairAddresses) ([]types.Pair, error)
 	DeleteByToken(baseAddress common.Address, quoteAddress common.Address) error
 	DeleteByTokenAndCoinbase(baseAddress common.Address, quoteAddress common.Address, addr common.Address) error
 }
@@ -157,6 +187,7 @@ type TradeDao interface {
 	GetByOrderHashes(hashes []common.Hash) ([]*types.Trade, error)
 	GetSortedTrades(bt, qt common.Address, from, to int64, n int) ([]*types.Trade, error)
 	GetSortedTradesByUserAddress(a, bt, qt common.Address, from, to int64, limit ...int) ([]*types.Trade, error)
+	GetUserTradesFrom(a common.Address, from time.Time, excluded []common.Hash, limit int) ([]*types.Trade, error)
 	GetNTradesByPairAddress(bt, qt common.Address, n int) ([]*types.Trade, error)
 	GetTradesByPairAddress(bt, qt common.Address, n int) ([]*types.Trade, error)
 	GetAllTradesByPairAddress(bt, qt common.Address) ([]*types.Trade, error)
@@ -169,6 +200,7 @@ type TradeDao interface {
 	Drop()
 	GetTrades(tradeSpec *types.TradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.TradeRes, error)
 	GetTradesUserHistory(a common.Address, tradeSpec *types.TradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.TradeRes, error)
+	StreamTrades(a common.Address, tradeSpec *types.TradeSpec, fn func(*types.Trade) error) error
 	GetTradeByTime(dateFrom, dateTo int64, pageOffset int, pageSize int) ([]*types.Trade, error)
 }
 
@@ -180,6 +212,7 @@ type TokenDao interface {
 	GetAllByCoinbase(addr common.Address) ([]types.Token, error)
 	GetByID(id bson.ObjectId) (*types.Token, error)
 	GetByAddress(addr common.Address) (*types.Token, error)
+	GetByAddresses(addrs []common.Address) ([]types.Token, error)
 	GetBySymbol(symbol string) (*types.Token, error)
 	GetQuoteTokens() ([]types.Token, error)
 	GetBaseTokens() ([]types.Token, error)
@@ -207,6 +240,68 @@ type NotificationDao interface {
 	MarkAllRead(addr common.Address) error
 }
 
+type NotificationChannelDao interface {
+	Create(c *types.NotificationChannel) error
+	GetByUserAddress(addr common.Address) ([]*types.NotificationChannel, error)
+	GetByID(id bson.ObjectId, addr common.Address) (*types.NotificationChannel, error)
+	MarkVerified(id bson.ObjectId) error
+	Delete(id bson.ObjectId, addr common.Address) error
+}
+
+type NotificationPreferencesDao interface {
+	GetByUserAddress(addr common.Address) (*types.NotificationPreferences, error)
+	Upsert(p *types.NotificationPreferences) error
+}
+
+type NotificationDigestDao interface {
+	Create(items ...*types.NotificationDigestItem) error
+	GetAll() ([]*types.NotificationDigestItem, error)
+	DeleteByIds(ids ...bson.ObjectId) error
+}
+
+type PortfolioDao interface {
+	GetByUserAddress(addr common.Address, method string) (*types.Portfolio, error)
+	GetAllByUserAddress(addr common.Address) ([]*types.Portfolio, error)
+	GetAll() ([]*types.Portfolio, error)
+	Upsert(p *types.Portfolio) error
+}
+
+type AuthNonceDao interface {
+	Create(n *types.AuthNonce) error
+	Consume(nonce string) (bool, error)
+}
+
+type NonceReservationDao interface {
+	GetTaken(kind string, addr common.Address, count uint64) (map[uint64]bool, error)
+	Claim(kind string, addr common.Address, nonce uint64, expiresAt time.Time) (bool, error)
+	Release(kind string, addr common.Address, nonce uint64) error
+	Confirm(kind string, addr common.Address, nonce uint64, expiresAt time.Time) error
+}
+
+type ComplianceDao interface {
+	Upsert(e *types.ComplianceEntry) error
+	GetByAddress(addr common.Address) (*types.ComplianceEntry, error)
+	GetAll() ([]*types.ComplianceEntry, error)
+	Delete(addr common.Address) (bool, error)
+	GetExpired(now time.Time) ([]*types.ComplianceEntry, error)
+	DeleteExpired(e *types.ComplianceEntry) (bool, error)
+}
+
+type ComplianceAuditDao interface {
+	Create(a *types.ComplianceAuditEntry) error
+	Query(addr common.Address, offset, size int) (*types.ComplianceAuditRes, error)
+}
+
+type UserPreferencesDao interface {
+	GetByUserAddress(addr common.Address) (*types.UserPreferences, error)
+	Save(p *types.UserPreferences, version int64) error
+}
+
+type PortfolioSnapshotDao interface {
+	Create(s *types.PortfolioSnapshot) error
+	GetByUserAddress(addr common.Address, method string, from, to int64) ([]*types.PortfolioSnapshot, error)
+}
+
 type Engine interface {
 	HandleOrders(msg *rabbitmq.Message) error
 	// RecoverOrders(matches types.Matches) error
@@ -227,7 +322,7 @@ type WalletService interface {
 type OHLCVService interface {
 	Unsubscribe(c *ws.Client)
 	UnsubscribeChannel(c *ws.Client, p *types.SubscriptionPayload)
-	Subscribe(c *ws.Client, p *types.SubscriptionPayload)
+	Subscribe(c *ws.Client, p *types.SubscriptionPayload) error
 	GetOHLCV(p []types.PairAddresses, duration int64, unit string, timeInterval ...int64) ([]*types.Tick, error)
 	Get24hTick(baseToken, quoteToken common.Address) *types.Tick
 	GetFiatPriceChart() (map[string][]*types.FiatPriceItem, error)
@@ -260,6 +355,7 @@ type OrderService interface {
 	CancelAllOrder(a common.Address) error
 	HandleEngineResponse(res *types.EngineResponse) error
 	GetOrders(orderSpec types.OrderSpec, sort []string, offset int, size int) (*types.OrderRes, error)
+	StreamOrders(orderSpec types.OrderSpec, fn func(*types.Order) error) error
 	GetOrderNonceByUserAddress(addr common.Address) (interface{}, error)
 	GetBestBid(baseToken, quouteToken common.Address) (*types.PriceVolume, error)
 	GetBestAsk(baseToken, quouteToken common.Address) (*types.PriceVolume, error)
@@ -269,10 +365,13 @@ type OrderBookService interface {
 	GetOrderBook(bt, qt common.Address) (*types.OrderBook, error)
 	GetDbOrderBook(bt, qt common.Address) (*types.OrderBook, error)
 	GetRawOrderBook(bt, qt common.Address) (*types.RawOrderBook, error)
-	SubscribeOrderBook(c *ws.Client, bt, qt common.Address)
+	GetOrderBookDepth(bt, qt common.Address, tickSize string, levels int, cumulative bool) (*types.OrderBook, error)
+	SubscribeOrderBook(c *ws.Client, bt, qt common.Address) error
+	SubscribeOrderBookDepth(c *ws.Client, bt, qt common.Address, tickSize string, levels int, cumulative bool) error
+	NotifyOrderBookUpdate(bt, qt common.Address, bids, asks []map[string]string)
 	UnsubscribeOrderBook(c *ws.Client)
 	UnsubscribeOrderBookChannel(c *ws.Client, bt, qt common.Address)
-	SubscribeRawOrderBook(c *ws.Client, bt, qt common.Address)
+	SubscribeRawOrderBook(c *ws.Client, bt, qt common.Address) error
 	UnsubscribeRawOrderBook(c *ws.Client)
 	UnsubscribeRawOrderBookChannel(c *ws.Client, bt, qt common.Address)
 }
@@ -309,25 +408,38 @@ type TradeService interface {
 	GetByOrderHashes(h []common.Hash) ([]*types.Trade, error)
 	GetByMakerOrderHash(h common.Hash) ([]*types.Trade, error)
 	GetByTakerOrderHash(h common.Hash) ([]*types.Trade, error)
-	Subscribe(c *ws.Client, bt, qt common.Address)
+	Subscribe(c *ws.Client, bt, qt common.Address) error
 	UnsubscribeChannel(c *ws.Client, bt, qt common.Address)
 	Unsubscribe(c *ws.Client)
 	GetTrades(tradeSpec *types.TradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.TradeRes, error)
 	GetTradesUserHistory(a common.Address, tradeSpec *types.TradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.TradeRes, error)
+	StreamTrades(a common.Address, tradeSpec *types.TradeSpec, fn func(*types.Trade) error) error
 }
 
 type PriceBoardService interface {
-	Subscribe(c *ws.Client, bt, qt common.Address)
-	UnsubscribeChannel(c *ws.Client, bt, qt common.Address)
+	Subscribe(c *ws.Client, bt, qt common.Address, currency string) error
+	UnsubscribeChannel(c *ws.Client, bt, qt common.Address, currency string)
 	Unsubscribe(c *ws.Client)
 }
 
 type MarketsService interface {
-	Subscribe(c *ws.Client)
-	UnsubscribeChannel(c *ws.Client)
+	Subscribe(c *ws.Client, currency string) error
+	UnsubscribeChannel(c *ws.Client, currency string)
 	Unsubscribe(c *ws.Client)
 }
 
+// FiatService converts USD values into the configured fiat currencies
+type FiatService interface {
+	Refresh() error
+	Currencies() []string
+	DefaultCurrency() string
+	IsSupported(currency string) bool
+	Convert(usd *big.Float, currency string) (*big.Float, error)
+	ConvertPairData(pairData []*types.PairData, currency string) error
+	ConvertFiatPriceChart(chart map[string][]*types.FiatPriceItem, currency string) (map[string][]*types.FiatPriceItem, error)
+	ConvertTokenBalances(balances map[common.Address]*types.TokenBalance, currency string) error
+}
+
 type NotificationService interface {
 	Create(n *types.Notification) ([]*types.Notification, error)
 	GetAll() ([]types.Notification, error)
@@ -338,6 +450,52 @@ type NotificationService interface {
 	MarkRead(id bson.ObjectId) error
 	MarkUnRead(id bson.ObjectId) error
 	MarkAllRead(addr common.Address) error
+	GetChannels(addr common.Address) ([]*types.NotificationChannel, error)
+	AddChannel(c *types.NotificationChannel) (*types.NotificationChannel, error)
+	VerifyChannel(id bson.ObjectId, addr common.Address, code string) error
+	RemoveChannel(id bson.ObjectId, addr common.Address) error
+	GetPreferences(addr common.Address) (*types.NotificationPreferences, error)
+	SavePreferences(p *types.NotificationPreferences) (*types.NotificationPreferences, error)
+}
+
+type AuthService interface {
+	NewNonce() (*types.AuthNonce, error)
+	Login(message string, signature []byte) (*types.AuthSession, error)
+	Authenticate(token string) (common.Address, error)
+}
+
+type ComplianceService interface {
+	CheckOpen(addr common.Address) error
+	CheckClose(addr common.Address) error
+	IsBlocked(addr common.Address) bool
+	GetAll() ([]*types.ComplianceEntry, error)
+	GetByAddress(addr common.Address) (*types.ComplianceEntry, error)
+	Block(e *types.ComplianceEntry, source string) error
+	Unblock(addr common.Address, reason string, source string) (bool, error)
+	Import(r io.Reader) ([]*types.ComplianceEntry, error)
+	GetAuditLog(addr common.Address, offset, size int) (*types.ComplianceAuditRes, error)
+}
+
+type PreferencesService interface {
+	Get(addr common.Address) (*types.UserPreferences, error)
+	Save(p *types.UserPreferences) error
+	Subscribe(c *ws.Client, addr common.Address) error
+	Unsubscribe(c *ws.Client, addr common.Address)
+}
+
+type NonceService interface {
+	Reserve(addr common.Address, n int) (*types.NonceReservation, error)
+	Release(addr common.Address, nonce uint64)
+	Confirm(addr common.Address, nonce uint64)
+}
+
+type StatementService interface {
+	GetStatement(addr, relayer common.Address, from, to int64, currency string) (*types.Statement, error)
+}
+
+type PortfolioService interface {
+	GetPortfolio(addr common.Address, method string) (*types.Portfolio, error)
+	GetSnapshots(addr common.Address, method string, from, to int64) ([]*types.PortfolioSnapshot, error)
 }
 
 type TxService interface {
@@ -348,6 +506,11 @@ type TxService interface {
 	GetCustomTxSendOptions(w *types.Wallet) *bind.TransactOpts
 }
 
+type BalanceService interface {
+	GetBalance(owner, token common.Address) (*big.Int, error)
+	GetTokenBalance(owner, token common.Address) (*types.TokenBalance, error)
+}
+
 type AccountService interface {
 	GetAll() ([]types.Account, error)
 	Create(account *types.Account) error
@@ -357,10 +520,9 @@ type AccountService interface {
 	GetTokenBalance(owner common.Address, token common.Address) (*types.TokenBalance, error)
 	GetTokenBalances(owner common.Address) (map[common.Address]*types.TokenBalance, error)
 	Transfer(token common.Address, fromAddress common.Address, toAddress common.Address, amount *big.Int) error
-	GetFavoriteTokens(account common.Address) (map[common.Address]bool, error)
-	AddFavoriteToken(account, token common.Address) error
-	DeleteFavoriteToken(account, token common.Address) error
 	GetTokenBalanceProvidor(owner common.Address, token common.Address) (*types.TokenBalance, error)
+	SubscribeBalances(c *ws.Client, owner common.Address) error
+	UnsubscribeBalances(c *ws.Client, owner common.Address)
 }
 
 type ValidatorService interface {
@@ -385,6 +547,7 @@ type EthereumClient interface {
 	BalanceAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (*big.Int, error)
 	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]eth.Log, error)
 	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- eth.Log) (ethereum.Subscription, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*eth.Header, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 }
 
@@ -448,18 +611,30 @@ type LendingOrderDao interface {
 	GetUserLockedBalance(account common.Address, token common.Address, tokens []types.Token) (*big.Int, error)
 }
 
+// L3OrderBookService interface for the per order orderbook feed
+type L3OrderBookService interface {
+	GetL3OrderBook(bt, qt common.Address) (*types.L3OrderBook, error)
+	SubscribeL3OrderBook(c *ws.Client, bt, qt common.Address) error
+	UnsubscribeL3OrderBook(c *ws.Client)
+	UnsubscribeL3OrderBookChannel(c *ws.Client, bt, qt common.Address)
+	HandleOrderChange(o *types.Order, sequence uint64)
+}
+
 // LendingOrderBookService interface for lending order book
 type LendingOrderBookService interface {
 	GetLendingOrderBook(term uint64, lendingToken common.Address) (*types.LendingOrderBook, error)
 	GetLendingOrderBookInDb(term uint64, lendingToken common.Address) (*types.LendingOrderBook, error)
-	SubscribeLendingOrderBook(c *ws.Client, term uint64, lendingToken common.Address)
+	GetLendingOrderBookDepth(term uint64, lendingToken common.Address, tickSize string, levels int, cumulative bool) (*types.LendingOrderBook, error)
+	SubscribeLendingOrderBook(c *ws.Client, term uint64, lendingToken common.Address) error
+	SubscribeLendingOrderBookDepth(c *ws.Client, term uint64, lendingToken common.Address, tickSize string, levels int, cumulative bool) error
+	NotifyLendingOrderBookUpdate(term uint64, lendingToken common.Address, borrow, lend []map[string]string)
 	UnsubscribeLendingOrderBook(c *ws.Client)
 	UnsubscribeLendingOrderBookChannel(c *ws.Client, term uint64, lendingToken common.Address)
 }
 
 // LendingTradeService interface for lending service
 type LendingTradeService interface {
-	Subscribe(c *ws.Client, term uint64, lendingToken common.Address)
+	Subscribe(c *ws.Client, term uint64, lendingToken common.Address) error
 	UnsubscribeChannel(c *ws.Client, term uint64, lendingToken common.Address)
 	Unsubscribe(c *ws.Client)
 	GetLendingTradesUserHistory(a common.Address, lendingtradeSpec *types.LendingTradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.LendingTradeRes, error)
@@ -476,18 +651,29 @@ type LendingTradeDao interface {
 	GetLendingTradesUserHistory(a common.Address, lendingtradeSpec *types.LendingTradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.LendingTradeRes, error)
 	GetLendingTrades(lendingtradeSpec *types.LendingTradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.LendingTradeRes, error)
 	GetByHash(hash common.Hash) (*types.LendingTrade, error)
+	StreamUserLendingTrades(a, relayer common.Address, from, to int64, fn func(*types.LendingTrade) error) error
 }
 
 // LendingOhlcvService interface for lending service
 type LendingOhlcvService interface {
 	GetOHLCV(term uint64, lendingToken common.Address, duration int64, unit string, timeInterval ...int64) ([]*types.LendingTick, error)
-	Subscribe(conn *ws.Client, p *types.SubscriptionPayload)
+	Subscribe(conn *ws.Client, p *types.SubscriptionPayload) error
 	Unsubscribe(conn *ws.Client)
 	GetAllTokenPairData() ([]*types.LendingTick, error)
 	GetTokenPairData(term uint64, lendingToken common.Address) *types.LendingTick
 	GetLendingVolumeByCoinbase(addr common.Address, years, months, days int) (*big.Int, *big.Int, error)
 }
 
+// IndicatorService interface for technical indicators over spot and lending candles
+type IndicatorService interface {
+	GetIndicators(bt, qt common.Address, duration int64, unit string, from, to int64, specs []types.IndicatorSpec) ([]*types.IndicatorSeries, error)
+	GetLendingIndicators(term uint64, lendingToken common.Address, duration int64, unit string, from, to int64, specs []types.IndicatorSpec) ([]*types.IndicatorSeries, error)
+	Subscribe(c *ws.Client, p *types.SubscriptionPayload, specs []types.IndicatorSpec) error
+	SubscribeLending(c *ws.Client, p *types.SubscriptionPayload, specs []types.IndicatorSpec) error
+	NotifyTicks(ticks []*types.Tick, duration int64, unit string)
+	NotifyLendingTicks(term uint64, lendingToken common.Address, ticks []*types.LendingTick, duration int64, unit string)
+}
+
 // LendingPairDao interface for lending pair by term/lendingtoken
 type LendingPairDao interface {
 	Create(o *types.LendingPair) error
@@ -507,14 +693,35 @@ type LendingPairService interface {
 
 // LendingMarketsService lending service interface
 type LendingMarketsService interface {
-	Subscribe(c *ws.Client)
+	Subscribe(c *ws.Client) error
 	UnsubscribeChannel(c *ws.Client)
 	Unsubscribe(c *ws.Client)
 }
 
 // LendingPriceBoardService lending price board service
 type LendingPriceBoardService interface {
-	Subscribe(c *ws.Client, term uint64, lendingToken common.Address)
+	Subscribe(c *ws.Client, term uint64, lendingToken common.Address) error
 	UnsubscribeChannel(c *ws.Client, term uint64, lendingToken common.Address)
 	Unsubscribe(c *ws.Client)
 }
+
+// FixAccountService manages the accounts of the FIX sessions
+type FixAccountService interface {
+	Create(senderCompID, password string) (*types.FixAccount, error)
+	GetAll() ([]*types.FixAccount, error)
+	Delete(senderCompID string) error
+	Authenticate(senderCompID, password string) (*types.FixAccount, *types.Wallet, error)
+}
+
+// WebhookService manages the webhooks and the log of their deliveries
+type WebhookService interface {
+	Create(w *types.Webhook) (*types.Webhook, error)
+	Update(w *types.Webhook) (*types.Webhook, error)
+	Delete(id bson.ObjectId) error
+	GetAll() ([]*types.Webhook, error)
+	GetByOwner(owner common.Address) ([]*types.Webhook, error)
+	GetByID(id bson.ObjectId) (*types.Webhook, error)
+	GetDeliveries(spec *types.WebhookDeliverySpec, offset, size int) (*types.WebhookDeliveryRes, error)
+	Replay(id bson.ObjectId) (*types.WebhookDelivery, error)
+	ReplayAll(spec *types.WebhookDeliverySpec) (int, error)
+}
@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/utils/httputils"
+)
+
+type contextKey int
+
+const addressKey contextKey = iota
+
+// Authenticate requires the session token returned by the login in the Authorization header,
+// as a bearer token, and puts the address of the session into the context of the request
+func Authenticate(authService interfaces.AuthService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := r.Header.Get("Authorization")
+			if !strings.HasPrefix(h, "Bearer ") {
+				httputils.WriteError(w, http.StatusUnauthorized, "Missing session token")
+				return
+			}
+
+			addr, err := authService.Authenticate(strings.TrimPrefix(h, "Bearer "))
+			if err != nil {
+				httputils.WriteError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), addressKey, addr)))
+		})
+	}
+}
+
+// AuthAddress returns the address of the session of a request authenticated by Authenticate
+func AuthAddress(r *http.Request) (common.Address, bool) {
+	addr, ok := r.Context().Value(addressKey).(common.Address)
+	return addr, ok
+}
+
+// IsAuthKey returns true if key is the api auth key of the config, no key matches when the api
+// auth key is not set
+func IsAuthKey(key string) bool {
+	if app.Config.ApiAuthKey == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(key), []byte(app.Config.ApiAuthKey)) == 1
+}
+
+// RequireAuthKey requires the api auth key in the authKey query parameter, every request is
+// refused when the api auth key is not set
+func RequireAuthKey(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("authKey")
+		if key == "" {
+			httputils.WriteError(w, http.StatusUnauthorized, "Missing auth key")
+			return
+		}
+
+		if !IsAuthKey(key) {
+			httputils.WriteError(w, http.StatusForbidden, "Invalid auth key")
+			return
+		}
+
+		fn(w, r)
+	}
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomochain/tomox-sdk/app"
+)
+
+func TestRequireAuthKey(t *testing.T) {
+	h := RequireAuthKey(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	status := func(url string) int {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest("GET", url, nil))
+		return w.Code
+	}
+
+	// every request is refused without a configured key
+	app.Config.ApiAuthKey = ""
+	assert.Equal(t, http.StatusUnauthorized, status("/"))
+	assert.Equal(t, http.StatusForbidden, status("/?authKey=x"))
+
+	app.Config.ApiAuthKey = "secret"
+	assert.Equal(t, http.StatusUnauthorized, status("/"))
+	assert.Equal(t, http.StatusForbidden, status("/?authKey=secre"))
+	assert.Equal(t, http.StatusOK, status("/?authKey=secret"))
+}
@@ -0,0 +1,129 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+
+	"github.com/streadway/amqp"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// Fanout exchanges shared by the instances of a cluster, each instance consumes them
+// through its own exclusive queue
+const (
+	relayExchange        = "tomox.ws_relay"
+	clusterEventExchange = "tomox.cluster_events"
+)
+
+// PublishRelayMessage publishes a websocket message to every instance of the cluster
+func (c *Connection) PublishRelayMessage(m *types.RelayMessage) error {
+	return c.publishFanout("relayPublish", relayExchange, m)
+}
+
+// SubscribeRelayMessages calls fn with the websocket messages published by the instances of the cluster
+func (c *Connection) SubscribeRelayMessages(fn func(*types.RelayMessage) error) error {
+	return c.subscribeFanout("relaySubscribe", relayExchange, func(b []byte) {
+		m := &types.RelayMessage{}
+		if err := json.Unmarshal(b, m); err != nil {
+			logger.Error(err)
+			return
+		}
+
+		fn(m)
+	})
+}
+
+// PublishClusterEvent publishes an event of the leader to every instance of the cluster
+func (c *Connection) PublishClusterEvent(e *types.ClusterEvent) error {
+	return c.publishFanout("clusterEventPublish", clusterEventExchange, e)
+}
+
+// SubscribeClusterEvents calls fn with the events published by the leader of the cluster
+func (c *Connection) SubscribeClusterEvents(fn func(*types.ClusterEvent) error) error {
+	return c.subscribeFanout("clusterEventSubscribe", clusterEventExchange, func(b []byte) {
+		e := &types.ClusterEvent{}
+		if err := json.Unmarshal(b, e); err != nil {
+			logger.Error(err)
+			return
+		}
+
+		fn(e)
+	})
+}
+
+func (c *Connection) publishFanout(channel, exchange string, v interface{}) error {
+	ch := c.GetChannel(channel)
+	if ch == nil {
+		return errors.New("Fail to open " + channel + " channel")
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err = ch.Publish(exchange, "", false, false, amqp.Publishing{
+		ContentType: "text/json",
+		Body:        b,
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// subscribeFanout binds an exclusive queue of this instance to a fanout exchange and calls fn
+// with each message, in the order they were published
+func (c *Connection) subscribeFanout(channel, exchange string, fn func([]byte)) error {
+	ch := c.GetChannel(channel)
+	if ch == nil {
+		return errors.New("Fail to open " + channel + " channel")
+	}
+
+	q, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err = ch.QueueBind(q.Name, "", exchange, false, nil)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	msgs, err := c.Consume(ch, &q)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	go func() {
+		for d := range msgs {
+			fn(d.Body)
+		}
+	}()
+
+	return nil
+}
+
+// DeclareClusterExchanges declares the fanout exchanges used by the instances of a cluster
+func (c *Connection) DeclareClusterExchanges() error {
+	ch := c.GetChannel("clusterExchanges")
+	if ch == nil {
+		return errors.New("Fail to open clusterExchanges channel")
+	}
+
+	for _, exchange := range []string{relayExchange, clusterEventExchange} {
+		err := ch.ExchangeDeclare(exchange, "fanout", false, false, false, false, nil)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// The webhook deliveries are queued in a durable queue shared by the instances. A failed
+// attempt is published to the retry queue of the attempt, whose messages expire after the
+// backoff delay and are dead lettered back to the delivery queue.
+const webhookQueue = "webhookDeliveries"
+
+func webhookRetryQueue(attempt int) string {
+	return fmt.Sprintf("%s.retry.%d", webhookQueue, attempt)
+}
+
+// DeclareWebhookQueues declares the durable delivery queue and its retry queues
+func (c *Connection) DeclareWebhookQueues() error {
+	ch := c.GetChannel("webhookPublish")
+	if ch == nil {
+		return errors.New("Fail to open webhookPublish channel")
+	}
+
+	_, err := ch.QueueDeclare(webhookQueue, true, false, false, false, nil)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	for attempt := 1; attempt < types.WebhookMaxAttempts; attempt++ {
+		args := amqp.Table{
+			"x-message-ttl":             int64(types.WebhookRetryDelay(attempt) / time.Millisecond),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": webhookQueue,
+		}
+
+		_, err := ch.QueueDeclare(webhookRetryQueue(attempt), true, false, false, false, args)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PublishWebhookDelivery queues a delivery, after the delay of the retry of the attempt if
+// attempt is not 0
+func (c *Connection) PublishWebhookDelivery(m *types.WebhookDeliveryMessage, attempt int) error {
+	ch := c.GetChannel("webhookPublish")
+	if ch == nil {
+		return errors.New("Fail to open webhookPublish channel")
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	queue := webhookQueue
+	if attempt > 0 {
+		queue = webhookRetryQueue(attempt)
+	}
+
+	err = ch.Publish("", queue, false, false, amqp.Publishing{
+		ContentType:  "text/json",
+		DeliveryMode: amqp.Persistent,
+		Body:         b,
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// SubscribeWebhookDeliveries calls fn with the queued deliveries, at most concurrency at a
+// time. A delivery is acknowledged once fn returns, it is queued again if fn fails.
+func (c *Connection) SubscribeWebhookDeliveries(concurrency int, fn func(*types.WebhookDeliveryMessage) error) error {
+	ch := c.GetChannel("webhookSubscribe")
+	if ch == nil {
+		return errors.New("Fail to open webhookSubscribe channel")
+	}
+
+	err := ch.Qos(concurrency, 0, false)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	msgs, err := c.ConsumeAfterAck(ch, &amqp.Queue{Name: webhookQueue})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for d := range msgs {
+			go func(d amqp.Delivery) {
+				m := &types.WebhookDeliveryMessage{}
+				if err := json.Unmarshal(d.Body, m); err != nil {
+					logger.Error(err)
+					d.Ack(false)
+					return
+				}
+
+				if err := fn(m); err != nil {
+					logger.Error(err)
+
+					// avoid a busy loop while the database is unavailable
+					time.Sleep(time.Second)
+					d.Nack(false, true)
+					return
+				}
+
+				d.Ack(false)
+			}(d)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,365 @@
+package rpc
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/rpc/pb"
+	"github.com/tomochain/tomox-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bigString returns the decimal representation of n, an empty string if n is nil
+func bigString(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+
+	return n.String()
+}
+
+// parseBig parses a decimal string, an empty string is a nil value
+func parseBig(field, s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %s", field, s)
+	}
+
+	return n, nil
+}
+
+// parseAddress parses a hex address, an empty string is the zero address
+func parseAddress(field, s string) (common.Address, error) {
+	if s == "" {
+		return common.Address{}, nil
+	}
+
+	if !common.IsHexAddress(s) {
+		return common.Address{}, status.Errorf(codes.InvalidArgument, "invalid %s: %s", field, s)
+	}
+
+	return common.HexToAddress(s), nil
+}
+
+// requireAddress parses a hex address which can not be empty
+func requireAddress(field, s string) (common.Address, error) {
+	if s == "" {
+		return common.Address{}, status.Errorf(codes.InvalidArgument, "%s is required", field)
+	}
+
+	return parseAddress(field, s)
+}
+
+func millis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func fromMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+func hashString(h common.Hash) string {
+	if h == (common.Hash{}) {
+		return ""
+	}
+
+	return h.Hex()
+}
+
+func objectIDString(id bson.ObjectId) string {
+	if !id.Valid() {
+		return ""
+	}
+
+	return id.Hex()
+}
+
+func toSignature(s *types.Signature) *pb.Signature {
+	if s == nil {
+		return nil
+	}
+
+	return &pb.Signature{V: uint32(s.V), R: s.R.Hex(), S: s.S.Hex()}
+}
+
+func fromSignature(s *pb.Signature) *types.Signature {
+	if s == nil {
+		return nil
+	}
+
+	return &types.Signature{V: byte(s.V), R: common.HexToHash(s.R), S: common.HexToHash(s.S)}
+}
+
+func toOrder(o *types.Order) *pb.Order {
+	return &pb.Order{
+		Id:              objectIDString(o.ID),
+		UserAddress:     o.UserAddress.Hex(),
+		ExchangeAddress: o.ExchangeAddress.Hex(),
+		BaseToken:       o.BaseToken.Hex(),
+		QuoteToken:      o.QuoteToken.Hex(),
+		Status:          o.Status,
+		Side:            o.Side,
+		Type:            o.Type,
+		Hash:            hashString(o.Hash),
+		Signature:       toSignature(o.Signature),
+		Pricepoint:      bigString(o.PricePoint),
+		Amount:          bigString(o.Amount),
+		FilledAmount:    bigString(o.FilledAmount),
+		Nonce:           bigString(o.Nonce),
+		PairName:        o.PairName,
+		CreatedAt:       millis(o.CreatedAt),
+		UpdatedAt:       millis(o.UpdatedAt),
+		OrderId:         o.OrderID,
+	}
+}
+
+func fromOrder(m *pb.Order) (*types.Order, error) {
+	var err error
+	o := &types.Order{
+		Status:    m.Status,
+		Side:      m.Side,
+		Type:      m.Type,
+		Hash:      common.HexToHash(m.Hash),
+		Signature: fromSignature(m.Signature),
+		PairName:  m.PairName,
+		OrderID:   m.OrderId,
+	}
+
+	if o.UserAddress, err = parseAddress("user_address", m.UserAddress); err != nil {
+		return nil, err
+	}
+	if o.ExchangeAddress, err = parseAddress("exchange_address", m.ExchangeAddress); err != nil {
+		return nil, err
+	}
+	if o.BaseToken, err = parseAddress("base_token", m.BaseToken); err != nil {
+		return nil, err
+	}
+	if o.QuoteToken, err = parseAddress("quote_token", m.QuoteToken); err != nil {
+		return nil, err
+	}
+	if o.PricePoint, err = parseBig("pricepoint", m.Pricepoint); err != nil {
+		return nil, err
+	}
+	if o.Amount, err = parseBig("amount", m.Amount); err != nil {
+		return nil, err
+	}
+	if o.FilledAmount, err = parseBig("filled_amount", m.FilledAmount); err != nil {
+		return nil, err
+	}
+	if o.Nonce, err = parseBig("nonce", m.Nonce); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func toOrderCancel(oc *types.OrderCancel) *pb.OrderCancel {
+	return &pb.OrderCancel{
+		OrderHash:       hashString(oc.OrderHash),
+		Nonce:           bigString(oc.Nonce),
+		Hash:            hashString(oc.Hash),
+		OrderId:         oc.OrderID,
+		Status:          oc.Status,
+		UserAddress:     oc.UserAddress.Hex(),
+		ExchangeAddress: oc.ExchangeAddress.Hex(),
+		Signature:       toSignature(oc.Signature),
+	}
+}
+
+func fromOrderCancel(m *pb.OrderCancel) (*types.OrderCancel, error) {
+	var err error
+	oc := &types.OrderCancel{
+		OrderHash: common.HexToHash(m.OrderHash),
+		Hash:      common.HexToHash(m.Hash),
+		OrderID:   m.OrderId,
+		Status:    m.Status,
+		Signature: fromSignature(m.Signature),
+	}
+
+	if oc.Nonce, err = parseBig("nonce", m.Nonce); err != nil {
+		return nil, err
+	}
+	if oc.UserAddress, err = parseAddress("user_address", m.UserAddress); err != nil {
+		return nil, err
+	}
+	if oc.ExchangeAddress, err = parseAddress("exchange_address", m.ExchangeAddress); err != nil {
+		return nil, err
+	}
+
+	return oc, nil
+}
+
+func toTrade(t *types.Trade) *pb.Trade {
+	return &pb.Trade{
+		Id:             objectIDString(t.ID),
+		Taker:          t.Taker.Hex(),
+		Maker:          t.Maker.Hex(),
+		BaseToken:      t.BaseToken.Hex(),
+		QuoteToken:     t.QuoteToken.Hex(),
+		MakerOrderHash: hashString(t.MakerOrderHash),
+		TakerOrderHash: hashString(t.TakerOrderHash),
+		Hash:           hashString(t.Hash),
+		TxHash:         hashString(t.TxHash),
+		PairName:       t.PairName,
+		Pricepoint:     bigString(t.PricePoint),
+		Amount:         bigString(t.Amount),
+		MakeFee:        bigString(t.MakeFee),
+		TakeFee:        bigString(t.TakeFee),
+		Status:         t.Status,
+		CreatedAt:      millis(t.CreatedAt),
+		UpdatedAt:      millis(t.UpdatedAt),
+		TakerOrderSide: t.TakerOrderSide,
+		TakerOrderType: t.TakerOrderType,
+		MakerOrderType: t.MakerOrderType,
+		MakerExchange:  t.MakerExchange.Hex(),
+		TakerExchange:  t.TakerExchange.Hex(),
+	}
+}
+
+func toTick(t *types.Tick) *pb.Tick {
+	return &pb.Tick{
+		Pair: &pb.PairID{
+			PairName:   t.Pair.PairName,
+			BaseToken:  t.Pair.BaseToken.Hex(),
+			QuoteToken: t.Pair.QuoteToken.Hex(),
+		},
+		Open:          bigString(t.Open),
+		Close:         bigString(t.Close),
+		High:          bigString(t.High),
+		Low:           bigString(t.Low),
+		Volume:        bigString(t.Volume),
+		VolumeByQuote: bigString(t.VolumeByQuote),
+		VolumeUsdt:    bigString(t.VolumeUsdt),
+		Count:         bigString(t.Count),
+		Timestamp:     t.Timestamp,
+		OpenTime:      millis(t.OpenTime),
+		CloseTime:     millis(t.CloseTime),
+		Duration:      t.Duration,
+		Unit:          t.Unit,
+	}
+}
+
+func toPair(p *types.Pair) *pb.Pair {
+	return &pb.Pair{
+		BaseTokenSymbol:    p.BaseTokenSymbol,
+		BaseTokenAddress:   p.BaseTokenAddress.Hex(),
+		BaseTokenDecimals:  int32(p.BaseTokenDecimals),
+		QuoteTokenSymbol:   p.QuoteTokenSymbol,
+		QuoteTokenAddress:  p.QuoteTokenAddress.Hex(),
+		QuoteTokenDecimals: int32(p.QuoteTokenDecimals),
+		Listed:             p.Listed,
+		Active:             p.Active,
+		Rank:               int32(p.Rank),
+		MakeFee:            bigString(p.MakeFee),
+		TakeFee:            bigString(p.TakeFee),
+		RelayerAddress:     p.RelayerAddress.Hex(),
+	}
+}
+
+// toPriceLevels converts the price levels of the orderbooks, stored as maps with the
+// pricepoint and amount keys
+func toPriceLevels(levels []map[string]string) []*pb.PriceLevel {
+	res := make([]*pb.PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		res = append(res, &pb.PriceLevel{Pricepoint: l["pricepoint"], Amount: l["amount"]})
+	}
+
+	return res
+}
+
+func toOrderBook(ob *types.OrderBook) *pb.OrderBook {
+	return &pb.OrderBook{
+		PairName: ob.PairName,
+		Bids:     toPriceLevels(ob.Bids),
+		Asks:     toPriceLevels(ob.Asks),
+	}
+}
+
+func toLendingOrder(o *types.LendingOrder) *pb.LendingOrder {
+	return &pb.LendingOrder{
+		Id:              objectIDString(o.ID),
+		Quantity:        bigString(o.Quantity),
+		Interest:        o.Interest,
+		Term:            o.Term,
+		Side:            o.Side,
+		Type:            o.Type,
+		LendingToken:    o.LendingToken.Hex(),
+		CollateralToken: o.CollateralToken.Hex(),
+		FilledAmount:    bigString(o.FilledAmount),
+		Status:          o.Status,
+		UserAddress:     o.UserAddress.Hex(),
+		RelayerAddress:  o.RelayerAddress.Hex(),
+		Signature:       toSignature(o.Signature),
+		Hash:            hashString(o.Hash),
+		TxHash:          hashString(o.TxHash),
+		Nonce:           bigString(o.Nonce),
+		CreatedAt:       millis(o.CreatedAt),
+		UpdatedAt:       millis(o.UpdatedAt),
+		LendingId:       o.LendingID,
+		ExtraData:       o.ExtraData,
+		TradeId:         o.LendingTradeID,
+		AutoTopUp:       o.AutoTopUp,
+	}
+}
+
+func fromLendingOrder(m *pb.LendingOrder) (*types.LendingOrder, error) {
+	var err error
+	o := &types.LendingOrder{
+		Interest:       m.Interest,
+		Term:           m.Term,
+		Side:           m.Side,
+		Type:           m.Type,
+		Status:         m.Status,
+		Signature:      fromSignature(m.Signature),
+		Hash:           common.HexToHash(m.Hash),
+		TxHash:         common.HexToHash(m.TxHash),
+		CreatedAt:      fromMillis(m.CreatedAt),
+		UpdatedAt:      fromMillis(m.UpdatedAt),
+		LendingID:      m.LendingId,
+		ExtraData:      m.ExtraData,
+		LendingTradeID: m.TradeId,
+		AutoTopUp:      m.AutoTopUp,
+	}
+
+	if bson.IsObjectIdHex(m.Id) {
+		o.ID = bson.ObjectIdHex(m.Id)
+	}
+	if o.Quantity, err = parseBig("quantity", m.Quantity); err != nil {
+		return nil, err
+	}
+	if o.FilledAmount, err = parseBig("filled_amount", m.FilledAmount); err != nil {
+		return nil, err
+	}
+	if o.Nonce, err = parseBig("nonce", m.Nonce); err != nil {
+		return nil, err
+	}
+	if o.LendingToken, err = parseAddress("lending_token", m.LendingToken); err != nil {
+		return nil, err
+	}
+	if o.CollateralToken, err = parseAddress("collateral_token", m.CollateralToken); err != nil {
+		return nil, err
+	}
+	if o.UserAddress, err = parseAddress("user_address", m.UserAddress); err != nil {
+		return nil, err
+	}
+	if o.RelayerAddress, err = parseAddress("relayer_address", m.RelayerAddress); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
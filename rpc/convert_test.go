@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomochain/tomox-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOrderConversion(t *testing.T) {
+	amount, _ := new(big.Int).SetString("123456789000000000000000000", 10)
+	o := &types.Order{
+		UserAddress: common.HexToAddress("0x1"),
+		BaseToken:   common.HexToAddress("0x2"),
+		QuoteToken:  common.HexToAddress("0x3"),
+		Amount:      amount,
+		PricePoint:  big.NewInt(1000),
+		Nonce:       big.NewInt(1),
+		Hash:        common.HexToHash("0x4"),
+		Side:        types.BUY,
+		Status:      types.OrderStatusOpen,
+		Signature:   &types.Signature{V: 27, R: common.HexToHash("0x5"), S: common.HexToHash("0x6")},
+		CreatedAt:   time.Unix(1500000000, 0),
+	}
+
+	m := toOrder(o)
+	assert.Equal(t, "123456789000000000000000000", m.Amount)
+	assert.Equal(t, "", m.FilledAmount)
+	assert.Equal(t, int64(1500000000000), m.CreatedAt)
+
+	decoded, err := fromOrder(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, o.UserAddress, decoded.UserAddress)
+	assert.Equal(t, o.Amount, decoded.Amount)
+	assert.Equal(t, o.PricePoint, decoded.PricePoint)
+	assert.Equal(t, o.Hash, decoded.Hash)
+	assert.Equal(t, o.Signature, decoded.Signature)
+	assert.Nil(t, decoded.FilledAmount)
+
+	m.Amount = "1e18"
+	_, err = fromOrder(m)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestToOrderBook(t *testing.T) {
+	ob := toOrderBook(&types.OrderBook{
+		PairName: "TOMO/USDT",
+		Bids:     []map[string]string{{"pricepoint": "100", "amount": "5"}},
+		Asks:     []map[string]string{},
+	})
+
+	assert.Equal(t, "TOMO/USDT", ob.PairName)
+	assert.Equal(t, "100", ob.Bids[0].Pricepoint)
+	assert.Equal(t, "5", ob.Bids[0].Amount)
+	assert.Empty(t, ob.Asks)
+}
+
+func TestHubSlowSubscriber(t *testing.T) {
+	h := newHub()
+	sub := h.subscribe("trades")
+
+	for i := 0; i <= streamBufferSize; i++ {
+		h.publish("trades", i)
+	}
+
+	err := h.run(context.Background(), "trades", sub, func(v interface{}) error {
+		return nil
+	})
+
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Empty(t, h.topics)
+}
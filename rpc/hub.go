@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamBufferSize is the number of messages queued for a stream, a stream which does not
+// keep up with its updates is closed
+const streamBufferSize = 256
+
+type subscriber struct {
+	messages chan interface{}
+	slow     chan struct{}
+	once     sync.Once
+}
+
+// hub dispatches the updates to the streams subscribed to a topic
+type hub struct {
+	topics map[string]map[*subscriber]bool
+	mutex  sync.RWMutex
+}
+
+func newHub() *hub {
+	return &hub{topics: make(map[string]map[*subscriber]bool)}
+}
+
+func (h *hub) subscribe(topic string) *subscriber {
+	sub := &subscriber{
+		messages: make(chan interface{}, streamBufferSize),
+		slow:     make(chan struct{}),
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[*subscriber]bool)
+	}
+
+	h.topics[topic][sub] = true
+	return sub
+}
+
+func (h *hub) unsubscribe(topic string, sub *subscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	delete(h.topics[topic], sub)
+	if len(h.topics[topic]) == 0 {
+		delete(h.topics, topic)
+	}
+}
+
+// publish sends v to the subscribers of the topic without blocking
+func (h *hub) publish(topic string, v interface{}) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	for sub := range h.topics[topic] {
+		select {
+		case sub.messages <- v:
+		default:
+			sub.once.Do(func() { close(sub.slow) })
+		}
+	}
+}
+
+// run sends the messages of the subscriber until the stream is closed by the client
+func (h *hub) run(ctx context.Context, topic string, sub *subscriber, send func(interface{}) error) error {
+	defer h.unsubscribe(topic, sub)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.slow:
+			logger.Warningf("Closing the slow stream of %v", topic)
+			return status.Error(codes.ResourceExhausted, "the stream does not keep up with the updates")
+		case v := <-sub.messages:
+			if err := send(v); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/rpc/pb"
+	"github.com/tomochain/tomox-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type lendingOrderServer struct {
+	*Server
+}
+
+func (s *lendingOrderServer) NewLendingOrder(ctx context.Context, m *pb.LendingOrder) (*pb.LendingOrder, error) {
+	o, err := fromLendingOrder(m)
+	if err != nil {
+		return nil, err
+	}
+
+	o.Hash = o.ComputeHash()
+	err = s.lendingOrderService.NewLendingOrder(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return toLendingOrder(o), nil
+}
+
+func (s *lendingOrderServer) CancelLendingOrder(ctx context.Context, m *pb.LendingOrder) (*pb.LendingOrder, error) {
+	return s.handle(m, s.lendingOrderService.CancelLendingOrder)
+}
+
+func (s *lendingOrderServer) RepayLendingOrder(ctx context.Context, m *pb.LendingOrder) (*pb.LendingOrder, error) {
+	return s.handle(m, s.lendingOrderService.RepayLendingOrder)
+}
+
+func (s *lendingOrderServer) TopupLendingOrder(ctx context.Context, m *pb.LendingOrder) (*pb.LendingOrder, error) {
+	return s.handle(m, s.lendingOrderService.TopupLendingOrder)
+}
+
+func (s *lendingOrderServer) handle(m *pb.LendingOrder, fn func(*types.LendingOrder) error) (*pb.LendingOrder, error) {
+	o, err := fromLendingOrder(m)
+	if err != nil {
+		return nil, err
+	}
+
+	err = fn(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return toLendingOrder(o), nil
+}
+
+func (s *lendingOrderServer) GetLendingOrders(ctx context.Context, req *pb.GetLendingOrdersRequest) (*pb.GetLendingOrdersResponse, error) {
+	spec := types.LendingSpec{
+		RelayerAddress: common.HexToAddress(app.Config.Tomochain["exchange_address"]),
+		Status:         req.Status,
+		Side:           req.Side,
+		Type:           req.Type,
+		DateFrom:       req.DateFrom,
+		DateTo:         req.DateTo,
+	}
+
+	if req.Term != "" {
+		if _, err := strconv.ParseUint(req.Term, 10, 64); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid term: %s", req.Term)
+		}
+
+		spec.Term = req.Term
+	}
+
+	for _, f := range []struct {
+		name  string
+		value string
+		spec  *string
+	}{
+		{"user_address", req.UserAddress, &spec.UserAddress},
+		{"collateral_token", req.CollateralToken, &spec.CollateralToken},
+		{"lending_token", req.LendingToken, &spec.LendingToken},
+	} {
+		if f.value == "" {
+			continue
+		}
+
+		addr, err := parseAddress(f.name, f.value)
+		if err != nil {
+			return nil, err
+		}
+
+		*f.spec = addr.Hex()
+	}
+
+	offset, size, err := page(req.PageOffset, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.lendingOrderService.GetLendingOrders(spec, []string{"-createdAt"}, offset*size, size)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := &pb.GetLendingOrdersResponse{Orders: []*pb.LendingOrder{}}
+	if res != nil {
+		orders.Total = int32(res.Total)
+		for _, o := range res.LendingItems {
+			orders.Orders = append(orders.Orders, toLendingOrder(o))
+		}
+	}
+
+	return orders, nil
+}
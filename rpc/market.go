@@ -0,0 +1,214 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/rpc/pb"
+	"github.com/tomochain/tomox-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type marketDataServer struct {
+	*Server
+}
+
+func (s *marketDataServer) GetPairs(ctx context.Context, req *pb.GetPairsRequest) (*pb.GetPairsResponse, error) {
+	pairs, err := s.pairService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.GetPairsResponse{Pairs: []*pb.Pair{}}
+	for i := range pairs {
+		res.Pairs = append(res.Pairs, toPair(&pairs[i]))
+	}
+
+	return res, nil
+}
+
+func (s *marketDataServer) GetOrderBook(ctx context.Context, req *pb.PairRequest) (*pb.OrderBook, error) {
+	bt, qt, err := pairAddresses(req.BaseToken, req.QuoteToken)
+	if err != nil {
+		return nil, err
+	}
+
+	ob, err := s.orderBookService.GetOrderBook(bt, qt)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrderBook(ob), nil
+}
+
+func (s *marketDataServer) GetTrades(ctx context.Context, req *pb.GetTradesRequest) (*pb.GetTradesResponse, error) {
+	spec := &types.TradeSpec{
+		RelayerAddress: common.HexToAddress(app.Config.Tomochain["exchange_address"]),
+		DateFrom:       req.DateFrom,
+		DateTo:         req.DateTo,
+	}
+
+	if req.BaseToken != "" {
+		bt, err := parseAddress("base_token", req.BaseToken)
+		if err != nil {
+			return nil, err
+		}
+
+		spec.BaseToken = bt.Hex()
+	}
+
+	if req.QuoteToken != "" {
+		qt, err := parseAddress("quote_token", req.QuoteToken)
+		if err != nil {
+			return nil, err
+		}
+
+		spec.QuoteToken = qt.Hex()
+	}
+
+	offset, size, err := page(req.PageOffset, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.tradeService.GetTrades(spec, []string{"-createdAt"}, offset*size, size)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := &pb.GetTradesResponse{Trades: []*pb.Trade{}}
+	if res != nil {
+		trades.Total = int32(res.Total)
+		for _, t := range res.Trades {
+			trades.Trades = append(trades.Trades, toTrade(t))
+		}
+	}
+
+	return trades, nil
+}
+
+func (s *marketDataServer) GetOHLCV(ctx context.Context, req *pb.GetOHLCVRequest) (*pb.GetOHLCVResponse, error) {
+	bt, qt, err := pairAddresses(req.BaseToken, req.QuoteToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTimeInterval(req.Duration, req.Unit); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from, to := req.From, req.To
+	if to == 0 {
+		to = now.Unix()
+	}
+
+	if from == 0 {
+		from = now.AddDate(-1, 0, 0).Unix()
+	}
+
+	pairs := []types.PairAddresses{{BaseToken: bt, QuoteToken: qt}}
+	ticks, err := s.ohlcvService.GetOHLCV(pairs, req.Duration, req.Unit, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.GetOHLCVResponse{Ticks: []*pb.Tick{}}
+	for _, t := range ticks {
+		res.Ticks = append(res.Ticks, toTick(t))
+	}
+
+	return res, nil
+}
+
+func (s *marketDataServer) StreamTrades(req *pb.PairRequest, stream pb.MarketDataService_StreamTradesServer) error {
+	bt, qt, err := pairAddresses(req.BaseToken, req.QuoteToken)
+	if err != nil {
+		return err
+	}
+
+	topic := tradesTopic(bt, qt)
+	sub := s.hub.subscribe(topic)
+
+	return s.hub.run(stream.Context(), topic, sub, func(v interface{}) error {
+		for _, t := range v.([]*types.Trade) {
+			if err := stream.Send(toTrade(t)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *marketDataServer) StreamOrderBook(req *pb.PairRequest, stream pb.MarketDataService_StreamOrderBookServer) error {
+	bt, qt, err := pairAddresses(req.BaseToken, req.QuoteToken)
+	if err != nil {
+		return err
+	}
+
+	// the stream subscribes before reading the orderbook so that no update is missed, the
+	// levels of the updates replace the levels of the orderbook
+	topic := orderBookTopic(bt, qt)
+	sub := s.hub.subscribe(topic)
+
+	ob, err := s.orderBookService.GetOrderBook(bt, qt)
+	if err != nil {
+		s.hub.unsubscribe(topic, sub)
+		return err
+	}
+
+	if err := stream.Send(toOrderBook(ob)); err != nil {
+		s.hub.unsubscribe(topic, sub)
+		return err
+	}
+
+	return s.hub.run(stream.Context(), topic, sub, func(v interface{}) error {
+		update := toOrderBook(v.(*types.OrderBook))
+		update.PairName = ob.PairName
+		return stream.Send(update)
+	})
+}
+
+func (s *marketDataServer) StreamOHLCV(req *pb.StreamOHLCVRequest, stream pb.MarketDataService_StreamOHLCVServer) error {
+	bt, qt, err := pairAddresses(req.BaseToken, req.QuoteToken)
+	if err != nil {
+		return err
+	}
+
+	if err := validateTimeInterval(req.Duration, req.Unit); err != nil {
+		return err
+	}
+
+	topic := ticksTopic(bt, qt, req.Duration, req.Unit)
+	sub := s.hub.subscribe(topic)
+
+	return s.hub.run(stream.Context(), topic, sub, func(v interface{}) error {
+		return stream.Send(toTick(v.(*types.Tick)))
+	})
+}
+
+func pairAddresses(baseToken, quoteToken string) (common.Address, common.Address, error) {
+	bt, err := requireAddress("base_token", baseToken)
+	if err != nil {
+		return bt, common.Address{}, err
+	}
+
+	qt, err := requireAddress("quote_token", quoteToken)
+	return bt, qt, err
+}
+
+// validateTimeInterval checks that the ticks of the interval are computed, see the
+// tick_duration configuration
+func validateTimeInterval(duration int64, unit string) error {
+	for _, d := range app.Config.TickDuration[unit] {
+		if d == duration {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.InvalidArgument, "invalid time interval: %d %s", duration, unit)
+}
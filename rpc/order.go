@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/rpc/pb"
+	"github.com/tomochain/tomox-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPageSize is the largest page of the queries, as in the HTTP endpoints
+const maxPageSize = 500
+
+type orderServer struct {
+	*Server
+}
+
+func (s *orderServer) NewOrder(ctx context.Context, m *pb.Order) (*pb.Order, error) {
+	o, err := fromOrder(m)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.orderService.NewOrder(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrder(o), nil
+}
+
+func (s *orderServer) CancelOrder(ctx context.Context, m *pb.OrderCancel) (*pb.OrderCancel, error) {
+	oc, err := fromOrderCancel(m)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.orderService.CancelOrder(oc)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrderCancel(oc), nil
+}
+
+func (s *orderServer) GetOrders(ctx context.Context, req *pb.GetOrdersRequest) (*pb.GetOrdersResponse, error) {
+	spec := types.OrderSpec{
+		RelayerAddress: common.HexToAddress(app.Config.Tomochain["exchange_address"]),
+		Status:         req.Status,
+		Side:           req.Side,
+		OrderType:      req.Type,
+		DateFrom:       req.DateFrom,
+		DateTo:         req.DateTo,
+	}
+
+	for _, f := range []struct {
+		name  string
+		value string
+		spec  *string
+	}{
+		{"user_address", req.UserAddress, &spec.UserAddress},
+		{"base_token", req.BaseToken, &spec.BaseToken},
+		{"quote_token", req.QuoteToken, &spec.QuoteToken},
+	} {
+		if f.value == "" {
+			continue
+		}
+
+		addr, err := parseAddress(f.name, f.value)
+		if err != nil {
+			return nil, err
+		}
+
+		*f.spec = addr.Hex()
+	}
+
+	offset, size, err := page(req.PageOffset, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.orderService.GetOrders(spec, []string{"-createdAt"}, offset*size, size)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := &pb.GetOrdersResponse{Orders: []*pb.Order{}}
+	if res != nil {
+		orders.Total = int32(res.Total)
+		for _, o := range res.Orders {
+			orders.Orders = append(orders.Orders, toOrder(o))
+		}
+	}
+
+	return orders, nil
+}
+
+func (s *orderServer) StreamOrders(req *pb.StreamOrdersRequest, stream pb.OrderService_StreamOrdersServer) error {
+	if err := authorize(stream.Context()); err != nil {
+		return err
+	}
+
+	addr, err := requireAddress("user_address", req.UserAddress)
+	if err != nil {
+		return err
+	}
+
+	topic := ordersTopic(addr)
+	sub := s.hub.subscribe(topic)
+
+	return s.hub.run(stream.Context(), topic, sub, func(v interface{}) error {
+		return stream.Send(toOrder(v.(*types.Order)))
+	})
+}
+
+// page validates the pagination of a query, the size defaults to types.DefaultLimit
+func page(offset, size int32) (int, int, error) {
+	if offset < 0 || offset > maxPageSize {
+		return 0, 0, status.Error(codes.InvalidArgument, "Invalid page offset")
+	}
+
+	if size < 0 || size > maxPageSize {
+		return 0, 0, status.Error(codes.InvalidArgument, "Invalid page size")
+	}
+
+	if size == 0 {
+		size = types.DefaultLimit
+	}
+
+	return int(offset), int(size), nil
+}
@@ -0,0 +1,432 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: lending.proto
+
+package pb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// The dates of the filter are unix timestamps in seconds
+type GetLendingOrdersRequest struct {
+	UserAddress          string   `protobuf:"bytes,1,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	CollateralToken      string   `protobuf:"bytes,2,opt,name=collateral_token,json=collateralToken,proto3" json:"collateral_token,omitempty"`
+	LendingToken         string   `protobuf:"bytes,3,opt,name=lending_token,json=lendingToken,proto3" json:"lending_token,omitempty"`
+	Term                 string   `protobuf:"bytes,4,opt,name=term,proto3" json:"term,omitempty"`
+	Status               string   `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Side                 string   `protobuf:"bytes,6,opt,name=side,proto3" json:"side,omitempty"`
+	Type                 string   `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	DateFrom             int64    `protobuf:"varint,8,opt,name=date_from,json=dateFrom,proto3" json:"date_from,omitempty"`
+	DateTo               int64    `protobuf:"varint,9,opt,name=date_to,json=dateTo,proto3" json:"date_to,omitempty"`
+	PageOffset           int32    `protobuf:"varint,10,opt,name=page_offset,json=pageOffset,proto3" json:"page_offset,omitempty"`
+	PageSize             int32    `protobuf:"varint,11,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLendingOrdersRequest) Reset()         { *m = GetLendingOrdersRequest{} }
+func (m *GetLendingOrdersRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLendingOrdersRequest) ProtoMessage()    {}
+func (*GetLendingOrdersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_71ed9f67089a5657, []int{0}
+}
+
+func (m *GetLendingOrdersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLendingOrdersRequest.Unmarshal(m, b)
+}
+func (m *GetLendingOrdersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLendingOrdersRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLendingOrdersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLendingOrdersRequest.Merge(m, src)
+}
+func (m *GetLendingOrdersRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLendingOrdersRequest.Size(m)
+}
+func (m *GetLendingOrdersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLendingOrdersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLendingOrdersRequest proto.InternalMessageInfo
+
+func (m *GetLendingOrdersRequest) GetUserAddress() string {
+	if m != nil {
+		return m.UserAddress
+	}
+	return ""
+}
+
+func (m *GetLendingOrdersRequest) GetCollateralToken() string {
+	if m != nil {
+		return m.CollateralToken
+	}
+	return ""
+}
+
+func (m *GetLendingOrdersRequest) GetLendingToken() string {
+	if m != nil {
+		return m.LendingToken
+	}
+	return ""
+}
+
+func (m *GetLendingOrdersRequest) GetTerm() string {
+	if m != nil {
+		return m.Term
+	}
+	return ""
+}
+
+func (m *GetLendingOrdersRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *GetLendingOrdersRequest) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *GetLendingOrdersRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *GetLendingOrdersRequest) GetDateFrom() int64 {
+	if m != nil {
+		return m.DateFrom
+	}
+	return 0
+}
+
+func (m *GetLendingOrdersRequest) GetDateTo() int64 {
+	if m != nil {
+		return m.DateTo
+	}
+	return 0
+}
+
+func (m *GetLendingOrdersRequest) GetPageOffset() int32 {
+	if m != nil {
+		return m.PageOffset
+	}
+	return 0
+}
+
+func (m *GetLendingOrdersRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type GetLendingOrdersResponse struct {
+	Total                int32           `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Orders               []*LendingOrder `protobuf:"bytes,2,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetLendingOrdersResponse) Reset()         { *m = GetLendingOrdersResponse{} }
+func (m *GetLendingOrdersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLendingOrdersResponse) ProtoMessage()    {}
+func (*GetLendingOrdersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_71ed9f67089a5657, []int{1}
+}
+
+func (m *GetLendingOrdersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLendingOrdersResponse.Unmarshal(m, b)
+}
+func (m *GetLendingOrdersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLendingOrdersResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLendingOrdersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLendingOrdersResponse.Merge(m, src)
+}
+func (m *GetLendingOrdersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLendingOrdersResponse.Size(m)
+}
+func (m *GetLendingOrdersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLendingOrdersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLendingOrdersResponse proto.InternalMessageInfo
+
+func (m *GetLendingOrdersResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *GetLendingOrdersResponse) GetOrders() []*LendingOrder {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetLendingOrdersRequest)(nil), "tomox.GetLendingOrdersRequest")
+	proto.RegisterType((*GetLendingOrdersResponse)(nil), "tomox.GetLendingOrdersResponse")
+}
+
+func init() { proto.RegisterFile("lending.proto", fileDescriptor_71ed9f67089a5657) }
+
+var fileDescriptor_71ed9f67089a5657 = []byte{
+	// 427 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0x51, 0x6b, 0x13, 0x41,
+	0x10, 0xc7, 0x49, 0xd2, 0xbb, 0x26, 0x73, 0x95, 0xd6, 0xad, 0xd8, 0x25, 0x82, 0x8d, 0xf5, 0xe5,
+	0x54, 0x4c, 0xa0, 0x3e, 0x16, 0x05, 0x15, 0xf4, 0x45, 0x2c, 0x5c, 0xf2, 0x24, 0x48, 0xd8, 0xdc,
+	0x4d, 0xd2, 0xa3, 0x77, 0xb7, 0xeb, 0xee, 0x9c, 0x36, 0xfd, 0x9c, 0x7e, 0x05, 0xbf, 0x87, 0xec,
+	0xdc, 0xa1, 0x41, 0x9b, 0x97, 0xbc, 0xcd, 0xfc, 0xfe, 0xbf, 0x19, 0xc2, 0x6c, 0x0e, 0xee, 0x15,
+	0x58, 0x65, 0x79, 0xb5, 0x1a, 0x1b, 0xab, 0x49, 0x8b, 0x80, 0x74, 0xa9, 0x6f, 0x86, 0x11, 0xad,
+	0x0d, 0xba, 0x86, 0x9d, 0xfd, 0xec, 0xc2, 0xc9, 0x47, 0xa4, 0x4f, 0x8d, 0x78, 0x69, 0x33, 0xb4,
+	0x2e, 0xc1, 0x6f, 0x35, 0x3a, 0x12, 0x4f, 0xe0, 0xa0, 0x76, 0x68, 0xe7, 0x2a, 0xcb, 0x2c, 0x3a,
+	0x27, 0x3b, 0xa3, 0x4e, 0x3c, 0x48, 0x22, 0xcf, 0xde, 0x36, 0x48, 0x3c, 0x83, 0xa3, 0x54, 0x17,
+	0x85, 0x22, 0xb4, 0xaa, 0x98, 0x93, 0xbe, 0xc6, 0x4a, 0x76, 0x59, 0x3b, 0xfc, 0xcb, 0x67, 0x1e,
+	0x8b, 0xa7, 0x7f, 0x7e, 0x4e, 0xeb, 0xf5, 0xd8, 0x3b, 0x68, 0x61, 0x23, 0x09, 0xd8, 0x23, 0xb4,
+	0xa5, 0xdc, 0xe3, 0x8c, 0x6b, 0xf1, 0x10, 0x42, 0x47, 0x8a, 0x6a, 0x27, 0x03, 0xa6, 0x6d, 0xe7,
+	0x5d, 0x97, 0x67, 0x28, 0xc3, 0xc6, 0xf5, 0x35, 0xcf, 0xaf, 0x0d, 0xca, 0xfd, 0x76, 0x7e, 0x6d,
+	0x50, 0x3c, 0x82, 0x41, 0xa6, 0x08, 0xe7, 0x4b, 0xab, 0x4b, 0xd9, 0x1f, 0x75, 0xe2, 0x5e, 0xd2,
+	0xf7, 0xe0, 0x83, 0xd5, 0xa5, 0x38, 0x81, 0x7d, 0x0e, 0x49, 0xcb, 0x01, 0x47, 0xa1, 0x6f, 0x67,
+	0x5a, 0x9c, 0x42, 0x64, 0xd4, 0x0a, 0xe7, 0x7a, 0xb9, 0x74, 0x48, 0x12, 0x46, 0x9d, 0x38, 0x48,
+	0xc0, 0xa3, 0x4b, 0x26, 0x7e, 0x2d, 0x0b, 0x2e, 0xbf, 0x45, 0x19, 0x71, 0xdc, 0xf7, 0x60, 0x9a,
+	0xdf, 0xe2, 0xd9, 0x57, 0x90, 0xff, 0x5f, 0xd5, 0x19, 0x5d, 0x39, 0x14, 0x0f, 0x20, 0x20, 0x4d,
+	0xaa, 0xe0, 0x7b, 0x06, 0x49, 0xd3, 0x88, 0x17, 0x10, 0x6a, 0xf6, 0x64, 0x77, 0xd4, 0x8b, 0xa3,
+	0xf3, 0xe3, 0x31, 0xbf, 0xd6, 0x78, 0x73, 0x47, 0xd2, 0x2a, 0xe7, 0xbf, 0xba, 0x70, 0xbc, 0x19,
+	0x4c, 0xd1, 0x7e, 0xcf, 0x53, 0x14, 0x17, 0x70, 0xf8, 0x19, 0x7f, 0x6c, 0x26, 0xe2, 0xae, 0x3d,
+	0xc3, 0xbb, 0xa0, 0x78, 0x03, 0xe2, 0xbd, 0xaa, 0x52, 0x2c, 0x76, 0x9c, 0x7f, 0x0d, 0xf7, 0x13,
+	0x34, 0x6a, 0xbd, 0xfb, 0xf8, 0x4c, 0x9b, 0xda, 0xec, 0x38, 0x3e, 0x85, 0xa3, 0x7f, 0x2f, 0x2e,
+	0x1e, 0xb7, 0xe2, 0x96, 0x3f, 0xf8, 0xf0, 0x74, 0x6b, 0xde, 0x3c, 0xd5, 0xbb, 0xe7, 0x5f, 0xe2,
+	0x55, 0x4e, 0x57, 0xf5, 0x62, 0x9c, 0xea, 0x72, 0xe2, 0xe5, 0xf4, 0x4a, 0xe5, 0x15, 0x57, 0x37,
+	0x2f, 0x5d, 0x76, 0x3d, 0xb1, 0x26, 0x9d, 0x98, 0xc5, 0x85, 0x59, 0x2c, 0x42, 0xfe, 0xa0, 0x5e,
+	0xfd, 0x1e, 0x00, 0x8f, 0x55, 0xcc, 0x29, 0x75, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// LendingOrderServiceClient is the client API for LendingOrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type LendingOrderServiceClient interface {
+	NewLendingOrder(ctx context.Context, in *LendingOrder, opts ...grpc.CallOption) (*LendingOrder, error)
+	CancelLendingOrder(ctx context.Context, in *LendingOrder, opts ...grpc.CallOption) (*LendingOrder, error)
+	RepayLendingOrder(ctx context.Context, in *LendingOrder, opts ...grpc.CallOption) (*LendingOrder, error)
+	TopupLendingOrder(ctx context.Context, in *LendingOrder, opts ...grpc.CallOption) (*LendingOrder, error)
+	GetLendingOrders(ctx context.Context, in *GetLendingOrdersRequest, opts ...grpc.CallOption) (*GetLendingOrdersResponse, error)
+}
+
+type lendingOrderServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLendingOrderServiceClient(cc *grpc.ClientConn) LendingOrderServiceClient {
+	return &lendingOrderServiceClient{cc}
+}
+
+func (c *lendingOrderServiceClient) NewLendingOrder(ctx context.Context, in *LendingOrder, opts ...grpc.CallOption) (*LendingOrder, error) {
+	out := new(LendingOrder)
+	err := c.cc.Invoke(ctx, "/tomox.LendingOrderService/NewLendingOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lendingOrderServiceClient) CancelLendingOrder(ctx context.Context, in *LendingOrder, opts ...grpc.CallOption) (*LendingOrder, error) {
+	out := new(LendingOrder)
+	err := c.cc.Invoke(ctx, "/tomox.LendingOrderService/CancelLendingOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lendingOrderServiceClient) RepayLendingOrder(ctx context.Context, in *LendingOrder, opts ...grpc.CallOption) (*LendingOrder, error) {
+	out := new(LendingOrder)
+	err := c.cc.Invoke(ctx, "/tomox.LendingOrderService/RepayLendingOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lendingOrderServiceClient) TopupLendingOrder(ctx context.Context, in *LendingOrder, opts ...grpc.CallOption) (*LendingOrder, error) {
+	out := new(LendingOrder)
+	err := c.cc.Invoke(ctx, "/tomox.LendingOrderService/TopupLendingOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lendingOrderServiceClient) GetLendingOrders(ctx context.Context, in *GetLendingOrdersRequest, opts ...grpc.CallOption) (*GetLendingOrdersResponse, error) {
+	out := new(GetLendingOrdersResponse)
+	err := c.cc.Invoke(ctx, "/tomox.LendingOrderService/GetLendingOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LendingOrderServiceServer is the server API for LendingOrderService service.
+type LendingOrderServiceServer interface {
+	NewLendingOrder(context.Context, *LendingOrder) (*LendingOrder, error)
+	CancelLendingOrder(context.Context, *LendingOrder) (*LendingOrder, error)
+	RepayLendingOrder(context.Context, *LendingOrder) (*LendingOrder, error)
+	TopupLendingOrder(context.Context, *LendingOrder) (*LendingOrder, error)
+	GetLendingOrders(context.Context, *GetLendingOrdersRequest) (*GetLendingOrdersResponse, error)
+}
+
+func RegisterLendingOrderServiceServer(s *grpc.Server, srv LendingOrderServiceServer) {
+	s.RegisterService(&_LendingOrderService_serviceDesc, srv)
+}
+
+func _LendingOrderService_NewLendingOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LendingOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LendingOrderServiceServer).NewLendingOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.LendingOrderService/NewLendingOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LendingOrderServiceServer).NewLendingOrder(ctx, req.(*LendingOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LendingOrderService_CancelLendingOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LendingOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LendingOrderServiceServer).CancelLendingOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.LendingOrderService/CancelLendingOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LendingOrderServiceServer).CancelLendingOrder(ctx, req.(*LendingOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LendingOrderService_RepayLendingOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LendingOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LendingOrderServiceServer).RepayLendingOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.LendingOrderService/RepayLendingOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LendingOrderServiceServer).RepayLendingOrder(ctx, req.(*LendingOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LendingOrderService_TopupLendingOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LendingOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LendingOrderServiceServer).TopupLendingOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.LendingOrderService/TopupLendingOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LendingOrderServiceServer).TopupLendingOrder(ctx, req.(*LendingOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LendingOrderService_GetLendingOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLendingOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LendingOrderServiceServer).GetLendingOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.LendingOrderService/GetLendingOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LendingOrderServiceServer).GetLendingOrders(ctx, req.(*GetLendingOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _LendingOrderService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tomox.LendingOrderService",
+	HandlerType: (*LendingOrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NewLendingOrder",
+			Handler:    _LendingOrderService_NewLendingOrder_Handler,
+		},
+		{
+			MethodName: "CancelLendingOrder",
+			Handler:    _LendingOrderService_CancelLendingOrder_Handler,
+		},
+		{
+			MethodName: "RepayLendingOrder",
+			Handler:    _LendingOrderService_RepayLendingOrder_Handler,
+		},
+		{
+			MethodName: "TopupLendingOrder",
+			Handler:    _LendingOrderService_TopupLendingOrder_Handler,
+		},
+		{
+			MethodName: "GetLendingOrders",
+			Handler:    _LendingOrderService_GetLendingOrders_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "lending.proto",
+}
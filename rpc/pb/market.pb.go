@@ -0,0 +1,856 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: market.proto
+
+package pb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type PairRequest struct {
+	BaseToken            string   `protobuf:"bytes,1,opt,name=base_token,json=baseToken,proto3" json:"base_token,omitempty"`
+	QuoteToken           string   `protobuf:"bytes,2,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PairRequest) Reset()         { *m = PairRequest{} }
+func (m *PairRequest) String() string { return proto.CompactTextString(m) }
+func (*PairRequest) ProtoMessage()    {}
+func (*PairRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3f90997f23a2c3f8, []int{0}
+}
+
+func (m *PairRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PairRequest.Unmarshal(m, b)
+}
+func (m *PairRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PairRequest.Marshal(b, m, deterministic)
+}
+func (m *PairRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PairRequest.Merge(m, src)
+}
+func (m *PairRequest) XXX_Size() int {
+	return xxx_messageInfo_PairRequest.Size(m)
+}
+func (m *PairRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PairRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PairRequest proto.InternalMessageInfo
+
+func (m *PairRequest) GetBaseToken() string {
+	if m != nil {
+		return m.BaseToken
+	}
+	return ""
+}
+
+func (m *PairRequest) GetQuoteToken() string {
+	if m != nil {
+		return m.QuoteToken
+	}
+	return ""
+}
+
+type GetPairsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPairsRequest) Reset()         { *m = GetPairsRequest{} }
+func (m *GetPairsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPairsRequest) ProtoMessage()    {}
+func (*GetPairsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3f90997f23a2c3f8, []int{1}
+}
+
+func (m *GetPairsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPairsRequest.Unmarshal(m, b)
+}
+func (m *GetPairsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPairsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPairsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPairsRequest.Merge(m, src)
+}
+func (m *GetPairsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPairsRequest.Size(m)
+}
+func (m *GetPairsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPairsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPairsRequest proto.InternalMessageInfo
+
+type GetPairsResponse struct {
+	Pairs                []*Pair  `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPairsResponse) Reset()         { *m = GetPairsResponse{} }
+func (m *GetPairsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPairsResponse) ProtoMessage()    {}
+func (*GetPairsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3f90997f23a2c3f8, []int{2}
+}
+
+func (m *GetPairsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPairsResponse.Unmarshal(m, b)
+}
+func (m *GetPairsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPairsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPairsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPairsResponse.Merge(m, src)
+}
+func (m *GetPairsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPairsResponse.Size(m)
+}
+func (m *GetPairsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPairsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPairsResponse proto.InternalMessageInfo
+
+func (m *GetPairsResponse) GetPairs() []*Pair {
+	if m != nil {
+		return m.Pairs
+	}
+	return nil
+}
+
+// The dates of the filter are unix timestamps in seconds
+type GetTradesRequest struct {
+	BaseToken            string   `protobuf:"bytes,1,opt,name=base_token,json=baseToken,proto3" json:"base_token,omitempty"`
+	QuoteToken           string   `protobuf:"bytes,2,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+	DateFrom             int64    `protobuf:"varint,3,opt,name=date_from,json=dateFrom,proto3" json:"date_from,omitempty"`
+	DateTo               int64    `protobuf:"varint,4,opt,name=date_to,json=dateTo,proto3" json:"date_to,omitempty"`
+	PageOffset           int32    `protobuf:"varint,5,opt,name=page_offset,json=pageOffset,proto3" json:"page_offset,omitempty"`
+	PageSize             int32    `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTradesRequest) Reset()         { *m = GetTradesRequest{} }
+func (m *GetTradesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTradesRequest) ProtoMessage()    {}
+func (*GetTradesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3f90997f23a2c3f8, []int{3}
+}
+
+func (m *GetTradesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTradesRequest.Unmarshal(m, b)
+}
+func (m *GetTradesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTradesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTradesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTradesRequest.Merge(m, src)
+}
+func (m *GetTradesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTradesRequest.Size(m)
+}
+func (m *GetTradesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTradesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTradesRequest proto.InternalMessageInfo
+
+func (m *GetTradesRequest) GetBaseToken() string {
+	if m != nil {
+		return m.BaseToken
+	}
+	return ""
+}
+
+func (m *GetTradesRequest) GetQuoteToken() string {
+	if m != nil {
+		return m.QuoteToken
+	}
+	return ""
+}
+
+func (m *GetTradesRequest) GetDateFrom() int64 {
+	if m != nil {
+		return m.DateFrom
+	}
+	return 0
+}
+
+func (m *GetTradesRequest) GetDateTo() int64 {
+	if m != nil {
+		return m.DateTo
+	}
+	return 0
+}
+
+func (m *GetTradesRequest) GetPageOffset() int32 {
+	if m != nil {
+		return m.PageOffset
+	}
+	return 0
+}
+
+func (m *GetTradesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type GetTradesResponse struct {
+	Total                int32    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Trades               []*Trade `protobuf:"bytes,2,rep,name=trades,proto3" json:"trades,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTradesResponse) Reset()         { *m = GetTradesResponse{} }
+func (m *GetTradesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTradesResponse) ProtoMessage()    {}
+func (*GetTradesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3f90997f23a2c3f8, []int{4}
+}
+
+func (m *GetTradesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTradesResponse.Unmarshal(m, b)
+}
+func (m *GetTradesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTradesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTradesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTradesResponse.Merge(m, src)
+}
+func (m *GetTradesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTradesResponse.Size(m)
+}
+func (m *GetTradesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTradesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTradesResponse proto.InternalMessageInfo
+
+func (m *GetTradesResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *GetTradesResponse) GetTrades() []*Trade {
+	if m != nil {
+		return m.Trades
+	}
+	return nil
+}
+
+// The dates of the filter are unix timestamps in seconds
+type GetOHLCVRequest struct {
+	BaseToken            string   `protobuf:"bytes,1,opt,name=base_token,json=baseToken,proto3" json:"base_token,omitempty"`
+	QuoteToken           string   `protobuf:"bytes,2,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+	Duration             int64    `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Unit                 string   `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	From                 int64    `protobuf:"varint,5,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int64    `protobuf:"varint,6,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOHLCVRequest) Reset()         { *m = GetOHLCVRequest{} }
+func (m *GetOHLCVRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOHLCVRequest) ProtoMessage()    {}
+func (*GetOHLCVRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3f90997f23a2c3f8, []int{5}
+}
+
+func (m *GetOHLCVRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOHLCVRequest.Unmarshal(m, b)
+}
+func (m *GetOHLCVRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOHLCVRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOHLCVRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOHLCVRequest.Merge(m, src)
+}
+func (m *GetOHLCVRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOHLCVRequest.Size(m)
+}
+func (m *GetOHLCVRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOHLCVRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOHLCVRequest proto.InternalMessageInfo
+
+func (m *GetOHLCVRequest) GetBaseToken() string {
+	if m != nil {
+		return m.BaseToken
+	}
+	return ""
+}
+
+func (m *GetOHLCVRequest) GetQuoteToken() string {
+	if m != nil {
+		return m.QuoteToken
+	}
+	return ""
+}
+
+func (m *GetOHLCVRequest) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *GetOHLCVRequest) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+func (m *GetOHLCVRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *GetOHLCVRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+type GetOHLCVResponse struct {
+	Ticks                []*Tick  `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOHLCVResponse) Reset()         { *m = GetOHLCVResponse{} }
+func (m *GetOHLCVResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOHLCVResponse) ProtoMessage()    {}
+func (*GetOHLCVResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3f90997f23a2c3f8, []int{6}
+}
+
+func (m *GetOHLCVResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOHLCVResponse.Unmarshal(m, b)
+}
+func (m *GetOHLCVResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOHLCVResponse.Marshal(b, m, deterministic)
+}
+func (m *GetOHLCVResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOHLCVResponse.Merge(m, src)
+}
+func (m *GetOHLCVResponse) XXX_Size() int {
+	return xxx_messageInfo_GetOHLCVResponse.Size(m)
+}
+func (m *GetOHLCVResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOHLCVResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOHLCVResponse proto.InternalMessageInfo
+
+func (m *GetOHLCVResponse) GetTicks() []*Tick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+type StreamOHLCVRequest struct {
+	BaseToken            string   `protobuf:"bytes,1,opt,name=base_token,json=baseToken,proto3" json:"base_token,omitempty"`
+	QuoteToken           string   `protobuf:"bytes,2,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+	Duration             int64    `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Unit                 string   `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamOHLCVRequest) Reset()         { *m = StreamOHLCVRequest{} }
+func (m *StreamOHLCVRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamOHLCVRequest) ProtoMessage()    {}
+func (*StreamOHLCVRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3f90997f23a2c3f8, []int{7}
+}
+
+func (m *StreamOHLCVRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamOHLCVRequest.Unmarshal(m, b)
+}
+func (m *StreamOHLCVRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamOHLCVRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamOHLCVRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamOHLCVRequest.Merge(m, src)
+}
+func (m *StreamOHLCVRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamOHLCVRequest.Size(m)
+}
+func (m *StreamOHLCVRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamOHLCVRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamOHLCVRequest proto.InternalMessageInfo
+
+func (m *StreamOHLCVRequest) GetBaseToken() string {
+	if m != nil {
+		return m.BaseToken
+	}
+	return ""
+}
+
+func (m *StreamOHLCVRequest) GetQuoteToken() string {
+	if m != nil {
+		return m.QuoteToken
+	}
+	return ""
+}
+
+func (m *StreamOHLCVRequest) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *StreamOHLCVRequest) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*PairRequest)(nil), "tomox.PairRequest")
+	proto.RegisterType((*GetPairsRequest)(nil), "tomox.GetPairsRequest")
+	proto.RegisterType((*GetPairsResponse)(nil), "tomox.GetPairsResponse")
+	proto.RegisterType((*GetTradesRequest)(nil), "tomox.GetTradesRequest")
+	proto.RegisterType((*GetTradesResponse)(nil), "tomox.GetTradesResponse")
+	proto.RegisterType((*GetOHLCVRequest)(nil), "tomox.GetOHLCVRequest")
+	proto.RegisterType((*GetOHLCVResponse)(nil), "tomox.GetOHLCVResponse")
+	proto.RegisterType((*StreamOHLCVRequest)(nil), "tomox.StreamOHLCVRequest")
+}
+
+func init() { proto.RegisterFile("market.proto", fileDescriptor_3f90997f23a2c3f8) }
+
+var fileDescriptor_3f90997f23a2c3f8 = []byte{
+	// 540 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x54, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0xd5, 0x26, 0x75, 0x48, 0xc6, 0x11, 0x6d, 0x57, 0x88, 0x18, 0x23, 0x44, 0xb0, 0x38, 0x44,
+	0x48, 0x24, 0x51, 0x00, 0x21, 0x54, 0x89, 0x43, 0x41, 0xc0, 0x81, 0x2a, 0xc8, 0x89, 0x38, 0x70,
+	0x89, 0x36, 0xce, 0xa6, 0xb5, 0x8c, 0xb3, 0xee, 0xee, 0x04, 0x95, 0x9e, 0xf9, 0x15, 0x4e, 0xfc,
+	0x07, 0xdf, 0x85, 0x76, 0xbc, 0x6d, 0xdc, 0x86, 0x0b, 0x52, 0x25, 0x6e, 0xbb, 0xef, 0xcd, 0x8c,
+	0xdf, 0xbc, 0x99, 0x35, 0xb4, 0x73, 0xa1, 0x33, 0x89, 0xfd, 0x42, 0x2b, 0x54, 0xdc, 0x43, 0x95,
+	0xab, 0xb3, 0xd0, 0xc7, 0xef, 0x85, 0x34, 0x25, 0x16, 0x1d, 0x81, 0xff, 0x49, 0xa4, 0x3a, 0x96,
+	0xa7, 0x6b, 0x69, 0x90, 0x3f, 0x00, 0x98, 0x0b, 0x23, 0x67, 0xa8, 0x32, 0xb9, 0x0a, 0x58, 0x97,
+	0xf5, 0x5a, 0x71, 0xcb, 0x22, 0x53, 0x0b, 0xf0, 0x87, 0xe0, 0x9f, 0xae, 0x15, 0x5e, 0xf0, 0x35,
+	0xe2, 0x81, 0x20, 0x0a, 0x88, 0xf6, 0x61, 0xf7, 0xbd, 0x44, 0x5b, 0xd1, 0xb8, 0x92, 0xd1, 0x0b,
+	0xd8, 0xdb, 0x40, 0xa6, 0x50, 0x2b, 0x23, 0xf9, 0x23, 0xf0, 0x0a, 0x0b, 0x04, 0xac, 0x5b, 0xef,
+	0xf9, 0x23, 0xbf, 0x4f, 0xca, 0xfa, 0xa4, 0xa4, 0x64, 0xa2, 0xdf, 0x8c, 0xf2, 0xa6, 0x5a, 0x2c,
+	0xa4, 0xb9, 0x21, 0x79, 0xfc, 0x3e, 0xb4, 0x16, 0x02, 0xe5, 0x6c, 0xa9, 0x55, 0x1e, 0xd4, 0xbb,
+	0xac, 0x57, 0x8f, 0x9b, 0x16, 0x78, 0xa7, 0x55, 0xce, 0x3b, 0x70, 0x8b, 0x48, 0x54, 0xc1, 0x0e,
+	0x51, 0x0d, 0x7b, 0x9d, 0x2a, 0x5b, 0xb6, 0x10, 0xc7, 0x72, 0xa6, 0x96, 0x4b, 0x23, 0x31, 0xf0,
+	0xba, 0xac, 0xe7, 0xc5, 0x60, 0xa1, 0x31, 0x21, 0xb6, 0x2c, 0x05, 0x98, 0xf4, 0x5c, 0x06, 0x0d,
+	0xa2, 0x9b, 0x16, 0x98, 0xa4, 0xe7, 0x32, 0x1a, 0xc3, 0x7e, 0xa5, 0x0f, 0x67, 0xc0, 0x1d, 0xf0,
+	0x50, 0xa1, 0xf8, 0x4a, 0x3d, 0x78, 0x71, 0x79, 0xe1, 0x8f, 0xa1, 0x81, 0x14, 0x17, 0xd4, 0xc8,
+	0x97, 0xb6, 0xf3, 0x85, 0x92, 0x63, 0xc7, 0x45, 0x3f, 0x19, 0x99, 0x3c, 0xfe, 0xf0, 0xf1, 0xcd,
+	0xe7, 0x9b, 0x32, 0x26, 0x84, 0xe6, 0x62, 0xad, 0x05, 0xa6, 0x6a, 0x75, 0xe9, 0x8b, 0xbb, 0x73,
+	0x0e, 0x3b, 0xeb, 0x55, 0x8a, 0x64, 0x4a, 0x2b, 0xa6, 0xb3, 0xc5, 0xc8, 0x43, 0x8f, 0x62, 0xe9,
+	0xcc, 0x6f, 0x43, 0x0d, 0x15, 0xb5, 0x5f, 0x8f, 0x6b, 0xa8, 0xdc, 0xe0, 0x9d, 0xcc, 0xcd, 0xe0,
+	0x31, 0x4d, 0xb2, 0xeb, 0x83, 0x9f, 0xa6, 0x49, 0x16, 0x97, 0x4c, 0xf4, 0x83, 0x01, 0x9f, 0xa0,
+	0x96, 0x22, 0xff, 0x9f, 0x1d, 0x8e, 0x7e, 0xd5, 0x61, 0xff, 0x88, 0x5e, 0xcf, 0x5b, 0x81, 0x62,
+	0x22, 0xf5, 0xb7, 0x34, 0x91, 0xfc, 0x00, 0x9a, 0x17, 0xcb, 0xcc, 0xef, 0x3a, 0xf1, 0xd7, 0x16,
+	0x3e, 0xec, 0x6c, 0xe1, 0xae, 0xf9, 0xe7, 0xd0, 0xb6, 0x86, 0xe8, 0x85, 0xd4, 0x87, 0x4a, 0x65,
+	0x9c, 0x57, 0xd7, 0xde, 0x25, 0xef, 0x39, 0x6c, 0x13, 0xf5, 0x1a, 0x5a, 0x97, 0xfb, 0xc3, 0x2b,
+	0xb5, 0xaf, 0xbc, 0x8c, 0x30, 0xd8, 0x26, 0xdc, 0x57, 0x4b, 0xc9, 0xe4, 0x65, 0x55, 0x72, 0xd5,
+	0xdc, 0xb0, 0xb3, 0x85, 0xbb, 0xe4, 0x11, 0xb4, 0xcb, 0x59, 0xb8, 0xef, 0xff, 0x4d, 0xf2, 0x95,
+	0x2d, 0x1d, 0x32, 0xfe, 0x0a, 0x76, 0xdd, 0xfc, 0xfe, 0xad, 0xd3, 0x21, 0xe3, 0x2f, 0xc1, 0xaf,
+	0x8c, 0x9e, 0xdf, 0x73, 0x21, 0xdb, 0xeb, 0x10, 0x56, 0x37, 0x67, 0xc8, 0x0e, 0x9f, 0x7c, 0xe9,
+	0x1d, 0xa7, 0x78, 0xb2, 0x9e, 0xf7, 0x13, 0x95, 0x0f, 0x2c, 0x95, 0x9c, 0x88, 0x74, 0x45, 0xa7,
+	0xb3, 0xa7, 0x66, 0x91, 0x0d, 0x74, 0x91, 0x0c, 0x8a, 0xf9, 0x41, 0x31, 0x9f, 0x37, 0xe8, 0xcf,
+	0xf7, 0xec, 0xcf, 0x00, 0x01, 0x3a, 0xcd, 0x8d, 0x1d, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// MarketDataServiceClient is the client API for MarketDataService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MarketDataServiceClient interface {
+	GetPairs(ctx context.Context, in *GetPairsRequest, opts ...grpc.CallOption) (*GetPairsResponse, error)
+	GetOrderBook(ctx context.Context, in *PairRequest, opts ...grpc.CallOption) (*OrderBook, error)
+	GetTrades(ctx context.Context, in *GetTradesRequest, opts ...grpc.CallOption) (*GetTradesResponse, error)
+	GetOHLCV(ctx context.Context, in *GetOHLCVRequest, opts ...grpc.CallOption) (*GetOHLCVResponse, error)
+	StreamTrades(ctx context.Context, in *PairRequest, opts ...grpc.CallOption) (MarketDataService_StreamTradesClient, error)
+	// StreamOrderBook sends the orderbook of the pair then the changed price levels,
+	// a level with a zero amount is removed from the orderbook
+	StreamOrderBook(ctx context.Context, in *PairRequest, opts ...grpc.CallOption) (MarketDataService_StreamOrderBookClient, error)
+	StreamOHLCV(ctx context.Context, in *StreamOHLCVRequest, opts ...grpc.CallOption) (MarketDataService_StreamOHLCVClient, error)
+}
+
+type marketDataServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMarketDataServiceClient(cc *grpc.ClientConn) MarketDataServiceClient {
+	return &marketDataServiceClient{cc}
+}
+
+func (c *marketDataServiceClient) GetPairs(ctx context.Context, in *GetPairsRequest, opts ...grpc.CallOption) (*GetPairsResponse, error) {
+	out := new(GetPairsResponse)
+	err := c.cc.Invoke(ctx, "/tomox.MarketDataService/GetPairs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketDataServiceClient) GetOrderBook(ctx context.Context, in *PairRequest, opts ...grpc.CallOption) (*OrderBook, error) {
+	out := new(OrderBook)
+	err := c.cc.Invoke(ctx, "/tomox.MarketDataService/GetOrderBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketDataServiceClient) GetTrades(ctx context.Context, in *GetTradesRequest, opts ...grpc.CallOption) (*GetTradesResponse, error) {
+	out := new(GetTradesResponse)
+	err := c.cc.Invoke(ctx, "/tomox.MarketDataService/GetTrades", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketDataServiceClient) GetOHLCV(ctx context.Context, in *GetOHLCVRequest, opts ...grpc.CallOption) (*GetOHLCVResponse, error) {
+	out := new(GetOHLCVResponse)
+	err := c.cc.Invoke(ctx, "/tomox.MarketDataService/GetOHLCV", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketDataServiceClient) StreamTrades(ctx context.Context, in *PairRequest, opts ...grpc.CallOption) (MarketDataService_StreamTradesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_MarketDataService_serviceDesc.Streams[0], "/tomox.MarketDataService/StreamTrades", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &marketDataServiceStreamTradesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MarketDataService_StreamTradesClient interface {
+	Recv() (*Trade, error)
+	grpc.ClientStream
+}
+
+type marketDataServiceStreamTradesClient struct {
+	grpc.ClientStream
+}
+
+func (x *marketDataServiceStreamTradesClient) Recv() (*Trade, error) {
+	m := new(Trade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *marketDataServiceClient) StreamOrderBook(ctx context.Context, in *PairRequest, opts ...grpc.CallOption) (MarketDataService_StreamOrderBookClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_MarketDataService_serviceDesc.Streams[1], "/tomox.MarketDataService/StreamOrderBook", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &marketDataServiceStreamOrderBookClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MarketDataService_StreamOrderBookClient interface {
+	Recv() (*OrderBook, error)
+	grpc.ClientStream
+}
+
+type marketDataServiceStreamOrderBookClient struct {
+	grpc.ClientStream
+}
+
+func (x *marketDataServiceStreamOrderBookClient) Recv() (*OrderBook, error) {
+	m := new(OrderBook)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *marketDataServiceClient) StreamOHLCV(ctx context.Context, in *StreamOHLCVRequest, opts ...grpc.CallOption) (MarketDataService_StreamOHLCVClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_MarketDataService_serviceDesc.Streams[2], "/tomox.MarketDataService/StreamOHLCV", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &marketDataServiceStreamOHLCVClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MarketDataService_StreamOHLCVClient interface {
+	Recv() (*Tick, error)
+	grpc.ClientStream
+}
+
+type marketDataServiceStreamOHLCVClient struct {
+	grpc.ClientStream
+}
+
+func (x *marketDataServiceStreamOHLCVClient) Recv() (*Tick, error) {
+	m := new(Tick)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MarketDataServiceServer is the server API for MarketDataService service.
+type MarketDataServiceServer interface {
+	GetPairs(context.Context, *GetPairsRequest) (*GetPairsResponse, error)
+	GetOrderBook(context.Context, *PairRequest) (*OrderBook, error)
+	GetTrades(context.Context, *GetTradesRequest) (*GetTradesResponse, error)
+	GetOHLCV(context.Context, *GetOHLCVRequest) (*GetOHLCVResponse, error)
+	StreamTrades(*PairRequest, MarketDataService_StreamTradesServer) error
+	// StreamOrderBook sends the orderbook of the pair then the changed price levels,
+	// a level with a zero amount is removed from the orderbook
+	StreamOrderBook(*PairRequest, MarketDataService_StreamOrderBookServer) error
+	StreamOHLCV(*StreamOHLCVRequest, MarketDataService_StreamOHLCVServer) error
+}
+
+func RegisterMarketDataServiceServer(s *grpc.Server, srv MarketDataServiceServer) {
+	s.RegisterService(&_MarketDataService_serviceDesc, srv)
+}
+
+func _MarketDataService_GetPairs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPairsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketDataServiceServer).GetPairs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.MarketDataService/GetPairs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketDataServiceServer).GetPairs(ctx, req.(*GetPairsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketDataService_GetOrderBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketDataServiceServer).GetOrderBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.MarketDataService/GetOrderBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketDataServiceServer).GetOrderBook(ctx, req.(*PairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketDataService_GetTrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTradesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketDataServiceServer).GetTrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.MarketDataService/GetTrades",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketDataServiceServer).GetTrades(ctx, req.(*GetTradesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketDataService_GetOHLCV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOHLCVRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketDataServiceServer).GetOHLCV(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.MarketDataService/GetOHLCV",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketDataServiceServer).GetOHLCV(ctx, req.(*GetOHLCVRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketDataService_StreamTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PairRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MarketDataServiceServer).StreamTrades(m, &marketDataServiceStreamTradesServer{stream})
+}
+
+type MarketDataService_StreamTradesServer interface {
+	Send(*Trade) error
+	grpc.ServerStream
+}
+
+type marketDataServiceStreamTradesServer struct {
+	grpc.ServerStream
+}
+
+func (x *marketDataServiceStreamTradesServer) Send(m *Trade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MarketDataService_StreamOrderBook_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PairRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MarketDataServiceServer).StreamOrderBook(m, &marketDataServiceStreamOrderBookServer{stream})
+}
+
+type MarketDataService_StreamOrderBookServer interface {
+	Send(*OrderBook) error
+	grpc.ServerStream
+}
+
+type marketDataServiceStreamOrderBookServer struct {
+	grpc.ServerStream
+}
+
+func (x *marketDataServiceStreamOrderBookServer) Send(m *OrderBook) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MarketDataService_StreamOHLCV_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOHLCVRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MarketDataServiceServer).StreamOHLCV(m, &marketDataServiceStreamOHLCVServer{stream})
+}
+
+type MarketDataService_StreamOHLCVServer interface {
+	Send(*Tick) error
+	grpc.ServerStream
+}
+
+type marketDataServiceStreamOHLCVServer struct {
+	grpc.ServerStream
+}
+
+func (x *marketDataServiceStreamOHLCVServer) Send(m *Tick) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _MarketDataService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tomox.MarketDataService",
+	HandlerType: (*MarketDataServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPairs",
+			Handler:    _MarketDataService_GetPairs_Handler,
+		},
+		{
+			MethodName: "GetOrderBook",
+			Handler:    _MarketDataService_GetOrderBook_Handler,
+		},
+		{
+			MethodName: "GetTrades",
+			Handler:    _MarketDataService_GetTrades_Handler,
+		},
+		{
+			MethodName: "GetOHLCV",
+			Handler:    _MarketDataService_GetOHLCV_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTrades",
+			Handler:       _MarketDataService_StreamTrades_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamOrderBook",
+			Handler:       _MarketDataService_StreamOrderBook_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamOHLCV",
+			Handler:       _MarketDataService_StreamOHLCV_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "market.proto",
+}
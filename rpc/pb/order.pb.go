@@ -0,0 +1,459 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: order.proto
+
+package pb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// The dates of the filter are unix timestamps in seconds
+type GetOrdersRequest struct {
+	UserAddress          string   `protobuf:"bytes,1,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	BaseToken            string   `protobuf:"bytes,2,opt,name=base_token,json=baseToken,proto3" json:"base_token,omitempty"`
+	QuoteToken           string   `protobuf:"bytes,3,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Side                 string   `protobuf:"bytes,5,opt,name=side,proto3" json:"side,omitempty"`
+	Type                 string   `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	DateFrom             int64    `protobuf:"varint,7,opt,name=date_from,json=dateFrom,proto3" json:"date_from,omitempty"`
+	DateTo               int64    `protobuf:"varint,8,opt,name=date_to,json=dateTo,proto3" json:"date_to,omitempty"`
+	PageOffset           int32    `protobuf:"varint,9,opt,name=page_offset,json=pageOffset,proto3" json:"page_offset,omitempty"`
+	PageSize             int32    `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrdersRequest) Reset()         { *m = GetOrdersRequest{} }
+func (m *GetOrdersRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrdersRequest) ProtoMessage()    {}
+func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{0}
+}
+
+func (m *GetOrdersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrdersRequest.Unmarshal(m, b)
+}
+func (m *GetOrdersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrdersRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOrdersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrdersRequest.Merge(m, src)
+}
+func (m *GetOrdersRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrdersRequest.Size(m)
+}
+func (m *GetOrdersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrdersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrdersRequest proto.InternalMessageInfo
+
+func (m *GetOrdersRequest) GetUserAddress() string {
+	if m != nil {
+		return m.UserAddress
+	}
+	return ""
+}
+
+func (m *GetOrdersRequest) GetBaseToken() string {
+	if m != nil {
+		return m.BaseToken
+	}
+	return ""
+}
+
+func (m *GetOrdersRequest) GetQuoteToken() string {
+	if m != nil {
+		return m.QuoteToken
+	}
+	return ""
+}
+
+func (m *GetOrdersRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *GetOrdersRequest) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *GetOrdersRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *GetOrdersRequest) GetDateFrom() int64 {
+	if m != nil {
+		return m.DateFrom
+	}
+	return 0
+}
+
+func (m *GetOrdersRequest) GetDateTo() int64 {
+	if m != nil {
+		return m.DateTo
+	}
+	return 0
+}
+
+func (m *GetOrdersRequest) GetPageOffset() int32 {
+	if m != nil {
+		return m.PageOffset
+	}
+	return 0
+}
+
+func (m *GetOrdersRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type GetOrdersResponse struct {
+	Total                int32    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Orders               []*Order `protobuf:"bytes,2,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrdersResponse) Reset()         { *m = GetOrdersResponse{} }
+func (m *GetOrdersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOrdersResponse) ProtoMessage()    {}
+func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{1}
+}
+
+func (m *GetOrdersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrdersResponse.Unmarshal(m, b)
+}
+func (m *GetOrdersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrdersResponse.Marshal(b, m, deterministic)
+}
+func (m *GetOrdersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrdersResponse.Merge(m, src)
+}
+func (m *GetOrdersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetOrdersResponse.Size(m)
+}
+func (m *GetOrdersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrdersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrdersResponse proto.InternalMessageInfo
+
+func (m *GetOrdersResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *GetOrdersResponse) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type StreamOrdersRequest struct {
+	UserAddress          string   `protobuf:"bytes,1,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamOrdersRequest) Reset()         { *m = StreamOrdersRequest{} }
+func (m *StreamOrdersRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamOrdersRequest) ProtoMessage()    {}
+func (*StreamOrdersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{2}
+}
+
+func (m *StreamOrdersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamOrdersRequest.Unmarshal(m, b)
+}
+func (m *StreamOrdersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamOrdersRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamOrdersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamOrdersRequest.Merge(m, src)
+}
+func (m *StreamOrdersRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamOrdersRequest.Size(m)
+}
+func (m *StreamOrdersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamOrdersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamOrdersRequest proto.InternalMessageInfo
+
+func (m *StreamOrdersRequest) GetUserAddress() string {
+	if m != nil {
+		return m.UserAddress
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*GetOrdersRequest)(nil), "tomox.GetOrdersRequest")
+	proto.RegisterType((*GetOrdersResponse)(nil), "tomox.GetOrdersResponse")
+	proto.RegisterType((*StreamOrdersRequest)(nil), "tomox.StreamOrdersRequest")
+}
+
+func init() { proto.RegisterFile("order.proto", fileDescriptor_cd01338c35d87077) }
+
+var fileDescriptor_cd01338c35d87077 = []byte{
+	// 419 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xd1, 0x6a, 0xd4, 0x40,
+	0x14, 0x86, 0xc9, 0x6e, 0x93, 0x6e, 0x4e, 0xf6, 0x42, 0x8f, 0x62, 0x87, 0x88, 0xb8, 0x2e, 0x22,
+	0x41, 0x30, 0x2b, 0x15, 0x41, 0x14, 0x04, 0x15, 0xf4, 0xce, 0x85, 0x6c, 0xaf, 0xbc, 0x09, 0x93,
+	0xe4, 0x6c, 0x1b, 0xda, 0x64, 0xd2, 0x99, 0x89, 0xd6, 0xbe, 0x91, 0x8f, 0xe6, 0x5b, 0xc8, 0x9c,
+	0x44, 0xd9, 0xd5, 0xde, 0x78, 0x37, 0xe7, 0xfb, 0xfe, 0x49, 0x66, 0x7e, 0x06, 0x22, 0xa5, 0x2b,
+	0xd2, 0x69, 0xa7, 0x95, 0x55, 0xe8, 0x5b, 0xd5, 0xa8, 0xab, 0x38, 0xb2, 0xdf, 0x3b, 0x32, 0x03,
+	0x5b, 0xfe, 0x98, 0xc0, 0xad, 0x4f, 0x64, 0xd7, 0x2e, 0x66, 0x32, 0xba, 0xec, 0xc9, 0x58, 0x7c,
+	0x04, 0xf3, 0xde, 0x90, 0xce, 0x65, 0x55, 0x69, 0x32, 0x46, 0x78, 0x0b, 0x2f, 0x09, 0xb3, 0xc8,
+	0xb1, 0x77, 0x03, 0xc2, 0x07, 0x00, 0x85, 0x34, 0x94, 0x5b, 0x75, 0x4e, 0xad, 0x98, 0x70, 0x20,
+	0x74, 0xe4, 0xc4, 0x01, 0x7c, 0x08, 0xd1, 0x65, 0xaf, 0xec, 0x6f, 0x3f, 0x65, 0x0f, 0x8c, 0x86,
+	0xc0, 0x3d, 0x08, 0x8c, 0x95, 0xb6, 0x37, 0xe2, 0x80, 0xdd, 0x38, 0x21, 0xc2, 0x81, 0xa9, 0x2b,
+	0x12, 0x3e, 0x53, 0x5e, 0x3b, 0xe6, 0x8e, 0x2c, 0x82, 0x81, 0xb9, 0x35, 0xde, 0x87, 0xb0, 0x92,
+	0x96, 0xf2, 0xad, 0x56, 0x8d, 0x38, 0x5c, 0x78, 0xc9, 0x34, 0x9b, 0x39, 0xf0, 0x51, 0xab, 0x06,
+	0x8f, 0xe0, 0x90, 0xa5, 0x55, 0x62, 0xc6, 0x2a, 0x70, 0xe3, 0x89, 0x72, 0xc7, 0xea, 0xe4, 0x29,
+	0xe5, 0x6a, 0xbb, 0x35, 0x64, 0x45, 0xb8, 0xf0, 0x12, 0x3f, 0x03, 0x87, 0xd6, 0x4c, 0xdc, 0x67,
+	0x39, 0x60, 0xea, 0x6b, 0x12, 0xc0, 0x7a, 0xe6, 0xc0, 0xa6, 0xbe, 0xa6, 0xe5, 0x1a, 0x6e, 0xef,
+	0x54, 0x65, 0x3a, 0xd5, 0x1a, 0xc2, 0xbb, 0xe0, 0x5b, 0x65, 0xe5, 0x05, 0x97, 0xe4, 0x67, 0xc3,
+	0x80, 0x8f, 0x21, 0xe0, 0xe6, 0x8d, 0x98, 0x2c, 0xa6, 0x49, 0x74, 0x3c, 0x4f, 0xb9, 0xfb, 0x94,
+	0x37, 0x67, 0xa3, 0x5b, 0xbe, 0x82, 0x3b, 0x1b, 0xab, 0x49, 0x36, 0xff, 0x5b, 0xff, 0xf1, 0x4f,
+	0x0f, 0xe6, 0xbc, 0x69, 0x43, 0xfa, 0x6b, 0x5d, 0x12, 0x3e, 0x81, 0xd9, 0x67, 0xfa, 0xc6, 0x08,
+	0xf7, 0x7e, 0x16, 0xef, 0x4d, 0xf8, 0x12, 0xa2, 0x0f, 0xb2, 0x2d, 0xe9, 0x62, 0x18, 0x71, 0x57,
+	0x0e, 0x22, 0xbe, 0x81, 0xe1, 0x5b, 0x08, 0xff, 0x5c, 0x1d, 0x8f, 0xc6, 0xc0, 0xdf, 0xef, 0x26,
+	0x16, 0xff, 0x8a, 0xb1, 0xa5, 0xd7, 0x30, 0xdf, 0xbd, 0x29, 0xc6, 0x63, 0xf2, 0x86, 0xeb, 0xef,
+	0x1f, 0xf8, 0xb9, 0xf7, 0xfe, 0xe9, 0x97, 0xe4, 0xb4, 0xb6, 0x67, 0x7d, 0x91, 0x96, 0xaa, 0x59,
+	0x39, 0x57, 0x9e, 0xc9, 0xba, 0xe5, 0xd5, 0xd5, 0x33, 0x53, 0x9d, 0xaf, 0x74, 0x57, 0xae, 0xba,
+	0xe2, 0x4d, 0x57, 0x14, 0x01, 0xbf, 0xea, 0x17, 0xbf, 0x06, 0x00, 0x49, 0x98, 0xa6, 0x86, 0xf8,
+	0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// OrderServiceClient is the client API for OrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OrderServiceClient interface {
+	NewOrder(ctx context.Context, in *Order, opts ...grpc.CallOption) (*Order, error)
+	CancelOrder(ctx context.Context, in *OrderCancel, opts ...grpc.CallOption) (*OrderCancel, error)
+	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error)
+	StreamOrders(ctx context.Context, in *StreamOrdersRequest, opts ...grpc.CallOption) (OrderService_StreamOrdersClient, error)
+}
+
+type orderServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOrderServiceClient(cc *grpc.ClientConn) OrderServiceClient {
+	return &orderServiceClient{cc}
+}
+
+func (c *orderServiceClient) NewOrder(ctx context.Context, in *Order, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/tomox.OrderService/NewOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CancelOrder(ctx context.Context, in *OrderCancel, opts ...grpc.CallOption) (*OrderCancel, error) {
+	out := new(OrderCancel)
+	err := c.cc.Invoke(ctx, "/tomox.OrderService/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error) {
+	out := new(GetOrdersResponse)
+	err := c.cc.Invoke(ctx, "/tomox.OrderService/GetOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) StreamOrders(ctx context.Context, in *StreamOrdersRequest, opts ...grpc.CallOption) (OrderService_StreamOrdersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_OrderService_serviceDesc.Streams[0], "/tomox.OrderService/StreamOrders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderServiceStreamOrdersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OrderService_StreamOrdersClient interface {
+	Recv() (*Order, error)
+	grpc.ClientStream
+}
+
+type orderServiceStreamOrdersClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderServiceStreamOrdersClient) Recv() (*Order, error) {
+	m := new(Order)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// OrderServiceServer is the server API for OrderService service.
+type OrderServiceServer interface {
+	NewOrder(context.Context, *Order) (*Order, error)
+	CancelOrder(context.Context, *OrderCancel) (*OrderCancel, error)
+	GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error)
+	StreamOrders(*StreamOrdersRequest, OrderService_StreamOrdersServer) error
+}
+
+func RegisterOrderServiceServer(s *grpc.Server, srv OrderServiceServer) {
+	s.RegisterService(&_OrderService_serviceDesc, srv)
+}
+
+func _OrderService_NewOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Order)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).NewOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.OrderService/NewOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).NewOrder(ctx, req.(*Order))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderCancel)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.OrderService/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelOrder(ctx, req.(*OrderCancel))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomox.OrderService/GetOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrders(ctx, req.(*GetOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_StreamOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceServer).StreamOrders(m, &orderServiceStreamOrdersServer{stream})
+}
+
+type OrderService_StreamOrdersServer interface {
+	Send(*Order) error
+	grpc.ServerStream
+}
+
+type orderServiceStreamOrdersServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderServiceStreamOrdersServer) Send(m *Order) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _OrderService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tomox.OrderService",
+	HandlerType: (*OrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NewOrder",
+			Handler:    _OrderService_NewOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrderService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "GetOrders",
+			Handler:    _OrderService_GetOrders_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamOrders",
+			Handler:       _OrderService_StreamOrders_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "order.proto",
+}
@@ -0,0 +1,1286 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: types.proto
+
+package pb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Signature struct {
+	V                    uint32   `protobuf:"varint,1,opt,name=v,proto3" json:"v,omitempty"`
+	R                    string   `protobuf:"bytes,2,opt,name=r,proto3" json:"r,omitempty"`
+	S                    string   `protobuf:"bytes,3,opt,name=s,proto3" json:"s,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Signature) Reset()         { *m = Signature{} }
+func (m *Signature) String() string { return proto.CompactTextString(m) }
+func (*Signature) ProtoMessage()    {}
+func (*Signature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{0}
+}
+
+func (m *Signature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Signature.Unmarshal(m, b)
+}
+func (m *Signature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Signature.Marshal(b, m, deterministic)
+}
+func (m *Signature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Signature.Merge(m, src)
+}
+func (m *Signature) XXX_Size() int {
+	return xxx_messageInfo_Signature.Size(m)
+}
+func (m *Signature) XXX_DiscardUnknown() {
+	xxx_messageInfo_Signature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Signature proto.InternalMessageInfo
+
+func (m *Signature) GetV() uint32 {
+	if m != nil {
+		return m.V
+	}
+	return 0
+}
+
+func (m *Signature) GetR() string {
+	if m != nil {
+		return m.R
+	}
+	return ""
+}
+
+func (m *Signature) GetS() string {
+	if m != nil {
+		return m.S
+	}
+	return ""
+}
+
+type Order struct {
+	Id                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserAddress          string     `protobuf:"bytes,2,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	ExchangeAddress      string     `protobuf:"bytes,3,opt,name=exchange_address,json=exchangeAddress,proto3" json:"exchange_address,omitempty"`
+	BaseToken            string     `protobuf:"bytes,4,opt,name=base_token,json=baseToken,proto3" json:"base_token,omitempty"`
+	QuoteToken           string     `protobuf:"bytes,5,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+	Status               string     `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Side                 string     `protobuf:"bytes,7,opt,name=side,proto3" json:"side,omitempty"`
+	Type                 string     `protobuf:"bytes,8,opt,name=type,proto3" json:"type,omitempty"`
+	Hash                 string     `protobuf:"bytes,9,opt,name=hash,proto3" json:"hash,omitempty"`
+	Signature            *Signature `protobuf:"bytes,10,opt,name=signature,proto3" json:"signature,omitempty"`
+	Pricepoint           string     `protobuf:"bytes,11,opt,name=pricepoint,proto3" json:"pricepoint,omitempty"`
+	Amount               string     `protobuf:"bytes,12,opt,name=amount,proto3" json:"amount,omitempty"`
+	FilledAmount         string     `protobuf:"bytes,13,opt,name=filled_amount,json=filledAmount,proto3" json:"filled_amount,omitempty"`
+	Nonce                string     `protobuf:"bytes,14,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	PairName             string     `protobuf:"bytes,15,opt,name=pair_name,json=pairName,proto3" json:"pair_name,omitempty"`
+	CreatedAt            int64      `protobuf:"varint,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            int64      `protobuf:"varint,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	OrderId              uint64     `protobuf:"varint,18,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Order) Reset()         { *m = Order{} }
+func (m *Order) String() string { return proto.CompactTextString(m) }
+func (*Order) ProtoMessage()    {}
+func (*Order) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{1}
+}
+
+func (m *Order) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Order.Unmarshal(m, b)
+}
+func (m *Order) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Order.Marshal(b, m, deterministic)
+}
+func (m *Order) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Order.Merge(m, src)
+}
+func (m *Order) XXX_Size() int {
+	return xxx_messageInfo_Order.Size(m)
+}
+func (m *Order) XXX_DiscardUnknown() {
+	xxx_messageInfo_Order.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Order proto.InternalMessageInfo
+
+func (m *Order) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Order) GetUserAddress() string {
+	if m != nil {
+		return m.UserAddress
+	}
+	return ""
+}
+
+func (m *Order) GetExchangeAddress() string {
+	if m != nil {
+		return m.ExchangeAddress
+	}
+	return ""
+}
+
+func (m *Order) GetBaseToken() string {
+	if m != nil {
+		return m.BaseToken
+	}
+	return ""
+}
+
+func (m *Order) GetQuoteToken() string {
+	if m != nil {
+		return m.QuoteToken
+	}
+	return ""
+}
+
+func (m *Order) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Order) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *Order) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Order) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Order) GetSignature() *Signature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Order) GetPricepoint() string {
+	if m != nil {
+		return m.Pricepoint
+	}
+	return ""
+}
+
+func (m *Order) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *Order) GetFilledAmount() string {
+	if m != nil {
+		return m.FilledAmount
+	}
+	return ""
+}
+
+func (m *Order) GetNonce() string {
+	if m != nil {
+		return m.Nonce
+	}
+	return ""
+}
+
+func (m *Order) GetPairName() string {
+	if m != nil {
+		return m.PairName
+	}
+	return ""
+}
+
+func (m *Order) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Order) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *Order) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+type OrderCancel struct {
+	OrderHash            string     `protobuf:"bytes,1,opt,name=order_hash,json=orderHash,proto3" json:"order_hash,omitempty"`
+	Nonce                string     `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Hash                 string     `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	OrderId              uint64     `protobuf:"varint,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status               string     `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	UserAddress          string     `protobuf:"bytes,6,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	ExchangeAddress      string     `protobuf:"bytes,7,opt,name=exchange_address,json=exchangeAddress,proto3" json:"exchange_address,omitempty"`
+	Signature            *Signature `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *OrderCancel) Reset()         { *m = OrderCancel{} }
+func (m *OrderCancel) String() string { return proto.CompactTextString(m) }
+func (*OrderCancel) ProtoMessage()    {}
+func (*OrderCancel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{2}
+}
+
+func (m *OrderCancel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderCancel.Unmarshal(m, b)
+}
+func (m *OrderCancel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderCancel.Marshal(b, m, deterministic)
+}
+func (m *OrderCancel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderCancel.Merge(m, src)
+}
+func (m *OrderCancel) XXX_Size() int {
+	return xxx_messageInfo_OrderCancel.Size(m)
+}
+func (m *OrderCancel) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderCancel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderCancel proto.InternalMessageInfo
+
+func (m *OrderCancel) GetOrderHash() string {
+	if m != nil {
+		return m.OrderHash
+	}
+	return ""
+}
+
+func (m *OrderCancel) GetNonce() string {
+	if m != nil {
+		return m.Nonce
+	}
+	return ""
+}
+
+func (m *OrderCancel) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *OrderCancel) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+func (m *OrderCancel) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *OrderCancel) GetUserAddress() string {
+	if m != nil {
+		return m.UserAddress
+	}
+	return ""
+}
+
+func (m *OrderCancel) GetExchangeAddress() string {
+	if m != nil {
+		return m.ExchangeAddress
+	}
+	return ""
+}
+
+func (m *OrderCancel) GetSignature() *Signature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type Trade struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Taker                string   `protobuf:"bytes,2,opt,name=taker,proto3" json:"taker,omitempty"`
+	Maker                string   `protobuf:"bytes,3,opt,name=maker,proto3" json:"maker,omitempty"`
+	BaseToken            string   `protobuf:"bytes,4,opt,name=base_token,json=baseToken,proto3" json:"base_token,omitempty"`
+	QuoteToken           string   `protobuf:"bytes,5,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+	MakerOrderHash       string   `protobuf:"bytes,6,opt,name=maker_order_hash,json=makerOrderHash,proto3" json:"maker_order_hash,omitempty"`
+	TakerOrderHash       string   `protobuf:"bytes,7,opt,name=taker_order_hash,json=takerOrderHash,proto3" json:"taker_order_hash,omitempty"`
+	Hash                 string   `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"`
+	TxHash               string   `protobuf:"bytes,9,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	PairName             string   `protobuf:"bytes,10,opt,name=pair_name,json=pairName,proto3" json:"pair_name,omitempty"`
+	Pricepoint           string   `protobuf:"bytes,11,opt,name=pricepoint,proto3" json:"pricepoint,omitempty"`
+	Amount               string   `protobuf:"bytes,12,opt,name=amount,proto3" json:"amount,omitempty"`
+	MakeFee              string   `protobuf:"bytes,13,opt,name=make_fee,json=makeFee,proto3" json:"make_fee,omitempty"`
+	TakeFee              string   `protobuf:"bytes,14,opt,name=take_fee,json=takeFee,proto3" json:"take_fee,omitempty"`
+	Status               string   `protobuf:"bytes,15,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            int64    `protobuf:"varint,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	TakerOrderSide       string   `protobuf:"bytes,18,opt,name=taker_order_side,json=takerOrderSide,proto3" json:"taker_order_side,omitempty"`
+	TakerOrderType       string   `protobuf:"bytes,19,opt,name=taker_order_type,json=takerOrderType,proto3" json:"taker_order_type,omitempty"`
+	MakerOrderType       string   `protobuf:"bytes,20,opt,name=maker_order_type,json=makerOrderType,proto3" json:"maker_order_type,omitempty"`
+	MakerExchange        string   `protobuf:"bytes,21,opt,name=maker_exchange,json=makerExchange,proto3" json:"maker_exchange,omitempty"`
+	TakerExchange        string   `protobuf:"bytes,22,opt,name=taker_exchange,json=takerExchange,proto3" json:"taker_exchange,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Trade) Reset()         { *m = Trade{} }
+func (m *Trade) String() string { return proto.CompactTextString(m) }
+func (*Trade) ProtoMessage()    {}
+func (*Trade) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{3}
+}
+
+func (m *Trade) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Trade.Unmarshal(m, b)
+}
+func (m *Trade) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Trade.Marshal(b, m, deterministic)
+}
+func (m *Trade) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Trade.Merge(m, src)
+}
+func (m *Trade) XXX_Size() int {
+	return xxx_messageInfo_Trade.Size(m)
+}
+func (m *Trade) XXX_DiscardUnknown() {
+	xxx_messageInfo_Trade.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Trade proto.InternalMessageInfo
+
+func (m *Trade) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Trade) GetTaker() string {
+	if m != nil {
+		return m.Taker
+	}
+	return ""
+}
+
+func (m *Trade) GetMaker() string {
+	if m != nil {
+		return m.Maker
+	}
+	return ""
+}
+
+func (m *Trade) GetBaseToken() string {
+	if m != nil {
+		return m.BaseToken
+	}
+	return ""
+}
+
+func (m *Trade) GetQuoteToken() string {
+	if m != nil {
+		return m.QuoteToken
+	}
+	return ""
+}
+
+func (m *Trade) GetMakerOrderHash() string {
+	if m != nil {
+		return m.MakerOrderHash
+	}
+	return ""
+}
+
+func (m *Trade) GetTakerOrderHash() string {
+	if m != nil {
+		return m.TakerOrderHash
+	}
+	return ""
+}
+
+func (m *Trade) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Trade) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *Trade) GetPairName() string {
+	if m != nil {
+		return m.PairName
+	}
+	return ""
+}
+
+func (m *Trade) GetPricepoint() string {
+	if m != nil {
+		return m.Pricepoint
+	}
+	return ""
+}
+
+func (m *Trade) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *Trade) GetMakeFee() string {
+	if m != nil {
+		return m.MakeFee
+	}
+	return ""
+}
+
+func (m *Trade) GetTakeFee() string {
+	if m != nil {
+		return m.TakeFee
+	}
+	return ""
+}
+
+func (m *Trade) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Trade) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Trade) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *Trade) GetTakerOrderSide() string {
+	if m != nil {
+		return m.TakerOrderSide
+	}
+	return ""
+}
+
+func (m *Trade) GetTakerOrderType() string {
+	if m != nil {
+		return m.TakerOrderType
+	}
+	return ""
+}
+
+func (m *Trade) GetMakerOrderType() string {
+	if m != nil {
+		return m.MakerOrderType
+	}
+	return ""
+}
+
+func (m *Trade) GetMakerExchange() string {
+	if m != nil {
+		return m.MakerExchange
+	}
+	return ""
+}
+
+func (m *Trade) GetTakerExchange() string {
+	if m != nil {
+		return m.TakerExchange
+	}
+	return ""
+}
+
+type PairID struct {
+	PairName             string   `protobuf:"bytes,1,opt,name=pair_name,json=pairName,proto3" json:"pair_name,omitempty"`
+	BaseToken            string   `protobuf:"bytes,2,opt,name=base_token,json=baseToken,proto3" json:"base_token,omitempty"`
+	QuoteToken           string   `protobuf:"bytes,3,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PairID) Reset()         { *m = PairID{} }
+func (m *PairID) String() string { return proto.CompactTextString(m) }
+func (*PairID) ProtoMessage()    {}
+func (*PairID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{4}
+}
+
+func (m *PairID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PairID.Unmarshal(m, b)
+}
+func (m *PairID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PairID.Marshal(b, m, deterministic)
+}
+func (m *PairID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PairID.Merge(m, src)
+}
+func (m *PairID) XXX_Size() int {
+	return xxx_messageInfo_PairID.Size(m)
+}
+func (m *PairID) XXX_DiscardUnknown() {
+	xxx_messageInfo_PairID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PairID proto.InternalMessageInfo
+
+func (m *PairID) GetPairName() string {
+	if m != nil {
+		return m.PairName
+	}
+	return ""
+}
+
+func (m *PairID) GetBaseToken() string {
+	if m != nil {
+		return m.BaseToken
+	}
+	return ""
+}
+
+func (m *PairID) GetQuoteToken() string {
+	if m != nil {
+		return m.QuoteToken
+	}
+	return ""
+}
+
+type Tick struct {
+	Pair                 *PairID  `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Open                 string   `protobuf:"bytes,2,opt,name=open,proto3" json:"open,omitempty"`
+	Close                string   `protobuf:"bytes,3,opt,name=close,proto3" json:"close,omitempty"`
+	High                 string   `protobuf:"bytes,4,opt,name=high,proto3" json:"high,omitempty"`
+	Low                  string   `protobuf:"bytes,5,opt,name=low,proto3" json:"low,omitempty"`
+	Volume               string   `protobuf:"bytes,6,opt,name=volume,proto3" json:"volume,omitempty"`
+	VolumeByQuote        string   `protobuf:"bytes,7,opt,name=volume_by_quote,json=volumeByQuote,proto3" json:"volume_by_quote,omitempty"`
+	VolumeUsdt           string   `protobuf:"bytes,8,opt,name=volume_usdt,json=volumeUsdt,proto3" json:"volume_usdt,omitempty"`
+	Count                string   `protobuf:"bytes,9,opt,name=count,proto3" json:"count,omitempty"`
+	Timestamp            int64    `protobuf:"varint,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	OpenTime             int64    `protobuf:"varint,11,opt,name=open_time,json=openTime,proto3" json:"open_time,omitempty"`
+	CloseTime            int64    `protobuf:"varint,12,opt,name=close_time,json=closeTime,proto3" json:"close_time,omitempty"`
+	Duration             int64    `protobuf:"varint,13,opt,name=duration,proto3" json:"duration,omitempty"`
+	Unit                 string   `protobuf:"bytes,14,opt,name=unit,proto3" json:"unit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Tick) Reset()         { *m = Tick{} }
+func (m *Tick) String() string { return proto.CompactTextString(m) }
+func (*Tick) ProtoMessage()    {}
+func (*Tick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{5}
+}
+
+func (m *Tick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tick.Unmarshal(m, b)
+}
+func (m *Tick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tick.Marshal(b, m, deterministic)
+}
+func (m *Tick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tick.Merge(m, src)
+}
+func (m *Tick) XXX_Size() int {
+	return xxx_messageInfo_Tick.Size(m)
+}
+func (m *Tick) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tick proto.InternalMessageInfo
+
+func (m *Tick) GetPair() *PairID {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *Tick) GetOpen() string {
+	if m != nil {
+		return m.Open
+	}
+	return ""
+}
+
+func (m *Tick) GetClose() string {
+	if m != nil {
+		return m.Close
+	}
+	return ""
+}
+
+func (m *Tick) GetHigh() string {
+	if m != nil {
+		return m.High
+	}
+	return ""
+}
+
+func (m *Tick) GetLow() string {
+	if m != nil {
+		return m.Low
+	}
+	return ""
+}
+
+func (m *Tick) GetVolume() string {
+	if m != nil {
+		return m.Volume
+	}
+	return ""
+}
+
+func (m *Tick) GetVolumeByQuote() string {
+	if m != nil {
+		return m.VolumeByQuote
+	}
+	return ""
+}
+
+func (m *Tick) GetVolumeUsdt() string {
+	if m != nil {
+		return m.VolumeUsdt
+	}
+	return ""
+}
+
+func (m *Tick) GetCount() string {
+	if m != nil {
+		return m.Count
+	}
+	return ""
+}
+
+func (m *Tick) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Tick) GetOpenTime() int64 {
+	if m != nil {
+		return m.OpenTime
+	}
+	return 0
+}
+
+func (m *Tick) GetCloseTime() int64 {
+	if m != nil {
+		return m.CloseTime
+	}
+	return 0
+}
+
+func (m *Tick) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *Tick) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+type Pair struct {
+	BaseTokenSymbol      string   `protobuf:"bytes,1,opt,name=base_token_symbol,json=baseTokenSymbol,proto3" json:"base_token_symbol,omitempty"`
+	BaseTokenAddress     string   `protobuf:"bytes,2,opt,name=base_token_address,json=baseTokenAddress,proto3" json:"base_token_address,omitempty"`
+	BaseTokenDecimals    int32    `protobuf:"varint,3,opt,name=base_token_decimals,json=baseTokenDecimals,proto3" json:"base_token_decimals,omitempty"`
+	QuoteTokenSymbol     string   `protobuf:"bytes,4,opt,name=quote_token_symbol,json=quoteTokenSymbol,proto3" json:"quote_token_symbol,omitempty"`
+	QuoteTokenAddress    string   `protobuf:"bytes,5,opt,name=quote_token_address,json=quoteTokenAddress,proto3" json:"quote_token_address,omitempty"`
+	QuoteTokenDecimals   int32    `protobuf:"varint,6,opt,name=quote_token_decimals,json=quoteTokenDecimals,proto3" json:"quote_token_decimals,omitempty"`
+	Listed               bool     `protobuf:"varint,7,opt,name=listed,proto3" json:"listed,omitempty"`
+	Active               bool     `protobuf:"varint,8,opt,name=active,proto3" json:"active,omitempty"`
+	Rank                 int32    `protobuf:"varint,9,opt,name=rank,proto3" json:"rank,omitempty"`
+	MakeFee              string   `protobuf:"bytes,10,opt,name=make_fee,json=makeFee,proto3" json:"make_fee,omitempty"`
+	TakeFee              string   `protobuf:"bytes,11,opt,name=take_fee,json=takeFee,proto3" json:"take_fee,omitempty"`
+	RelayerAddress       string   `protobuf:"bytes,12,opt,name=relayer_address,json=relayerAddress,proto3" json:"relayer_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Pair) Reset()         { *m = Pair{} }
+func (m *Pair) String() string { return proto.CompactTextString(m) }
+func (*Pair) ProtoMessage()    {}
+func (*Pair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{6}
+}
+
+func (m *Pair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pair.Unmarshal(m, b)
+}
+func (m *Pair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pair.Marshal(b, m, deterministic)
+}
+func (m *Pair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pair.Merge(m, src)
+}
+func (m *Pair) XXX_Size() int {
+	return xxx_messageInfo_Pair.Size(m)
+}
+func (m *Pair) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pair proto.InternalMessageInfo
+
+func (m *Pair) GetBaseTokenSymbol() string {
+	if m != nil {
+		return m.BaseTokenSymbol
+	}
+	return ""
+}
+
+func (m *Pair) GetBaseTokenAddress() string {
+	if m != nil {
+		return m.BaseTokenAddress
+	}
+	return ""
+}
+
+func (m *Pair) GetBaseTokenDecimals() int32 {
+	if m != nil {
+		return m.BaseTokenDecimals
+	}
+	return 0
+}
+
+func (m *Pair) GetQuoteTokenSymbol() string {
+	if m != nil {
+		return m.QuoteTokenSymbol
+	}
+	return ""
+}
+
+func (m *Pair) GetQuoteTokenAddress() string {
+	if m != nil {
+		return m.QuoteTokenAddress
+	}
+	return ""
+}
+
+func (m *Pair) GetQuoteTokenDecimals() int32 {
+	if m != nil {
+		return m.QuoteTokenDecimals
+	}
+	return 0
+}
+
+func (m *Pair) GetListed() bool {
+	if m != nil {
+		return m.Listed
+	}
+	return false
+}
+
+func (m *Pair) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *Pair) GetRank() int32 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+func (m *Pair) GetMakeFee() string {
+	if m != nil {
+		return m.MakeFee
+	}
+	return ""
+}
+
+func (m *Pair) GetTakeFee() string {
+	if m != nil {
+		return m.TakeFee
+	}
+	return ""
+}
+
+func (m *Pair) GetRelayerAddress() string {
+	if m != nil {
+		return m.RelayerAddress
+	}
+	return ""
+}
+
+type PriceLevel struct {
+	Pricepoint           string   `protobuf:"bytes,1,opt,name=pricepoint,proto3" json:"pricepoint,omitempty"`
+	Amount               string   `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PriceLevel) Reset()         { *m = PriceLevel{} }
+func (m *PriceLevel) String() string { return proto.CompactTextString(m) }
+func (*PriceLevel) ProtoMessage()    {}
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{7}
+}
+
+func (m *PriceLevel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PriceLevel.Unmarshal(m, b)
+}
+func (m *PriceLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PriceLevel.Marshal(b, m, deterministic)
+}
+func (m *PriceLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PriceLevel.Merge(m, src)
+}
+func (m *PriceLevel) XXX_Size() int {
+	return xxx_messageInfo_PriceLevel.Size(m)
+}
+func (m *PriceLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_PriceLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PriceLevel proto.InternalMessageInfo
+
+func (m *PriceLevel) GetPricepoint() string {
+	if m != nil {
+		return m.Pricepoint
+	}
+	return ""
+}
+
+func (m *PriceLevel) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+type OrderBook struct {
+	PairName             string        `protobuf:"bytes,1,opt,name=pair_name,json=pairName,proto3" json:"pair_name,omitempty"`
+	Bids                 []*PriceLevel `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks                 []*PriceLevel `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *OrderBook) Reset()         { *m = OrderBook{} }
+func (m *OrderBook) String() string { return proto.CompactTextString(m) }
+func (*OrderBook) ProtoMessage()    {}
+func (*OrderBook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{8}
+}
+
+func (m *OrderBook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderBook.Unmarshal(m, b)
+}
+func (m *OrderBook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderBook.Marshal(b, m, deterministic)
+}
+func (m *OrderBook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderBook.Merge(m, src)
+}
+func (m *OrderBook) XXX_Size() int {
+	return xxx_messageInfo_OrderBook.Size(m)
+}
+func (m *OrderBook) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderBook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderBook proto.InternalMessageInfo
+
+func (m *OrderBook) GetPairName() string {
+	if m != nil {
+		return m.PairName
+	}
+	return ""
+}
+
+func (m *OrderBook) GetBids() []*PriceLevel {
+	if m != nil {
+		return m.Bids
+	}
+	return nil
+}
+
+func (m *OrderBook) GetAsks() []*PriceLevel {
+	if m != nil {
+		return m.Asks
+	}
+	return nil
+}
+
+type LendingOrder struct {
+	Id                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Quantity             string     `protobuf:"bytes,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Interest             uint64     `protobuf:"varint,3,opt,name=interest,proto3" json:"interest,omitempty"`
+	Term                 uint64     `protobuf:"varint,4,opt,name=term,proto3" json:"term,omitempty"`
+	Side                 string     `protobuf:"bytes,5,opt,name=side,proto3" json:"side,omitempty"`
+	Type                 string     `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	LendingToken         string     `protobuf:"bytes,7,opt,name=lending_token,json=lendingToken,proto3" json:"lending_token,omitempty"`
+	CollateralToken      string     `protobuf:"bytes,8,opt,name=collateral_token,json=collateralToken,proto3" json:"collateral_token,omitempty"`
+	FilledAmount         string     `protobuf:"bytes,9,opt,name=filled_amount,json=filledAmount,proto3" json:"filled_amount,omitempty"`
+	Status               string     `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	UserAddress          string     `protobuf:"bytes,11,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	RelayerAddress       string     `protobuf:"bytes,12,opt,name=relayer_address,json=relayerAddress,proto3" json:"relayer_address,omitempty"`
+	Signature            *Signature `protobuf:"bytes,13,opt,name=signature,proto3" json:"signature,omitempty"`
+	Hash                 string     `protobuf:"bytes,14,opt,name=hash,proto3" json:"hash,omitempty"`
+	TxHash               string     `protobuf:"bytes,15,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Nonce                string     `protobuf:"bytes,16,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	CreatedAt            int64      `protobuf:"varint,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            int64      `protobuf:"varint,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LendingId            uint64     `protobuf:"varint,19,opt,name=lending_id,json=lendingId,proto3" json:"lending_id,omitempty"`
+	ExtraData            string     `protobuf:"bytes,20,opt,name=extra_data,json=extraData,proto3" json:"extra_data,omitempty"`
+	TradeId              uint64     `protobuf:"varint,21,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	AutoTopUp            uint64     `protobuf:"varint,22,opt,name=auto_top_up,json=autoTopUp,proto3" json:"auto_top_up,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *LendingOrder) Reset()         { *m = LendingOrder{} }
+func (m *LendingOrder) String() string { return proto.CompactTextString(m) }
+func (*LendingOrder) ProtoMessage()    {}
+func (*LendingOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{9}
+}
+
+func (m *LendingOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LendingOrder.Unmarshal(m, b)
+}
+func (m *LendingOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LendingOrder.Marshal(b, m, deterministic)
+}
+func (m *LendingOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LendingOrder.Merge(m, src)
+}
+func (m *LendingOrder) XXX_Size() int {
+	return xxx_messageInfo_LendingOrder.Size(m)
+}
+func (m *LendingOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_LendingOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LendingOrder proto.InternalMessageInfo
+
+func (m *LendingOrder) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetQuantity() string {
+	if m != nil {
+		return m.Quantity
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetInterest() uint64 {
+	if m != nil {
+		return m.Interest
+	}
+	return 0
+}
+
+func (m *LendingOrder) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *LendingOrder) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetLendingToken() string {
+	if m != nil {
+		return m.LendingToken
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetCollateralToken() string {
+	if m != nil {
+		return m.CollateralToken
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetFilledAmount() string {
+	if m != nil {
+		return m.FilledAmount
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetUserAddress() string {
+	if m != nil {
+		return m.UserAddress
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetRelayerAddress() string {
+	if m != nil {
+		return m.RelayerAddress
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetSignature() *Signature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *LendingOrder) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetNonce() string {
+	if m != nil {
+		return m.Nonce
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *LendingOrder) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *LendingOrder) GetLendingId() uint64 {
+	if m != nil {
+		return m.LendingId
+	}
+	return 0
+}
+
+func (m *LendingOrder) GetExtraData() string {
+	if m != nil {
+		return m.ExtraData
+	}
+	return ""
+}
+
+func (m *LendingOrder) GetTradeId() uint64 {
+	if m != nil {
+		return m.TradeId
+	}
+	return 0
+}
+
+func (m *LendingOrder) GetAutoTopUp() uint64 {
+	if m != nil {
+		return m.AutoTopUp
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Signature)(nil), "tomox.Signature")
+	proto.RegisterType((*Order)(nil), "tomox.Order")
+	proto.RegisterType((*OrderCancel)(nil), "tomox.OrderCancel")
+	proto.RegisterType((*Trade)(nil), "tomox.Trade")
+	proto.RegisterType((*PairID)(nil), "tomox.PairID")
+	proto.RegisterType((*Tick)(nil), "tomox.Tick")
+	proto.RegisterType((*Pair)(nil), "tomox.Pair")
+	proto.RegisterType((*PriceLevel)(nil), "tomox.PriceLevel")
+	proto.RegisterType((*OrderBook)(nil), "tomox.OrderBook")
+	proto.RegisterType((*LendingOrder)(nil), "tomox.LendingOrder")
+}
+
+func init() { proto.RegisterFile("types.proto", fileDescriptor_d938547f84707355) }
+
+var fileDescriptor_d938547f84707355 = []byte{
+	// 1268 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xdd, 0x6e, 0xdc, 0x44,
+	0x14, 0xd6, 0xfe, 0x66, 0x7d, 0x76, 0x37, 0xbb, 0x99, 0xa6, 0xc5, 0x2d, 0xb4, 0xa4, 0x5b, 0x15,
+	0xb6, 0x15, 0xa4, 0xa8, 0x88, 0x2b, 0xae, 0x52, 0x02, 0x22, 0x52, 0x45, 0x8b, 0x9b, 0xde, 0x70,
+	0x63, 0xcd, 0x7a, 0xa6, 0xc9, 0x68, 0xfd, 0x57, 0x7b, 0x1c, 0x92, 0x27, 0xe0, 0x1e, 0x89, 0x07,
+	0xe1, 0x19, 0x78, 0x2b, 0xae, 0xd0, 0x39, 0x33, 0xf6, 0xda, 0x4e, 0x9a, 0x56, 0xf4, 0x6e, 0xce,
+	0x77, 0xbe, 0x1d, 0x9f, 0x39, 0xff, 0x0b, 0x63, 0x7d, 0x91, 0xca, 0x7c, 0x3f, 0xcd, 0x12, 0x9d,
+	0xb0, 0x81, 0x4e, 0xa2, 0xe4, 0x7c, 0xf1, 0x1d, 0x38, 0xaf, 0xd4, 0x49, 0xcc, 0x75, 0x91, 0x49,
+	0x36, 0x81, 0xce, 0x99, 0xdb, 0xd9, 0xeb, 0x2c, 0xa7, 0x5e, 0xe7, 0x0c, 0xa5, 0xcc, 0xed, 0xee,
+	0x75, 0x96, 0x8e, 0xd7, 0xc9, 0x50, 0xca, 0xdd, 0x9e, 0x91, 0xf2, 0xc5, 0x9f, 0x7d, 0x18, 0xbc,
+	0xc8, 0x84, 0xcc, 0xd8, 0x36, 0x74, 0x95, 0xa0, 0x1f, 0x39, 0x5e, 0x57, 0x09, 0x76, 0x1f, 0x26,
+	0x45, 0x2e, 0x33, 0x9f, 0x0b, 0x91, 0xc9, 0x3c, 0xb7, 0x17, 0x8c, 0x11, 0x3b, 0x30, 0x10, 0x7b,
+	0x04, 0x73, 0x79, 0x1e, 0x9c, 0xf2, 0xf8, 0x44, 0x56, 0x34, 0x73, 0xf3, 0xac, 0xc4, 0x4b, 0xea,
+	0x5d, 0x80, 0x15, 0xcf, 0xa5, 0xaf, 0x93, 0xb5, 0x8c, 0xdd, 0x3e, 0x91, 0x1c, 0x44, 0x8e, 0x11,
+	0x60, 0x9f, 0xc3, 0xf8, 0x6d, 0x91, 0xe8, 0x52, 0x3f, 0x20, 0x3d, 0x10, 0x64, 0x08, 0xb7, 0x60,
+	0x98, 0x6b, 0xae, 0x8b, 0xdc, 0x1d, 0x92, 0xce, 0x4a, 0x8c, 0x41, 0x3f, 0x57, 0x42, 0xba, 0x5b,
+	0x84, 0xd2, 0x19, 0x31, 0x74, 0x90, 0x3b, 0x32, 0x18, 0x9e, 0x11, 0x3b, 0xe5, 0xf9, 0xa9, 0xeb,
+	0x18, 0x0c, 0xcf, 0x6c, 0x1f, 0x9c, 0xbc, 0x74, 0x99, 0x0b, 0x7b, 0x9d, 0xe5, 0xf8, 0xe9, 0x7c,
+	0x9f, 0xbc, 0xb9, 0x5f, 0xb9, 0xd2, 0xdb, 0x50, 0xd8, 0x3d, 0x80, 0x34, 0x53, 0x81, 0x4c, 0x13,
+	0x15, 0x6b, 0x77, 0x6c, 0x6c, 0xdc, 0x20, 0x68, 0x23, 0x8f, 0x92, 0x22, 0xd6, 0xee, 0xc4, 0xd8,
+	0x68, 0x24, 0xf6, 0x00, 0xa6, 0x6f, 0x54, 0x18, 0x4a, 0xe1, 0x5b, 0xf5, 0x94, 0xd4, 0x13, 0x03,
+	0x1e, 0x18, 0xd2, 0x2e, 0x0c, 0xe2, 0x24, 0x0e, 0xa4, 0xbb, 0x4d, 0x4a, 0x23, 0xb0, 0x4f, 0xc1,
+	0x49, 0xb9, 0xca, 0xfc, 0x98, 0x47, 0xd2, 0x9d, 0x91, 0x66, 0x84, 0xc0, 0x2f, 0x3c, 0x92, 0xe8,
+	0xd3, 0x20, 0x93, 0x5c, 0xe3, 0xc5, 0xda, 0x9d, 0xef, 0x75, 0x96, 0x3d, 0xcf, 0xb1, 0xc8, 0x81,
+	0x46, 0x75, 0x91, 0x8a, 0x52, 0xbd, 0x63, 0xd4, 0x16, 0x39, 0xd0, 0xec, 0x36, 0x8c, 0x12, 0x0c,
+	0xbc, 0xaf, 0x84, 0xcb, 0xf6, 0x3a, 0xcb, 0xbe, 0xb7, 0x45, 0xf2, 0x91, 0x58, 0xfc, 0xd1, 0x85,
+	0x31, 0x25, 0xc5, 0x0f, 0x3c, 0x0e, 0x64, 0x88, 0x37, 0x19, 0x2a, 0xb9, 0xd0, 0xa4, 0x88, 0x43,
+	0xc8, 0xcf, 0xe8, 0xc7, 0xca, 0xf4, 0x6e, 0xdd, 0xf4, 0xd2, 0xe3, 0xbd, 0x9a, 0xc7, 0xeb, 0xdf,
+	0xec, 0x37, 0xbe, 0x59, 0x0b, 0xf0, 0xa0, 0x11, 0xe0, 0x76, 0x1a, 0x0e, 0x3f, 0x2c, 0x0d, 0xb7,
+	0xae, 0x4e, 0xc3, 0x46, 0xc8, 0x47, 0xef, 0x0d, 0xf9, 0xe2, 0xef, 0x01, 0x0c, 0x8e, 0x33, 0x2e,
+	0xe4, 0xa5, 0xf2, 0xd8, 0x85, 0x81, 0xe6, 0x6b, 0x59, 0x16, 0x96, 0x11, 0x10, 0x8d, 0x08, 0x35,
+	0xaf, 0x36, 0xc2, 0x47, 0x27, 0xff, 0x12, 0xe6, 0x74, 0x91, 0x5f, 0x8b, 0x82, 0xf1, 0xc3, 0x36,
+	0xe1, 0x2f, 0xaa, 0x50, 0x2c, 0x61, 0xae, 0xdb, 0x4c, 0xe3, 0x8a, 0x6d, 0xdd, 0x64, 0x96, 0xe1,
+	0x19, 0xd5, 0xc2, 0xf3, 0x09, 0x6c, 0xe9, 0x73, 0xbf, 0x56, 0x27, 0x43, 0x7d, 0x4e, 0xe4, 0x46,
+	0x1a, 0x42, 0x2b, 0x0d, 0xff, 0x6f, 0x59, 0xdc, 0x86, 0x11, 0x5a, 0xef, 0xbf, 0x91, 0xd2, 0x56,
+	0xc4, 0x16, 0xca, 0x3f, 0x49, 0x89, 0x2a, 0x5d, 0xaa, 0x4c, 0x3d, 0x6c, 0x69, 0xab, 0xda, 0xe4,
+	0xc9, 0xac, 0x91, 0x27, 0x1f, 0x57, 0x0c, 0x2d, 0xbf, 0x51, 0x4b, 0x61, 0x6d, 0xbf, 0xbd, 0xc2,
+	0xe6, 0xd2, 0x62, 0x52, 0xa3, 0xb9, 0xd1, 0x66, 0x1e, 0x63, 0xcb, 0x69, 0x45, 0x8d, 0x98, 0xbb,
+	0xed, 0xa8, 0x11, 0xf3, 0x21, 0x18, 0xc4, 0x2f, 0xd3, 0xd5, 0xbd, 0x49, 0xbc, 0x29, 0xa1, 0x3f,
+	0x5a, 0x10, 0x69, 0xba, 0x49, 0xbb, 0x65, 0x68, 0xba, 0x4e, 0x5b, 0x48, 0x18, 0xbe, 0xe4, 0x2a,
+	0x3b, 0x3a, 0x6c, 0x86, 0xad, 0x73, 0xb9, 0x7b, 0xd4, 0x92, 0xb2, 0xfb, 0x9e, 0xa4, 0xec, 0xb5,
+	0x93, 0x72, 0xf1, 0x6f, 0x17, 0xfa, 0xc7, 0x2a, 0x58, 0xb3, 0xfb, 0xd0, 0xc7, 0x4b, 0xe9, 0x03,
+	0xe3, 0xa7, 0x53, 0x5b, 0x4e, 0xc6, 0x04, 0x8f, 0x54, 0x98, 0x6c, 0x49, 0x5a, 0x7d, 0x85, 0xce,
+	0x58, 0x2a, 0x41, 0x98, 0xe4, 0xb2, 0x2c, 0x15, 0x12, 0x28, 0x2d, 0xd5, 0xc9, 0xa9, 0x2d, 0x12,
+	0x3a, 0xb3, 0x39, 0xf4, 0xc2, 0xe4, 0x77, 0x5b, 0x17, 0x78, 0xc4, 0x24, 0x38, 0x4b, 0xc2, 0x22,
+	0x92, 0xe5, 0x34, 0x30, 0x12, 0xfb, 0x02, 0x66, 0xe6, 0xe4, 0xaf, 0x2e, 0x7c, 0xb2, 0xd5, 0x66,
+	0xff, 0xd4, 0xc0, 0xcf, 0x2e, 0x7e, 0x45, 0x10, 0x1f, 0x67, 0x79, 0x45, 0x2e, 0xb4, 0xad, 0x01,
+	0x30, 0xd0, 0xeb, 0x5c, 0x50, 0x37, 0x0e, 0x28, 0x65, 0x1d, 0x6b, 0x1c, 0x0a, 0xec, 0x33, 0x70,
+	0xb4, 0x8a, 0x64, 0xae, 0x79, 0x94, 0x52, 0x19, 0xf4, 0xbc, 0x0d, 0x80, 0xde, 0xc6, 0x87, 0xf9,
+	0x88, 0x50, 0x19, 0xf4, 0xbc, 0x11, 0x02, 0xc7, 0xca, 0xf6, 0x6a, 0x7c, 0xa0, 0xd1, 0x4e, 0x6c,
+	0x7a, 0x22, 0x42, 0xea, 0x3b, 0x30, 0x12, 0x45, 0xc6, 0xb5, 0x4a, 0x62, 0xaa, 0x85, 0x9e, 0x57,
+	0xc9, 0xe8, 0x92, 0x22, 0x56, 0xda, 0x16, 0x02, 0x9d, 0x17, 0xff, 0xf4, 0xa0, 0x8f, 0x1e, 0x66,
+	0x8f, 0x61, 0x67, 0x13, 0x45, 0x3f, 0xbf, 0x88, 0x56, 0x49, 0x68, 0x43, 0x3d, 0xab, 0x82, 0xf9,
+	0x8a, 0x60, 0xf6, 0x15, 0xb0, 0x1a, 0xb7, 0x39, 0xd7, 0xe7, 0x15, 0x79, 0xd3, 0x2a, 0x6f, 0xd4,
+	0xd8, 0x42, 0x06, 0x2a, 0xe2, 0xa1, 0x99, 0xef, 0x03, 0x6f, 0xa7, 0xa2, 0x1f, 0x5a, 0x05, 0xde,
+	0x5e, 0x4b, 0x98, 0xd2, 0x14, 0x13, 0xc7, 0xf9, 0x26, 0x6f, 0xac, 0x2d, 0xfb, 0x70, 0xa3, 0xce,
+	0x2e, 0x8d, 0x31, 0x31, 0xde, 0xd9, 0xd0, 0x4b, 0x6b, 0xbe, 0x81, 0xdd, 0x3a, 0xbf, 0x32, 0x67,
+	0x48, 0xe6, 0xb0, 0xcd, 0x0f, 0x2a, 0x7b, 0x6e, 0xc1, 0x30, 0x54, 0xb9, 0x96, 0x82, 0x52, 0x60,
+	0xe4, 0x59, 0x09, 0x71, 0x1e, 0x68, 0x75, 0x66, 0xfa, 0xff, 0xc8, 0xb3, 0x12, 0xba, 0x39, 0xe3,
+	0xf1, 0x9a, 0x22, 0x3e, 0xf0, 0xe8, 0xdc, 0x68, 0x51, 0xf0, 0xee, 0x16, 0x35, 0x6e, 0xb6, 0xa8,
+	0x2f, 0x61, 0x96, 0xc9, 0x90, 0x5f, 0xd4, 0xa6, 0x96, 0xe9, 0x7c, 0xdb, 0x16, 0xb6, 0x8f, 0x5a,
+	0x1c, 0x02, 0xbc, 0xc4, 0x3e, 0xf9, 0x5c, 0x9e, 0xc9, 0xb0, 0xd5, 0x47, 0x3b, 0xd7, 0xf4, 0xd1,
+	0x6e, 0xbd, 0x8f, 0x2e, 0xce, 0xc0, 0xa1, 0x56, 0xf2, 0x2c, 0x49, 0xd6, 0xd7, 0x97, 0xfc, 0x43,
+	0xe8, 0xaf, 0x94, 0xc0, 0x90, 0xf7, 0x96, 0xe3, 0xa7, 0x3b, 0x65, 0xa5, 0x56, 0x26, 0x78, 0xa4,
+	0x46, 0x1a, 0xcf, 0xd7, 0x18, 0xea, 0x77, 0xd1, 0x50, 0xbd, 0xf8, 0x6b, 0x00, 0x93, 0xe7, 0x32,
+	0x16, 0x2a, 0x3e, 0xb9, 0x7a, 0x83, 0xbc, 0x03, 0xa3, 0xb7, 0x05, 0x8f, 0xb5, 0xd2, 0x17, 0xd6,
+	0xe4, 0x4a, 0x46, 0x9d, 0x8a, 0xb5, 0xcc, 0x64, 0xae, 0x29, 0xa5, 0xfa, 0x5e, 0x25, 0xd3, 0xfe,
+	0x26, 0xb3, 0xc8, 0x6e, 0x08, 0x74, 0xae, 0xf6, 0xbc, 0xc1, 0x15, 0x7b, 0xde, 0xb0, 0xb6, 0xe7,
+	0x3d, 0x80, 0x69, 0x68, 0x6c, 0xb2, 0x8d, 0xcb, 0xd4, 0xff, 0xc4, 0x82, 0xa6, 0xb7, 0x3d, 0x82,
+	0x79, 0x90, 0x84, 0x21, 0xd7, 0x32, 0xe3, 0xa1, 0xe5, 0x99, 0x1e, 0x30, 0xdb, 0xe0, 0x86, 0x7a,
+	0x69, 0x77, 0x73, 0xae, 0xd8, 0xdd, 0x36, 0x33, 0x09, 0xae, 0xdd, 0x5d, 0xc6, 0x97, 0x77, 0x97,
+	0x0f, 0xcd, 0x95, 0xe6, 0xe6, 0x32, 0x7d, 0xff, 0xb2, 0x5a, 0xce, 0xf7, 0xed, 0xab, 0xe7, 0xfb,
+	0xac, 0x31, 0xdf, 0xab, 0x0d, 0x6e, 0x5e, 0xdf, 0xe0, 0x9a, 0x23, 0x75, 0xe7, 0xfa, 0x91, 0xca,
+	0xda, 0x23, 0xf5, 0x2e, 0x40, 0x19, 0x09, 0x25, 0x68, 0x44, 0xf6, 0x3d, 0xc7, 0x22, 0x47, 0x02,
+	0xd5, 0xf2, 0x5c, 0x67, 0xdc, 0x17, 0x5c, 0x73, 0x3b, 0x17, 0x1d, 0x42, 0x0e, 0xb9, 0xe6, 0x54,
+	0x5e, 0x19, 0x17, 0x12, 0x7f, 0x7b, 0xd3, 0x6c, 0x8a, 0x24, 0x1f, 0x09, 0x76, 0x0f, 0xc6, 0xbc,
+	0xd0, 0x89, 0xaf, 0x93, 0xd4, 0x2f, 0x52, 0x9a, 0x81, 0x7d, 0xcf, 0x41, 0xe8, 0x38, 0x49, 0x5f,
+	0xa7, 0xcf, 0x1e, 0xff, 0xb6, 0x3c, 0x51, 0xfa, 0xb4, 0x58, 0xed, 0x07, 0x49, 0xf4, 0x04, 0x5d,
+	0x14, 0x9c, 0x72, 0x15, 0xd3, 0xe9, 0xfc, 0xeb, 0x5c, 0xac, 0x9f, 0x64, 0x69, 0xf0, 0x24, 0x5d,
+	0x7d, 0x9f, 0xae, 0x56, 0x43, 0xfa, 0x0f, 0xf5, 0xed, 0x7f, 0x03, 0x00, 0xb6, 0xeb, 0x3d, 0x6d,
+	0x52, 0x0d, 0x00, 0x00,
+}
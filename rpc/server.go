@@ -0,0 +1,190 @@
+// Package rpc serves the gRPC API of the exchange. The services and messages are generated
+// in the pb package from the definitions of the proto directory.
+package rpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/middlewares"
+	"github.com/tomochain/tomox-sdk/rpc/pb"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var logger = utils.Logger
+
+// Server implements the gRPC services with the services used by the HTTP endpoints. The
+// Notify methods are registered as hooks of the services to feed the streams.
+type Server struct {
+	orderService        interfaces.OrderService
+	lendingOrderService interfaces.LendingOrderService
+	pairService         interfaces.PairService
+	orderBookService    interfaces.OrderBookService
+	tradeService        interfaces.TradeService
+	ohlcvService        interfaces.OHLCVService
+
+	hub *hub
+}
+
+// NewServer returns a new instance of Server
+func NewServer(
+	orderService interfaces.OrderService,
+	lendingOrderService interfaces.LendingOrderService,
+	pairService interfaces.PairService,
+	orderBookService interfaces.OrderBookService,
+	tradeService interfaces.TradeService,
+	ohlcvService interfaces.OHLCVService,
+) *Server {
+	return &Server{
+		orderService:        orderService,
+		lendingOrderService: lendingOrderService,
+		pairService:         pairService,
+		orderBookService:    orderBookService,
+		tradeService:        tradeService,
+		ohlcvService:        ohlcvService,
+		hub:                 newHub(),
+	}
+}
+
+// ListenAndServe serves the gRPC services on the tcp address. It fails if the api auth key,
+// which protects the order streams of the users, is not set.
+func (s *Server) ListenAndServe(address string) error {
+	if app.Config.ApiAuthKey == "" {
+		return errors.New("api_auth_key must be set to serve the gRPC API")
+	}
+
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	g := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryErrorInterceptor),
+		grpc.StreamInterceptor(streamErrorInterceptor),
+	)
+
+	pb.RegisterOrderServiceServer(g, &orderServer{s})
+	pb.RegisterLendingOrderServiceServer(g, &lendingOrderServer{s})
+	pb.RegisterMarketDataServiceServer(g, &marketDataServer{s})
+
+	return g.Serve(lis)
+}
+
+// NotifyOrderChange sends an order received from the change stream to the streams of its owner
+func (s *Server) NotifyOrderChange(o *types.Order) {
+	s.hub.publish(ordersTopic(o.UserAddress), o)
+}
+
+// NotifyOrderBookUpdate sends the updated price levels of a pair to the orderbook streams
+func (s *Server) NotifyOrderBookUpdate(bt, qt common.Address, bids, asks []map[string]string) {
+	s.hub.publish(orderBookTopic(bt, qt), &types.OrderBook{Bids: bids, Asks: asks})
+}
+
+// NotifyTrades sends the trades of a pair to the trade streams
+func (s *Server) NotifyTrades(trades []*types.Trade) {
+	if len(trades) == 0 {
+		return
+	}
+
+	s.hub.publish(tradesTopic(trades[0].BaseToken, trades[0].QuoteToken), trades)
+}
+
+// NotifyTicks sends the updated ticks to the OHLCV streams
+func (s *Server) NotifyTicks(ticks []*types.Tick, duration int64, unit string) {
+	for _, t := range ticks {
+		s.hub.publish(ticksTopic(t.Pair.BaseToken, t.Pair.QuoteToken, duration, unit), t)
+	}
+}
+
+func ordersTopic(addr common.Address) string {
+	return "orders/" + addr.Hex()
+}
+
+func orderBookTopic(bt, qt common.Address) string {
+	return "orderbook/" + utils.GetOrderBookChannelID(bt, qt)
+}
+
+func tradesTopic(bt, qt common.Address) string {
+	return "trades/" + utils.GetTradeChannelID(bt, qt)
+}
+
+func ticksTopic(bt, qt common.Address, duration int64, unit string) string {
+	return "ohlcv/" + utils.GetTickChannelID(bt, qt, unit, duration)
+}
+
+// authorize checks the api key of the request, sent in the authorization metadata
+func authorize(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range md.Get("authorization") {
+		if middlewares.IsAuthKey(key) {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "Invalid auth key")
+}
+
+// toStatus converts the errors of the services to gRPC status errors
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var apiErr *errors.APIError
+	switch e := err.(type) {
+	case *errors.APIError:
+		apiErr = e
+	case errors.APIError:
+		apiErr = &e
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+
+	code := codes.Unknown
+	switch apiErr.Status {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusInternalServerError:
+		code = codes.Internal
+	}
+
+	return status.Error(code, apiErr.Message)
+}
+
+func unaryErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		logger.Error(info.FullMethod, err)
+	}
+
+	return res, toStatus(err)
+}
+
+func streamErrorInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	if err != nil {
+		logger.Error(info.FullMethod, err)
+	}
+
+	return toStatus(err)
+}
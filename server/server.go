@@ -1,12 +1,14 @@
 package server
 
 import (
+	"crypto/rand"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"runtime"
 	"strconv"
+	"time"
 
 	"runtime/pprof"
 
@@ -20,10 +22,17 @@ import (
 	"github.com/tomochain/tomox-sdk/engine"
 	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/ethereum"
+	"github.com/tomochain/tomox-sdk/fix"
+	"github.com/tomochain/tomox-sdk/gql"
+	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/rabbitmq"
 	"github.com/tomochain/tomox-sdk/relayer"
+	"github.com/tomochain/tomox-sdk/rpc"
 	"github.com/tomochain/tomox-sdk/services"
+	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils"
+	"github.com/tomochain/tomox-sdk/utils/fx"
+	"github.com/tomochain/tomox-sdk/utils/notify"
 	"github.com/tomochain/tomox-sdk/ws"
 )
 
@@ -47,6 +56,8 @@ func Start() {
 	}
 
 	logger.Infof("Server port: %v", app.Config.ServerPort)
+	logger.Infof("gRPC port: %v", app.Config.GrpcPort)
+	logger.Infof("FIX port: %v", app.Config.Fix["port"])
 	logger.Infof("Tomochain node HTTP url: %v", app.Config.Tomochain["http_url"])
 	logger.Infof("Tomochain node WS url: %v", app.Config.Tomochain["ws_url"])
 	logger.Infof("MongoDB url: %v", app.Config.MongoURL)
@@ -125,6 +136,17 @@ func NewRouter(
 	lendingTradeDao := daos.NewLendingTradeDao()
 	lengdingPairDao := daos.NewLendingPairDao()
 	relayerDao := daos.NewRelayerDao()
+	fixAccountDao := daos.NewFixAccountDao()
+	webhookDao := daos.NewWebhookDao()
+	webhookDeliveryDao := daos.NewWebhookDeliveryDao()
+	notificationChannelDao := daos.NewNotificationChannelDao()
+	notificationPreferencesDao := daos.NewNotificationPreferencesDao()
+	notificationDigestDao := daos.NewNotificationDigestDao()
+	portfolioDao := daos.NewPortfolioDao()
+	portfolioSnapshotDao := daos.NewPortfolioSnapshotDao()
+	complianceDao := daos.NewComplianceDao()
+	complianceAuditDao := daos.NewComplianceAuditDao()
+	userPreferencesDao := daos.NewUserPreferencesDao()
 	// instantiate engine
 	eng := engine.NewEngine(rabbitConn, orderDao, tradeDao, pairDao, provider)
 
@@ -132,32 +154,170 @@ func NewRouter(
 	ohlcvService := services.NewOHLCVService(tradeDao, pairDao, tokenDao)
 	ohlcvService.Init()
 
-	accountService := services.NewAccountService(accountDao, tokenDao, pairDao, orderDao, lendingOrderDao, provider, ohlcvService)
+	fxSource, err := fx.NewSource(app.Config.FxSource, app.Config.FxRates)
+	if err != nil {
+		panic(err)
+	}
+	fiatService := services.NewFiatService(fxSource, app.Config.FiatCurrencies)
+
+	notificationTemplates, err := notify.NewTemplatesFromDir(app.Config.Notifications["templates_dir"])
+	if err != nil {
+		panic(err)
+	}
+	notificationService := services.NewNotificationService(notificationDao, notificationChannelDao, notificationPreferencesDao, notificationDigestDao, notify.NewTransports(app.Config.Notifications), notificationTemplates)
+
+	preferencesService := services.NewPreferencesService(userPreferencesDao, accountDao, pairDao, notificationService, fiatService)
+
+	// the notifications created by the services are delivered to the channels of their recipients
+	deliveredNotificationDao := notificationService.Dao()
+
+	// the balances are cached by every instance, following the transfers of the listed tokens
+	balanceService := services.NewBalanceService(provider.Client, tokenDao, pairDao, orderDao, lendingOrderDao)
+	if err := balanceService.Start(); err != nil {
+		panic(err)
+	}
+
+	accountService := services.NewAccountService(accountDao, tokenDao, pairDao, orderDao, lendingOrderDao, balanceService, ohlcvService)
+
+	// the orders of the blocked and restricted addresses are refused by the order services
+	complianceService := services.NewComplianceService(complianceDao, complianceAuditDao)
+	complianceService.Start()
+	accountService.RegisterBlockedCheck(complianceService.IsBlocked)
+
+	// the on-chain balance changes are pushed by each instance to its own connections
+	balanceService.RegisterBalanceNotify(accountService.NotifyBalanceChange)
+	balanceService.RegisterBalanceWatch(func(owner common.Address) bool {
+		return ws.GetBalanceSocket().HasSubscriptions(owner.Hex())
+	})
 	tokenService := services.NewTokenService(tokenDao)
-	validatorService := services.NewValidatorService(provider, accountDao, orderDao, lendingOrderDao, pairDao, tokenDao)
+	validatorService := services.NewValidatorService(balanceService, accountDao, orderDao, lendingOrderDao, pairDao, tokenDao)
 	pairService := services.NewPairService(pairDao, tokenDao, tradeDao, orderDao, ohlcvService, eng, provider)
 
-	orderService := services.NewOrderService(orderDao, tokenDao, pairDao, accountDao, tradeDao, notificationDao, eng, validatorService, rabbitConn)
+	orderService := services.NewOrderService(orderDao, tokenDao, pairDao, accountDao, tradeDao, deliveredNotificationDao, eng, validatorService, complianceService, rabbitConn)
 	orderService.LoadCache()
 	orderBookService := services.NewOrderBookService(pairDao, tokenDao, orderDao, eng)
-	tradeService := services.NewTradeService(orderDao, tradeDao, ohlcvService, notificationDao, rabbitConn)
+	l3OrderBookService := newL3OrderBookService(pairDao, orderDao)
+	tradeService := services.NewTradeService(orderDao, tradeDao, ohlcvService, deliveredNotificationDao, rabbitConn)
 
 	walletService := services.NewWalletService(walletDao)
 
-	priceBoardService := services.NewPriceBoardService(tokenDao, tradeDao, ohlcvService)
-	marketsService := services.NewMarketsService(pairDao, orderDao, tradeDao, ohlcvService, pairService)
-	notificationService := services.NewNotificationService(notificationDao)
+	priceBoardService := services.NewPriceBoardService(tokenDao, tradeDao, ohlcvService, fiatService)
+	marketsService := services.NewMarketsService(pairDao, orderDao, tradeDao, ohlcvService, pairService, fiatService)
 
 	// LEDNDING SERVICE
 	tokenLendingService := services.NewTokenService(tokenLendingDao)
 	tokenCollateralService := services.NewTokenService(tokenCollateralDao)
 
-	lendingOrderService := services.NewLendingOrderService(lendingOrderDao, lendingTopupDao, lendingRepayDao, lendingRecallDao, tokenCollateralDao, tokenLendingDao, notificationDao, lendingTradeDao, validatorService, eng, rabbitConn)
-	lendingTradeService := services.NewLendingTradeService(lendingOrderDao, lendingTradeDao, notificationDao, rabbitConn)
+	lendingOrderService := services.NewLendingOrderService(lendingOrderDao, lendingTopupDao, lendingRepayDao, lendingRecallDao, tokenCollateralDao, tokenLendingDao, deliveredNotificationDao, lendingTradeDao, validatorService, complianceService, eng, rabbitConn)
+	lendingTradeService := services.NewLendingTradeService(lendingOrderDao, lendingTradeDao, deliveredNotificationDao, rabbitConn)
 	lendingOhlcvService := services.NewLendingOhlcvService(lendingTradeService, ohlcvService, lengdingPairDao)
 	lendingOhlcvService.Init()
 
 	lendingOrderbookService := services.NewLendingOrderBookService(lendingOrderDao)
+	indicatorService := services.NewIndicatorService(ohlcvService, lendingOhlcvService)
+
+	rpcServer := rpc.NewServer(orderService, lendingOrderService, pairService, orderBookService, tradeService, ohlcvService)
+
+	fixAccountService := services.NewFixAccountService(fixAccountDao, app.Config.Fix["keystore_password"])
+	fixCompID := app.Config.Fix["sender_comp_id"]
+	if fixCompID == "" {
+		fixCompID = "TOMOX"
+	}
+	fixGateway := fix.NewGateway(fixCompID, fixAccountService, orderService, orderBookService, pairDao)
+
+	webhookService := services.NewWebhookService(webhookDao, webhookDeliveryDao, rabbitConn)
+	portfolioService := services.NewPortfolioService(portfolioDao, portfolioSnapshotDao, tradeDao, pairDao, ohlcvService)
+	statementService := services.NewStatementService(tradeDao, lendingTradeDao, lendingTopupDao, tokenDao, ohlcvService, fiatService)
+	orderNonceService, lendingNonceService := newNonceServices(orderDao, lendingOrderDao)
+	if err := webhookService.Start(); err != nil {
+		panic(err)
+	}
+
+	// the hooks feed the websocket channels, the gRPC streams and the FIX sessions
+	orderBookNotify := func(bt, qt common.Address, bids, asks []map[string]string) {
+		orderBookService.NotifyOrderBookUpdate(bt, qt, bids, asks)
+		rpcServer.NotifyOrderBookUpdate(bt, qt, bids, asks)
+		fixGateway.NotifyOrderBookUpdate(bt, qt, bids, asks)
+	}
+	orderChangeNotify := func(o *types.Order, sequence uint64) {
+		l3OrderBookService.HandleOrderChange(o, sequence)
+		rpcServer.NotifyOrderChange(o)
+	}
+	tickNotify := func(ticks []*types.Tick, duration int64, unit string) {
+		indicatorService.NotifyTicks(ticks, duration, unit)
+		rpcServer.NotifyTicks(ticks, duration, unit)
+	}
+	tradeNotify := func(trades []*types.Trade) {
+		rpcServer.NotifyTrades(trades)
+		fixGateway.NotifyTrades(trades)
+	}
+
+	// the derived feeds are computed by every instance of a cluster from the events of the leader
+	clusterService := newClusterService(rabbitConn)
+	if clusterService != nil {
+		orderService.RegisterOrderBookNotify(clusterService.OrderBookNotify(orderBookNotify))
+		orderService.RegisterOrderChangeNotify(clusterService.OrderChangeNotify(orderChangeNotify))
+		lendingOrderService.RegisterOrderBookNotify(clusterService.LendingOrderBookNotify(lendingOrderbookService.NotifyLendingOrderBookUpdate))
+		tradeService.RegisterTickNotify(clusterService.TickNotify(tickNotify))
+		tradeService.RegisterTradeNotify(clusterService.TradeNotify(tradeNotify))
+		engineResponseNotify := clusterService.EngineResponseNotify(fixGateway.NotifyEngineResponse)
+		orderService.RegisterEngineResponseNotify(func(res *types.EngineResponse) {
+			engineResponseNotify(res)
+			webhookService.NotifyEngineResponse(res)
+			accountService.NotifyEngineResponse(res)
+			orderNonceService.NotifyEngineResponse(res)
+		})
+		lendingOhlcvService.RegisterTickNotify(clusterService.LendingTickNotify(indicatorService.NotifyLendingTicks))
+	} else {
+		orderService.RegisterOrderBookNotify(orderBookNotify)
+		orderService.RegisterOrderChangeNotify(orderChangeNotify)
+		lendingOrderService.RegisterOrderBookNotify(lendingOrderbookService.NotifyLendingOrderBookUpdate)
+		tradeService.RegisterTickNotify(tickNotify)
+		tradeService.RegisterTradeNotify(tradeNotify)
+		orderService.RegisterEngineResponseNotify(func(res *types.EngineResponse) {
+			fixGateway.NotifyEngineResponse(res)
+			webhookService.NotifyEngineResponse(res)
+			accountService.NotifyEngineResponse(res)
+			orderNonceService.NotifyEngineResponse(res)
+		})
+		lendingOhlcvService.RegisterTickNotify(indicatorService.NotifyLendingTicks)
+	}
+
+	// the engine responses are consumed by a single instance, which dispatches them to the webhooks
+	// and relays the balance updates to the instances of the subscribed connections
+	tradeService.RegisterTradeInsertNotify(func(t *types.Trade) {
+		webhookService.NotifyTrade(t)
+		accountService.NotifyTrade(t)
+		portfolioService.NotifyTrade(t)
+	})
+	lendingOrderService.RegisterEngineResponseNotify(func(res *types.EngineResponse) {
+		webhookService.NotifyLendingOrderResponse(res)
+		accountService.NotifyLendingOrderResponse(res)
+		lendingNonceService.NotifyLendingOrderResponse(res)
+	})
+	orderService.RegisterOrderRejectNotify(orderNonceService.NotifyOrderRejected)
+	lendingOrderService.RegisterOrderRejectNotify(lendingNonceService.NotifyLendingOrderRejected)
+	lendingTradeService.RegisterUpdateNotify(func(t *types.LendingTrade) {
+		webhookService.NotifyLendingTradeUpdate(t)
+		notificationService.NotifyLendingTradeUpdate(t)
+	})
+
+	if app.Config.GrpcPort != 0 {
+		go func() {
+			panic(rpcServer.ListenAndServe(fmt.Sprintf(":%v", app.Config.GrpcPort)))
+		}()
+	}
+
+	if app.Config.Fix["port"] != "" {
+		if app.Config.Fix["keystore_password"] == "" || app.Config.Fix["cert_file"] == "" || app.Config.Fix["key_file"] == "" {
+			panic("fix.keystore_password, fix.cert_file and fix.key_file are required by the FIX gateway")
+		}
+
+		go func() {
+			panic(fixGateway.ListenAndServe(fmt.Sprintf(":%v", app.Config.Fix["port"]), app.Config.Fix["cert_file"], app.Config.Fix["key_file"]))
+		}()
+	}
+
 	lendingMarketService := services.NewLendingMarketsService(lengdingPairDao, lendingOhlcvService)
 	lendingPairService := services.NewLendingPairService(lengdingPairDao)
 	lendingPriceboardService := services.NewLendingPriceBoardService(lendingPairService, lendingOhlcvService)
@@ -168,20 +328,26 @@ func NewRouter(
 	relayerEngine := relayer.NewRelayer(app.Config.Tomochain["http_url"], exchangeAddress, contractAddress, lendingContractAddress)
 	relayerService := services.NewRelayerService(relayerEngine, tokenDao, tokenCollateralDao, tokenLendingDao, pairDao, lengdingPairDao, relayerDao)
 
+	authService := newAuthService()
+
 	// deploy http and ws endpoints
+	endpoints.ServeAuthResource(r, authService)
 	endpoints.ServeInfoResource(r, walletService, tokenService, relayerService)
-	endpoints.ServeAccountResource(r, accountService)
+	endpoints.ServeAccountResource(r, accountService, fiatService, statementService, relayerService, authService)
 	endpoints.ServeTokenResource(r, tokenService, relayerService)
 	endpoints.ServePairResource(r, pairService, relayerService)
 	endpoints.ServeOrderBookResource(r, orderBookService)
-	endpoints.ServeOHLCVResource(r, ohlcvService)
+	endpoints.ServeL3OrderBookResource(r, l3OrderBookService)
+	endpoints.ServeOHLCVResource(r, ohlcvService, indicatorService)
+	endpoints.ServeIndicatorResource(r, indicatorService)
 
 	endpoints.ServeTradeResource(r, tradeService, relayerService)
-	endpoints.ServeOrderResource(r, orderService, accountService, relayerService)
+	endpoints.ServeOrderResource(r, orderService, relayerService, orderNonceService, authService)
 
-	endpoints.ServePriceBoardResource(r, priceBoardService)
-	endpoints.ServeMarketsResource(r, marketsService, pairService, relayerService)
-	endpoints.ServeNotificationResource(r, notificationService)
+	endpoints.ServePriceBoardResource(r, priceBoardService, fiatService)
+	endpoints.ServeMarketsResource(r, marketsService, pairService, relayerService, fiatService)
+	endpoints.ServeNotificationResource(r, notificationService, authService)
+	endpoints.ServePreferencesResource(r, preferencesService, authService)
 
 	// Endpoint for lending
 
@@ -190,12 +356,19 @@ func NewRouter(
 	endpoints.ServeLendingPairResource(r, lendingPairService, relayerService)
 	endpoints.ServeLendingOrderBookResource(r, lendingOrderbookService)
 	endpoints.ServeLendingTradeResource(r, lendingTradeService, relayerService)
-	endpoints.ServeLendingOrderResource(r, lendingOrderService, relayerService)
-	endpoints.ServeLendingOhlcvResource(r, lendingOhlcvService)
+	endpoints.ServeLendingOrderResource(r, lendingOrderService, relayerService, lendingNonceService, authService)
+	endpoints.ServeLendingOhlcvResource(r, lendingOhlcvService, indicatorService)
 	endpoints.ServeLendingMarketsResource(r, lendingMarketService, lendingOhlcvService)
 	endpoints.ServeLendingPriceBoardResource(r, lendingPriceboardService)
 
 	endpoints.ServeRelayerResource(r, relayerService, ohlcvService, lendingOhlcvService)
+	endpoints.ServeFixAccountResource(r, fixAccountService)
+	endpoints.ServeWebhookResource(r, webhookService, authService)
+	endpoints.ServeComplianceResource(r, complianceService)
+	endpoints.ServePortfolioResource(r, portfolioService)
+
+	// GraphQL queries and subscriptions
+	r.Handle("/graphql", gql.NewHandler(relayerService, pairService, tokenService, orderService, tradeService, accountService, notificationService, lendingOrderService, lendingTradeService, orderBookService, pairDao, tokenDao, orderDao))
 
 	// Swagger UI
 	sh := http.StripPrefix(swaggerUIDir, http.FileServer(http.Dir("."+swaggerUIDir)))
@@ -215,14 +388,132 @@ func NewRouter(
 	rabbitConn.SubscribeLendingOrderResponses(lendingOrderService.HandleLendingOrderResponse)
 	rabbitConn.SubscribeLendingTradeResponses(lendingTradeService.HandleLendingTradeResponse)
 	// start cron service
-	cronService := crons.NewCronService(ohlcvService, priceBoardService, pairService, relayerService, eng, lendingPriceboardService, lendingPairService, lendingOhlcvService)
-	// initialize MongoDB Change Streams
-	go orderService.WatchChanges()
-	go tradeService.WatchChanges()
-
-	// lending mongo watch change
-	go lendingOrderService.WatchChanges()
-	go lendingTradeService.WatchChanges()
-	cronService.InitCrons()
+	cronService := crons.NewCronService(ohlcvService, priceBoardService, pairService, relayerService, eng, lendingPriceboardService, lendingPairService, lendingOhlcvService, fiatService, marketsService, notificationService, portfolioService)
+	leader := func() {
+		// initialize MongoDB Change Streams
+		go orderService.WatchChanges()
+		go tradeService.WatchChanges()
+
+		// lending mongo watch change
+		go lendingOrderService.WatchChanges()
+		go lendingTradeService.WatchChanges()
+		cronService.InitCrons()
+	}
+
+	// in a cluster only the leader watches the changes and runs the crons
+	if clusterService != nil {
+		if err := clusterService.Start(); err != nil {
+			panic(err)
+		}
+
+		go clusterService.RunAsLeader(leader)
+	} else {
+		leader()
+	}
+
 	return r
 }
+
+// newAuthService returns the service of the Sign-In with Ethereum sessions. Without a configured
+// secret the sessions are signed with a random one and do not survive a restart. The domain of
+// the login messages defaults to the local server.
+func newAuthService() *services.AuthService {
+	secret := []byte(app.Config.Auth["jwt_secret"])
+	if len(secret) == 0 {
+		if app.Config.Cluster["enabled"] == "true" {
+			panic("auth.jwt_secret must be set in the clustered mode")
+		}
+
+		logger.Warning("auth.jwt_secret is not set, the sessions are signed with a random secret")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(err)
+		}
+	}
+
+	// the login messages signed for another site must not be accepted, the host of the login
+	// requests is set by the clients so it is never used
+	domain := app.Config.Auth["domain"]
+	if domain == "" {
+		domain = fmt.Sprintf("localhost:%v", app.Config.ServerPort)
+		logger.Warningf("auth.domain is not set, only the login messages for %v are accepted", domain)
+	}
+
+	nonceTTL, sessionTTL := 300, 3600
+	if v, err := strconv.Atoi(app.Config.Auth["nonce_ttl"]); err == nil && v > 0 {
+		nonceTTL = v
+	}
+
+	if v, err := strconv.Atoi(app.Config.Auth["session_ttl"]); err == nil && v > 0 {
+		sessionTTL = v
+	}
+
+	return services.NewAuthService(
+		daos.NewAuthNonceDao(),
+		secret,
+		domain,
+		types.ChainID(),
+		time.Duration(nonceTTL)*time.Second,
+		time.Duration(sessionTTL)*time.Second,
+	)
+}
+
+// newL3OrderBookService returns the level 3 orderbook service. The order ids are anonymized with
+// l3_orderbook.id_secret, which is required in the clustered mode so that the instances report the
+// same ids. Without secret the ids are anonymized with a random one and change on every restart.
+func newL3OrderBookService(pairDao interfaces.PairDao, orderDao interfaces.OrderDao) *services.L3OrderBookService {
+	secret := []byte(app.Config.L3OrderBook["id_secret"])
+	if len(secret) == 0 {
+		if app.Config.Cluster["enabled"] == "true" {
+			panic("l3_orderbook.id_secret must be set in the clustered mode")
+		}
+
+		logger.Warning("l3_orderbook.id_secret is not set, the order ids are anonymized with a random secret")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(err)
+		}
+	}
+
+	return services.NewL3OrderBookService(pairDao, orderDao, secret)
+}
+
+// newNonceServices returns the nonce services of the orders and of the lending orders
+func newNonceServices(orderDao interfaces.OrderDao, lendingOrderDao interfaces.LendingOrderDao) (*services.NonceService, *services.NonceService) {
+	ttl := 60
+	if v, err := strconv.Atoi(app.Config.Tomochain["nonce_reservation_ttl"]); err == nil && v > 0 {
+		ttl = v
+	}
+
+	// the reservations are stored in the database, shared by the instances of a cluster
+	nonceDao := daos.NewNonceReservationDao()
+	orderNonceService := services.NewOrderNonceService(orderDao, nonceDao, time.Duration(ttl)*time.Second)
+	lendingNonceService := services.NewLendingNonceService(lendingOrderDao, nonceDao, time.Duration(ttl)*time.Second)
+
+	return orderNonceService, lendingNonceService
+}
+
+// newClusterService returns the cluster service if the clustered mode is enabled, nil otherwise
+func newClusterService(rabbitConn *rabbitmq.Connection) *services.ClusterService {
+	if app.Config.Cluster["enabled"] != "true" {
+		return nil
+	}
+
+	instanceID := app.Config.Cluster["instance_id"]
+	if instanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			panic(err)
+		}
+
+		instanceID = fmt.Sprintf("%v-%v", hostname, os.Getpid())
+	}
+
+	ttl := 15
+	if v, err := strconv.Atoi(app.Config.Cluster["lease_ttl"]); err == nil && v > 0 {
+		ttl = v
+	}
+
+	logger.Infof("Cluster instance: %v", instanceID)
+	return services.NewClusterService(daos.NewLeaseDao(), rabbitConn, instanceID, time.Duration(ttl)*time.Second)
+}
@@ -10,16 +10,18 @@ import (
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils/math"
+	"github.com/tomochain/tomox-sdk/ws"
 )
 
 type AccountService struct {
-	AccountDao   interfaces.AccountDao
-	TokenDao     interfaces.TokenDao
-	PairDao      interfaces.PairDao
-	OrderDao     interfaces.OrderDao
-	LendingDao   interfaces.LendingOrderDao
-	Provider     interfaces.EthereumProvider
-	OHLCVService interfaces.OHLCVService
+	AccountDao     interfaces.AccountDao
+	TokenDao       interfaces.TokenDao
+	PairDao        interfaces.PairDao
+	OrderDao       interfaces.OrderDao
+	LendingDao     interfaces.LendingOrderDao
+	BalanceService interfaces.BalanceService
+	OHLCVService   interfaces.OHLCVService
+	blocked        func(owner common.Address) bool
 }
 
 // NewAccountService returns a new instance of accountService
@@ -29,17 +31,17 @@ func NewAccountService(
 	pairDao interfaces.PairDao,
 	orderDao interfaces.OrderDao,
 	lendingDao interfaces.LendingOrderDao,
-	provider interfaces.EthereumProvider,
+	balanceService interfaces.BalanceService,
 	ohlcvService interfaces.OHLCVService,
 ) *AccountService {
 	return &AccountService{
-		AccountDao:   accountDao,
-		TokenDao:     tokenDao,
-		PairDao:      pairDao,
-		OrderDao:     orderDao,
-		LendingDao:   lendingDao,
-		Provider:     provider,
-		OHLCVService: ohlcvService,
+		AccountDao:     accountDao,
+		TokenDao:       tokenDao,
+		PairDao:        pairDao,
+		OrderDao:       orderDao,
+		LendingDao:     lendingDao,
+		BalanceService: balanceService,
+		OHLCVService:   ohlcvService,
 	}
 }
 
@@ -168,6 +170,12 @@ func (s *AccountService) GetAll() ([]types.Account, error) {
 	return s.AccountDao.GetAll()
 }
 
+// RegisterBlockedCheck registers the function telling if an address is blocked, which sets
+// the IsBlocked field of the accounts
+func (s *AccountService) RegisterBlockedCheck(fn func(owner common.Address) bool) {
+	s.blocked = fn
+}
+
 // GetByAddress get account from address
 func (s *AccountService) GetByAddress(a common.Address) (*types.Account, error) {
 	account, err := s.AccountDao.GetByAddress(a)
@@ -181,6 +189,9 @@ func (s *AccountService) GetByAddress(a common.Address) (*types.Account, error)
 			IsBlocked:     false,
 		}
 	}
+	if s.blocked != nil {
+		account.IsBlocked = s.blocked(a)
+	}
 	tokens, err := s.TokenDao.GetAll()
 	if err != nil || tokens == nil {
 		return nil, err
@@ -202,50 +213,12 @@ func (s *AccountService) GetTokenBalance(owner common.Address, token common.Addr
 	return s.AccountDao.GetTokenBalance(owner, token)
 }
 
-// GetTokenBalanceProvidor get balance from chain
+// GetTokenBalanceProvidor get balance from the balance cache
 func (s *AccountService) GetTokenBalanceProvidor(owner common.Address, tokenAddress common.Address) (*types.TokenBalance, error) {
-	token, err := s.TokenDao.GetByAddress(tokenAddress)
-	if err != nil || token == nil {
-		return nil, err
-	}
-	tokenBalance := &types.TokenBalance{
-		Address:          tokenAddress,
-		Symbol:           token.Symbol,
-		Decimals:         token.Decimals,
-		AvailableBalance: big.NewInt(0),
-		InOrderBalance:   big.NewInt(0),
-		Balance:          big.NewInt(0),
-		InUsdBalance:     big.NewFloat(0),
-	}
-	b, err := s.Provider.Balance(owner, tokenAddress)
-	if err != nil {
+	tokenBalance, err := s.BalanceService.GetTokenBalance(owner, tokenAddress)
+	if err != nil || tokenBalance == nil {
 		return nil, err
 	}
-	tokenBalance.Balance = b
-
-	listPairs, err := s.PairDao.GetActivePairs()
-	if err != nil {
-		logger.Error(err)
-		return nil, err
-	}
-	tokens, err := s.TokenDao.GetAll()
-	if err != nil {
-		logger.Error(err)
-		return nil, err
-	}
-	sellTokenExchangeLockedBalance, err := s.OrderDao.GetUserLockedBalance(owner, tokenAddress, listPairs)
-	if err != nil {
-		logger.Error(err)
-		return nil, err
-	}
-	sellTokenLendingLockedBalance, err := s.LendingDao.GetUserLockedBalance(owner, tokenAddress, tokens)
-	if err != nil {
-		logger.Error(err)
-		return nil, err
-	}
-	sellTokenLockedBalance := new(big.Int).Add(sellTokenExchangeLockedBalance, sellTokenLendingLockedBalance)
-	tokenBalance.InOrderBalance = sellTokenLockedBalance
-	tokenBalance.AvailableBalance = math.Sub(b, sellTokenLockedBalance)
 
 	price, _ := s.OHLCVService.GetLastPriceCurrentByTime(tokenBalance.Symbol, time.Now())
 
@@ -273,17 +246,122 @@ func (s *AccountService) Transfer(token common.Address, fromAddress common.Addre
 	return s.AccountDao.Transfer(token, fromAddress, toAddress, amount)
 }
 
-// GetFavoriteTokens get favorite token
-func (s *AccountService) GetFavoriteTokens(owner common.Address) (map[common.Address]bool, error) {
-	return s.AccountDao.GetFavoriteTokens(owner)
+// SubscribeBalances sends the balances of owner and registers the connection for their updates,
+// the connection must be logged in with owner
+func (s *AccountService) SubscribeBalances(c *ws.Client, owner common.Address) error {
+	err := c.Authorize(ws.BalanceChannel, owner)
+	if err != nil {
+		return err
+	}
+
+	account, err := s.GetByAddress(owner)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	socket := ws.GetBalanceSocket()
+	id := owner.Hex()
+
+	err = socket.Subscribe(id, c)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
+	socket.SendInitMessage(c, account.TokenBalances)
+	return nil
+}
+
+// UnsubscribeBalances removes the connection from the balance updates of owner
+func (s *AccountService) UnsubscribeBalances(c *ws.Client, owner common.Address) {
+	ws.GetBalanceSocket().UnsubscribeChannel(owner.Hex(), c)
+}
+
+// NotifyTrade pushes the balances of the tokens of a trade to its maker and taker, their locked
+// amounts changed
+func (s *AccountService) NotifyTrade(t *types.Trade) {
+	go s.broadcastBalances(t.Maker, t.BaseToken, t.QuoteToken)
+	go s.broadcastBalances(t.Taker, t.BaseToken, t.QuoteToken)
 }
 
-// AddFavoriteToken add favorite token
-func (s *AccountService) AddFavoriteToken(owner, token common.Address) error {
-	return s.AccountDao.AddFavoriteToken(owner, token)
+// NotifyEngineResponse pushes the balance of the sell token of the added and cancelled orders
+func (s *AccountService) NotifyEngineResponse(res *types.EngineResponse) {
+	switch res.Status {
+	case types.ORDER_ADDED, types.ORDER_CANCELLED:
+	default:
+		return
+	}
+
+	if o := res.Order; o != nil {
+		go s.broadcastBalances(o.UserAddress, o.SellToken())
+	}
+}
+
+// NotifyLendingOrderResponse pushes the balances of the lending and collateral tokens of the
+// added and cancelled lending orders, and of the topups, repayments and recalls of the loans
+func (s *AccountService) NotifyLendingOrderResponse(res *types.EngineResponse) {
+	switch res.Status {
+	case types.LENDING_ORDER_ADDED, types.LENDING_ORDER_CANCELLED,
+		types.LENDING_ORDER_TOPUPED, types.LENDING_ORDER_REPAYED, types.LENDING_ORDER_RECALLED:
+	default:
+		return
+	}
+
+	o := res.LendingOrder
+	if o == nil {
+		return
+	}
+
+	tokens := []common.Address{o.LendingToken}
+	if (o.CollateralToken != common.Address{}) {
+		tokens = append(tokens, o.CollateralToken)
+	}
+
+	go s.broadcastBalances(o.UserAddress, tokens...)
 }
 
-// DeleteFavoriteToken delete favorite token
-func (s *AccountService) DeleteFavoriteToken(owner, token common.Address) error {
-	return s.AccountDao.DeleteFavoriteToken(owner, token)
+// NotifyBalanceChange pushes an on-chain balance change seen by the balance cache of this
+// instance, every instance of a cluster sees the changes of the balances of its connections
+func (s *AccountService) NotifyBalanceChange(owner, token common.Address) {
+	socket := ws.GetBalanceSocket()
+	if !socket.HasSubscriptions(owner.Hex()) {
+		return
+	}
+
+	balances := s.tokenBalances(owner, token)
+	if len(balances) > 0 {
+		socket.DeliverMessage(owner.Hex(), balances)
+	}
+}
+
+func (s *AccountService) broadcastBalances(owner common.Address, tokens ...common.Address) {
+	socket := ws.GetBalanceSocket()
+	if !socket.Watched(owner.Hex()) {
+		return
+	}
+
+	balances := s.tokenBalances(owner, tokens...)
+	if len(balances) > 0 {
+		socket.BroadcastMessage(owner.Hex(), balances)
+	}
+}
+
+// tokenBalances returns the balances of the listed tokens of owner, keyed by token
+func (s *AccountService) tokenBalances(owner common.Address, tokens ...common.Address) map[common.Address]*types.TokenBalance {
+	res := make(map[common.Address]*types.TokenBalance)
+	for _, token := range tokens {
+		b, err := s.GetTokenBalanceProvidor(owner, token)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		if b != nil {
+			res[token] = b
+		}
+	}
+
+	return res
 }
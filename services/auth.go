@@ -0,0 +1,118 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// AuthService opens the sessions of the users which sign in with Ethereum (EIP-4361). The
+// sessions are JWTs bound to the address of the user, signed with the secret of the SDK.
+type AuthService struct {
+	nonceDao   interfaces.AuthNonceDao
+	secret     []byte
+	domain     string
+	chainID    int64
+	nonceTTL   time.Duration
+	sessionTTL time.Duration
+}
+
+// NewAuthService returns a new instance of AuthService. The login messages must be issued for
+// domain and for the chain chainID.
+func NewAuthService(
+	nonceDao interfaces.AuthNonceDao,
+	secret []byte,
+	domain string,
+	chainID int64,
+	nonceTTL time.Duration,
+	sessionTTL time.Duration,
+) *AuthService {
+	return &AuthService{
+		nonceDao:   nonceDao,
+		secret:     secret,
+		domain:     domain,
+		chainID:    chainID,
+		nonceTTL:   nonceTTL,
+		sessionTTL: sessionTTL,
+	}
+}
+
+// NewNonce issues a nonce for a login message
+func (s *AuthService) NewNonce() (*types.AuthNonce, error) {
+	n, err := types.NewAuthNonce(s.nonceTTL)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = s.nonceDao.Create(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// Login opens a session for the address of a Sign-In with Ethereum message signed by this
+// address. The nonce of the message must have been issued by NewNonce and is used once.
+func (s *AuthService) Login(message string, signature []byte) (*types.AuthSession, error) {
+	m, err := types.ParseSiweMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.CheckIssuer(s.domain, s.chainID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := m.ValidAt(now); err != nil {
+		return nil, err
+	}
+
+	signer, err := types.VerifySiweSignature(message, signature)
+	if err != nil || signer != m.Address {
+		return nil, types.ErrInvalidLoginSignature
+	}
+
+	ok, err := s.nonceDao.Consume(m.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, types.ErrInvalidAuthNonce
+	}
+
+	expiresAt := now.Add(s.sessionTTL)
+	if !m.ExpirationTime.IsZero() && m.ExpirationTime.Before(expiresAt) {
+		expiresAt = m.ExpirationTime
+	}
+
+	c := &types.SessionClaims{
+		Subject:   m.Address.Hex(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		ID:        m.Nonce,
+	}
+
+	token, err := types.NewSessionToken(c, s.secret)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &types.AuthSession{Token: token, Address: m.Address, ExpiresAt: time.Unix(c.ExpiresAt, 0)}, nil
+}
+
+// Authenticate returns the address of the session of a token
+func (s *AuthService) Authenticate(token string) (common.Address, error) {
+	c, err := types.ParseSessionToken(token, s.secret, time.Now())
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return common.HexToAddress(c.Subject), nil
+}
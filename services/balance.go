@@ -0,0 +1,420 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils"
+	"github.com/tomochain/tomox-sdk/utils/balances"
+	"github.com/tomochain/tomox-sdk/utils/math"
+)
+
+const (
+	// catchUpInterval is the interval of the catch ups of the transfers, which also advance the
+	// block of the cache while no transfer happens
+	catchUpInterval = 10 * time.Second
+
+	// reconcileInterval is the interval of the comparisons of the cached balances with the chain
+	reconcileInterval = 5 * time.Minute
+
+	// balanceIdleTTL is the time a balance stays in the cache without being read
+	balanceIdleTTL = time.Hour
+
+	// nativeBalanceTTL bounds the age of the native balances, which have no Transfer events
+	nativeBalanceTTL = 5 * time.Second
+
+	// listingCacheTTL is the time the tokens and the active pairs are cached
+	listingCacheTTL = 10 * time.Second
+
+	// maxCatchUpBlocks bounds the blocks of a catch up, the cache is cleared after a longer gap
+	maxCatchUpBlocks = 5000
+
+	balanceRPCTimeout = 10 * time.Second
+)
+
+// BalanceService caches the balances of the listed tokens. The token balances are updated with
+// the Transfer events of the tokens and periodically reconciled with the chain.
+type BalanceService struct {
+	client     interfaces.EthereumClient
+	tokenDao   interfaces.TokenDao
+	pairDao    interfaces.PairDao
+	orderDao   interfaces.OrderDao
+	lendingDao interfaces.LendingOrderDao
+	cache      *balances.Cache
+	notify     func(owner, token common.Address)
+	watched    func(owner common.Address) bool
+
+	tokens     []types.Token
+	pairs      []*types.Pair
+	listedAt   time.Time
+	listingMtx sync.Mutex
+}
+
+// NewBalanceService returns a new instance of BalanceService
+func NewBalanceService(
+	client interfaces.EthereumClient,
+	tokenDao interfaces.TokenDao,
+	pairDao interfaces.PairDao,
+	orderDao interfaces.OrderDao,
+	lendingDao interfaces.LendingOrderDao,
+) *BalanceService {
+	return &BalanceService{
+		client:     client,
+		tokenDao:   tokenDao,
+		pairDao:    pairDao,
+		orderDao:   orderDao,
+		lendingDao: lendingDao,
+		cache:      balances.NewCache(),
+	}
+}
+
+// RegisterBalanceNotify registers the function called with the cached balances which changed
+func (s *BalanceService) RegisterBalanceNotify(fn func(owner, token common.Address)) {
+	s.notify = fn
+}
+
+// RegisterBalanceWatch registers the function telling if the balances of an owner are watched,
+// they are then kept in the cache even when they are not read
+func (s *BalanceService) RegisterBalanceWatch(fn func(owner common.Address) bool) {
+	s.watched = fn
+}
+
+// Start positions the cache at the current block and follows the transfers of the tokens.
+// The cache starts empty, the balances are read from the chain on their first use, so there
+// are no transfers to catch up.
+func (s *BalanceService) Start() error {
+	head, err := s.head()
+	if err != nil {
+		return err
+	}
+
+	s.cache.Advance(head)
+	go s.follow()
+	return nil
+}
+
+// GetBalance returns the balance of a token of owner
+func (s *BalanceService) GetBalance(owner, token common.Address) (*big.Int, error) {
+	k := balances.Key{Owner: owner, Token: token}
+
+	maxAge := time.Duration(0)
+	if utils.IsNativeTokenByAddress(token) {
+		maxAge = nativeBalanceTTL
+	}
+
+	if b, ok := s.cache.Get(k, maxAge); ok {
+		return b, nil
+	}
+
+	var b *big.Int
+	block := s.cache.Block()
+
+	// retry in case the provider connection fell asleep
+	err := utils.Retry(3, func() error {
+		var err error
+		b, err = s.balanceAt(owner, token, block)
+		return err
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	s.cache.Put(k, b, block)
+	return b, nil
+}
+
+// GetTokenBalance returns the balance of a token of owner with its amount locked in the orders
+// and the lending orders, nil if the token is not listed
+func (s *BalanceService) GetTokenBalance(owner, token common.Address) (*types.TokenBalance, error) {
+	tokens, pairs, err := s.listing()
+	if err != nil {
+		return nil, err
+	}
+
+	t := types.TokensFrom(token, tokens)
+	if t == nil {
+		return nil, nil
+	}
+
+	b, err := s.GetBalance(owner, token)
+	if err != nil {
+		return nil, err
+	}
+
+	exchangeLocked, err := s.orderDao.GetUserLockedBalance(owner, token, pairs)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	lendingLocked, err := s.lendingDao.GetUserLockedBalance(owner, token, tokens)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	locked := new(big.Int).Add(exchangeLocked, lendingLocked)
+
+	return &types.TokenBalance{
+		Address:          token,
+		Symbol:           t.Symbol,
+		Decimals:         t.Decimals,
+		Balance:          b,
+		InOrderBalance:   locked,
+		AvailableBalance: math.Sub(b, locked),
+		InUsdBalance:     big.NewFloat(0),
+	}, nil
+}
+
+// listing returns the tokens and the active pairs, cached for listingCacheTTL
+func (s *BalanceService) listing() ([]types.Token, []*types.Pair, error) {
+	s.listingMtx.Lock()
+	defer s.listingMtx.Unlock()
+
+	if s.tokens != nil && time.Since(s.listedAt) < listingCacheTTL {
+		return s.tokens, s.pairs, nil
+	}
+
+	tokens, err := s.tokenDao.GetAll()
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	pairs, err := s.pairDao.GetActivePairs()
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	s.tokens = tokens
+	s.pairs = pairs
+	s.listedAt = time.Now()
+	return tokens, pairs, nil
+}
+
+// follow applies the transfers of the subscription and catches up the transfers missed while
+// the subscription was down. The subscription is renewed when the listed tokens change.
+func (s *BalanceService) follow() {
+	catchUp := time.NewTicker(catchUpInterval)
+	reconcile := time.NewTicker(reconcileInterval)
+	defer catchUp.Stop()
+	defer reconcile.Stop()
+
+	var sub ethereum.Subscription
+	var subErr <-chan error
+	var listed []common.Address
+	logs := make(chan eth.Log, 1024)
+
+	for {
+		tokens := s.tokenAddresses()
+		if len(tokens) > 0 && (sub == nil || !sameAddresses(tokens, listed)) {
+			if sub != nil {
+				sub.Unsubscribe()
+				sub = nil
+				subErr = nil
+			}
+
+			var err error
+			sub, err = s.client.SubscribeFilterLogs(context.Background(), s.query(tokens), logs)
+			if err != nil {
+				logger.Error(err)
+			} else {
+				subErr = sub.Err()
+				listed = tokens
+			}
+		}
+
+		select {
+		case l := <-logs:
+			s.apply(&l)
+		case err := <-subErr:
+			logger.Warningf("Transfer subscription failed: %v", err)
+			sub = nil
+			subErr = nil
+		case <-catchUp.C:
+			s.catchUp(tokens)
+		case <-reconcile.C:
+			s.reconcile()
+		}
+	}
+}
+
+// catchUp applies the transfers between the block of the cache and the head of the chain,
+// the transfers already applied by the subscription are ignored by the cache
+func (s *BalanceService) catchUp(tokens []common.Address) {
+	head, err := s.head()
+	if err != nil {
+		return
+	}
+
+	from := s.cache.Block()
+	if head <= from {
+		return
+	}
+
+	if head-from > maxCatchUpBlocks {
+		logger.Warningf("Balance cache is %d blocks behind, clearing it", head-from)
+		s.cache.Clear()
+		s.cache.Advance(head)
+		return
+	}
+
+	if len(tokens) > 0 {
+		q := s.query(tokens)
+		// the block of the position may be partially applied, its applied transfers are ignored
+		q.FromBlock = new(big.Int).SetUint64(from)
+		q.ToBlock = new(big.Int).SetUint64(head)
+
+		ctx, cancel := context.WithTimeout(context.Background(), balanceRPCTimeout)
+		logs, err := s.client.FilterLogs(ctx, q)
+		cancel()
+
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		for i := range logs {
+			s.apply(&logs[i])
+		}
+	}
+
+	s.cache.Advance(head)
+}
+
+func (s *BalanceService) apply(l *eth.Log) {
+	t, ok := balances.DecodeTransfer(l)
+	if !ok {
+		return
+	}
+
+	for _, k := range s.cache.Apply(t) {
+		if s.notify != nil {
+			s.notify(k.Owner, k.Token)
+		}
+	}
+}
+
+// reconcile reads again the cached balances at the block of the cache, the balances which
+// drifted from the chain, for example with the fees of TRC21 tokens, are replaced
+func (s *BalanceService) reconcile() {
+	block := s.cache.Block()
+	var keep func(balances.Key) bool
+	if s.watched != nil {
+		keep = func(k balances.Key) bool { return s.watched(k.Owner) }
+	}
+
+	keys := s.cache.Keys(balanceIdleTTL, keep)
+	drifted := 0
+
+	for _, k := range keys {
+		b, err := s.balanceAt(k.Owner, k.Token, block)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		cached, ok := s.cache.Get(k, 0)
+		if ok && cached.Cmp(b) == 0 {
+			continue
+		}
+
+		if !s.cache.Put(k, b, block) {
+			// transfers were applied meanwhile, the balance is read again on its next use
+			s.cache.Remove(k)
+		}
+
+		drifted++
+		if s.notify != nil {
+			s.notify(k.Owner, k.Token)
+		}
+	}
+
+	if drifted > 0 {
+		logger.Warningf("Reconciled %d of %d cached balances at block %d", drifted, len(keys), block)
+	}
+}
+
+// balanceAt reads a balance at block
+func (s *BalanceService) balanceAt(owner, token common.Address, block uint64) (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), balanceRPCTimeout)
+	defer cancel()
+
+	n := new(big.Int).SetUint64(block)
+	if utils.IsNativeTokenByAddress(token) {
+		return s.client.BalanceAt(ctx, owner, n)
+	}
+
+	res, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: balances.BalanceOfData(owner)}, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(res), nil
+}
+
+func (s *BalanceService) head() (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), balanceRPCTimeout)
+	defer cancel()
+
+	h, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return h.Number.Uint64(), nil
+}
+
+// tokenAddresses returns the listed tokens which have Transfer events
+func (s *BalanceService) tokenAddresses() []common.Address {
+	tokens, _, err := s.listing()
+	if err != nil {
+		return nil
+	}
+
+	res := []common.Address{}
+	for _, t := range tokens {
+		if !utils.IsNativeTokenByAddress(t.ContractAddress) {
+			res = append(res, t.ContractAddress)
+		}
+	}
+
+	return res
+}
+
+func (s *BalanceService) query(tokens []common.Address) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: tokens,
+		Topics:    [][]common.Hash{{balances.TransferTopic}},
+	}
+}
+
+func sameAddresses(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	set := make(map[common.Address]bool, len(a))
+	for _, addr := range a {
+		set[addr] = true
+	}
+
+	for _, addr := range b {
+		if !set[addr] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,327 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/rabbitmq"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/ws"
+)
+
+// leaderLease is the name of the lease held by the leader of the cluster
+const leaderLease = "leader"
+
+// Events published by the leader so that every instance computes the derived feeds
+// (depth, level 3 orderbook, indicators) for its own websocket subscribers
+const (
+	clusterOrderBookEvent        = "ORDERBOOK"
+	clusterLendingOrderBookEvent = "LENDING_ORDERBOOK"
+	clusterOrderChangeEvent      = "ORDER_CHANGE"
+	clusterTicksEvent            = "TICKS"
+	clusterTradesEvent           = "TRADES"
+	clusterEngineResponseEvent   = "ENGINE_RESPONSE"
+	clusterLendingTicksEvent     = "LENDING_TICKS"
+)
+
+type orderBookEvent struct {
+	BaseToken  common.Address      `json:"baseToken"`
+	QuoteToken common.Address      `json:"quoteToken"`
+	Bids       []map[string]string `json:"bids"`
+	Asks       []map[string]string `json:"asks"`
+}
+
+type lendingOrderBookEvent struct {
+	Term         uint64              `json:"term"`
+	LendingToken common.Address      `json:"lendingToken"`
+	Borrow       []map[string]string `json:"borrow"`
+	Lend         []map[string]string `json:"lend"`
+}
+
+type orderChangeEvent struct {
+	Order    *types.Order `json:"order"`
+	Sequence uint64       `json:"sequence"`
+}
+
+type ticksEvent struct {
+	Ticks    []*types.Tick `json:"ticks"`
+	Duration int64         `json:"duration"`
+	Unit     string        `json:"unit"`
+}
+
+type lendingTicksEvent struct {
+	Term         uint64               `json:"term"`
+	LendingToken common.Address       `json:"lendingToken"`
+	Ticks        []*types.LendingTick `json:"ticks"`
+	Duration     int64                `json:"duration"`
+	Unit         string               `json:"unit"`
+}
+
+// ClusterService runs the instance as a member of a cluster. A single instance, elected with
+// a lease in the database, runs the change streams and crons. The websocket messages and the
+// events of the leader are relayed to every instance through RabbitMQ fanout exchanges.
+type ClusterService struct {
+	leaseDao   interfaces.LeaseDao
+	rabbitConn *rabbitmq.Connection
+	instanceID string
+	leaseTTL   time.Duration
+
+	handlers map[string]func(json.RawMessage) error
+	mutex    sync.RWMutex
+}
+
+// NewClusterService returns a new instance of ClusterService
+func NewClusterService(
+	leaseDao interfaces.LeaseDao,
+	rabbitConn *rabbitmq.Connection,
+	instanceID string,
+	leaseTTL time.Duration,
+) *ClusterService {
+	return &ClusterService{
+		leaseDao:   leaseDao,
+		rabbitConn: rabbitConn,
+		instanceID: instanceID,
+		leaseTTL:   leaseTTL,
+		handlers:   make(map[string]func(json.RawMessage) error),
+	}
+}
+
+// Start subscribes to the messages of the cluster and relays the websocket messages of this
+// instance to the cluster
+func (s *ClusterService) Start() error {
+	err := s.rabbitConn.DeclareClusterExchanges()
+	if err != nil {
+		return err
+	}
+
+	err = s.rabbitConn.SubscribeRelayMessages(ws.HandleRelayMessage)
+	if err != nil {
+		return err
+	}
+
+	err = s.rabbitConn.SubscribeClusterEvents(s.handleEvent)
+	if err != nil {
+		return err
+	}
+
+	ws.EnableRelay(s.rabbitConn.PublishRelayMessage)
+	return nil
+}
+
+// RunAsLeader waits until this instance is elected leader then calls fn. The lease is renewed
+// every third of its ttl. The change streams and crons can not be stopped, so an instance losing
+// its lease exits to let another instance take over without running them twice.
+func (s *ClusterService) RunAsLeader(fn func()) {
+	ticker := time.NewTicker(s.leaseTTL / 3)
+	defer ticker.Stop()
+
+	leader := false
+	renewedAt := time.Now()
+
+	for {
+		ok, err := s.leaseDao.Acquire(leaderLease, s.instanceID, s.leaseTTL)
+		if err != nil {
+			logger.Error(err)
+		}
+
+		switch {
+		case ok && !leader:
+			logger.Infof("Instance %v is elected leader", s.instanceID)
+			leader = true
+			renewedAt = time.Now()
+			fn()
+		case ok:
+			renewedAt = time.Now()
+		case leader && (err == nil || time.Since(renewedAt) > s.leaseTTL):
+			logger.Errorf("Instance %v lost the leadership, exiting", s.instanceID)
+			os.Exit(1)
+		}
+
+		<-ticker.C
+	}
+}
+
+// Stop releases the lease of the leader if it is held by this instance
+func (s *ClusterService) Stop() error {
+	return s.leaseDao.Release(leaderLease, s.instanceID)
+}
+
+// OrderBookNotify relays the orderbook updates of the leader to fn on every instance
+func (s *ClusterService) OrderBookNotify(fn func(bt, qt common.Address, bids, asks []map[string]string)) func(bt, qt common.Address, bids, asks []map[string]string) {
+	s.register(clusterOrderBookEvent, func(data json.RawMessage) error {
+		e := &orderBookEvent{}
+		if err := json.Unmarshal(data, e); err != nil {
+			return err
+		}
+
+		fn(e.BaseToken, e.QuoteToken, e.Bids, e.Asks)
+		return nil
+	})
+
+	return func(bt, qt common.Address, bids, asks []map[string]string) {
+		if !s.publish(clusterOrderBookEvent, &orderBookEvent{bt, qt, bids, asks}) {
+			fn(bt, qt, bids, asks)
+		}
+	}
+}
+
+// LendingOrderBookNotify relays the lending orderbook updates of the leader to fn on every instance
+func (s *ClusterService) LendingOrderBookNotify(fn func(term uint64, lendingToken common.Address, borrow, lend []map[string]string)) func(term uint64, lendingToken common.Address, borrow, lend []map[string]string) {
+	s.register(clusterLendingOrderBookEvent, func(data json.RawMessage) error {
+		e := &lendingOrderBookEvent{}
+		if err := json.Unmarshal(data, e); err != nil {
+			return err
+		}
+
+		fn(e.Term, e.LendingToken, e.Borrow, e.Lend)
+		return nil
+	})
+
+	return func(term uint64, lendingToken common.Address, borrow, lend []map[string]string) {
+		if !s.publish(clusterLendingOrderBookEvent, &lendingOrderBookEvent{term, lendingToken, borrow, lend}) {
+			fn(term, lendingToken, borrow, lend)
+		}
+	}
+}
+
+// OrderChangeNotify relays the orders received from the change stream of the leader and the
+// sequences of their updates to fn on every instance
+func (s *ClusterService) OrderChangeNotify(fn func(o *types.Order, sequence uint64)) func(o *types.Order, sequence uint64) {
+	s.register(clusterOrderChangeEvent, func(data json.RawMessage) error {
+		e := &orderChangeEvent{}
+		if err := json.Unmarshal(data, e); err != nil {
+			return err
+		}
+
+		fn(e.Order, e.Sequence)
+		return nil
+	})
+
+	return func(o *types.Order, sequence uint64) {
+		if !s.publish(clusterOrderChangeEvent, &orderChangeEvent{Order: o, Sequence: sequence}) {
+			fn(o, sequence)
+		}
+	}
+}
+
+// TickNotify relays the ticks broadcast by the leader to fn on every instance
+func (s *ClusterService) TickNotify(fn func(ticks []*types.Tick, duration int64, unit string)) func(ticks []*types.Tick, duration int64, unit string) {
+	s.register(clusterTicksEvent, func(data json.RawMessage) error {
+		e := &ticksEvent{}
+		if err := json.Unmarshal(data, e); err != nil {
+			return err
+		}
+
+		fn(e.Ticks, e.Duration, e.Unit)
+		return nil
+	})
+
+	return func(ticks []*types.Tick, duration int64, unit string) {
+		if !s.publish(clusterTicksEvent, &ticksEvent{ticks, duration, unit}) {
+			fn(ticks, duration, unit)
+		}
+	}
+}
+
+// TradeNotify relays the trades broadcast by the leader to fn on every instance
+func (s *ClusterService) TradeNotify(fn func(trades []*types.Trade)) func(trades []*types.Trade) {
+	s.register(clusterTradesEvent, func(data json.RawMessage) error {
+		trades := []*types.Trade{}
+		if err := json.Unmarshal(data, &trades); err != nil {
+			return err
+		}
+
+		fn(trades)
+		return nil
+	})
+
+	return func(trades []*types.Trade) {
+		if !s.publish(clusterTradesEvent, trades) {
+			fn(trades)
+		}
+	}
+}
+
+// EngineResponseNotify relays the engine responses handled by the leader to fn on every instance
+func (s *ClusterService) EngineResponseNotify(fn func(*types.EngineResponse)) func(*types.EngineResponse) {
+	s.register(clusterEngineResponseEvent, func(data json.RawMessage) error {
+		res := &types.EngineResponse{}
+		if err := json.Unmarshal(data, res); err != nil {
+			return err
+		}
+
+		fn(res)
+		return nil
+	})
+
+	return func(res *types.EngineResponse) {
+		if !s.publish(clusterEngineResponseEvent, res) {
+			fn(res)
+		}
+	}
+}
+
+// LendingTickNotify relays the lending ticks broadcast by the leader to fn on every instance
+func (s *ClusterService) LendingTickNotify(fn func(term uint64, lendingToken common.Address, ticks []*types.LendingTick, duration int64, unit string)) func(term uint64, lendingToken common.Address, ticks []*types.LendingTick, duration int64, unit string) {
+	s.register(clusterLendingTicksEvent, func(data json.RawMessage) error {
+		e := &lendingTicksEvent{}
+		if err := json.Unmarshal(data, e); err != nil {
+			return err
+		}
+
+		fn(e.Term, e.LendingToken, e.Ticks, e.Duration, e.Unit)
+		return nil
+	})
+
+	return func(term uint64, lendingToken common.Address, ticks []*types.LendingTick, duration int64, unit string) {
+		if !s.publish(clusterLendingTicksEvent, &lendingTicksEvent{term, lendingToken, ticks, duration, unit}) {
+			fn(term, lendingToken, ticks, duration, unit)
+		}
+	}
+}
+
+func (s *ClusterService) register(eventType string, fn func(json.RawMessage) error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.handlers[eventType] = fn
+}
+
+// publish sends an event to the cluster, it returns false if the event has to be handled locally
+func (s *ClusterService) publish(eventType string, v interface{}) bool {
+	b, err := json.Marshal(v)
+	if err != nil {
+		logger.Error(err)
+		return false
+	}
+
+	err = s.rabbitConn.PublishClusterEvent(&types.ClusterEvent{Type: eventType, Data: json.RawMessage(b)})
+	if err != nil {
+		logger.Error(err)
+		return false
+	}
+
+	return true
+}
+
+func (s *ClusterService) handleEvent(e *types.ClusterEvent) error {
+	s.mutex.RLock()
+	fn := s.handlers[e.Type]
+	s.mutex.RUnlock()
+
+	if fn == nil {
+		logger.Warning("Unknown cluster event ", e.Type)
+		return nil
+	}
+
+	err := fn(e.Data)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// complianceSweepInterval is the interval of the removals of the expired entries
+const complianceSweepInterval = time.Minute
+
+// ComplianceService manages the addresses blocked or restricted by the relayer and checks the
+// orders against them. The lookups fail closed: an order is refused if its address cannot be
+// checked. Every block, unblock and expiry is written to the audit log.
+type ComplianceService struct {
+	complianceDao interfaces.ComplianceDao
+	auditDao      interfaces.ComplianceAuditDao
+}
+
+// NewComplianceService returns a new instance of ComplianceService
+func NewComplianceService(
+	complianceDao interfaces.ComplianceDao,
+	auditDao interfaces.ComplianceAuditDao,
+) *ComplianceService {
+	return &ComplianceService{
+		complianceDao: complianceDao,
+		auditDao:      auditDao,
+	}
+}
+
+// Start removes the expired entries periodically
+func (s *ComplianceService) Start() {
+	go func() {
+		ticker := time.NewTicker(complianceSweepInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.SweepExpired()
+		}
+	}()
+}
+
+// SweepExpired removes the expired entries and writes their expiry to the audit log
+func (s *ComplianceService) SweepExpired() {
+	entries, err := s.complianceDao.GetExpired(time.Now())
+	if err != nil {
+		return
+	}
+
+	for _, e := range entries {
+		s.expire(e)
+	}
+}
+
+// expire removes an expired entry and writes its expiry to the audit log, unless the entry
+// was already removed, for example by another instance
+func (s *ComplianceService) expire(e *types.ComplianceEntry) {
+	ok, err := s.complianceDao.DeleteExpired(e)
+	if err != nil || !ok {
+		return
+	}
+
+	err = s.auditDao.Create(&types.ComplianceAuditEntry{
+		Address:   e.Address,
+		Action:    types.ComplianceActionExpire,
+		Status:    e.Status,
+		Reason:    e.Reason,
+		ExpiresAt: e.ExpiresAt,
+		Source:    types.ComplianceSourceExpiry,
+	})
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// getEntry returns the entry of an address, nil if it has none. An expired entry is removed
+// the first time it is seen, and nil is returned.
+func (s *ComplianceService) getEntry(addr common.Address) (*types.ComplianceEntry, error) {
+	e, err := s.complianceDao.GetByAddress(addr)
+	if err != nil || e == nil {
+		return e, err
+	}
+
+	if !e.IsActive(time.Now()) {
+		s.expire(e)
+		return nil, nil
+	}
+
+	return e, nil
+}
+
+// CheckOpen returns an error if an address is not allowed to send new orders
+func (s *ComplianceService) CheckOpen(addr common.Address) error {
+	e, err := s.getEntry(addr)
+	if err != nil {
+		return err
+	}
+
+	return e.CheckOpen(time.Now())
+}
+
+// CheckClose returns an error if an address is not allowed to cancel its orders or to repay
+// and top up its loans
+func (s *ComplianceService) CheckClose(addr common.Address) error {
+	e, err := s.getEntry(addr)
+	if err != nil {
+		return err
+	}
+
+	return e.CheckClose(time.Now())
+}
+
+// IsBlocked returns true if an address is blocked
+func (s *ComplianceService) IsBlocked(addr common.Address) bool {
+	e, err := s.getEntry(addr)
+	if err != nil || e == nil {
+		return false
+	}
+
+	return e.Status == types.ComplianceBlocked && e.IsActive(time.Now())
+}
+
+// GetAll returns the blocked and restricted addresses
+func (s *ComplianceService) GetAll() ([]*types.ComplianceEntry, error) {
+	return s.complianceDao.GetAll()
+}
+
+// GetByAddress returns the entry of an address, nil if it is neither blocked nor restricted
+func (s *ComplianceService) GetByAddress(addr common.Address) (*types.ComplianceEntry, error) {
+	return s.getEntry(addr)
+}
+
+// Block blocks or restricts an address, replacing its current entry
+func (s *ComplianceService) Block(e *types.ComplianceEntry, source string) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	if !e.IsActive(time.Now()) {
+		return errors.New("Expiration time must be in the future")
+	}
+
+	current, err := s.getEntry(e.Address)
+	if err != nil {
+		return err
+	}
+
+	e.CreatedAt = time.Time{}
+	if current != nil {
+		e.CreatedAt = current.CreatedAt
+	}
+
+	err = s.complianceDao.Upsert(e)
+	if err != nil {
+		return err
+	}
+
+	return s.auditDao.Create(&types.ComplianceAuditEntry{
+		Address:   e.Address,
+		Action:    types.ComplianceActionBlock,
+		Status:    e.Status,
+		Reason:    e.Reason,
+		ExpiresAt: e.ExpiresAt,
+		Source:    source,
+	})
+}
+
+// Unblock removes the entry of an address, it returns false if the address has none
+func (s *ComplianceService) Unblock(addr common.Address, reason string, source string) (bool, error) {
+	ok, err := s.complianceDao.Delete(addr)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	err = s.auditDao.Create(&types.ComplianceAuditEntry{
+		Address: addr,
+		Action:  types.ComplianceActionUnblock,
+		Reason:  reason,
+		Source:  source,
+	})
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// Import blocks or restricts the addresses of a CSV file, nothing is imported if one of its
+// lines is invalid
+func (s *ComplianceService) Import(r io.Reader) ([]*types.ComplianceEntry, error) {
+	entries, err := types.ParseComplianceCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i, e := range entries {
+		if !e.IsActive(now) {
+			return nil, errors.Errorf("Line %d: expiration time must be in the future", i+2)
+		}
+	}
+
+	for _, e := range entries {
+		err := s.Block(e, types.ComplianceSourceCSV)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// GetAuditLog returns the audit log of an address, of all addresses if addr is the zero address
+func (s *ComplianceService) GetAuditLog(addr common.Address, offset, size int) (*types.ComplianceAuditRes, error) {
+	return s.auditDao.Query(addr, offset, size)
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/fx"
+)
+
+// ErrUnsupportedCurrency is returned when a value is requested in a currency that is not configured
+var ErrUnsupportedCurrency = errors.New("Unsupported currency")
+
+// ErrRateUnavailable is returned when a value is requested in a configured currency whose
+// exchange rate was not loaded yet from the fx source
+var ErrRateUnavailable = errors.New("Exchange rate unavailable")
+
+// FiatService converts the USD values computed by the exchange into the configured fiat currencies
+type FiatService struct {
+	source     fx.Source
+	currencies []string
+	rates      map[string]*big.Float
+	mutex      sync.RWMutex
+}
+
+// NewFiatService returns a new instance of FiatService
+func NewFiatService(source fx.Source, currencies []string) *FiatService {
+	list := []string{fx.BaseCurrency}
+	for _, c := range currencies {
+		c = strings.ToUpper(c)
+		if c != fx.BaseCurrency {
+			list = append(list, c)
+		}
+	}
+
+	return &FiatService{
+		source:     source,
+		currencies: list,
+		rates:      map[string]*big.Float{fx.BaseCurrency: big.NewFloat(1)},
+	}
+}
+
+// Refresh reloads the exchange rates from the fx source
+func (s *FiatService) Refresh() error {
+	rates, err := s.source.Rates()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, c := range s.currencies {
+		if r, ok := rates[c]; ok && r > 0 {
+			s.rates[c] = big.NewFloat(r)
+		}
+	}
+
+	return nil
+}
+
+// Currencies returns the list of supported currencies, the base currency first
+func (s *FiatService) Currencies() []string {
+	return s.currencies
+}
+
+// DefaultCurrency returns the currency used when none is requested
+func (s *FiatService) DefaultCurrency() string {
+	return fx.BaseCurrency
+}
+
+// IsSupported returns true if the currency is configured, its rate may not be loaded yet
+func (s *FiatService) IsSupported(currency string) bool {
+	currency = strings.ToUpper(currency)
+	for _, c := range s.currencies {
+		if c == currency {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Convert converts an USD value into the requested currency, it returns ErrRateUnavailable if
+// the rate of the currency was not loaded yet
+func (s *FiatService) Convert(usd *big.Float, currency string) (*big.Float, error) {
+	if currency == "" {
+		currency = fx.BaseCurrency
+	}
+
+	if !s.IsSupported(currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	s.mutex.RLock()
+	rate, ok := s.rates[strings.ToUpper(currency)]
+	s.mutex.RUnlock()
+
+	if !ok {
+		return nil, ErrRateUnavailable
+	}
+
+	if usd == nil {
+		return big.NewFloat(0), nil
+	}
+
+	return new(big.Float).Mul(usd, rate), nil
+}
+
+// ConvertPairData sets the fiat close price of each pair data in the requested currency
+func (s *FiatService) ConvertPairData(pairData []*types.PairData, currency string) error {
+	for _, p := range pairData {
+		price, err := s.Convert(p.CloseBaseUsd, currency)
+		if err != nil {
+			return err
+		}
+
+		p.CloseBaseFiat = price
+		p.FiatCurrency = strings.ToUpper(currency)
+	}
+
+	return nil
+}
+
+// ConvertFiatPriceChart returns a copy of the small charts data valued in the requested currency
+func (s *FiatService) ConvertFiatPriceChart(chart map[string][]*types.FiatPriceItem, currency string) (map[string][]*types.FiatPriceItem, error) {
+	res := make(map[string][]*types.FiatPriceItem)
+	for symbol, items := range chart {
+		converted := make([]*types.FiatPriceItem, 0, len(items))
+		for _, i := range items {
+			usd, ok := new(big.Float).SetString(i.Price)
+			if !ok {
+				continue
+			}
+
+			price, err := s.Convert(usd, currency)
+			if err != nil {
+				return nil, err
+			}
+
+			converted = append(converted, &types.FiatPriceItem{
+				Symbol:       i.Symbol,
+				Price:        price.String(),
+				Timestamp:    i.Timestamp,
+				FiatCurrency: strings.ToUpper(currency),
+				TotalVolume:  i.TotalVolume,
+			})
+		}
+
+		res[symbol] = converted
+	}
+
+	return res, nil
+}
+
+// ConvertTokenBalances sets the fiat value of each token balance in the requested currency
+func (s *FiatService) ConvertTokenBalances(balances map[common.Address]*types.TokenBalance, currency string) error {
+	for _, b := range balances {
+		if b == nil {
+			continue
+		}
+
+		value, err := s.Convert(b.InUsdBalance, currency)
+		if err != nil {
+			return err
+		}
+
+		b.InFiatBalance = value
+		b.FiatCurrency = strings.ToUpper(currency)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomochain/tomox-sdk/errors"
+)
+
+type ratesSource struct {
+	rates map[string]float64
+}
+
+func (s *ratesSource) Rates() (map[string]float64, error) {
+	if s.rates == nil {
+		return nil, errors.New("fx source is down")
+	}
+
+	return s.rates, nil
+}
+
+func TestFiatServiceRateUnavailable(t *testing.T) {
+	source := &ratesSource{}
+	s := NewFiatService(source, []string{"eur"})
+
+	// the configured currencies are supported before their rates are loaded
+	assert.NotNil(t, s.Refresh())
+	assert.True(t, s.IsSupported("EUR"))
+	assert.False(t, s.IsSupported("JPY"))
+
+	_, err := s.Convert(big.NewFloat(2), "EUR")
+	assert.Equal(t, ErrRateUnavailable, err)
+
+	_, err = s.Convert(big.NewFloat(2), "JPY")
+	assert.Equal(t, ErrUnsupportedCurrency, err)
+
+	source.rates = map[string]float64{"EUR": 0.5}
+	assert.Nil(t, s.Refresh())
+
+	v, err := s.Convert(big.NewFloat(2), "eur")
+	assert.Nil(t, err)
+	assert.Equal(t, "1", v.String())
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// FixAccountService manages the accounts of the FIX sessions and their delegated keys. The keys
+// are encrypted with the passphrase of the operator and only decrypted in memory on logon.
+type FixAccountService struct {
+	fixAccountDao interfaces.FixAccountDao
+	passphrase    string
+}
+
+// NewFixAccountService returns a new instance of FixAccountService, passphrase encrypts the
+// delegated keys of the accounts
+func NewFixAccountService(fixAccountDao interfaces.FixAccountDao, passphrase string) *FixAccountService {
+	return &FixAccountService{fixAccountDao, passphrase}
+}
+
+// Create creates the account of a FIX session with a new delegated key. The orders of the
+// session are placed by the address of the key, which has to be funded by the client.
+func (s *FixAccountService) Create(senderCompID, password string) (*types.FixAccount, error) {
+	if s.passphrase == "" {
+		return nil, errors.New("FIX keystore password is not configured")
+	}
+
+	if senderCompID == "" || password == "" {
+		return nil, errors.New("senderCompId and password are required")
+	}
+
+	a, err := s.fixAccountDao.GetBySenderCompID(senderCompID)
+	if err != nil {
+		return nil, err
+	}
+
+	if a != nil {
+		return nil, errors.New("FIX account already exists")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	key := &keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	encrypted, err := keystore.EncryptKey(key, s.passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	a = &types.FixAccount{
+		SenderCompID: senderCompID,
+		PasswordHash: string(hash),
+		Address:      key.Address,
+		Keystore:     string(encrypted),
+	}
+
+	err = s.fixAccountDao.Create(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// GetAll returns the accounts of the FIX sessions
+func (s *FixAccountService) GetAll() ([]*types.FixAccount, error) {
+	return s.fixAccountDao.GetAll()
+}
+
+// Delete removes the account of a FIX session with its delegated key
+func (s *FixAccountService) Delete(senderCompID string) error {
+	return s.fixAccountDao.Delete(senderCompID)
+}
+
+// Authenticate checks the credentials of a FIX logon and returns the account with its delegated key
+func (s *FixAccountService) Authenticate(senderCompID, password string) (*types.FixAccount, *types.Wallet, error) {
+	a, err := s.fixAccountDao.GetBySenderCompID(senderCompID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if a == nil || bcrypt.CompareHashAndPassword([]byte(a.PasswordHash), []byte(password)) != nil {
+		return nil, nil, errors.New("Invalid credentials")
+	}
+
+	if a.Keystore == "" {
+		return nil, nil, errors.New("Delegated key not found")
+	}
+
+	key, err := keystore.DecryptKey([]byte(a.Keystore), s.passphrase)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, errors.New("Delegated key cannot be decrypted")
+	}
+
+	return a, &types.Wallet{Address: key.Address, PrivateKey: key.PrivateKey}, nil
+}
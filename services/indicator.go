@@ -0,0 +1,309 @@
+package services
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils"
+	"github.com/tomochain/tomox-sdk/utils/indicators"
+	"github.com/tomochain/tomox-sdk/ws"
+)
+
+// indicatorDecimals is the number of decimals of the indicator values sent to clients
+const indicatorDecimals = 8
+
+// IndicatorService computes technical indicators over the spot and lending candles
+type IndicatorService struct {
+	ohlcvService        interfaces.OHLCVService
+	lendingOhlcvService interfaces.LendingOhlcvService
+
+	// subscriptions maps a tick channel to the indicator channels built on top of it
+	subscriptions        map[string]map[string][]types.IndicatorSpec
+	lendingSubscriptions map[string]map[string][]types.IndicatorSpec
+	mutex                sync.RWMutex
+}
+
+// NewIndicatorService returns a new instance of IndicatorService
+func NewIndicatorService(
+	ohlcvService interfaces.OHLCVService,
+	lendingOhlcvService interfaces.LendingOhlcvService,
+) *IndicatorService {
+	return &IndicatorService{
+		ohlcvService:         ohlcvService,
+		lendingOhlcvService:  lendingOhlcvService,
+		subscriptions:        make(map[string]map[string][]types.IndicatorSpec),
+		lendingSubscriptions: make(map[string]map[string][]types.IndicatorSpec),
+	}
+}
+
+// GetIndicators returns the indicators of a pair between from and to, the candles needed
+// to warm up the indicators are fetched before from
+func (s *IndicatorService) GetIndicators(bt, qt common.Address, duration int64, unit string, from, to int64, specs []types.IndicatorSpec) ([]*types.IndicatorSeries, error) {
+	ticks, err := s.getTicks(bt, qt, duration, unit, warmUpStart(from, duration, unit, specs), to)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeIndicators(ticksToCandles(ticks), specs, from*1000), nil
+}
+
+// GetLendingIndicators returns the indicators of the interest rate of a lending pair between from and to
+func (s *IndicatorService) GetLendingIndicators(term uint64, lendingToken common.Address, duration int64, unit string, from, to int64, specs []types.IndicatorSpec) ([]*types.IndicatorSeries, error) {
+	ticks, err := s.lendingOhlcvService.GetOHLCV(term, lendingToken, duration, unit, warmUpStart(from, duration, unit, specs), to)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeIndicators(lendingTicksToCandles(ticks), specs, from*1000), nil
+}
+
+// Subscribe sends the ticks and indicators of a pair and registers the connection for updates
+func (s *IndicatorService) Subscribe(c *ws.Client, p *types.SubscriptionPayload, specs []types.IndicatorSpec) error {
+	socket := ws.GetOHLCVSocket()
+
+	ticks, err := s.getTicks(p.BaseToken, p.QuoteToken, p.Duration, p.Units, warmUpStart(p.From, p.Duration, p.Units, specs), p.To)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	tickID := utils.GetOHLCVChannelID(p.BaseToken, p.QuoteToken, p.Units, p.Duration)
+	id := utils.GetIndicatorChannelID(tickID, types.IndicatorSpecsKey(specs))
+	err = socket.Subscribe(id, c)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	s.register(s.subscriptions, tickID, id, specs)
+	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
+
+	res := make([]*types.Tick, 0)
+	for _, t := range ticks {
+		if t.Timestamp >= p.From*1000 {
+			res = append(res, t)
+		}
+	}
+
+	socket.SendInitMessage(c, &types.OHLCVIndicatorData{
+		Ticks:      res,
+		Indicators: computeIndicators(ticksToCandles(ticks), specs, p.From*1000),
+	})
+	return nil
+}
+
+// SubscribeLending sends the interest rate ticks and indicators of a lending pair and registers the connection for updates
+func (s *IndicatorService) SubscribeLending(c *ws.Client, p *types.SubscriptionPayload, specs []types.IndicatorSpec) error {
+	socket := ws.GetLendingOhlcvSocket()
+
+	ticks, err := s.lendingOhlcvService.GetOHLCV(p.Term, p.LendingToken, p.Duration, p.Units, warmUpStart(p.From, p.Duration, p.Units, specs), p.To)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	tickID := utils.GetLendingOhlcvChannelID(p.Term, p.LendingToken, p.Units, p.Duration)
+	id := utils.GetIndicatorChannelID(tickID, types.IndicatorSpecsKey(specs))
+	err = socket.Subscribe(id, c)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	s.register(s.lendingSubscriptions, tickID, id, specs)
+	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
+
+	res := make([]*types.LendingTick, 0)
+	for _, t := range ticks {
+		if t.Timestamp >= p.From*1000 {
+			res = append(res, t)
+		}
+	}
+
+	socket.SendInitMessage(c, &types.OHLCVIndicatorData{
+		Ticks:      res,
+		Indicators: computeIndicators(lendingTicksToCandles(ticks), specs, p.From*1000),
+	})
+	return nil
+}
+
+// NotifyTicks broadcasts the updated ticks with the latest indicator values to the indicator
+// subscribers, the candles of a pair are fetched once for all its indicator channels
+func (s *IndicatorService) NotifyTicks(ticks []*types.Tick, duration int64, unit string) {
+	socket := ws.GetOHLCVSocket()
+	for _, tick := range ticks {
+		bt, qt := tick.Pair.BaseToken, tick.Pair.QuoteToken
+		tickID := utils.GetOHLCVChannelID(bt, qt, unit, duration)
+		channels := s.channels(s.subscriptions, tickID, socket.HasSubscriptions)
+		if len(channels) == 0 {
+			continue
+		}
+
+		from := time.Now().Unix()
+		history, err := s.getTicks(bt, qt, duration, unit, warmUpStart(from, duration, unit, channelsSpecs(channels)), 0)
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		candles := ticksToCandles(history)
+		for id, specs := range channels {
+			series := computeIndicators(candles, specs, tick.Timestamp)
+			socket.DeliverMessage(id, &types.OHLCVIndicatorData{Ticks: tick, Indicators: series})
+		}
+	}
+}
+
+// NotifyLendingTicks broadcasts the updated interest rate ticks with the latest indicator values
+// to the indicator subscribers, the candles are fetched once for all the indicator channels
+func (s *IndicatorService) NotifyLendingTicks(term uint64, lendingToken common.Address, ticks []*types.LendingTick, duration int64, unit string) {
+	socket := ws.GetLendingOhlcvSocket()
+	tickID := utils.GetLendingOhlcvChannelID(term, lendingToken, unit, duration)
+	channels := s.channels(s.lendingSubscriptions, tickID, socket.HasSubscriptions)
+	if len(channels) == 0 {
+		return
+	}
+
+	from := time.Now().Unix()
+	history, err := s.lendingOhlcvService.GetOHLCV(term, lendingToken, duration, unit, warmUpStart(from, duration, unit, channelsSpecs(channels)), from)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	candles := lendingTicksToCandles(history)
+	for id, specs := range channels {
+		for _, tick := range ticks {
+			series := computeIndicators(candles, specs, tick.Timestamp)
+			socket.DeliverMessage(id, &types.OHLCVIndicatorData{Ticks: tick, Indicators: series})
+		}
+	}
+}
+
+// channelsSpecs returns the indicators of all the channels
+func channelsSpecs(channels map[string][]types.IndicatorSpec) []types.IndicatorSpec {
+	res := make([]types.IndicatorSpec, 0)
+	for _, specs := range channels {
+		res = append(res, specs...)
+	}
+
+	return res
+}
+
+func (s *IndicatorService) getTicks(bt, qt common.Address, duration int64, unit string, from, to int64) ([]*types.Tick, error) {
+	if to == 0 {
+		to = time.Now().Unix()
+	}
+
+	return s.ohlcvService.GetOHLCV([]types.PairAddresses{{BaseToken: bt, QuoteToken: qt}}, duration, unit, from, to)
+}
+
+func (s *IndicatorService) register(subscriptions map[string]map[string][]types.IndicatorSpec, tickID, id string, specs []types.IndicatorSpec) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if subscriptions[tickID] == nil {
+		subscriptions[tickID] = make(map[string][]types.IndicatorSpec)
+	}
+
+	subscriptions[tickID][id] = specs
+}
+
+// channels returns the indicator channels of a tick channel which still have subscribers,
+// the others are removed from the registry
+func (s *IndicatorService) channels(subscriptions map[string]map[string][]types.IndicatorSpec, tickID string, active func(string) bool) map[string][]types.IndicatorSpec {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	res := make(map[string][]types.IndicatorSpec)
+	for id, specs := range subscriptions[tickID] {
+		if !active(id) {
+			delete(subscriptions[tickID], id)
+			continue
+		}
+
+		res[id] = specs
+	}
+
+	if len(subscriptions[tickID]) == 0 {
+		delete(subscriptions, tickID)
+	}
+
+	return res
+}
+
+// warmUpStart returns the start of the candles needed so that the indicators have a value at from
+func warmUpStart(from, duration int64, unit string, specs []types.IndicatorSpec) int64 {
+	_, interval := utils.GetModTime(from, duration, unit)
+	return from - int64(types.IndicatorsLookback(specs))*interval
+}
+
+func ticksToCandles(ticks []*types.Tick) []indicators.Candle {
+	candles := make([]indicators.Candle, len(ticks))
+	for i, t := range ticks {
+		candles[i] = indicators.NewCandle(t.Timestamp, t.High, t.Low, t.Close, t.Volume)
+	}
+
+	return candles
+}
+
+func lendingTicksToCandles(ticks []*types.LendingTick) []indicators.Candle {
+	candles := make([]indicators.Candle, len(ticks))
+	for i, t := range ticks {
+		high := new(big.Int).SetUint64(t.High)
+		low := new(big.Int).SetUint64(t.Low)
+		closePrice := new(big.Int).SetUint64(t.Close)
+		candles[i] = indicators.NewCandle(t.Timestamp, high, low, closePrice, t.Volume)
+	}
+
+	return candles
+}
+
+// computeIndicators computes the indicators over the candles and returns the points from the given timestamp (in milliseconds)
+func computeIndicators(candles []indicators.Candle, specs []types.IndicatorSpec, from int64) []*types.IndicatorSeries {
+	closes := indicators.Closes(candles)
+	res := make([]*types.IndicatorSeries, 0, len(specs))
+	for _, spec := range specs {
+		lines := make(map[string][]*big.Rat)
+		switch spec.Name {
+		case types.IndicatorSMA:
+			lines["value"] = indicators.SMA(closes, spec.Period)
+		case types.IndicatorEMA:
+			lines["value"] = indicators.EMA(closes, spec.Period)
+		case types.IndicatorRSI:
+			lines["value"] = indicators.RSI(closes, spec.Period)
+		case types.IndicatorMACD:
+			lines["macd"], lines["signal"], lines["histogram"] = indicators.MACD(closes, spec.Fast, spec.Slow, spec.Signal)
+		case types.IndicatorBollinger:
+			lines["middle"], lines["upper"], lines["lower"] = indicators.BollingerBands(closes, spec.Period, spec.Multiplier)
+		case types.IndicatorVWAP:
+			lines["value"] = indicators.VWAP(candles)
+		}
+
+		series := &types.IndicatorSeries{Indicator: spec.String(), Points: []*types.IndicatorPoint{}}
+		for i, c := range candles {
+			if c.Timestamp < from {
+				continue
+			}
+
+			values := make(map[string]string)
+			for key, line := range lines {
+				if line[i] != nil {
+					values[key] = line[i].FloatString(indicatorDecimals)
+				}
+			}
+
+			if len(values) > 0 {
+				series.Points = append(series.Points, &types.IndicatorPoint{Timestamp: c.Timestamp, Values: values})
+			}
+		}
+
+		res = append(res, series)
+	}
+
+	return res
+}
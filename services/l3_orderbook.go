@@ -0,0 +1,156 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils"
+	"github.com/tomochain/tomox-sdk/ws"
+)
+
+// L3OrderBookService maintains the per order books of the pairs requested by clients
+// and streams the changes of their resting orders
+type L3OrderBookService struct {
+	pairDao  interfaces.PairDao
+	orderDao interfaces.OrderDao
+
+	// key anonymizes the order hashes so ids can not be linked to the orders on chain, it is
+	// shared by the instances so they report the same ids
+	key   []byte
+	books map[string]*types.L3Book
+	mutex sync.Mutex
+
+	// sequence is the sequence of the last order update, the books loaded include the updates
+	// up to it
+	sequence uint64
+}
+
+// NewL3OrderBookService returns a new instance of L3OrderBookService, key anonymizes the order hashes
+func NewL3OrderBookService(
+	pairDao interfaces.PairDao,
+	orderDao interfaces.OrderDao,
+	key []byte,
+) *L3OrderBookService {
+	return &L3OrderBookService{
+		pairDao:  pairDao,
+		orderDao: orderDao,
+		key:      key,
+		books:    make(map[string]*types.L3Book),
+	}
+}
+
+// GetL3OrderBook returns a snapshot of the resting orders of a pair
+func (s *L3OrderBookService) GetL3OrderBook(bt, qt common.Address) (*types.L3OrderBook, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	book, err := s.getBook(bt, qt)
+	if err != nil {
+		return nil, err
+	}
+
+	return book.Snapshot(), nil
+}
+
+// SubscribeL3OrderBook sends the snapshot of the resting orders of a pair and registers the connection for its events
+func (s *L3OrderBookService) SubscribeL3OrderBook(c *ws.Client, bt, qt common.Address) error {
+	socket := ws.GetL3OrderBookSocket()
+
+	// the snapshot is taken and the connection registered under the lock so no event is missed
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	book, err := s.getBook(bt, qt)
+	if err != nil {
+		return err
+	}
+
+	id := utils.GetOrderBookChannelID(bt, qt)
+	err = socket.Subscribe(id, c)
+	if err != nil {
+		return err
+	}
+
+	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
+	socket.SendInitMessage(c, book.Snapshot())
+	return nil
+}
+
+// UnsubscribeL3OrderBook removes the connection from all the l3 orderbook channels
+func (s *L3OrderBookService) UnsubscribeL3OrderBook(c *ws.Client) {
+	socket := ws.GetL3OrderBookSocket()
+	socket.Unsubscribe(c)
+}
+
+// UnsubscribeL3OrderBookChannel removes the connection from the l3 orderbook channel of a pair
+func (s *L3OrderBookService) UnsubscribeL3OrderBookChannel(c *ws.Client, bt, qt common.Address) {
+	socket := ws.GetL3OrderBookSocket()
+	id := utils.GetOrderBookChannelID(bt, qt)
+	socket.UnsubscribeChannel(id, c)
+}
+
+// HandleOrderChange applies the latest state of an order at the sequence of its update to the
+// book of its pair and broadcasts the resulting events, pairs nobody requested are ignored
+func (s *L3OrderBookService) HandleOrderChange(o *types.Order, sequence uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if sequence > s.sequence {
+		s.sequence = sequence
+	}
+
+	book, ok := s.books[utils.GetPairKey(o.BaseToken, o.QuoteToken)]
+	if !ok {
+		return
+	}
+
+	events := book.Apply(o, sequence)
+	if len(events) == 0 {
+		return
+	}
+
+	id := utils.GetOrderBookChannelID(o.BaseToken, o.QuoteToken)
+	ws.GetL3OrderBookSocket().BroadcastMessage(id, events)
+}
+
+// getBook returns the book of a pair, loading its resting orders on first use
+func (s *L3OrderBookService) getBook(bt, qt common.Address) (*types.L3Book, error) {
+	key := utils.GetPairKey(bt, qt)
+	if book, ok := s.books[key]; ok {
+		return book, nil
+	}
+
+	pair, err := s.pairDao.GetByTokenAddress(bt, qt)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if pair == nil {
+		return nil, errors.New("Pair not found")
+	}
+
+	orders, err := s.orderDao.GetRawOrderBook(pair)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	book := types.NewL3Book(pair.Name(), s.anonymize)
+	book.Load(orders, s.sequence)
+	s.books[key] = book
+
+	return book, nil
+}
+
+func (s *L3OrderBookService) anonymize(h common.Hash) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(h.Bytes())
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
@@ -26,21 +26,19 @@ func NewLendingMarketsService(
 }
 
 // Subscribe market
-func (s *LendingMarketsService) Subscribe(c *ws.Client) {
+func (s *LendingMarketsService) Subscribe(c *ws.Client) error {
 	socket := ws.GetLendingMarketSocket()
 	id := utils.GetLendingMarketsChannelID(ws.LendingMarketsChannel)
 
 	err := socket.Subscribe(id, c)
 	if err != nil {
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	tick, err := s.LendingOhlcvService.GetAllTokenPairData()
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	data := &types.LendingMarketData{
@@ -49,6 +47,7 @@ func (s *LendingMarketsService) Subscribe(c *ws.Client) {
 
 	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
 	socket.SendInitMessage(c, data)
+	return nil
 }
 
 // UnsubscribeChannel UnsubscribeChannel lending market socket
@@ -35,6 +35,7 @@ type LendingOhlcvService struct {
 	mutex               sync.RWMutex
 	tokenCache          map[common.Address]int
 	ohlcv               interfaces.OHLCVService
+	tickNotify          func(term uint64, lendingToken common.Address, ticks []*types.LendingTick, duration int64, unit string)
 }
 
 type lendingTickCache struct {
@@ -79,7 +80,7 @@ func (s *LendingOhlcvService) UnsubscribeChannel(conn *ws.Client, p *types.Subsc
 
 // Subscribe handles all the subscription messages for ticks corresponding to a pair
 // It calls the corresponding channel's subscription method and sends trade history back on the connection
-func (s *LendingOhlcvService) Subscribe(conn *ws.Client, p *types.SubscriptionPayload) {
+func (s *LendingOhlcvService) Subscribe(conn *ws.Client, p *types.SubscriptionPayload) error {
 	socket := ws.GetLendingOhlcvSocket()
 
 	ohlcv, err := s.GetOHLCV(
@@ -93,20 +94,24 @@ func (s *LendingOhlcvService) Subscribe(conn *ws.Client, p *types.SubscriptionPa
 
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(conn, err.Error())
-		return
+		return err
 	}
 
 	id := utils.GetLendingOhlcvChannelID(p.Term, p.LendingToken, p.Units, p.Duration)
 	err = socket.Subscribe(id, conn)
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(conn, err.Error())
-		return
+		return err
 	}
 
 	ws.RegisterConnectionUnsubscribeHandler(conn, socket.UnsubscribeChannelHandler(id))
 	socket.SendInitMessage(conn, ohlcv)
+	return nil
+}
+
+// RegisterTickNotify register a function called with the updated ticks after each tick broadcast
+func (s *LendingOhlcvService) RegisterTickNotify(fn func(term uint64, lendingToken common.Address, ticks []*types.LendingTick, duration int64, unit string)) {
+	s.tickNotify = fn
 }
 
 func (s *LendingOhlcvService) processBulkOhlcv() {
@@ -138,6 +143,10 @@ func (s *LendingOhlcvService) broadcastTickUpdate(pairs []string) {
 					id := utils.GetLendingOhlcvChannelID(term, lendingToken, unit, duration)
 					ws.GetLendingOhlcvSocket().BroadcastLendingOhlcv(id, tick)
 				}
+
+				if s.tickNotify != nil {
+					s.tickNotify(term, lendingToken, ticks, duration, unit)
+				}
 			}
 
 		}
@@ -36,10 +36,14 @@ type LendingOrderService struct {
 	notificationDao    interfaces.NotificationDao
 	lendingTradeDao    interfaces.LendingTradeDao
 	validator          interfaces.ValidatorService
+	compliance         interfaces.ComplianceService
 	engine             interfaces.Engine
 	broker             *rabbitmq.Connection
 	mutext             sync.RWMutex
 	bulkLendingOrders  map[string]map[common.Hash]*types.LendingOrder
+	orderBookNotify    func(term uint64, lendingToken common.Address, borrow, lend []map[string]string)
+	engineNotify       func(*types.EngineResponse)
+	rejectNotify       func(*types.LendingOrder)
 }
 
 // NewLendingOrderService returns a new instance of lending order service
@@ -53,6 +57,7 @@ func NewLendingOrderService(
 	notificationDao interfaces.NotificationDao,
 	lendingTradeDao interfaces.LendingTradeDao,
 	validator interfaces.ValidatorService,
+	compliance interfaces.ComplianceService,
 	engine interfaces.Engine,
 	broker *rabbitmq.Connection,
 ) *LendingOrderService {
@@ -67,10 +72,14 @@ func NewLendingOrderService(
 		notificationDao,
 		lendingTradeDao,
 		validator,
+		compliance,
 		engine,
 		broker,
 		sync.RWMutex{},
 		bulkLendingOrders,
+		nil,
+		nil,
+		nil,
 	}
 }
 
@@ -83,7 +92,7 @@ func (s *LendingOrderService) GetByHash(hash common.Hash) (*types.LendingOrder,
 // funds and order data.
 // If valid: LendingOrder is inserted in DB with order status as new and order is publiched
 // on rabbitmq queue for matching engine to process the order
-func (s *LendingOrderService) NewLendingOrder(o *types.LendingOrder) error {
+func (s *LendingOrderService) NewLendingOrder(o *types.LendingOrder) (err error) {
 	if err := o.Validate(); err != nil {
 		logger.Error(err)
 		return err
@@ -98,6 +107,18 @@ func (s *LendingOrderService) NewLendingOrder(o *types.LendingOrder) error {
 		return errors.New("Invalid Signature")
 	}
 
+	// the order is not sent to the node, its nonce can be used again
+	defer func() {
+		if err != nil && s.rejectNotify != nil {
+			s.rejectNotify(o)
+		}
+	}()
+
+	err = s.compliance.CheckOpen(o.UserAddress)
+	if err != nil {
+		return err
+	}
+
 	if o.Type == types.TypeLimitOrder {
 		err = s.validator.ValidateAvailablLendingBalance(o)
 		if err != nil {
@@ -119,16 +140,34 @@ func (s *LendingOrderService) NewLendingOrder(o *types.LendingOrder) error {
 // Only Orders which are OPEN or NEW i.e. Not yet filled/partially filled
 // can be cancelled
 func (s *LendingOrderService) CancelLendingOrder(o *types.LendingOrder) error {
+	if err := s.compliance.CheckClose(o.UserAddress); err != nil {
+		return err
+	}
+
+	// the node checks the signature of the action, only its type is forwarded with it
+	o.SignatureType, _ = o.RecoverSignatureType()
+
 	return s.lendingDao.CancelLendingOrder(o)
 }
 
 // RepayLendingOrder repay
 func (s *LendingOrderService) RepayLendingOrder(o *types.LendingOrder) error {
+	if err := s.compliance.CheckClose(o.UserAddress); err != nil {
+		return err
+	}
+
+	o.SignatureType, _ = o.RecoverSignatureType()
+
 	return s.lendingDao.RepayLendingOrder(o)
 }
 
 // TopupLendingOrder topup
 func (s *LendingOrderService) TopupLendingOrder(o *types.LendingOrder) error {
+	if err := s.compliance.CheckClose(o.UserAddress); err != nil {
+		return err
+	}
+
+	o.SignatureType, _ = o.RecoverSignatureType()
 
 	return s.lendingDao.TopupLendingOrder(o)
 }
@@ -186,6 +225,10 @@ func (s *LendingOrderService) HandleLendingOrderResponse(res *types.EngineRespon
 		}
 	}
 
+	if s.engineNotify != nil {
+		s.engineNotify(res)
+	}
+
 	return nil
 }
 
@@ -498,7 +541,13 @@ func (s *LendingOrderService) handleNewLendingOrder(bytes []byte) error {
 		logger.Error(err)
 		return err
 	}
-	return s.lendingDao.AddNewLendingOrder(o)
+
+	err = s.lendingDao.AddNewLendingOrder(o)
+	if err != nil && s.rejectNotify != nil {
+		s.rejectNotify(o)
+	}
+
+	return err
 }
 
 func (s *LendingOrderService) handleCancelLendingOrder(bytes []byte) error {
@@ -531,6 +580,21 @@ func (s *LendingOrderService) saveBulkLendingOrders(res *types.EngineResponse) e
 	return nil
 }
 
+// RegisterOrderBookNotify register a function called with the interest levels updated after each orderbook broadcast
+func (s *LendingOrderService) RegisterOrderBookNotify(fn func(term uint64, lendingToken common.Address, borrow, lend []map[string]string)) {
+	s.orderBookNotify = fn
+}
+
+// RegisterEngineResponseNotify register a function called with each lending engine response once it is handled
+func (s *LendingOrderService) RegisterEngineResponseNotify(fn func(*types.EngineResponse)) {
+	s.engineNotify = fn
+}
+
+// RegisterOrderRejectNotify register a function called with each signed lending order which is not sent to the node
+func (s *LendingOrderService) RegisterOrderRejectNotify(fn func(*types.LendingOrder)) {
+	s.rejectNotify = fn
+}
+
 func (s *LendingOrderService) processBulkLendingOrders() {
 	s.mutext.Lock()
 	defer s.mutext.Unlock()
@@ -569,6 +633,13 @@ func (s *LendingOrderService) processBulkLendingOrders() {
 			Borrow: borrow,
 			Lend:   lend,
 		})
+
+		if s.orderBookNotify != nil {
+			term, lendingToken, err := utils.ParseLendingChannelID(p)
+			if err == nil {
+				s.orderBookNotify(term, lendingToken, borrow, lend)
+			}
+		}
 	}
 	s.bulkLendingOrders = make(map[string]map[common.Hash]*types.LendingOrder)
 }
@@ -1,7 +1,10 @@
 package services
 
 import (
+	"sync"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils"
@@ -13,13 +16,20 @@ import (
 // PairService functions are responsible for interacting with daos and implements business logics.
 type LendingOrderBookService struct {
 	lendingOrderDao interfaces.LendingOrderDao
+
+	// depthSubscriptions maps a lending orderbook channel to the aggregated channels built on top of it
+	depthSubscriptions map[string]map[string]*types.DepthParams
+	mutex              sync.RWMutex
 }
 
 // NewLendingOrderBookService returns a new instance of balance service
 func NewLendingOrderBookService(
 	lendingOrderDao interfaces.LendingOrderDao,
 ) *LendingOrderBookService {
-	return &LendingOrderBookService{lendingOrderDao}
+	return &LendingOrderBookService{
+		lendingOrderDao:    lendingOrderDao,
+		depthSubscriptions: make(map[string]map[string]*types.DepthParams),
+	}
 }
 
 // GetLendingOrderBook fetches orderbook from engine and returns it as an map[string]interface
@@ -55,27 +65,125 @@ func (s *LendingOrderBookService) GetLendingOrderBookInDb(term uint64, lendingTo
 	return ob, nil
 }
 
+// GetLendingOrderBookDepth returns the lending orderbook aggregated by interest tick size (in percent),
+// limited to the requested number of levels and with the cumulative depth when requested
+func (s *LendingOrderBookService) GetLendingOrderBookDepth(term uint64, lendingToken common.Address, tickSize string, levels int, cumulative bool) (*types.LendingOrderBook, error) {
+	params, err := types.NewDepthParams(tickSize, types.LendingInterestDecimals, levels, cumulative)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getLendingOrderBookDepth(term, lendingToken, params)
+}
+
+func (s *LendingOrderBookService) getLendingOrderBookDepth(term uint64, lendingToken common.Address, params *types.DepthParams) (*types.LendingOrderBook, error) {
+	borrow, lend, err := s.lendingOrderDao.GetLendingOrderBook(term, lendingToken)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &types.LendingOrderBook{
+		Name:   utils.GetLendingOrderBookChannelID(term, lendingToken),
+		Borrow: types.AggregateDepth(borrow, "interest", true, params),
+		Lend:   types.AggregateDepth(lend, "interest", false, params),
+	}, nil
+}
+
+// SubscribeLendingOrderBookDepth subscribes the connection to the lending orderbook aggregated by interest tick size,
+// the updates only carry the buckets touched by the orders
+func (s *LendingOrderBookService) SubscribeLendingOrderBookDepth(c *ws.Client, term uint64, lendingToken common.Address, tickSize string, levels int, cumulative bool) error {
+	socket := ws.GetLendingOrderBookSocket()
+
+	params, err := types.NewDepthParams(tickSize, types.LendingInterestDecimals, levels, cumulative)
+	if err != nil {
+		return errors.InvalidPayload(err.Error())
+	}
+
+	ob, err := s.getLendingOrderBookDepth(term, lendingToken, params)
+	if err != nil {
+		return err
+	}
+
+	bookID := utils.GetLendingOrderBookChannelID(term, lendingToken)
+	id := utils.GetDepthChannelID(bookID, params.Key())
+	err = socket.Subscribe(id, c)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	if s.depthSubscriptions[bookID] == nil {
+		s.depthSubscriptions[bookID] = make(map[string]*types.DepthParams)
+	}
+	s.depthSubscriptions[bookID][id] = params
+	s.mutex.Unlock()
+
+	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
+	socket.SendInitMessage(c, ob)
+	return nil
+}
+
+// NotifyLendingOrderBookUpdate pushes the aggregated buckets touched by a lending orderbook update,
+// or the whole aggregated book for the limited and cumulative books, to the depth subscribers
+func (s *LendingOrderBookService) NotifyLendingOrderBookUpdate(term uint64, lendingToken common.Address, borrow, lend []map[string]string) {
+	socket := ws.GetLendingOrderBookSocket()
+	bookID := utils.GetLendingOrderBookChannelID(term, lendingToken)
+
+	s.mutex.Lock()
+	subs := make(map[string]*types.DepthParams)
+	for id, params := range s.depthSubscriptions[bookID] {
+		if !socket.HasSubscriptions(id) {
+			delete(s.depthSubscriptions[bookID], id)
+			continue
+		}
+
+		subs[id] = params
+	}
+
+	if len(s.depthSubscriptions[bookID]) == 0 {
+		delete(s.depthSubscriptions, bookID)
+	}
+	s.mutex.Unlock()
+
+	if len(subs) == 0 {
+		return
+	}
+
+	bookBorrow, bookLend, err := s.lendingOrderDao.GetLendingOrderBook(term, lendingToken)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	for id, params := range subs {
+		socket.DeliverMessage(id, &types.LendingOrderBook{
+			Name:   bookID,
+			Borrow: types.AggregateDepthUpdate(borrow, bookBorrow, "interest", true, params),
+			Lend:   types.AggregateDepthUpdate(lend, bookLend, "interest", false, params),
+		})
+	}
+}
+
 // SubscribeLendingOrderBook is responsible for handling incoming orderbook subscription messages
 // It makes an entry of connection in pairSocket corresponding to pair,unit and duration
-func (s *LendingOrderBookService) SubscribeLendingOrderBook(c *ws.Client, term uint64, lendingToken common.Address) {
+func (s *LendingOrderBookService) SubscribeLendingOrderBook(c *ws.Client, term uint64, lendingToken common.Address) error {
 	socket := ws.GetLendingOrderBookSocket()
 
 	ob, err := s.GetLendingOrderBook(term, lendingToken)
 	if err != nil {
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	id := utils.GetLendingOrderBookChannelID(term, lendingToken)
 	err = socket.Subscribe(id, c)
 	if err != nil {
-		msg := map[string]string{"Message": err.Error()}
-		socket.SendErrorMessage(c, msg)
-		return
+		return err
 	}
 
 	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
 	socket.SendInitMessage(c, ob)
+	return nil
 }
 
 // UnsubscribeLendingOrderBook is responsible for handling incoming orderbook unsubscription messages
@@ -89,4 +197,10 @@ func (s *LendingOrderBookService) UnsubscribeLendingOrderBookChannel(c *ws.Clien
 	socket := ws.GetLendingOrderBookSocket()
 	id := utils.GetLendingOrderBookChannelID(term, lendingToken)
 	socket.UnsubscribeChannel(id, c)
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for depthID := range s.depthSubscriptions[id] {
+		socket.UnsubscribeChannel(depthID, c)
+	}
 }
@@ -24,23 +24,22 @@ func NewLendingPriceBoardService(
 	}
 }
 
-func (s *LendingPriceBoardService) Subscribe(c *ws.Client, term uint64, lendingToken common.Address) {
+func (s *LendingPriceBoardService) Subscribe(c *ws.Client, term uint64, lendingToken common.Address) error {
 	socket := ws.GetLendingPriceBoardSocket()
 	_, err := s.lendingPairService.GetByLendingID(term, lendingToken)
 	if err != nil {
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 	tick := s.GetLendingPriceBoardData(term, lendingToken)
 	id := utils.GetLendingChannelID(term, lendingToken)
 	err = socket.Subscribe(id, c)
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
 	socket.SendInitMessage(c, tick)
+	return nil
 }
 
 func (s *LendingPriceBoardService) UnsubscribeChannel(c *ws.Client, term uint64, lendingToken common.Address) {
@@ -24,6 +24,7 @@ type LendingTradeService struct {
 	bulkLendingTrades   map[string][]*types.LendingTrade
 	mutext              sync.RWMutex
 	tradeNotifyCallback func(*types.LendingTrade)
+	updateNotify        func(*types.LendingTrade)
 }
 
 // NewLendingTradeService returns a new instance of LendingTradeService
@@ -50,27 +51,31 @@ func (s *LendingTradeService) RegisterNotify(fn func(*types.LendingTrade)) {
 	s.tradeNotifyCallback = fn
 }
 
+// RegisterUpdateNotify register a function called with each lending trade updated by the engine
+func (s *LendingTradeService) RegisterUpdateNotify(fn func(*types.LendingTrade)) {
+	s.updateNotify = fn
+}
+
 // Subscribe Subscribe lending trade channel
-func (s *LendingTradeService) Subscribe(c *ws.Client, term uint64, lendingToken common.Address) {
+func (s *LendingTradeService) Subscribe(c *ws.Client, term uint64, lendingToken common.Address) error {
 	socket := ws.GetLendingTradeSocket()
 	numTrades := types.DefaultLimit
 	trades, err := s.GetLendingTradeByOrderBook(term, lendingToken, 0, 0, numTrades)
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	id := utils.GetLendingTradeChannelID(term, lendingToken)
 	err = socket.Subscribe(id, c)
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
 	socket.SendInitMessage(c, trades)
+	return nil
 }
 
 // UnsubscribeChannel unsubscribe lending channel
@@ -194,6 +199,10 @@ func (s *LendingTradeService) HandleLendingTradeResponse(res *types.EngineRespon
 		break
 	case types.TradeUpdated:
 		s.HandleOperationUpdate(res.LendingTrade)
+
+		if s.updateNotify != nil {
+			s.updateNotify(res.LendingTrade)
+		}
 		break
 	}
 
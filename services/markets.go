@@ -20,6 +20,7 @@ type MarketsService struct {
 	TradeDao     interfaces.TradeDao
 	OHLCVService interfaces.OHLCVService
 	PairService  interfaces.PairService
+	FiatService  interfaces.FiatService
 }
 
 // NewMarketsService returns a new instance of TradeService
@@ -29,6 +30,7 @@ func NewMarketsService(
 	tradeDao interfaces.TradeDao,
 	ohlcvService interfaces.OHLCVService,
 	pairService interfaces.PairService,
+	fiatService interfaces.FiatService,
 ) *MarketsService {
 	return &MarketsService{
 		PairDao:      pairDao,
@@ -36,45 +38,67 @@ func NewMarketsService(
 		TradeDao:     tradeDao,
 		OHLCVService: ohlcvService,
 		PairService:  pairService,
+		FiatService:  fiatService,
 	}
 }
 
 // Subscribe market
-func (s *MarketsService) Subscribe(c *ws.Client) {
+func (s *MarketsService) Subscribe(c *ws.Client, currency string) error {
 	socket := ws.GetMarketSocket()
 
-	pairData, err := s.OHLCVService.GetAllTokenPairData()
-
+	data, err := s.GetMarketData(currency)
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
-	id := utils.GetMarketsChannelID(ws.MarketsChannel)
+	id := utils.GetFiatChannelID(utils.GetMarketsChannelID(ws.MarketsChannel), currency)
 	err = socket.Subscribe(id, c)
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
+	}
+
+	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
+	socket.SendInitMessage(c, data)
+	return nil
+}
+
+// GetMarketData returns the pair data and small charts data valued in the requested currency
+func (s *MarketsService) GetMarketData(currency string) (*types.MarketData, error) {
+	pairData, err := s.OHLCVService.GetAllTokenPairData()
+	if err != nil {
+		return nil, err
 	}
 
 	smallChartsDataResult, err := s.OHLCVService.GetFiatPriceChart()
+	if err != nil {
+		return nil, err
+	}
 
-	data := &types.MarketData{
-		PairData:        pairData,
-		SmallChartsData: smallChartsDataResult,
+	if currency != "" {
+		err = s.FiatService.ConvertPairData(pairData, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		smallChartsDataResult, err = s.FiatService.ConvertFiatPriceChart(smallChartsDataResult, currency)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
-	socket.SendInitMessage(c, data)
+	return &types.MarketData{
+		PairData:        pairData,
+		SmallChartsData: smallChartsDataResult,
+	}, nil
 }
 
 // UnsubscribeChannel
-func (s *MarketsService) UnsubscribeChannel(c *ws.Client) {
+func (s *MarketsService) UnsubscribeChannel(c *ws.Client, currency string) {
 	socket := ws.GetMarketSocket()
 
-	id := utils.GetMarketsChannelID(ws.MarketsChannel)
+	id := utils.GetFiatChannelID(utils.GetMarketsChannelID(ws.MarketsChannel), currency)
 	socket.UnsubscribeChannel(id, c)
 }
 
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// confirmedNonceTTL is the time a nonce used by an order accepted by the node stays taken, the
+// node counts the order well before
+const confirmedNonceTTL = 10 * time.Minute
+
+// NonceService hands out the nonces of the orders of an address without collisions between
+// the clients which sign orders concurrently. The nonces are reserved on top of the count of
+// orders of the address known by the node, and the reservations are stored in the database so
+// that they are shared by the instances of a cluster.
+type NonceService struct {
+	kind     string
+	count    func(addr common.Address) (uint64, error)
+	nonceDao interfaces.NonceReservationDao
+	ttl      time.Duration
+}
+
+// NewOrderNonceService returns a new instance of NonceService for the nonces of the orders
+func NewOrderNonceService(orderDao interfaces.OrderDao, nonceDao interfaces.NonceReservationDao, ttl time.Duration) *NonceService {
+	return &NonceService{
+		kind: types.NonceKindOrder,
+		count: func(addr common.Address) (uint64, error) {
+			res, err := orderDao.GetOrderNonce(addr)
+			if err != nil {
+				return 0, err
+			}
+
+			s, ok := res.(string)
+			if !ok {
+				return 0, errors.New("Invalid order count")
+			}
+
+			return strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+		},
+		nonceDao: nonceDao,
+		ttl:      ttl,
+	}
+}
+
+// NewLendingNonceService returns a new instance of NonceService for the nonces of the lending
+// orders, topups, repayments and recalls
+func NewLendingNonceService(lendingDao interfaces.LendingOrderDao, nonceDao interfaces.NonceReservationDao, ttl time.Duration) *NonceService {
+	return &NonceService{
+		kind:     types.NonceKindLending,
+		count:    lendingDao.GetLendingNonce,
+		nonceDao: nonceDao,
+		ttl:      ttl,
+	}
+}
+
+// Reserve hands out n nonces to an address. The lowest free nonces are claimed one by one, the
+// nonces claimed meanwhile by another instance are skipped.
+func (s *NonceService) Reserve(addr common.Address, n int) (*types.NonceReservation, error) {
+	count, err := s.count(addr)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	taken, err := s.nonceDao.GetTaken(s.kind, addr, count)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.ttl)
+	nonces := make([]uint64, 0, n)
+
+	for len(nonces) < n {
+		for _, nonce := range types.FreeNonces(count, taken, n-len(nonces)) {
+			ok, err := s.nonceDao.Claim(s.kind, addr, nonce, expiresAt)
+			if err != nil {
+				for _, claimed := range nonces {
+					s.Release(addr, claimed)
+				}
+
+				return nil, err
+			}
+
+			taken[nonce] = true
+			if ok {
+				nonces = append(nonces, nonce)
+			}
+		}
+	}
+
+	return &types.NonceReservation{Address: addr, Nonces: nonces, ExpiresAt: expiresAt}, nil
+}
+
+// Release hands a reserved nonce back when the order using it is rejected
+func (s *NonceService) Release(addr common.Address, nonce uint64) {
+	err := s.nonceDao.Release(s.kind, addr, nonce)
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// Confirm marks a reserved nonce as used by an order accepted by the node
+func (s *NonceService) Confirm(addr common.Address, nonce uint64) {
+	err := s.nonceDao.Confirm(s.kind, addr, nonce, time.Now().Add(confirmedNonceTTL))
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+// NotifyOrderRejected releases the nonce of an order which was not sent to the node
+func (s *NonceService) NotifyOrderRejected(o *types.Order) {
+	if o.Nonce != nil {
+		s.Release(o.UserAddress, o.Nonce.Uint64())
+	}
+}
+
+// NotifyLendingOrderRejected releases the nonce of a lending order which was not sent to the node
+func (s *NonceService) NotifyLendingOrderRejected(o *types.LendingOrder) {
+	if o.Nonce != nil {
+		s.Release(o.UserAddress, o.Nonce.Uint64())
+	}
+}
+
+// NotifyEngineResponse confirms the nonces of the added orders and releases the nonces of the
+// rejected orders
+func (s *NonceService) NotifyEngineResponse(res *types.EngineResponse) {
+	o := res.Order
+	if o == nil || o.Nonce == nil {
+		return
+	}
+
+	switch res.Status {
+	case types.ORDER_ADDED:
+		s.Confirm(o.UserAddress, o.Nonce.Uint64())
+	case types.ORDER_REJECTED:
+		s.Release(o.UserAddress, o.Nonce.Uint64())
+	}
+}
+
+// NotifyLendingOrderResponse confirms the nonces of the added lending orders and of the
+// topups, repayments and recalls, and releases the nonces of the rejected ones
+func (s *NonceService) NotifyLendingOrderResponse(res *types.EngineResponse) {
+	o := res.LendingOrder
+	if o == nil || o.Nonce == nil {
+		return
+	}
+
+	switch res.Status {
+	case types.LENDING_ORDER_ADDED, types.LENDING_ORDER_TOPUPED, types.LENDING_ORDER_REPAYED, types.LENDING_ORDER_RECALLED:
+		s.Confirm(o.UserAddress, o.Nonce.Uint64())
+	case types.LENDING_ORDER_REJECTED, types.LENDING_ORDER_TOPUP_REJECTED,
+		types.LENDING_ORDER_REPAY_REJECTED, types.LENDING_ORDER_RECALL_REJECTED:
+		s.Release(o.UserAddress, o.Nonce.Uint64())
+	}
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// nonceStore keeps the reserved nonces in memory, the nonces in stolen are claimed by another
+// instance between the reads and the claims of a reservation
+type nonceStore struct {
+	nonces map[string]*types.ReservedNonce
+	stolen map[uint64]bool
+}
+
+func newNonceStore() *nonceStore {
+	return &nonceStore{nonces: map[string]*types.ReservedNonce{}, stolen: map[uint64]bool{}}
+}
+
+func (d *nonceStore) GetTaken(kind string, addr common.Address, count uint64) (map[uint64]bool, error) {
+	taken := map[uint64]bool{}
+	for _, n := range d.nonces {
+		if n.Kind == kind && n.Address == addr.Hex() && uint64(n.Nonce) >= count && n.ExpiresAt.After(time.Now()) {
+			taken[uint64(n.Nonce)] = true
+		}
+	}
+
+	return taken, nil
+}
+
+func (d *nonceStore) Claim(kind string, addr common.Address, nonce uint64, expiresAt time.Time) (bool, error) {
+	if d.stolen[nonce] {
+		return false, nil
+	}
+
+	id := types.ReservedNonceID(kind, addr, nonce)
+	if n, ok := d.nonces[id]; ok && n.ExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+
+	d.nonces[id] = &types.ReservedNonce{ID: id, Kind: kind, Address: addr.Hex(), Nonce: int64(nonce), Status: types.ReservedNoncePending, ExpiresAt: expiresAt}
+	return true, nil
+}
+
+func (d *nonceStore) Release(kind string, addr common.Address, nonce uint64) error {
+	id := types.ReservedNonceID(kind, addr, nonce)
+	if n, ok := d.nonces[id]; ok && n.Status == types.ReservedNoncePending {
+		delete(d.nonces, id)
+	}
+
+	return nil
+}
+
+func (d *nonceStore) Confirm(kind string, addr common.Address, nonce uint64, expiresAt time.Time) error {
+	id := types.ReservedNonceID(kind, addr, nonce)
+	d.nonces[id] = &types.ReservedNonce{ID: id, Kind: kind, Address: addr.Hex(), Nonce: int64(nonce), Status: types.ReservedNonceConfirmed, ExpiresAt: expiresAt}
+	return nil
+}
+
+func TestNonceServiceReserve(t *testing.T) {
+	store := newNonceStore()
+	count := uint64(5)
+	s := &NonceService{
+		kind:     types.NonceKindOrder,
+		count:    func(common.Address) (uint64, error) { return count, nil },
+		nonceDao: store,
+		ttl:      time.Minute,
+	}
+	addr := common.HexToAddress("0x1")
+
+	r, err := s.Reserve(addr, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{5, 6}, r.Nonces)
+
+	// the nonces claimed by another instance are skipped
+	store.stolen[7] = true
+	r, _ = s.Reserve(addr, 2)
+	assert.Equal(t, []uint64{8, 9}, r.Nonces)
+
+	// the released nonces are handed out again, the confirmed ones stay taken
+	s.Release(addr, 5)
+	s.Confirm(addr, 6)
+	s.Release(addr, 6)
+	r, _ = s.Reserve(addr, 2)
+	assert.Equal(t, []uint64{5, 10}, r.Nonces)
+
+	// the nonces below the count of the node are used
+	count = 9
+	r, _ = s.Reserve(addr, 1)
+	assert.Equal(t, []uint64{11}, r.Nonces)
+}
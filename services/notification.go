@@ -1,28 +1,73 @@
 package services
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo"
 	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/notify"
+	"github.com/tomochain/tomox-sdk/ws"
 )
 
 // NotificationService struct with daos required, responsible for communicating with dao
 // NotificationService functions are responsible for interacting with dao and implements business logic.
+// The created notifications are delivered to the channels of their recipients (email, Telegram,
+// webhook) according to their preferences.
 type NotificationService struct {
 	NotificationDao interfaces.NotificationDao
+	channelDao      interfaces.NotificationChannelDao
+	preferencesDao  interfaces.NotificationPreferencesDao
+	digestDao       interfaces.NotificationDigestDao
+	transports      map[string]notify.Transport
+	templates       *notify.Templates
 }
 
 // NewNotificationService returns a new instance of NewNotificationService
 func NewNotificationService(
 	notificationDao interfaces.NotificationDao,
+	channelDao interfaces.NotificationChannelDao,
+	preferencesDao interfaces.NotificationPreferencesDao,
+	digestDao interfaces.NotificationDigestDao,
+	transports map[string]notify.Transport,
+	templates *notify.Templates,
 ) *NotificationService {
 	return &NotificationService{
 		NotificationDao: notificationDao,
+		channelDao:      channelDao,
+		preferencesDao:  preferencesDao,
+		digestDao:       digestDao,
+		transports:      transports,
+		templates:       templates,
+	}
+}
+
+// deliveringNotificationDao delivers the notifications it inserts
+type deliveringNotificationDao struct {
+	interfaces.NotificationDao
+	service *NotificationService
+}
+
+func (dao *deliveringNotificationDao) Create(notifications ...*types.Notification) ([]*types.Notification, error) {
+	res, err := dao.NotificationDao.Create(notifications...)
+	if err != nil {
+		return nil, err
 	}
+
+	go dao.service.deliver(res)
+	return res, nil
+}
+
+// Dao returns the notification dao of the services creating notifications, the notifications
+// inserted with it are delivered like the notifications of Create
+func (s *NotificationService) Dao() interfaces.NotificationDao {
+	return &deliveringNotificationDao{s.NotificationDao, s}
 }
 
-// Create inserts a new notification into the database
+// Create inserts a new notification into the database and delivers it
 func (s *NotificationService) Create(n *types.Notification) ([]*types.Notification, error) {
 	notifications, err := s.NotificationDao.Create(n)
 
@@ -31,6 +76,7 @@ func (s *NotificationService) Create(n *types.Notification) ([]*types.Notificati
 		return nil, err
 	}
 
+	go s.deliver(notifications)
 	return notifications, nil
 }
 
@@ -81,3 +127,300 @@ func (s *NotificationService) MarkUnRead(id bson.ObjectId) error {
 func (s *NotificationService) MarkAllRead(addr common.Address) error {
 	return s.NotificationDao.MarkAllRead(addr)
 }
+
+// GetChannels returns the delivery channels of a user
+func (s *NotificationService) GetChannels(addr common.Address) ([]*types.NotificationChannel, error) {
+	return s.channelDao.GetByUserAddress(addr)
+}
+
+// AddChannel registers a delivery channel, the kind of the channel must be enabled on the server.
+// The channel is not verified, a verification code is sent to its target.
+func (s *NotificationService) AddChannel(c *types.NotificationChannel) (*types.NotificationChannel, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.transports[c.Kind]; !ok {
+		return nil, errors.New("Channel kind " + c.Kind + " is not enabled")
+	}
+
+	err = c.NewVerificationCode(time.Now(), types.ChannelVerificationTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.channelDao.Create(c)
+	if mgo.IsDup(err) {
+		return nil, errors.New("Channel already exists")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	locale := notify.DefaultLocale
+	if p, err := s.GetPreferences(c.UserAddress); err == nil {
+		locale = p.Locale
+	}
+
+	if !s.send(c, locale, notify.VerificationTemplate, &notify.Data{Description: c.VerificationCode, CreatedAt: c.CreatedAt}) {
+		err = s.channelDao.Delete(c.ID, c.UserAddress)
+		if err != nil {
+			logger.Error(err)
+		}
+
+		return nil, errors.New("The verification code could not be sent to the channel")
+	}
+
+	return c, nil
+}
+
+// VerifyChannel verifies a channel of a user with the code sent to its target
+func (s *NotificationService) VerifyChannel(id bson.ObjectId, addr common.Address, code string) error {
+	c, err := s.channelDao.GetByID(id, addr)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		return errors.New("Channel not found")
+	}
+
+	if c.Verified {
+		return nil
+	}
+
+	err = c.CheckVerificationCode(code, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return s.channelDao.MarkVerified(id)
+}
+
+// RemoveChannel removes a delivery channel of a user, its pending digest is dropped
+func (s *NotificationService) RemoveChannel(id bson.ObjectId, addr common.Address) error {
+	err := s.channelDao.Delete(id, addr)
+	if err == mgo.ErrNotFound {
+		return errors.New("Channel not found")
+	}
+
+	return err
+}
+
+// GetPreferences returns the delivery preferences of a user, the default preferences if the
+// user has none
+func (s *NotificationService) GetPreferences(addr common.Address) (*types.NotificationPreferences, error) {
+	p, err := s.preferencesDao.GetByUserAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		return types.NewNotificationPreferences(addr), nil
+	}
+
+	return p, nil
+}
+
+// SavePreferences replaces the delivery preferences of a user
+func (s *NotificationService) SavePreferences(p *types.NotificationPreferences) (*types.NotificationPreferences, error) {
+	p.SetDefaults()
+
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.preferencesDao.Upsert(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// NotifyLendingTradeUpdate alerts the borrower and the investor of a liquidated loan
+func (s *NotificationService) NotifyLendingTradeUpdate(t *types.LendingTrade) {
+	if t.Status != types.TradeStatusLiquidated {
+		return
+	}
+
+	for _, addr := range []common.Address{t.Borrower, t.Investor} {
+		notifications, err := s.Create(&types.Notification{
+			Recipient: addr,
+			Message: types.Message{
+				MessageType: types.LENDING_TRADE_LIQUIDATED,
+				Description: t.Hash.Hex(),
+			},
+			Type:   types.TypeAlert,
+			Status: types.StatusUnread,
+		})
+		if err != nil {
+			continue
+		}
+
+		ws.SendNotificationMessage(types.LENDING_TRADE_LIQUIDATED, addr, notifications)
+	}
+}
+
+// deliver sends the notifications to the channels selected by the preferences of their
+// recipients, the notifications routed to a digest are stored until FlushDigests sends them
+func (s *NotificationService) deliver(notifications []*types.Notification) {
+	for _, n := range notifications {
+		channels, err := s.channelDao.GetByUserAddress(n.Recipient)
+		if err != nil || len(channels) == 0 {
+			continue
+		}
+
+		p, err := s.GetPreferences(n.Recipient)
+		if err != nil {
+			continue
+		}
+
+		rule := p.Route(n)
+		digest := []*types.NotificationDigestItem{}
+
+		for _, c := range channels {
+			if !c.Verified || !rule.Selects(c.Kind) {
+				continue
+			}
+
+			if rule.Digest {
+				digest = append(digest, &types.NotificationDigestItem{
+					UserAddress:    n.Recipient.Hex(),
+					ChannelID:      c.ID,
+					NotificationID: n.ID,
+					Type:           n.Type,
+					Event:          n.Message.MessageType,
+					Description:    n.Message.Description,
+					CreatedAt:      n.CreatedAt,
+				})
+				continue
+			}
+
+			s.send(c, p.Locale, n.Message.MessageType, notificationData(n))
+		}
+
+		err = s.digestDao.Create(digest...)
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+}
+
+// FlushDigests sends the digests of the channels whose oldest pending notification is older
+// than the digest interval of their user. The items of a failed digest are sent on the next run.
+func (s *NotificationService) FlushDigests() {
+	items, err := s.digestDao.GetAll()
+	if err != nil {
+		return
+	}
+
+	// the items are sorted by date, the first item of a channel is its oldest
+	digests := map[bson.ObjectId][]*types.NotificationDigestItem{}
+	order := []bson.ObjectId{}
+	for _, i := range items {
+		if _, ok := digests[i.ChannelID]; !ok {
+			order = append(order, i.ChannelID)
+		}
+
+		digests[i.ChannelID] = append(digests[i.ChannelID], i)
+	}
+
+	preferences := map[string]*types.NotificationPreferences{}
+	channels := map[string][]*types.NotificationChannel{}
+
+	for _, id := range order {
+		items := digests[id]
+		user := items[0].UserAddress
+
+		if _, ok := preferences[user]; !ok {
+			p, err := s.GetPreferences(common.HexToAddress(user))
+			if err != nil {
+				continue
+			}
+
+			c, err := s.channelDao.GetByUserAddress(common.HexToAddress(user))
+			if err != nil {
+				continue
+			}
+
+			preferences[user] = p
+			channels[user] = c
+		}
+
+		p := preferences[user]
+		if time.Since(items[0].CreatedAt) < time.Duration(p.DigestInterval)*time.Second {
+			continue
+		}
+
+		ids := make([]bson.ObjectId, len(items))
+		data := &notify.Data{Recipient: user, Event: notify.DigestTemplate, CreatedAt: time.Now()}
+		for i, item := range items {
+			ids[i] = item.ID
+			data.Items = append(data.Items, notify.Data{
+				Recipient:   user,
+				Type:        item.Type,
+				Event:       item.Event,
+				Description: item.Description,
+				CreatedAt:   item.CreatedAt,
+			})
+		}
+
+		// the items of a removed channel are dropped
+		c := findChannel(channels[user], id)
+		if c != nil && !s.send(c, p.Locale, notify.DigestTemplate, data) {
+			continue
+		}
+
+		err := s.digestDao.DeleteByIds(ids...)
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+}
+
+// send renders the template name in locale and sends it to the channel, it returns false if
+// the message was not sent
+func (s *NotificationService) send(c *types.NotificationChannel, locale, name string, data *notify.Data) bool {
+	t, ok := s.transports[c.Kind]
+	if !ok {
+		return false
+	}
+
+	m, err := s.templates.Render(locale, name, data)
+	if err != nil {
+		logger.Error(err)
+		return false
+	}
+
+	err = t.Send(c.Target, m)
+	if err != nil {
+		logger.Errorf("notification to %s channel %s failed: %v", c.Kind, c.ID.Hex(), err)
+		return false
+	}
+
+	return true
+}
+
+func notificationData(n *types.Notification) *notify.Data {
+	return &notify.Data{
+		Recipient:   n.Recipient.Hex(),
+		Type:        n.Type,
+		Event:       n.Message.MessageType,
+		Description: n.Message.Description,
+		CreatedAt:   n.CreatedAt,
+	}
+}
+
+func findChannel(channels []*types.NotificationChannel, id bson.ObjectId) *types.NotificationChannel {
+	for _, c := range channels {
+		if c.ID == id {
+			return c
+		}
+	}
+
+	return nil
+}
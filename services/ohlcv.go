@@ -128,7 +128,7 @@ func (s *OHLCVService) UnsubscribeChannel(conn *ws.Client, p *types.Subscription
 
 // Subscribe handles all the subscription messages for ticks corresponding to a pair
 // It calls the corresponding channel's subscription method and sends trade history back on the connection
-func (s *OHLCVService) Subscribe(conn *ws.Client, p *types.SubscriptionPayload) {
+func (s *OHLCVService) Subscribe(conn *ws.Client, p *types.SubscriptionPayload) error {
 	socket := ws.GetOHLCVSocket()
 
 	ohlcv, err := s.GetOHLCV(
@@ -141,20 +141,19 @@ func (s *OHLCVService) Subscribe(conn *ws.Client, p *types.SubscriptionPayload)
 
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(conn, err.Error())
-		return
+		return err
 	}
 
 	id := utils.GetOHLCVChannelID(p.BaseToken, p.QuoteToken, p.Units, p.Duration)
 	err = socket.Subscribe(id, conn)
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(conn, err.Error())
-		return
+		return err
 	}
 
 	ws.RegisterConnectionUnsubscribeHandler(conn, socket.UnsubscribeChannelHandler(id))
 	socket.SendInitMessage(conn, ohlcv)
+	return nil
 }
 
 func (s *OHLCVService) getConfig() []durationtick {
@@ -100,8 +100,8 @@ func TestOHLCV(t *testing.T) {
 	app.Config.DBName = "tomodex"
 	tradeDao := daos.NewTradeDao()
 	pairDao := daos.NewPairDao()
-	fiatPriceDao := daos.NewFiatPriceDao()
-	ohlcvService := NewOHLCVService(tradeDao, pairDao, fiatPriceDao)
+	tokenDao := daos.NewTokenDao()
+	ohlcvService := NewOHLCVService(tradeDao, pairDao, tokenDao)
 
 	for _, t := range testTimes {
 		tTime, err := time.Parse(timeLayoutString, t)
@@ -28,12 +28,17 @@ type OrderService struct {
 	notificationDao   interfaces.NotificationDao
 	engine            interfaces.Engine
 	validator         interfaces.ValidatorService
+	compliance        interfaces.ComplianceService
 	broker            *rabbitmq.Connection
 	orderByPricepoint map[string]map[common.Hash]*amountByTime
 	mutext            sync.RWMutex
 	orderPending      []*types.Order
 	isFinishCache     bool
 	bulkOrders        map[*types.PairAddresses]map[common.Hash]*types.Order
+	orderBookNotify   func(bt, qt common.Address, bids, asks []map[string]string)
+	orderChangeNotify func(o *types.Order, sequence uint64)
+	engineNotify      func(*types.EngineResponse)
+	rejectNotify      func(*types.Order)
 }
 
 type amountByTime struct {
@@ -51,6 +56,7 @@ func NewOrderService(
 	notificationDao interfaces.NotificationDao,
 	engine interfaces.Engine,
 	validator interfaces.ValidatorService,
+	compliance interfaces.ComplianceService,
 	broker *rabbitmq.Connection,
 ) *OrderService {
 	bulkOrders := make(map[*types.PairAddresses]map[common.Hash]*types.Order)
@@ -64,12 +70,17 @@ func NewOrderService(
 		notificationDao,
 		engine,
 		validator,
+		compliance,
 		broker,
 		orderByPricepoint,
 		sync.RWMutex{},
 		[]*types.Order{},
 		false,
 		bulkOrders,
+		nil,
+		nil,
+		nil,
+		nil,
 	}
 }
 
@@ -187,6 +198,11 @@ func (s *OrderService) GetOrders(orderSpec types.OrderSpec, sort []string, offse
 	return s.orderDao.GetOrders(orderSpec, sort, offset, size)
 }
 
+// StreamOrders calls fn on each order matching the spec from the oldest to the most recent
+func (s *OrderService) StreamOrders(orderSpec types.OrderSpec, fn func(*types.Order) error) error {
+	return s.orderDao.StreamOrders(orderSpec, fn)
+}
+
 // GetByHash fetches all trades corresponding to a trade hash
 func (s *OrderService) GetByHash(hash common.Hash) (*types.Order, error) {
 	return s.orderDao.GetByHash(hash)
@@ -218,7 +234,7 @@ func (s *OrderService) GetHistoryByUserAddress(addr, bt, qt common.Address, from
 // funds and order data.
 // If valid: Order is inserted in DB with order status as new and order is publiched
 // on rabbitmq queue for matching engine to process the order
-func (s *OrderService) NewOrder(o *types.Order) error {
+func (s *OrderService) NewOrder(o *types.Order) (err error) {
 	if err := o.Validate(); err != nil {
 		logger.Error(err)
 		return err
@@ -233,6 +249,18 @@ func (s *OrderService) NewOrder(o *types.Order) error {
 		return errors.New("Invalid Signature")
 	}
 
+	// the order is not sent to the node, its nonce can be used again
+	defer func() {
+		if err != nil && s.rejectNotify != nil {
+			s.rejectNotify(o)
+		}
+	}()
+
+	err = s.compliance.CheckOpen(o.UserAddress)
+	if err != nil {
+		return err
+	}
+
 	p, err := s.pairDao.GetByTokenAddress(o.BaseToken, o.QuoteToken)
 	if err != nil {
 		logger.Error(err)
@@ -287,8 +315,17 @@ func (s *OrderService) CancelOrder(oc *types.OrderCancel) error {
 		return fmt.Errorf("Cannot cancel order. Status is %v", o.Status)
 	}
 
+	err = s.compliance.CheckClose(o.UserAddress)
+	if err != nil {
+		return err
+	}
+
+	// the node checks the signature of the cancel, only its type is forwarded with it
+	oc.SignatureType, _ = oc.RecoverSignatureType()
+
 	o.Nonce = oc.Nonce
 	o.Signature = oc.Signature
+	o.SignatureType = oc.SignatureType
 	o.OrderID = oc.OrderID
 	o.Status = oc.Status
 	o.UserAddress = oc.UserAddress
@@ -307,6 +344,11 @@ func (s *OrderService) CancelOrder(oc *types.OrderCancel) error {
 // Only Orders which are OPEN or NEW i.e. Not yet filled/partially filled
 // can be cancelled
 func (s *OrderService) CancelAllOrder(a common.Address) error {
+	err := s.compliance.CheckClose(a)
+	if err != nil {
+		return err
+	}
+
 	orders, err := s.orderDao.GetOpenOrdersByUserAddress(a)
 
 	if err != nil {
@@ -363,6 +405,10 @@ func (s *OrderService) HandleEngineResponse(res *types.EngineResponse) error {
 		}
 	}
 
+	if s.engineNotify != nil {
+		s.engineNotify(res)
+	}
+
 	return nil
 }
 
@@ -522,6 +568,10 @@ func (s *OrderService) broadcastOrderBookUpdate(orders []*types.Order) {
 		Bids:     bids,
 		Asks:     asks,
 	})
+
+	if s.orderBookNotify != nil {
+		s.orderBookNotify(p.BaseTokenAddress, p.QuoteTokenAddress, bids, asks)
+	}
 }
 
 func (s *OrderService) broadcastRawOrderBookUpdate(orders []*types.Order) {
@@ -535,6 +585,27 @@ func (s *OrderService) broadcastRawOrderBookUpdate(orders []*types.Order) {
 	ws.GetRawOrderBookSocket().BroadcastMessage(id, orders)
 }
 
+// RegisterOrderBookNotify register a function called with the price levels updated after each orderbook broadcast
+func (s *OrderService) RegisterOrderBookNotify(fn func(bt, qt common.Address, bids, asks []map[string]string)) {
+	s.orderBookNotify = fn
+}
+
+// RegisterOrderChangeNotify register a function called with each order received from the change stream
+// and the sequence of its update
+func (s *OrderService) RegisterOrderChangeNotify(fn func(o *types.Order, sequence uint64)) {
+	s.orderChangeNotify = fn
+}
+
+// RegisterEngineResponseNotify register a function called with each engine response once it is handled
+func (s *OrderService) RegisterEngineResponseNotify(fn func(*types.EngineResponse)) {
+	s.engineNotify = fn
+}
+
+// RegisterOrderRejectNotify register a function called with each signed order which is not sent to the node
+func (s *OrderService) RegisterOrderRejectNotify(fn func(*types.Order)) {
+	s.rejectNotify = fn
+}
+
 // WatchChanges wath change record
 func (s *OrderService) WatchChanges() {
 	go func() {
@@ -625,6 +696,10 @@ func (s *OrderService) processBulkOrders() {
 			Bids:     bids,
 			Asks:     asks,
 		})
+
+		if s.orderBookNotify != nil {
+			s.orderBookNotify(p.BaseToken, p.QuoteToken, bids, asks)
+		}
 	}
 	s.bulkOrders = make(map[*types.PairAddresses]map[common.Hash]*types.Order)
 }
@@ -634,6 +709,11 @@ func (s *OrderService) HandleDocumentType(ev types.OrderChangeEvent) error {
 	if ev.FullDocument == nil {
 		return nil
 	}
+
+	if s.orderChangeNotify != nil {
+		s.orderChangeNotify(ev.FullDocument, types.ChangeSequence(ev.ClusterTime))
+	}
+
 	res := &types.EngineResponse{}
 	if ev.FullDocument.Status == types.OrderStatusOpen {
 		res.Status = types.ORDER_ADDED
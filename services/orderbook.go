@@ -1,6 +1,8 @@
 package services
 
 import (
+	"sync"
+
 	"github.com/tomochain/tomox-sdk/errors"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -18,6 +20,15 @@ type OrderBookService struct {
 	tokenDao interfaces.TokenDao
 	orderDao interfaces.OrderDao
 	eng      interfaces.Engine
+
+	// depthSubscriptions maps an orderbook channel to the aggregated channels built on top of it
+	depthSubscriptions map[string]map[string]*depthSubscription
+	mutex              sync.RWMutex
+}
+
+type depthSubscription struct {
+	pair   *types.Pair
+	params *types.DepthParams
 }
 
 // NewPairService returns a new instance of balance service
@@ -27,7 +38,13 @@ func NewOrderBookService(
 	orderDao interfaces.OrderDao,
 	eng interfaces.Engine,
 ) *OrderBookService {
-	return &OrderBookService{pairDao, tokenDao, orderDao, eng}
+	return &OrderBookService{
+		pairDao:            pairDao,
+		tokenDao:           tokenDao,
+		orderDao:           orderDao,
+		eng:                eng,
+		depthSubscriptions: make(map[string]map[string]*depthSubscription),
+	}
 }
 
 // GetOrderBook fetches orderbook from engine and returns it as an map[string]interface
@@ -83,27 +100,146 @@ func (s *OrderBookService) GetDbOrderBook(bt, qt common.Address) (*types.OrderBo
 	return ob, nil
 }
 
+// GetOrderBookDepth returns the orderbook aggregated by tick size (in quote token units),
+// limited to the requested number of levels and with the cumulative depth when requested
+func (s *OrderBookService) GetOrderBookDepth(bt, qt common.Address, tickSize string, levels int, cumulative bool) (*types.OrderBook, error) {
+	pair, err := s.pairDao.GetByTokenAddress(bt, qt)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if pair == nil {
+		return nil, errors.New("Pair not found")
+	}
+
+	params, err := types.NewDepthParams(tickSize, pair.QuoteTokenDecimals, levels, cumulative)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getOrderBookDepth(pair, params)
+}
+
+func (s *OrderBookService) getOrderBookDepth(pair *types.Pair, params *types.DepthParams) (*types.OrderBook, error) {
+	bids, asks, err := s.orderDao.GetOrderBook(pair)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &types.OrderBook{
+		PairName: pair.Name(),
+		Bids:     types.AggregateDepth(bids, "pricepoint", true, params),
+		Asks:     types.AggregateDepth(asks, "pricepoint", false, params),
+	}, nil
+}
+
+// SubscribeOrderBookDepth subscribes the connection to the orderbook aggregated by tick size,
+// the updates only carry the buckets touched by the orders
+func (s *OrderBookService) SubscribeOrderBookDepth(c *ws.Client, bt, qt common.Address, tickSize string, levels int, cumulative bool) error {
+	socket := ws.GetOrderBookSocket()
+
+	pair, err := s.pairDao.GetByTokenAddress(bt, qt)
+	if err != nil || pair == nil {
+		return errors.NotFound("Pair")
+	}
+
+	params, err := types.NewDepthParams(tickSize, pair.QuoteTokenDecimals, levels, cumulative)
+	if err != nil {
+		return errors.InvalidPayload(err.Error())
+	}
+
+	ob, err := s.getOrderBookDepth(pair, params)
+	if err != nil {
+		return err
+	}
+
+	bookID := utils.GetOrderBookChannelID(bt, qt)
+	id := utils.GetDepthChannelID(bookID, params.Key())
+	err = socket.Subscribe(id, c)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	if s.depthSubscriptions[bookID] == nil {
+		s.depthSubscriptions[bookID] = make(map[string]*depthSubscription)
+	}
+	s.depthSubscriptions[bookID][id] = &depthSubscription{pair, params}
+	s.mutex.Unlock()
+
+	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
+	socket.SendInitMessage(c, ob)
+	return nil
+}
+
+// NotifyOrderBookUpdate pushes the aggregated buckets touched by an orderbook update, or the whole
+// aggregated book for the limited and cumulative books, to the depth subscribers
+func (s *OrderBookService) NotifyOrderBookUpdate(bt, qt common.Address, bids, asks []map[string]string) {
+	socket := ws.GetOrderBookSocket()
+	bookID := utils.GetOrderBookChannelID(bt, qt)
+
+	s.mutex.Lock()
+	subs := make(map[string]*depthSubscription)
+	for id, sub := range s.depthSubscriptions[bookID] {
+		if !socket.HasSubscriptions(id) {
+			delete(s.depthSubscriptions[bookID], id)
+			continue
+		}
+
+		subs[id] = sub
+	}
+
+	if len(s.depthSubscriptions[bookID]) == 0 {
+		delete(s.depthSubscriptions, bookID)
+	}
+	s.mutex.Unlock()
+
+	if len(subs) == 0 {
+		return
+	}
+
+	var pair *types.Pair
+	for _, sub := range subs {
+		pair = sub.pair
+		break
+	}
+
+	bookBids, bookAsks, err := s.orderDao.GetOrderBook(pair)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	for id, sub := range subs {
+		socket.DeliverMessage(id, &types.OrderBook{
+			PairName: sub.pair.Name(),
+			Bids:     types.AggregateDepthUpdate(bids, bookBids, "pricepoint", true, sub.params),
+			Asks:     types.AggregateDepthUpdate(asks, bookAsks, "pricepoint", false, sub.params),
+		})
+	}
+}
+
 // SubscribeOrderBook is responsible for handling incoming orderbook subscription messages
 // It makes an entry of connection in pairSocket corresponding to pair,unit and duration
-func (s *OrderBookService) SubscribeOrderBook(c *ws.Client, bt, qt common.Address) {
+func (s *OrderBookService) SubscribeOrderBook(c *ws.Client, bt, qt common.Address) error {
 	socket := ws.GetOrderBookSocket()
 
 	ob, err := s.GetOrderBook(bt, qt)
 	if err != nil {
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	id := utils.GetOrderBookChannelID(bt, qt)
 	err = socket.Subscribe(id, c)
 	if err != nil {
-		msg := map[string]string{"Message": err.Error()}
-		socket.SendErrorMessage(c, msg)
-		return
+		return err
 	}
 
 	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
 	socket.SendInitMessage(c, ob)
+	return nil
 }
 
 // UnsubscribeOrderBook is responsible for handling incoming orderbook unsubscription messages
@@ -116,6 +252,12 @@ func (s *OrderBookService) UnsubscribeOrderBookChannel(c *ws.Client, bt, qt comm
 	socket := ws.GetOrderBookSocket()
 	id := utils.GetOrderBookChannelID(bt, qt)
 	socket.UnsubscribeChannel(id, c)
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for depthID := range s.depthSubscriptions[id] {
+		socket.UnsubscribeChannel(depthID, c)
+	}
 }
 
 // GetRawOrderBook fetches complete orderbook from engine
@@ -144,25 +286,23 @@ func (s *OrderBookService) GetRawOrderBook(bt, qt common.Address) (*types.RawOrd
 
 // SubscribeRawOrderBook is responsible for handling incoming orderbook subscription messages
 // It makes an entry of connection in pairSocket corresponding to pair,unit and duration
-func (s *OrderBookService) SubscribeRawOrderBook(c *ws.Client, bt, qt common.Address) {
+func (s *OrderBookService) SubscribeRawOrderBook(c *ws.Client, bt, qt common.Address) error {
 	socket := ws.GetRawOrderBookSocket()
 
 	ob, err := s.GetRawOrderBook(bt, qt)
 	if err != nil {
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	id := utils.GetOrderBookChannelID(bt, qt)
 	err = socket.Subscribe(id, c)
 	if err != nil {
-		msg := map[string]string{"Message": err.Error()}
-		socket.SendErrorMessage(c, msg)
-		return
+		return err
 	}
 
 	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
 	socket.SendInitMessage(c, ob)
+	return nil
 }
 
 // UnsubscribeRawOrderBook is responsible for handling incoming orderbook unsubscription messages
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/math"
+)
+
+const (
+	// portfolioPageSize is the number of trades applied at once when a portfolio is updated, the
+	// portfolio is saved after each page
+	portfolioPageSize = 500
+
+	// portfolioWorkers is the number of workers applying the new trades to the portfolios
+	portfolioWorkers = 4
+
+	// portfolioQueueSize bounds the trades waiting for the workers, the trades dropped when the
+	// queue is full are applied on the next update of the portfolios
+	portfolioQueueSize = 1024
+)
+
+// PortfolioService computes the positions and the PnL of the users from their trades. The
+// portfolios are persisted and only the trades since their last update are applied.
+type PortfolioService struct {
+	portfolioDao interfaces.PortfolioDao
+	snapshotDao  interfaces.PortfolioSnapshotDao
+	tradeDao     interfaces.TradeDao
+	pairDao      interfaces.PairDao
+	ohlcvService interfaces.OHLCVService
+	trades       chan *types.Trade
+
+	// locks serializes the updates of each portfolio, mutex guards it
+	locks map[string]*portfolioLock
+	mutex sync.Mutex
+}
+
+// portfolioLock is the lock of a portfolio, removed when no update holds or waits for it
+type portfolioLock struct {
+	sync.Mutex
+	refs int
+}
+
+// NewPortfolioService returns a new instance of PortfolioService
+func NewPortfolioService(
+	portfolioDao interfaces.PortfolioDao,
+	snapshotDao interfaces.PortfolioSnapshotDao,
+	tradeDao interfaces.TradeDao,
+	pairDao interfaces.PairDao,
+	ohlcvService interfaces.OHLCVService,
+) *PortfolioService {
+	s := &PortfolioService{
+		portfolioDao: portfolioDao,
+		snapshotDao:  snapshotDao,
+		tradeDao:     tradeDao,
+		pairDao:      pairDao,
+		ohlcvService: ohlcvService,
+		trades:       make(chan *types.Trade, portfolioQueueSize),
+		locks:        make(map[string]*portfolioLock),
+	}
+
+	for i := 0; i < portfolioWorkers; i++ {
+		go s.work()
+	}
+
+	return s
+}
+
+// GetPortfolio returns the portfolio of a user for a cost basis method, updated with the trades
+// of the user and marked to the last prices
+func (s *PortfolioService) GetPortfolio(addr common.Address, method string) (*types.Portfolio, error) {
+	p, err := s.update(addr, method)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mark(p)
+	return p, nil
+}
+
+// GetSnapshots returns the snapshots of the portfolio of a user between from and to
+func (s *PortfolioService) GetSnapshots(addr common.Address, method string, from, to int64) ([]*types.PortfolioSnapshot, error) {
+	return s.snapshotDao.GetByUserAddress(addr, method, from, to)
+}
+
+// NotifyTrade queues a new trade to be applied to the portfolios of its maker and taker
+func (s *PortfolioService) NotifyTrade(t *types.Trade) {
+	select {
+	case s.trades <- t:
+	default:
+		logger.Warningf("Portfolio queue is full, trade %s is applied on the next update", t.Hash.Hex())
+	}
+}
+
+// work applies the queued trades to the portfolios of their users
+func (s *PortfolioService) work() {
+	for t := range s.trades {
+		users := []common.Address{t.Maker}
+		if t.Taker != t.Maker {
+			users = append(users, t.Taker)
+		}
+
+		for _, addr := range users {
+			portfolios, err := s.portfolioDao.GetAllByUserAddress(addr)
+			if err != nil {
+				continue
+			}
+
+			for _, p := range portfolios {
+				s.update(addr, p.Method)
+			}
+		}
+	}
+}
+
+// TakeSnapshots records the value and the PnL of all the portfolios
+func (s *PortfolioService) TakeSnapshots() {
+	portfolios, err := s.portfolioDao.GetAll()
+	if err != nil {
+		return
+	}
+
+	now := time.Now().Unix()
+	for _, p := range portfolios {
+		p, err := s.update(p.UserAddress, p.Method)
+		if err != nil {
+			continue
+		}
+
+		s.mark(p)
+		snapshot := &types.PortfolioSnapshot{
+			UserAddress:   p.UserAddress,
+			Method:        p.Method,
+			ValueUsd:      p.ValueUsd.String(),
+			RealizedUsd:   p.RealizedUsd.String(),
+			UnrealizedUsd: p.UnrealizedUsd.String(),
+			Timestamp:     now,
+		}
+
+		s.snapshotDao.Create(snapshot)
+	}
+}
+
+// update applies the new trades of a user to its portfolio, oldest first, and saves it after
+// each page of trades
+func (s *PortfolioService) update(addr common.Address, method string) (*types.Portfolio, error) {
+	unlock := s.lock(addr.Hex() + "/" + method)
+	defer unlock()
+
+	p, err := s.portfolioDao.GetByUserAddress(addr, method)
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		p, err = types.NewPortfolio(addr, method)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pairs := make(map[string]*types.Pair)
+	for {
+		trades, err := s.tradeDao.GetUserTradesFrom(addr, p.LastTradeAt, p.LastTradeHashes, portfolioPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(trades) == 0 && !p.CreatedAt.IsZero() {
+			return p, nil
+		}
+
+		for _, t := range trades {
+			pair, err := s.pair(pairs, t.BaseToken, t.QuoteToken)
+			if err != nil {
+				return nil, err
+			}
+
+			if pair == nil {
+				logger.Warningf("Trade %s of an unknown pair is not applied to the portfolio", t.Hash.Hex())
+				p.SkipTrade(t)
+				continue
+			}
+
+			p.ApplyTrade(t, pair.BaseTokenMultiplier())
+		}
+
+		err = s.portfolioDao.Upsert(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(trades) < portfolioPageSize {
+			return p, nil
+		}
+	}
+}
+
+// lock locks the updates of a portfolio and returns the function unlocking them
+func (s *PortfolioService) lock(key string) func() {
+	s.mutex.Lock()
+	l, ok := s.locks[key]
+	if !ok {
+		l = &portfolioLock{}
+		s.locks[key] = l
+	}
+	l.refs++
+	s.mutex.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		s.mutex.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(s.locks, key)
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// mark values the positions at the last prices of their pairs, or at their cost when their
+// pairs were never traded, and computes the USD values of the portfolio
+func (s *PortfolioService) mark(p *types.Portfolio) {
+	pairs := make(map[string]*types.Pair)
+	p.ValueUsd = big.NewFloat(0)
+	p.RealizedUsd = big.NewFloat(0)
+	p.UnrealizedUsd = big.NewFloat(0)
+
+	for _, pos := range p.Positions {
+		pair, err := s.pair(pairs, pos.BaseToken, pos.QuoteToken)
+		if err != nil || pair == nil {
+			continue
+		}
+
+		price := s.lastPrice(pos.BaseToken, pos.QuoteToken)
+		if price == nil && pos.Amount.Sign() > 0 {
+			price = math.Div(math.Mul(pos.Cost, pair.BaseTokenMultiplier()), pos.Amount)
+		}
+
+		if price == nil {
+			price = big.NewInt(0)
+		}
+
+		pos.Mark(price, pair.BaseTokenMultiplier())
+
+		quoteUsd, err := s.ohlcvService.GetLastPriceCurrentByTime(pair.QuoteTokenSymbol, time.Now())
+		if err != nil || quoteUsd == nil || quoteUsd.Sign() == 0 {
+			continue
+		}
+
+		toUsd := func(amount *big.Int) *big.Float {
+			v := new(big.Float).Mul(new(big.Float).SetInt(amount), quoteUsd)
+			return v.Quo(v, new(big.Float).SetInt(pair.QuoteTokenMultiplier()))
+		}
+
+		pos.ValueUsd = toUsd(pos.Value)
+		pos.RealizedUsd = toUsd(pos.Realized)
+		pos.UnrealizedUsd = toUsd(pos.Unrealized)
+
+		p.ValueUsd.Add(p.ValueUsd, pos.ValueUsd)
+		p.RealizedUsd.Add(p.RealizedUsd, pos.RealizedUsd)
+		p.UnrealizedUsd.Add(p.UnrealizedUsd, pos.UnrealizedUsd)
+	}
+}
+
+// lastPrice returns the close price of the last tick of a pair, or the price of its last trade
+func (s *PortfolioService) lastPrice(baseToken, quoteToken common.Address) *big.Int {
+	data := s.ohlcvService.GetTokenPairData(baseToken, quoteToken)
+	if data != nil && data.Close != nil && data.Close.Sign() > 0 {
+		return data.Close
+	}
+
+	t, err := s.tradeDao.GetLatestTrade(baseToken, quoteToken)
+	if err != nil || t == nil {
+		return nil
+	}
+
+	return t.PricePoint
+}
+
+// pair returns a pair, cached in pairs for the duration of an update
+func (s *PortfolioService) pair(pairs map[string]*types.Pair, baseToken, quoteToken common.Address) (*types.Pair, error) {
+	key := baseToken.Hex() + quoteToken.Hex()
+	if pair, ok := pairs[key]; ok {
+		return pair, nil
+	}
+
+	pair, err := s.pairDao.GetByTokenAddress(baseToken, quoteToken)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	pairs[key] = pair
+	return pair, nil
+}
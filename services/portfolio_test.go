@@ -0,0 +1,118 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+type portfolioStore struct {
+	interfaces.PortfolioDao
+	portfolio *types.Portfolio
+	upserts   int
+}
+
+func (d *portfolioStore) GetByUserAddress(addr common.Address, method string) (*types.Portfolio, error) {
+	return d.portfolio, nil
+}
+
+func (d *portfolioStore) Upsert(p *types.Portfolio) error {
+	p.CreatedAt = time.Unix(1, 0)
+	d.portfolio = p
+	d.upserts++
+	return nil
+}
+
+// tradeStore returns the trades of a user sorted oldest first, as the trades collection does
+type tradeStore struct {
+	interfaces.TradeDao
+	trades []*types.Trade
+	reads  int
+}
+
+func (d *tradeStore) GetUserTradesFrom(a common.Address, from time.Time, excluded []common.Hash, limit int) ([]*types.Trade, error) {
+	d.reads++
+	skip := map[common.Hash]bool{}
+	for _, h := range excluded {
+		skip[h] = true
+	}
+
+	res := []*types.Trade{}
+	for _, t := range d.trades {
+		if len(res) == limit {
+			break
+		}
+
+		if !t.CreatedAt.Before(from) && !skip[t.Hash] {
+			res = append(res, t)
+		}
+	}
+
+	return res, nil
+}
+
+type pairStore struct {
+	interfaces.PairDao
+}
+
+func (d *pairStore) GetByTokenAddress(bt, qt common.Address) (*types.Pair, error) {
+	if qt != common.HexToAddress("0x2") {
+		return nil, nil
+	}
+
+	return &types.Pair{BaseTokenAddress: bt, QuoteTokenAddress: qt}, nil
+}
+
+func TestPortfolioServiceUpdatePages(t *testing.T) {
+	user := common.HexToAddress("0xa")
+	trades := &tradeStore{}
+	for i := 0; i < 2*portfolioPageSize+10; i++ {
+		quote := common.HexToAddress("0x2")
+		if i == 0 {
+			// the trades of unknown pairs are skipped
+			quote = common.HexToAddress("0x3")
+		}
+
+		trades.trades = append(trades.trades, &types.Trade{
+			Taker:          user,
+			Maker:          common.HexToAddress("0xb"),
+			BaseToken:      common.HexToAddress("0x1"),
+			QuoteToken:     quote,
+			Hash:           common.BigToHash(big.NewInt(int64(i + 1))),
+			PricePoint:     big.NewInt(1),
+			Amount:         big.NewInt(1),
+			TakeFee:        big.NewInt(0),
+			MakeFee:        big.NewInt(0),
+			TakerOrderSide: types.BUY,
+			Status:         types.TradeStatusSuccess,
+			// several trades share their creation time
+			CreatedAt: time.Unix(int64(i/3), 0),
+		})
+	}
+
+	portfolios := &portfolioStore{}
+	s := &PortfolioService{
+		portfolioDao: portfolios,
+		tradeDao:     trades,
+		pairDao:      &pairStore{},
+		locks:        make(map[string]*portfolioLock),
+	}
+
+	p, err := s.update(user, types.CostBasisFIFO)
+	assert.Nil(t, err)
+	assert.Equal(t, 2*portfolioPageSize+9, p.TradeCount)
+	assert.Equal(t, 3, portfolios.upserts)
+	assert.Equal(t, 3, trades.reads)
+	assert.Len(t, s.locks, 0)
+
+	// the saved portfolio has no new trades
+	p, err = s.update(user, types.CostBasisFIFO)
+	assert.Nil(t, err)
+	assert.Equal(t, 2*portfolioPageSize+9, p.TradeCount)
+	assert.Equal(t, 3, portfolios.upserts)
+}
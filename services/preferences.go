@@ -0,0 +1,189 @@
+package services
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/ws"
+)
+
+// PreferencesService manages the preferences of the users: their watchlists, chart resolution,
+// display currency and layouts, along with the delivery preferences of their notifications.
+// The preferences are created on first read, the favorite tokens of the account become the
+// Favorites watchlist. The saved preferences are pushed to the other devices of the user.
+type PreferencesService struct {
+	preferencesDao      interfaces.UserPreferencesDao
+	accountDao          interfaces.AccountDao
+	pairDao             interfaces.PairDao
+	notificationService interfaces.NotificationService
+	fiatService         interfaces.FiatService
+}
+
+// NewPreferencesService returns a new instance of PreferencesService
+func NewPreferencesService(
+	preferencesDao interfaces.UserPreferencesDao,
+	accountDao interfaces.AccountDao,
+	pairDao interfaces.PairDao,
+	notificationService interfaces.NotificationService,
+	fiatService interfaces.FiatService,
+) *PreferencesService {
+	return &PreferencesService{
+		preferencesDao:      preferencesDao,
+		accountDao:          accountDao,
+		pairDao:             pairDao,
+		notificationService: notificationService,
+		fiatService:         fiatService,
+	}
+}
+
+// Get returns the preferences of a user, they are created from the favorite tokens of the
+// account if the user has none
+func (s *PreferencesService) Get(addr common.Address) (*types.UserPreferences, error) {
+	p, err := s.preferencesDao.GetByUserAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		p, err = s.migrate(addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p.Notifications, err = s.notificationService.GetPreferences(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// migrate creates the first version of the preferences of a user with the favorite tokens of
+// its account, which are then removed from the account
+func (s *PreferencesService) migrate(addr common.Address) (*types.UserPreferences, error) {
+	p := types.NewUserPreferences(addr, s.fiatService.DefaultCurrency())
+
+	tokens, err := s.accountDao.GetFavoriteTokens(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) > 0 {
+		pairs, err := s.pairDao.GetAll()
+		if err != nil {
+			return nil, err
+		}
+
+		if w := types.FavoritesFromTokens(tokens, pairs); len(w.Items) > 0 {
+			p.Watchlists = append(p.Watchlists, w)
+		}
+	}
+
+	err = s.preferencesDao.Save(p, 0)
+	if err == types.ErrPreferencesVersion {
+		// created by a concurrent request
+		return s.preferencesDao.GetByUserAddress(addr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) > 0 {
+		err = s.accountDao.ClearFavoriteTokens(addr)
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+
+	return p, nil
+}
+
+// Save replaces the preferences of a user if they were not saved since p.Version, it returns
+// types.ErrPreferencesVersion otherwise. The notification preferences, which are not versioned,
+// are checked first and replaced once the versioned save succeeded, so that a stale save
+// changes nothing. On success p is at its new version and is sent to the devices of the user.
+func (s *PreferencesService) Save(p *types.UserPreferences) error {
+	if p.DisplayCurrency == "" {
+		p.DisplayCurrency = s.fiatService.DefaultCurrency()
+	}
+
+	if !s.fiatService.IsSupported(p.DisplayCurrency) {
+		return errors.New("Unsupported currency " + p.DisplayCurrency)
+	}
+
+	err := p.Validate(app.Config.TickDuration)
+	if err != nil {
+		return err
+	}
+
+	if p.Version == 0 {
+		// the preferences are created on first read, they must be read before they are saved
+		return types.ErrPreferencesVersion
+	}
+
+	notifications := p.Notifications
+	if notifications != nil {
+		notifications.UserAddress = p.UserAddress
+		notifications.SetDefaults()
+
+		err = notifications.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.preferencesDao.Save(p, p.Version)
+	if err != nil {
+		return err
+	}
+
+	if notifications != nil {
+		p.Notifications, err = s.notificationService.SavePreferences(notifications)
+	} else {
+		p.Notifications, err = s.notificationService.GetPreferences(p.UserAddress)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ws.GetPreferencesSocket().BroadcastMessage(p.UserAddress.Hex(), p)
+	return nil
+}
+
+// Subscribe sends the preferences of addr and registers the connection for the preferences
+// saved by the other devices, the connection must be logged in with addr
+func (s *PreferencesService) Subscribe(c *ws.Client, addr common.Address) error {
+	err := c.Authorize(ws.PreferencesChannel, addr)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.Get(addr)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	socket := ws.GetPreferencesSocket()
+	id := addr.Hex()
+
+	err = socket.Subscribe(id, c)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
+	socket.SendInitMessage(c, p)
+	return nil
+}
+
+// Unsubscribe removes the connection from the preferences updates of addr
+func (s *PreferencesService) Unsubscribe(c *ws.Client, addr common.Address) {
+	ws.GetPreferencesSocket().UnsubscribeChannel(addr.Hex(), c)
+}
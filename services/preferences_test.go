@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+type stalePreferencesDao struct {
+	interfaces.UserPreferencesDao
+}
+
+func (dao *stalePreferencesDao) Save(p *types.UserPreferences, version int64) error {
+	return types.ErrPreferencesVersion
+}
+
+type recordingNotificationService struct {
+	interfaces.NotificationService
+	saved []*types.NotificationPreferences
+}
+
+func (s *recordingNotificationService) SavePreferences(p *types.NotificationPreferences) (*types.NotificationPreferences, error) {
+	s.saved = append(s.saved, p)
+	return p, nil
+}
+
+type usdFiatService struct {
+	interfaces.FiatService
+}
+
+func (s *usdFiatService) DefaultCurrency() string          { return "USD" }
+func (s *usdFiatService) IsSupported(currency string) bool { return currency == "USD" }
+
+func TestPreferencesSaveStaleVersion(t *testing.T) {
+	app.Config.TickDuration = map[string][]int64{"hour": {1}}
+
+	notificationService := &recordingNotificationService{}
+	s := NewPreferencesService(&stalePreferencesDao{}, nil, nil, notificationService, &usdFiatService{})
+
+	addr := common.HexToAddress("0x1")
+	p := types.NewUserPreferences(addr, "USD")
+	p.Version = 3
+	p.Notifications = types.NewNotificationPreferences(addr)
+
+	err := s.Save(p)
+	assert.Equal(t, types.ErrPreferencesVersion, err)
+
+	// the notification preferences of the stale save are not applied
+	assert.Empty(t, notificationService.saved)
+}
@@ -1,10 +1,13 @@
 package services
 
 import (
+	"math/big"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
 	"github.com/tomochain/tomox-sdk/utils"
@@ -17,6 +20,7 @@ type PriceBoardService struct {
 	TokenDao     interfaces.TokenDao
 	TradeDao     interfaces.TradeDao
 	OHLCVService interfaces.OHLCVService
+	FiatService  interfaces.FiatService
 }
 
 // NewPriceBoardService returns a new instance of TradeService
@@ -24,16 +28,18 @@ func NewPriceBoardService(
 	tokenDao interfaces.TokenDao,
 	tradeDao interfaces.TradeDao,
 	ohlcvService interfaces.OHLCVService,
+	fiatService interfaces.FiatService,
 ) *PriceBoardService {
 	return &PriceBoardService{
 		TokenDao:     tokenDao,
 		TradeDao:     tradeDao,
 		OHLCVService: ohlcvService,
+		FiatService:  fiatService,
 	}
 }
 
 // Subscribe
-func (s *PriceBoardService) Subscribe(c *ws.Client, bt, qt common.Address) {
+func (s *PriceBoardService) Subscribe(c *ws.Client, bt, qt common.Address, currency string) error {
 	socket := ws.GetPriceBoardSocket()
 
 	// Fix the value at 1 day because we only care about 24h change
@@ -48,20 +54,17 @@ func (s *PriceBoardService) Subscribe(c *ws.Client, bt, qt common.Address) {
 
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	quoteToken, err := s.TokenDao.GetByAddress(qt)
 
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 	if quoteToken == nil {
-		socket.SendErrorMessage(c, "Token not found")
-		return
+		return errors.NotFound("Token")
 	}
 	var lastTradePrice string
 	lastTrade, err := s.TradeDao.GetLatestTrade(bt, qt)
@@ -73,35 +76,59 @@ func (s *PriceBoardService) Subscribe(c *ws.Client, bt, qt common.Address) {
 
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
-	id := utils.GetPriceBoardChannelID(bt, qt)
+	usd, err := s.OHLCVService.GetLastPriceCurrentByTime(quoteToken.Symbol, time.Now())
+	if err != nil || usd == nil {
+		usd = big.NewFloat(0)
+	}
+
+	result, err := s.NewPriceBoardData(ticks, usd, lastTradePrice, currency)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	id := utils.GetFiatChannelID(utils.GetPriceBoardChannelID(bt, qt), currency)
 	err = socket.Subscribe(id, c)
 
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
 
-	result := types.PriceBoardData{
-		Ticks:          ticks,
-		PriceUSD:       quoteToken.USD,
-		LastTradePrice: lastTradePrice,
+	socket.SendInitMessage(c, result)
+	return nil
+}
+
+// NewPriceBoardData builds the price board payload with the quote token price valued in the requested currency
+func (s *PriceBoardService) NewPriceBoardData(ticks []*types.Tick, usd *big.Float, lastTradePrice string, currency string) (*types.PriceBoardData, error) {
+	if currency == "" {
+		currency = s.FiatService.DefaultCurrency()
 	}
 
-	socket.SendInitMessage(c, result)
+	price, err := s.FiatService.Convert(usd, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PriceBoardData{
+		Ticks:          ticks,
+		PriceUSD:       usd.String(),
+		PriceFiat:      price.String(),
+		Currency:       strings.ToUpper(currency),
+		LastTradePrice: lastTradePrice,
+	}, nil
 }
 
 // UnsubscribeChannel
-func (s *PriceBoardService) UnsubscribeChannel(c *ws.Client, bt, qt common.Address) {
+func (s *PriceBoardService) UnsubscribeChannel(c *ws.Client, bt, qt common.Address, currency string) {
 	socket := ws.GetPriceBoardSocket()
 
-	id := utils.GetPriceBoardChannelID(bt, qt)
+	id := utils.GetFiatChannelID(utils.GetPriceBoardChannelID(bt, qt), currency)
 	socket.UnsubscribeChannel(id, c)
 }
 
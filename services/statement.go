@@ -0,0 +1,154 @@
+package services
+
+import (
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/math"
+)
+
+// StatementService builds the account statements of the users: their spot trades and fees,
+// their lending trades with the interest and the liquidations, and their collateral topups,
+// valued in a fiat currency at the time of each movement
+type StatementService struct {
+	tradeDao        interfaces.TradeDao
+	lendingTradeDao interfaces.LendingTradeDao
+	topupDao        interfaces.LendingOrderDao
+	tokenDao        interfaces.TokenDao
+	ohlcvService    interfaces.OHLCVService
+	fiatService     interfaces.FiatService
+}
+
+// NewStatementService returns a new instance of StatementService
+func NewStatementService(
+	tradeDao interfaces.TradeDao,
+	lendingTradeDao interfaces.LendingTradeDao,
+	topupDao interfaces.LendingOrderDao,
+	tokenDao interfaces.TokenDao,
+	ohlcvService interfaces.OHLCVService,
+	fiatService interfaces.FiatService,
+) *StatementService {
+	return &StatementService{
+		tradeDao:        tradeDao,
+		lendingTradeDao: lendingTradeDao,
+		topupDao:        topupDao,
+		tokenDao:        tokenDao,
+		ohlcvService:    ohlcvService,
+		fiatService:     fiatService,
+	}
+}
+
+// GetStatement returns the statement of a user on a relayer between from and to, unix
+// timestamps in seconds. The USD prices at the time of the movements are converted with the
+// current rate of the currency.
+func (s *StatementService) GetStatement(addr, relayer common.Address, from, to int64, currency string) (*types.Statement, error) {
+	if currency == "" {
+		currency = s.fiatService.DefaultCurrency()
+	}
+
+	tokens, err := s.tokenDao.GetAll()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	byAddress := make(map[common.Address]types.Token)
+	for _, t := range tokens {
+		byAddress[t.ContractAddress] = t
+	}
+
+	st := &types.Statement{
+		UserAddress: addr,
+		Currency:    strings.ToUpper(currency),
+		From:        time.Unix(from, 0),
+		To:          time.Unix(to, 0),
+		GeneratedAt: time.Now(),
+		Lines:       []*types.StatementLine{},
+	}
+
+	tradeSpec := &types.TradeSpec{RelayerAddress: relayer, DateFrom: from, DateTo: to}
+	err = s.tradeDao.StreamTrades(addr, tradeSpec, func(t *types.Trade) error {
+		base := byAddress[t.BaseToken]
+		multiplier := math.Exp(big.NewInt(10), big.NewInt(int64(base.Decimals)))
+		st.Add(types.TradeStatementLines(t, addr, multiplier)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.lendingTradeDao.StreamUserLendingTrades(addr, relayer, from, to, func(t *types.LendingTrade) error {
+		st.Add(types.LendingTradeStatementLines(t, addr, byAddress[t.LendingToken].Symbol)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	topupSpec := types.LendingSpec{
+		UserAddress:    addr.Hex(),
+		RelayerAddress: relayer,
+		DateFrom:       from,
+		DateTo:         to,
+	}
+
+	topups, err := s.topupDao.GetLendingOrders(topupSpec, []string{"+createdAt"}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range topups.LendingItems {
+		st.Add(types.TopupStatementLine(o, byAddress[o.LendingToken].Symbol))
+	}
+
+	prices := make(map[string]*big.Float)
+	for _, l := range st.Lines {
+		t, ok := byAddress[l.Token]
+		if !ok {
+			logger.Warningf("Statement line %s of the unknown token %s", l.Reference.Hex(), l.Token.Hex())
+			t = types.Token{Decimals: 18}
+		}
+
+		l.Symbol = t.Symbol
+		l.Decimals = t.Decimals
+		l.Quantity = types.FormatUnits(l.Amount, t.Decimals)
+		l.Price = s.price(prices, t.Symbol, l.Time, currency)
+
+		if l.Price != nil {
+			v := new(big.Float).Quo(new(big.Float).SetInt(l.Amount), new(big.Float).SetInt(math.Exp(big.NewInt(10), big.NewInt(int64(t.Decimals)))))
+			l.Value = v.Mul(v, l.Price)
+		}
+	}
+
+	st.Close()
+	return st, nil
+}
+
+// price returns the fiat price of a token at a time, nil if it has no known price. The prices
+// are cached in prices for the lines of the same time.
+func (s *StatementService) price(prices map[string]*big.Float, symbol string, at time.Time, currency string) *big.Float {
+	if symbol == "" {
+		return nil
+	}
+
+	key := symbol + "/" + at.String()
+	if p, ok := prices[key]; ok {
+		return p
+	}
+
+	var price *big.Float
+	usd, err := s.ohlcvService.GetLastPriceCurrentByTime(symbol, at)
+	if err == nil && usd != nil {
+		price, err = s.fiatService.Convert(usd, currency)
+		if err != nil {
+			price = nil
+		}
+	}
+
+	prices[key] = price
+	return price
+}
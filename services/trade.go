@@ -25,6 +25,9 @@ type TradeService struct {
 	ohlcvService    *OHLCVService
 	bulkTrades      map[types.PairAddresses][]*types.Trade
 	mutext          sync.RWMutex
+	tickNotify      func(ticks []*types.Tick, duration int64, unit string)
+	tradeNotify     func(trades []*types.Trade)
+	insertNotify    func(trade *types.Trade)
 }
 
 // NewTradeService returns a new instance of TradeService
@@ -47,28 +50,42 @@ func NewTradeService(
 	}
 }
 
+// RegisterTickNotify register a function called with the updated ticks after each tick broadcast
+func (s *TradeService) RegisterTickNotify(fn func(ticks []*types.Tick, duration int64, unit string)) {
+	s.tickNotify = fn
+}
+
+// RegisterTradeNotify register a function called with the trades of a pair after each trade broadcast
+func (s *TradeService) RegisterTradeNotify(fn func(trades []*types.Trade)) {
+	s.tradeNotify = fn
+}
+
+// RegisterTradeInsertNotify register a function called with each trade inserted by the engine
+func (s *TradeService) RegisterTradeInsertNotify(fn func(trade *types.Trade)) {
+	s.insertNotify = fn
+}
+
 // Subscribe
-func (s *TradeService) Subscribe(c *ws.Client, bt, qt common.Address) {
+func (s *TradeService) Subscribe(c *ws.Client, bt, qt common.Address) error {
 	socket := ws.GetTradeSocket()
 
 	numTrades := types.DefaultLimit
 	trades, err := s.GetSortedTrades(bt, qt, 0, 0, numTrades)
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	id := utils.GetTradeChannelID(bt, qt)
 	err = socket.Subscribe(id, c)
 	if err != nil {
 		logger.Error(err)
-		socket.SendErrorMessage(c, err.Error())
-		return
+		return err
 	}
 
 	ws.RegisterConnectionUnsubscribeHandler(c, socket.UnsubscribeChannelHandler(id))
 	socket.SendInitMessage(c, trades)
+	return nil
 }
 
 // Unsubscribe
@@ -197,6 +214,10 @@ func (s *TradeService) processBulkTrades() {
 		if len(trades) > 0 {
 			id := utils.GetTradeChannelID(pair.BaseToken, pair.QuoteToken)
 			ws.GetTradeSocket().BroadcastMessage(id, trades)
+
+			if s.tradeNotify != nil {
+				s.tradeNotify(trades)
+			}
 		}
 	}
 	s.bulkTrades = make(map[types.PairAddresses][]*types.Trade)
@@ -275,6 +296,10 @@ func (s *TradeService) HandleOperationInsert(trade *types.Trade) error {
 
 	s.HandleTradeSuccess(m)
 
+	if s.insertNotify != nil {
+		s.insertNotify(trade)
+	}
+
 	return nil
 }
 
@@ -365,6 +390,10 @@ func (s *TradeService) broadcastTickUpdate(pairs []types.PairAddresses) {
 				id := utils.GetTickChannelID(baseTokenAddress, quoteTokenAddress, unit, duration)
 				ws.GetOHLCVSocket().BroadcastOHLCV(id, tick)
 			}
+
+			if s.tickNotify != nil {
+				s.tickNotify(ticks, duration, unit)
+			}
 		}
 	}
 }
@@ -389,3 +418,9 @@ func (s *TradeService) GetTrades(tradeSpec *types.TradeSpec, sortedBy []string,
 func (s *TradeService) GetTradesUserHistory(a common.Address, tradeSpec *types.TradeSpec, sortedBy []string, pageOffset int, pageSize int) (*types.TradeRes, error) {
 	return s.tradeDao.GetTradesUserHistory(a, tradeSpec, sortedBy, pageOffset, pageSize)
 }
+
+// StreamTrades calls fn on each trade matching the spec from the oldest to the most recent,
+// restricted to the trades of a user unless a is the zero address
+func (s *TradeService) StreamTrades(a common.Address, tradeSpec *types.TradeSpec, fn func(*types.Trade) error) error {
+	return s.tradeDao.StreamTrades(a, tradeSpec, fn)
+}
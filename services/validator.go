@@ -8,21 +8,20 @@ import (
 	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/interfaces"
 	"github.com/tomochain/tomox-sdk/types"
-	"github.com/tomochain/tomox-sdk/utils"
 	"github.com/tomochain/tomox-sdk/utils/math"
 )
 
 type ValidatorService struct {
-	ethereumProvider interfaces.EthereumProvider
-	accountDao       interfaces.AccountDao
-	orderDao         interfaces.OrderDao
-	lendingDao       interfaces.LendingOrderDao
-	pairDao          interfaces.PairDao
-	tokenDao         interfaces.TokenDao
+	balanceService interfaces.BalanceService
+	accountDao     interfaces.AccountDao
+	orderDao       interfaces.OrderDao
+	lendingDao     interfaces.LendingOrderDao
+	pairDao        interfaces.PairDao
+	tokenDao       interfaces.TokenDao
 }
 
 func NewValidatorService(
-	ethereumProvider interfaces.EthereumProvider,
+	balanceService interfaces.BalanceService,
 	accountDao interfaces.AccountDao,
 	orderDao interfaces.OrderDao,
 	lendingDao interfaces.LendingOrderDao,
@@ -31,7 +30,7 @@ func NewValidatorService(
 ) *ValidatorService {
 
 	return &ValidatorService{
-		ethereumProvider,
+		balanceService,
 		accountDao,
 		orderDao,
 		lendingDao,
@@ -51,40 +50,18 @@ func (s *ValidatorService) ValidateAvailablExchangeBalance(o *types.Order) error
 
 	totalRequiredAmount := o.TotalRequiredSellAmount(pair)
 
-	var sellTokenBalance *big.Int
-
-	// we implement retries in the case the provider connection fell asleep
-	err = utils.Retry(3, func() error {
-		sellTokenBalance, err = s.ethereumProvider.Balance(o.UserAddress, o.SellToken())
-		return nil
-	})
-
-	if err != nil {
-		logger.Error(err)
-		return err
-	}
-	listPairs, err := s.pairDao.GetActivePairs()
-	if err != nil {
-		logger.Error(err)
-		return err
-	}
-	tokens, err := s.tokenDao.GetAll()
+	balance, err := s.balanceService.GetTokenBalance(o.UserAddress, o.SellToken())
 	if err != nil {
 		logger.Error(err)
 		return err
 	}
-	sellExchangeTokenLockedBalance, err := s.orderDao.GetUserLockedBalance(o.UserAddress, o.SellToken(), listPairs)
-	if err != nil {
-		logger.Error(err)
-		return err
-	}
-	sellLendingTokenLockedBalance, err := s.lendingDao.GetUserLockedBalance(o.UserAddress, o.SellToken(), tokens)
-	if err != nil {
-		logger.Error(err)
-		return err
+
+	if balance == nil {
+		return errors.New("Token not found")
 	}
-	sellTokenLockedBalance := new(big.Int).Add(sellExchangeTokenLockedBalance, sellLendingTokenLockedBalance)
-	availableSellTokenBalance := math.Sub(sellTokenBalance, sellTokenLockedBalance)
+
+	sellTokenBalance := balance.Balance
+	availableSellTokenBalance := balance.AvailableBalance
 
 	//Sell Token Balance
 	if sellTokenBalance.Cmp(totalRequiredAmount) == -1 {
@@ -101,7 +78,6 @@ func (s *ValidatorService) ValidateAvailablExchangeBalance(o *types.Order) error
 // ValidateAvailablLendingBalance validate avalable lending order
 func (s *ValidatorService) ValidateAvailablLendingBalance(o *types.LendingOrder) error {
 
-	var sellTokenBalance *big.Int
 	var totalRequiredAmount *big.Int
 	var err error
 	sellToken := o.CollateralToken
@@ -132,41 +108,18 @@ func (s *ValidatorService) ValidateAvailablLendingBalance(o *types.LendingOrder)
 		totalRequiredAmount = collateralAmount
 
 	}
-	err = utils.Retry(3, func() error {
-		sellTokenBalance, err = s.ethereumProvider.Balance(o.UserAddress, sellToken)
-		return err
-	})
-
+	balance, err := s.balanceService.GetTokenBalance(o.UserAddress, sellToken)
 	if err != nil {
 		logger.Error(err, "addr:", sellToken.Hex())
 		return err
 	}
-	tokens, err := s.tokenDao.GetAll()
-	if err != nil {
-		logger.Error(err)
-		return err
-	}
-	tokenInfo := types.TokensFrom(sellToken, tokens)
-	if tokenInfo == nil {
+
+	if balance == nil {
 		return errors.New("Token not found")
 	}
-	listPairs, err := s.pairDao.GetActivePairs()
-	if err != nil {
-		logger.Error(err)
-		return err
-	}
-	sellExchangeTokenLockedBalance, err := s.orderDao.GetUserLockedBalance(o.UserAddress, sellToken, listPairs)
-	if err != nil {
-		logger.Error(err)
-		return err
-	}
-	sellLendingTokenLockedBalance, err := s.lendingDao.GetUserLockedBalance(o.UserAddress, sellToken, tokens)
-	if err != nil {
-		logger.Error(err)
-		return err
-	}
-	sellTokenLockedBalance := new(big.Int).Add(sellExchangeTokenLockedBalance, sellLendingTokenLockedBalance)
-	availableSellTokenBalance := math.Sub(sellTokenBalance, sellTokenLockedBalance)
+
+	sellTokenBalance := balance.Balance
+	availableSellTokenBalance := balance.AvailableBalance
 
 	//Sell Token Balance
 	if sellTokenBalance.Cmp(totalRequiredAmount) == -1 {
@@ -0,0 +1,423 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/interfaces"
+	"github.com/tomochain/tomox-sdk/rabbitmq"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+const (
+	// webhookCacheTTL bounds the time the changes of the webhooks made on another instance take
+	// to be applied
+	webhookCacheTTL = 30 * time.Second
+
+	webhookTimeout     = 10 * time.Second
+	webhookConcurrency = 16
+
+	// maxReplay limits the number of deliveries replayed by a request
+	maxReplay = 1000
+)
+
+// WebhookService dispatches the events of the exchange to the registered webhooks. The
+// deliveries are logged and queued in RabbitMQ, they are retried with an exponential backoff.
+type WebhookService struct {
+	webhookDao  interfaces.WebhookDao
+	deliveryDao interfaces.WebhookDeliveryDao
+	broker      *rabbitmq.Connection
+	client      *http.Client
+	webhooks    []*types.Webhook
+	loadedAt    time.Time
+	mutex       sync.Mutex
+}
+
+// NewWebhookService returns a new instance of WebhookService
+func NewWebhookService(
+	webhookDao interfaces.WebhookDao,
+	deliveryDao interfaces.WebhookDeliveryDao,
+	broker *rabbitmq.Connection,
+) *WebhookService {
+	return &WebhookService{
+		webhookDao:  webhookDao,
+		deliveryDao: deliveryDao,
+		broker:      broker,
+		client:      &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Start declares the delivery queues and consumes the deliveries
+func (s *WebhookService) Start() error {
+	err := s.broker.DeclareWebhookQueues()
+	if err != nil {
+		return err
+	}
+
+	return s.broker.SubscribeWebhookDeliveries(webhookConcurrency, s.Deliver)
+}
+
+// Create registers a webhook with a new secret, which is only returned by this call
+func (s *WebhookService) Create(w *types.Webhook) (*types.Webhook, error) {
+	err := w.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	w.Secret = hex.EncodeToString(secret)
+	w.Active = true
+
+	err = s.webhookDao.Create(w)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate()
+	return w, nil
+}
+
+// Update replaces the url, the filters and the status of a webhook
+func (s *WebhookService) Update(w *types.Webhook) (*types.Webhook, error) {
+	existing, err := s.webhookDao.GetByID(w.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return nil, errors.New("Webhook not found")
+	}
+
+	err = w.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.webhookDao.Update(w)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate()
+
+	w.Secret = ""
+	w.CreatedAt = existing.CreatedAt
+	return w, nil
+}
+
+// Delete removes a webhook, its pending deliveries fail on their next attempt
+func (s *WebhookService) Delete(id bson.ObjectId) error {
+	err := s.webhookDao.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// GetAll returns the webhooks without their secrets
+func (s *WebhookService) GetAll() ([]*types.Webhook, error) {
+	webhooks, err := s.webhookDao.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range webhooks {
+		w.Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+// GetByOwner returns the webhooks registered by a user without their secrets
+func (s *WebhookService) GetByOwner(owner common.Address) ([]*types.Webhook, error) {
+	webhooks, err := s.webhookDao.GetByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range webhooks {
+		w.Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+// GetByID returns a webhook without its secret, nil if it does not exist
+func (s *WebhookService) GetByID(id bson.ObjectId) (*types.Webhook, error) {
+	w, err := s.webhookDao.GetByID(id)
+	if err != nil || w == nil {
+		return nil, err
+	}
+
+	w.Secret = ""
+	return w, nil
+}
+
+// GetDeliveries returns the deliveries of a webhook, the latest first
+func (s *WebhookService) GetDeliveries(spec *types.WebhookDeliverySpec, offset, size int) (*types.WebhookDeliveryRes, error) {
+	return s.deliveryDao.Query(spec, offset, size)
+}
+
+// Replay queues a delivery again with its original payload, with all its attempts
+func (s *WebhookService) Replay(id bson.ObjectId) (*types.WebhookDelivery, error) {
+	d, err := s.deliveryDao.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if d == nil {
+		return nil, errors.New("Delivery not found")
+	}
+
+	err = s.replay(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// ReplayAll queues again the deliveries of a webhook matching the spec, for example the
+// failed deliveries of an outage of the endpoint. It returns the number of replayed deliveries.
+func (s *WebhookService) ReplayAll(spec *types.WebhookDeliverySpec) (int, error) {
+	res, err := s.deliveryDao.Query(spec, 0, maxReplay)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, d := range res.Deliveries {
+		if err := s.replay(d); err != nil {
+			return i, err
+		}
+	}
+
+	return len(res.Deliveries), nil
+}
+
+func (s *WebhookService) replay(d *types.WebhookDelivery) error {
+	d.Status = types.DeliveryStatusPending
+	d.Attempts = 0
+	d.ResponseCode = 0
+	d.Error = ""
+	d.NextRetryAt = time.Now()
+
+	err := s.deliveryDao.UpdateStatus(d)
+	if err != nil {
+		return err
+	}
+
+	return s.broker.PublishWebhookDelivery(&types.WebhookDeliveryMessage{DeliveryID: d.ID}, 0)
+}
+
+// NotifyEngineResponse dispatches the status changes of the orders
+func (s *WebhookService) NotifyEngineResponse(res *types.EngineResponse) {
+	switch res.Status {
+	case types.ORDER_ADDED, types.ORDER_PARTIALLY_FILLED, types.ORDER_FILLED, types.ORDER_CANCELLED, types.ORDER_REJECTED:
+	default:
+		return
+	}
+
+	o := res.Order
+	if o == nil {
+		return
+	}
+
+	s.dispatch(res.Status, []common.Address{o.UserAddress, o.ExchangeAddress}, o)
+}
+
+// NotifyTrade dispatches the trades inserted by the engine
+func (s *WebhookService) NotifyTrade(t *types.Trade) {
+	s.dispatch(types.WebhookTradeAdded, []common.Address{t.Maker, t.Taker, t.MakerExchange, t.TakerExchange}, t)
+}
+
+// NotifyLendingOrderResponse dispatches the topups, repayments and recalls of the loans
+func (s *WebhookService) NotifyLendingOrderResponse(res *types.EngineResponse) {
+	switch res.Status {
+	case types.LENDING_ORDER_TOPUPED, types.LENDING_ORDER_REPAYED, types.LENDING_ORDER_RECALLED:
+	default:
+		return
+	}
+
+	o := res.LendingOrder
+	if o == nil {
+		return
+	}
+
+	s.dispatch(res.Status, []common.Address{o.UserAddress, o.RelayerAddress}, o)
+}
+
+// NotifyLendingTradeUpdate dispatches the liquidations of the loans
+func (s *WebhookService) NotifyLendingTradeUpdate(t *types.LendingTrade) {
+	if t.Status != types.TradeStatusLiquidated {
+		return
+	}
+
+	s.dispatch(types.WebhookLendingLiquidated, []common.Address{t.Borrower, t.Investor}, t)
+}
+
+// dispatch logs and queues a delivery of the event for each matching webhook
+func (s *WebhookService) dispatch(event string, addresses []common.Address, data interface{}) {
+	webhooks, err := s.activeWebhooks()
+	if err != nil {
+		return
+	}
+
+	var body []byte
+	for _, w := range webhooks {
+		if !w.Matches(event, addresses) {
+			continue
+		}
+
+		// the payload is shared by the deliveries of the event, its id identifies the event
+		if body == nil {
+			body, err = json.Marshal(&types.WebhookPayload{
+				ID:        bson.NewObjectId(),
+				Event:     event,
+				Data:      data,
+				CreatedAt: time.Now(),
+			})
+			if err != nil {
+				logger.Error(err)
+				return
+			}
+		}
+
+		d := &types.WebhookDelivery{
+			WebhookID:   w.ID,
+			Event:       event,
+			Body:        string(body),
+			Status:      types.DeliveryStatusPending,
+			NextRetryAt: time.Now(),
+		}
+
+		err := s.deliveryDao.Create(d)
+		if err != nil {
+			continue
+		}
+
+		err = s.broker.PublishWebhookDelivery(&types.WebhookDeliveryMessage{DeliveryID: d.ID}, 0)
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+}
+
+// Deliver makes an attempt of a queued delivery, a failed attempt is queued again after the
+// backoff delay until the maximum number of attempts
+func (s *WebhookService) Deliver(m *types.WebhookDeliveryMessage) error {
+	d, err := s.deliveryDao.GetByID(m.DeliveryID)
+	if err != nil {
+		return err
+	}
+
+	if d == nil || d.Status != types.DeliveryStatusPending {
+		return nil
+	}
+
+	w, err := s.webhookDao.GetByID(d.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	if w == nil || !w.Active {
+		d.Status = types.DeliveryStatusFailed
+		d.Error = "Webhook removed or disabled"
+		return s.deliveryDao.UpdateStatus(d)
+	}
+
+	d.Attempts++
+	d.ResponseCode, err = s.post(w, d)
+	if err == nil {
+		d.Status = types.DeliveryStatusSuccess
+		d.Error = ""
+		return s.deliveryDao.UpdateStatus(d)
+	}
+
+	d.Error = err.Error()
+	if d.Attempts >= types.WebhookMaxAttempts {
+		d.Status = types.DeliveryStatusFailed
+		return s.deliveryDao.UpdateStatus(d)
+	}
+
+	d.NextRetryAt = time.Now().Add(types.WebhookRetryDelay(d.Attempts))
+	err = s.deliveryDao.UpdateStatus(d)
+	if err != nil {
+		return err
+	}
+
+	return s.broker.PublishWebhookDelivery(m, d.Attempts)
+}
+
+// post sends the signed payload of the delivery, any response other than 2xx is an error
+func (s *WebhookService) post(w *types.Webhook, d *types.WebhookDelivery) (int, error) {
+	body := []byte(d.Body)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequest("POST", w.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(types.WebhookEventHeader, d.Event)
+	req.Header.Set(types.WebhookDeliveryHeader, d.ID.Hex())
+	req.Header.Set(types.WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(types.WebhookSignatureHeader, types.SignWebhookPayload(w.Secret, timestamp, body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, errors.New("Webhook responded with status " + strconv.Itoa(res.StatusCode))
+	}
+
+	return res.StatusCode, nil
+}
+
+// activeWebhooks returns the webhooks, cached for webhookCacheTTL
+func (s *WebhookService) activeWebhooks() ([]*types.Webhook, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.webhooks != nil && time.Since(s.loadedAt) < webhookCacheTTL {
+		return s.webhooks, nil
+	}
+
+	webhooks, err := s.webhookDao.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	s.webhooks = webhooks
+	s.loadedAt = time.Now()
+	return webhooks, nil
+}
+
+func (s *WebhookService) invalidate() {
+	s.mutex.Lock()
+	s.webhooks = nil
+	s.mutex.Unlock()
+}
@@ -286,6 +286,8 @@ type TokenBalance struct {
 	AvailableBalance *big.Int       `json:"availableBalance" bson:"availableBalance"`
 	InOrderBalance   *big.Int       `json:"inOrderBalance" bson:"inOrderBalance"`
 	InUsdBalance     *big.Float     `json:"inUsdBalance" bson:"inUsdBalance"`
+	InFiatBalance    *big.Float     `json:"inFiatBalance,omitempty" bson:"-"`
+	FiatCurrency     string         `json:"fiatCurrency,omitempty" bson:"-"`
 }
 
 // MarshalJSON implements the json.Marshal interface
@@ -300,6 +302,11 @@ func (t *TokenBalance) MarshalJSON() ([]byte, error) {
 		"availableBalance": t.AvailableBalance.String(),
 	}
 
+	if t.InFiatBalance != nil {
+		tb["inFiatBalance"] = t.InFiatBalance.String()
+		tb["fiatCurrency"] = t.FiatCurrency
+	}
+
 	return json.Marshal(tb)
 }
 
@@ -355,8 +362,3 @@ type TokenBalanceRecord struct {
 	AvailableBalance string `json:"availableBalance" base:"availableBalance"`
 	InOrderBalance   string `json:"inOrderBalance" bson:"inOrderBalance"`
 }
-
-type FavoriteTokenRequest struct {
-	Address string `json:"address" bson:"address"`
-	Token   string `json:"token" bson:"token"`
-}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Lease is held by the leader of a cluster of SDK instances until it expires
+type Lease struct {
+	Name      string    `json:"name" bson:"_id"`
+	Owner     string    `json:"owner" bson:"owner"`
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+// RelayMessage is a websocket message published by an instance of a cluster and delivered
+// by every instance to the clients connected to it. ChannelID is the id of the channel
+// subscription, or the user address on the private channels.
+type RelayMessage struct {
+	Channel   string            `json:"channel"`
+	ChannelID string            `json:"channelId"`
+	Type      SubscriptionEvent `json:"type"`
+	Payload   json.RawMessage   `json:"payload"`
+}
+
+// ClusterEvent is an event produced by the leader of a cluster and handled by every instance,
+// it feeds the streams computed from the subscriptions of each instance (depth, indicators, l3)
+type ClusterEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
@@ -0,0 +1,277 @@
+package types
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/errors"
+)
+
+// Statuses of the compliance entries. The blocked addresses cannot send any order, the
+// restricted addresses can only cancel their orders and repay or top up their loans.
+const (
+	ComplianceBlocked    = "BLOCKED"
+	ComplianceRestricted = "RESTRICTED"
+)
+
+// Actions of the compliance audit log
+const (
+	ComplianceActionBlock   = "BLOCK"
+	ComplianceActionUnblock = "UNBLOCK"
+	ComplianceActionExpire  = "EXPIRE"
+)
+
+// Sources of the compliance audit log
+const (
+	ComplianceSourceAPI    = "API"
+	ComplianceSourceCSV    = "CSV"
+	ComplianceSourceExpiry = "EXPIRY"
+)
+
+var (
+	// ErrAddressBlocked is returned for the orders of the blocked addresses
+	ErrAddressBlocked = errors.New("Address is blocked")
+
+	// ErrAddressRestricted is returned for the new orders of the restricted addresses
+	ErrAddressRestricted = errors.New("Address is restricted to cancelling orders and repaying loans")
+)
+
+// ComplianceEntry blocks or restricts an address until it expires, an entry without
+// expiration time is kept until the address is unblocked
+type ComplianceEntry struct {
+	Address   common.Address `json:"address"`
+	Status    string         `json:"status"`
+	Reason    string         `json:"reason"`
+	ExpiresAt time.Time      `json:"expiresAt"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// ComplianceEntryRecord is the bson representation of a ComplianceEntry, the expiration time
+// is omitted when it is not set so that the entry is never swept
+type ComplianceEntryRecord struct {
+	Address   string     `bson:"address"`
+	Status    string     `bson:"status"`
+	Reason    string     `bson:"reason"`
+	ExpiresAt *time.Time `bson:"expiresAt,omitempty"`
+	CreatedAt time.Time  `bson:"createdAt"`
+	UpdatedAt time.Time  `bson:"updatedAt"`
+}
+
+func (e *ComplianceEntry) GetBSON() (interface{}, error) {
+	r := ComplianceEntryRecord{
+		Address:   e.Address.Hex(),
+		Status:    e.Status,
+		Reason:    e.Reason,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+
+	if !e.ExpiresAt.IsZero() {
+		r.ExpiresAt = &e.ExpiresAt
+	}
+
+	return r, nil
+}
+
+func (e *ComplianceEntry) SetBSON(raw bson.Raw) error {
+	decoded := &ComplianceEntryRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	e.Address = common.HexToAddress(decoded.Address)
+	e.Status = decoded.Status
+	e.Reason = decoded.Reason
+	e.ExpiresAt = time.Time{}
+	if decoded.ExpiresAt != nil {
+		e.ExpiresAt = *decoded.ExpiresAt
+	}
+	e.CreatedAt = decoded.CreatedAt
+	e.UpdatedAt = decoded.UpdatedAt
+	return nil
+}
+
+// Validate checks the address, the status and the reason of the entry
+func (e *ComplianceEntry) Validate() error {
+	if (e.Address == common.Address{}) {
+		return errors.New("Invalid address")
+	}
+
+	if e.Status != ComplianceBlocked && e.Status != ComplianceRestricted {
+		return errors.New("Status must be BLOCKED or RESTRICTED")
+	}
+
+	if strings.TrimSpace(e.Reason) == "" {
+		return errors.New("Reason is required")
+	}
+
+	return nil
+}
+
+// IsActive returns true if the entry is not expired at now
+func (e *ComplianceEntry) IsActive(now time.Time) bool {
+	return e.ExpiresAt.IsZero() || e.ExpiresAt.After(now)
+}
+
+// CheckOpen returns an error if the entry forbids new orders at now, a nil entry forbids nothing
+func (e *ComplianceEntry) CheckOpen(now time.Time) error {
+	if e == nil || !e.IsActive(now) {
+		return nil
+	}
+
+	if e.Status == ComplianceRestricted {
+		return ErrAddressRestricted
+	}
+
+	return ErrAddressBlocked
+}
+
+// CheckClose returns an error if the entry forbids cancels and repayments at now
+func (e *ComplianceEntry) CheckClose(now time.Time) error {
+	if e == nil || !e.IsActive(now) || e.Status == ComplianceRestricted {
+		return nil
+	}
+
+	return ErrAddressBlocked
+}
+
+// ParseComplianceCSV parses the entries of a CSV file whose header names the columns address,
+// status, reason and expiresAt. The status defaults to BLOCKED and the expiration time, in
+// RFC 3339 format, is optional.
+func ParseComplianceCSV(r io.Reader) ([]*ComplianceEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("Missing CSV header")
+	}
+
+	columns := map[string]int{}
+	for i, h := range header {
+		columns[strings.TrimSpace(h)] = i
+	}
+
+	if _, ok := columns["address"]; !ok {
+		return nil, errors.New("Missing address column")
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	entries := []*ComplianceEntry{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.Errorf("Line %d: %v", line, err)
+		}
+
+		addr := field(record, "address")
+		if !common.IsHexAddress(addr) {
+			return nil, errors.Errorf("Line %d: invalid address %q", line, addr)
+		}
+
+		e := &ComplianceEntry{
+			Address: common.HexToAddress(addr),
+			Status:  strings.ToUpper(field(record, "status")),
+			Reason:  field(record, "reason"),
+		}
+
+		if e.Status == "" {
+			e.Status = ComplianceBlocked
+		}
+
+		if s := field(record, "expiresAt"); s != "" {
+			e.ExpiresAt, err = time.Parse(time.RFC3339, s)
+			if err != nil {
+				return nil, errors.Errorf("Line %d: invalid expiration time %q", line, s)
+			}
+		}
+
+		if err := e.Validate(); err != nil {
+			return nil, errors.Errorf("Line %d: %v", line, err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// ComplianceAuditEntry records a block, an unblock or an expiry of an address
+type ComplianceAuditEntry struct {
+	ID        bson.ObjectId  `json:"id"`
+	Address   common.Address `json:"address"`
+	Action    string         `json:"action"`
+	Status    string         `json:"status,omitempty"`
+	Reason    string         `json:"reason"`
+	ExpiresAt time.Time      `json:"expiresAt"`
+	Source    string         `json:"source"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// ComplianceAuditEntryRecord is the bson representation of a ComplianceAuditEntry
+type ComplianceAuditEntryRecord struct {
+	ID        bson.ObjectId `bson:"_id"`
+	Address   string        `bson:"address"`
+	Action    string        `bson:"action"`
+	Status    string        `bson:"status"`
+	Reason    string        `bson:"reason"`
+	ExpiresAt time.Time     `bson:"expiresAt"`
+	Source    string        `bson:"source"`
+	CreatedAt time.Time     `bson:"createdAt"`
+}
+
+func (a *ComplianceAuditEntry) GetBSON() (interface{}, error) {
+	return ComplianceAuditEntryRecord{
+		ID:        a.ID,
+		Address:   a.Address.Hex(),
+		Action:    a.Action,
+		Status:    a.Status,
+		Reason:    a.Reason,
+		ExpiresAt: a.ExpiresAt,
+		Source:    a.Source,
+		CreatedAt: a.CreatedAt,
+	}, nil
+}
+
+func (a *ComplianceAuditEntry) SetBSON(raw bson.Raw) error {
+	decoded := &ComplianceAuditEntryRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	a.ID = decoded.ID
+	a.Address = common.HexToAddress(decoded.Address)
+	a.Action = decoded.Action
+	a.Status = decoded.Status
+	a.Reason = decoded.Reason
+	a.ExpiresAt = decoded.ExpiresAt
+	a.Source = decoded.Source
+	a.CreatedAt = decoded.CreatedAt
+	return nil
+}
+
+// ComplianceAuditRes is a page of the compliance audit log
+type ComplianceAuditRes struct {
+	Total   int                     `json:"total"`
+	Entries []*ComplianceAuditEntry `json:"entries"`
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplianceEntryChecks(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	var e *ComplianceEntry
+	assert.Nil(t, e.CheckOpen(now))
+	assert.Nil(t, e.CheckClose(now))
+
+	e = &ComplianceEntry{Address: common.HexToAddress("0x1"), Status: ComplianceRestricted, Reason: "review"}
+	assert.Equal(t, ErrAddressRestricted, e.CheckOpen(now))
+	assert.Nil(t, e.CheckClose(now))
+
+	e.Status = ComplianceBlocked
+	assert.Equal(t, ErrAddressBlocked, e.CheckOpen(now))
+	assert.Equal(t, ErrAddressBlocked, e.CheckClose(now))
+
+	e.ExpiresAt = now
+	assert.Nil(t, e.CheckOpen(now))
+	assert.Nil(t, e.CheckClose(now))
+}
+
+func TestComplianceEntryBSON(t *testing.T) {
+	e := &ComplianceEntry{
+		Address:   common.HexToAddress("0x1"),
+		Status:    ComplianceBlocked,
+		Reason:    "sanctions",
+		CreatedAt: time.Unix(1000, 0).UTC(),
+		UpdatedAt: time.Unix(1000, 0).UTC(),
+	}
+
+	data, err := bson.Marshal(e)
+	assert.Nil(t, err)
+
+	m := bson.M{}
+	assert.Nil(t, bson.Unmarshal(data, m))
+	_, ok := m["expiresAt"]
+	assert.False(t, ok)
+
+	decoded := &ComplianceEntry{}
+	assert.Nil(t, bson.Unmarshal(data, decoded))
+	assert.Equal(t, e.Address, decoded.Address)
+	assert.True(t, decoded.ExpiresAt.IsZero())
+}
+
+func TestParseComplianceCSV(t *testing.T) {
+	data := "address,status,reason,expiresAt\n" +
+		"0x0000000000000000000000000000000000000001,,sanctions,\n" +
+		"0x0000000000000000000000000000000000000002,restricted,review,2030-01-01T00:00:00Z\n"
+
+	entries, err := ParseComplianceCSV(strings.NewReader(data))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, ComplianceBlocked, entries[0].Status)
+	assert.True(t, entries[0].ExpiresAt.IsZero())
+	assert.Equal(t, ComplianceRestricted, entries[1].Status)
+	assert.Equal(t, 2030, entries[1].ExpiresAt.Year())
+
+	_, err = ParseComplianceCSV(strings.NewReader("address,reason\n0x1,sanctions\n"))
+	assert.EqualError(t, err, `Line 2: invalid address "0x1"`)
+
+	_, err = ParseComplianceCSV(strings.NewReader("address\n0x0000000000000000000000000000000000000001\n"))
+	assert.EqualError(t, err, "Line 2: Reason is required")
+
+	_, err = ParseComplianceCSV(strings.NewReader("wallet,reason\n"))
+	assert.NotNil(t, err)
+}
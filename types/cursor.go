@@ -0,0 +1,112 @@
+package types
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/errors"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor can not be decoded
+var ErrInvalidCursor = errors.New("Invalid cursor")
+
+// Cursor is the position of a document in a list sorted by createdAt then _id.
+// A cursor with a zero ID requests the first page.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        bson.ObjectId
+	Ascending bool
+}
+
+// NewCursor returns the cursor of the first page of a list
+func NewCursor(ascending bool) *Cursor {
+	return &Cursor{Ascending: ascending}
+}
+
+// DecodeCursor parses an opaque cursor, an empty string being the first page in the given order
+func DecodeCursor(s string, ascending bool) (*Cursor, error) {
+	if s == "" {
+		return NewCursor(ascending), nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.Split(string(b), ":")
+	if len(parts) != 3 || (parts[0] != "a" && parts[0] != "d") || !bson.IsObjectIdHex(parts[2]) {
+		return nil, ErrInvalidCursor
+	}
+
+	ns, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{
+		CreatedAt: time.Unix(0, ns).UTC(),
+		ID:        bson.ObjectIdHex(parts[2]),
+		Ascending: parts[0] == "a",
+	}, nil
+}
+
+// Encode returns the opaque representation of the cursor
+func (c *Cursor) Encode() string {
+	direction := "d"
+	if c.Ascending {
+		direction = "a"
+	}
+
+	s := direction + ":" + strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + c.ID.Hex()
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// IsFirstPage returns true if the cursor does not point after a document
+func (c *Cursor) IsFirstPage() bool {
+	return c.ID == ""
+}
+
+// Sort returns the sort of the documents paginated with the cursor
+func (c *Cursor) Sort() []string {
+	if c.Ascending {
+		return []string{"+createdAt", "+_id"}
+	}
+
+	return []string{"-createdAt", "-_id"}
+}
+
+// Query returns the condition selecting the documents after the cursor
+func (c *Cursor) Query() bson.M {
+	if c.IsFirstPage() {
+		return bson.M{}
+	}
+
+	op := "$lt"
+	if c.Ascending {
+		op = "$gt"
+	}
+
+	// createdAt is stored with a millisecond precision
+	createdAt := c.CreatedAt.Truncate(time.Millisecond)
+	return bson.M{
+		"$or": []bson.M{
+			{"createdAt": bson.M{op: createdAt}},
+			{"createdAt": createdAt, "_id": bson.M{op: c.ID}},
+		},
+	}
+}
+
+// Next returns the encoded cursor of the page following a page of n documents ending with the
+// given document, or an empty string when the page is not full and there is nothing left
+func (c *Cursor) Next(n, size int, createdAt time.Time, id bson.ObjectId) string {
+	if n == 0 || n < size {
+		return ""
+	}
+
+	next := &Cursor{CreatedAt: createdAt, ID: id, Ascending: c.Ascending}
+	return next.Encode()
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorEncodeDecode(t *testing.T) {
+	c := &Cursor{
+		CreatedAt: time.Unix(1570000000, 123000000).UTC(),
+		ID:        bson.NewObjectId(),
+		Ascending: true,
+	}
+
+	decoded, err := DecodeCursor(c.Encode(), false)
+	assert.Nil(t, err)
+	assert.Equal(t, c, decoded)
+	assert.False(t, decoded.IsFirstPage())
+}
+
+func TestDecodeEmptyCursor(t *testing.T) {
+	c, err := DecodeCursor("", true)
+	assert.Nil(t, err)
+	assert.True(t, c.IsFirstPage())
+	assert.True(t, c.Ascending)
+	assert.Equal(t, bson.M{}, c.Query())
+	assert.Equal(t, []string{"+createdAt", "+_id"}, c.Sort())
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	for _, s := range []string{"%%%", "YWJj", (&Cursor{}).Encode()} {
+		_, err := DecodeCursor(s, false)
+		assert.Equal(t, ErrInvalidCursor, err, s)
+	}
+}
+
+func TestCursorQuery(t *testing.T) {
+	id := bson.NewObjectId()
+	createdAt := time.Unix(1570000000, 123456789).UTC()
+	c := &Cursor{CreatedAt: createdAt, ID: id}
+
+	expected := bson.M{
+		"$or": []bson.M{
+			{"createdAt": bson.M{"$lt": createdAt.Truncate(time.Millisecond)}},
+			{"createdAt": createdAt.Truncate(time.Millisecond), "_id": bson.M{"$lt": id}},
+		},
+	}
+
+	assert.Equal(t, expected, c.Query())
+	assert.Equal(t, []string{"-createdAt", "-_id"}, c.Sort())
+}
+
+func TestCursorNext(t *testing.T) {
+	c := NewCursor(false)
+	id := bson.NewObjectId()
+	createdAt := time.Unix(1570000000, 0).UTC()
+
+	assert.Equal(t, "", c.Next(0, 10, createdAt, id))
+	assert.Equal(t, "", c.Next(9, 10, createdAt, id))
+
+	next, err := DecodeCursor(c.Next(10, 10, createdAt, id), true)
+	assert.Nil(t, err)
+	assert.Equal(t, &Cursor{CreatedAt: createdAt, ID: id, Ascending: false}, next)
+}
@@ -0,0 +1,220 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/tomochain/tomox-sdk/errors"
+)
+
+const (
+	// LendingInterestDecimals is the number of decimals of the lending interest rates (in percent)
+	LendingInterestDecimals = 8
+
+	// MaxDepthLevels bounds the number of levels returned for each side of an aggregated book
+	MaxDepthLevels = 1000
+)
+
+var (
+	// ErrInvalidTickSize is returned when the tick size of an aggregated book is not a positive multiple of the token precision
+	ErrInvalidTickSize = errors.New("Invalid tick size")
+
+	// ErrInvalidDepthLevels is returned when the number of levels of an aggregated book is out of range
+	ErrInvalidDepthLevels = errors.New("Invalid levels")
+)
+
+// DepthParams describes how an order book is aggregated.
+// TickSize is expressed in the raw units of the book (pricepoint or interest), a nil or zero
+// tick size keeps the raw price levels. Levels limits the number of levels on each side and
+// Cumulative adds the running total of the amounts from the top of the book.
+type DepthParams struct {
+	TickSize   *big.Int
+	Levels     int
+	Cumulative bool
+}
+
+// Key returns a representation of the params used to build channel ids
+func (p *DepthParams) Key() string {
+	tick := "0"
+	if p.TickSize != nil {
+		tick = p.TickSize.String()
+	}
+
+	return fmt.Sprintf("%s::%d::%t", tick, p.Levels, p.Cumulative)
+}
+
+// NewDepthParams validates and builds the aggregation params of a book whose prices have the given number of decimals.
+// An empty tick size keeps the raw price levels.
+func NewDepthParams(tickSize string, decimals int, levels int, cumulative bool) (*DepthParams, error) {
+	if levels < 0 || levels > MaxDepthLevels {
+		return nil, ErrInvalidDepthLevels
+	}
+
+	p := &DepthParams{Levels: levels, Cumulative: cumulative}
+	if tickSize != "" {
+		tick, err := ParseTickSize(tickSize, decimals)
+		if err != nil {
+			return nil, err
+		}
+
+		p.TickSize = tick
+	}
+
+	return p, nil
+}
+
+// IsGrouped returns true if the params change the raw order book
+func (p *DepthParams) IsGrouped() bool {
+	return p != nil && ((p.TickSize != nil && p.TickSize.Sign() > 0) || p.Levels > 0 || p.Cumulative)
+}
+
+// ParseTickSize converts a tick size expressed in human readable units (for example 0.01)
+// into raw units with the given number of decimals
+func ParseTickSize(s string, decimals int) (*big.Int, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || r.Sign() <= 0 {
+		return nil, ErrInvalidTickSize
+	}
+
+	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	r.Mul(r, new(big.Rat).SetInt(multiplier))
+	if !r.IsInt() {
+		return nil, ErrInvalidTickSize
+	}
+
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// BucketOf returns the bucket of a price level. Bids (and borrow) are rounded down and asks (and lend)
+// are rounded up so that grouped levels never cross the spread.
+func BucketOf(price, tick *big.Int, roundUp bool) *big.Int {
+	if tick == nil || tick.Sign() <= 0 {
+		return new(big.Int).Set(price)
+	}
+
+	q, m := new(big.Int).DivMod(price, tick, new(big.Int))
+	if roundUp && m.Sign() > 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	return q.Mul(q, tick)
+}
+
+// AggregateDepth groups the levels of one side of an order book by tick size. key is the name of the
+// price field of the levels ("pricepoint" or "interest"), descending is true for bids and borrow.
+// The result is sorted from the top of the book, limited to the requested number of levels and
+// carries a "total" field with the cumulative amount when requested.
+func AggregateDepth(levels []map[string]string, key string, descending bool, p *DepthParams) []map[string]string {
+	var tick *big.Int
+	if p != nil {
+		tick = p.TickSize
+	}
+
+	buckets := make(map[string]*big.Int)
+	prices := make(map[string]*big.Int)
+	for _, l := range levels {
+		price, ok := new(big.Int).SetString(l[key], 10)
+		if !ok {
+			continue
+		}
+
+		amount, ok := new(big.Int).SetString(l["amount"], 10)
+		if !ok || amount.Sign() <= 0 {
+			continue
+		}
+
+		bucket := BucketOf(price, tick, !descending)
+		k := bucket.String()
+		if _, ok := buckets[k]; !ok {
+			buckets[k] = new(big.Int)
+			prices[k] = bucket
+		}
+
+		buckets[k].Add(buckets[k], amount)
+	}
+
+	keys := make([]string, 0, len(buckets))
+	for k := range buckets {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		c := prices[keys[i]].Cmp(prices[keys[j]])
+		if descending {
+			return c > 0
+		}
+
+		return c < 0
+	})
+
+	if p != nil && p.Levels > 0 && len(keys) > p.Levels {
+		keys = keys[:p.Levels]
+	}
+
+	res := make([]map[string]string, 0, len(keys))
+	total := new(big.Int)
+	for _, k := range keys {
+		l := map[string]string{
+			key:      k,
+			"amount": buckets[k].String(),
+		}
+
+		if p != nil && p.Cumulative {
+			total.Add(total, buckets[k])
+			l["total"] = total.String()
+		}
+
+		res = append(res, l)
+	}
+
+	return res
+}
+
+// IsSnapshot returns true if the updates of the aggregated book are whole books. The levels
+// and totals of a limited or cumulative book depend on the whole book, which a list of touched
+// buckets would leave stale.
+func (p *DepthParams) IsSnapshot() bool {
+	return p != nil && (p.Levels > 0 || p.Cumulative)
+}
+
+// AggregateDepthUpdate returns the buckets touched by a raw order book update with their
+// amount in the aggregated book, empty buckets being sent with a zero amount. When the params
+// are a snapshot, the whole aggregated side of the book is returned instead, it replaces the
+// previous one.
+func AggregateDepthUpdate(update, book []map[string]string, key string, descending bool, p *DepthParams) []map[string]string {
+	if p.IsSnapshot() {
+		return AggregateDepth(book, key, descending, p)
+	}
+
+	grouped := AggregateDepth(book, key, descending, &DepthParams{TickSize: p.TickSize})
+	amounts := make(map[string]string)
+	for _, l := range grouped {
+		amounts[l[key]] = l["amount"]
+	}
+
+	seen := make(map[string]bool)
+	res := []map[string]string{}
+	for _, l := range update {
+		price, ok := new(big.Int).SetString(l[key], 10)
+		if !ok {
+			continue
+		}
+
+		k := BucketOf(price, p.TickSize, !descending).String()
+		if seen[k] {
+			continue
+		}
+
+		seen[k] = true
+		amount, ok := amounts[k]
+		if !ok {
+			amount = "0"
+		}
+
+		res = append(res, map[string]string{key: k, "amount": amount})
+	}
+
+	return res
+}
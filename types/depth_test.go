@@ -0,0 +1,107 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTickSize(t *testing.T) {
+	tick, err := ParseTickSize("0.01", 6)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10000), tick)
+
+	tick, err = ParseTickSize("1", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1), tick)
+
+	for _, s := range []string{"", "abc", "0", "-1", "0.001"} {
+		_, err := ParseTickSize(s, 2)
+		assert.Equal(t, ErrInvalidTickSize, err, s)
+	}
+}
+
+func TestNewDepthParams(t *testing.T) {
+	p, err := NewDepthParams("", 18, 0, false)
+	assert.Nil(t, err)
+	assert.False(t, p.IsGrouped())
+
+	_, err = NewDepthParams("", 18, MaxDepthLevels+1, false)
+	assert.Equal(t, ErrInvalidDepthLevels, err)
+}
+
+func TestBucketOf(t *testing.T) {
+	tick := big.NewInt(10)
+	assert.Equal(t, big.NewInt(120), BucketOf(big.NewInt(125), tick, false))
+	assert.Equal(t, big.NewInt(130), BucketOf(big.NewInt(125), tick, true))
+	assert.Equal(t, big.NewInt(120), BucketOf(big.NewInt(120), tick, true))
+	assert.Equal(t, big.NewInt(125), BucketOf(big.NewInt(125), nil, true))
+}
+
+func TestAggregateDepth(t *testing.T) {
+	bids := []map[string]string{
+		{"pricepoint": "99", "amount": "1"},
+		{"pricepoint": "101", "amount": "2"},
+		{"pricepoint": "105", "amount": "3"},
+		{"pricepoint": "92", "amount": "4"},
+	}
+
+	res := AggregateDepth(bids, "pricepoint", true, &DepthParams{TickSize: big.NewInt(10), Cumulative: true})
+	assert.Equal(t, []map[string]string{
+		{"pricepoint": "100", "amount": "5", "total": "5"},
+		{"pricepoint": "90", "amount": "5", "total": "10"},
+	}, res)
+
+	asks := []map[string]string{
+		{"interest": "101", "amount": "2"},
+		{"interest": "111", "amount": "1"},
+		{"interest": "120", "amount": "6"},
+		{"interest": "135", "amount": "0"},
+	}
+
+	res = AggregateDepth(asks, "interest", false, &DepthParams{TickSize: big.NewInt(10), Levels: 1})
+	assert.Equal(t, []map[string]string{{"interest": "110", "amount": "2"}}, res)
+}
+
+func TestAggregateDepthUpdate(t *testing.T) {
+	book := []map[string]string{
+		{"pricepoint": "101", "amount": "2"},
+		{"pricepoint": "105", "amount": "3"},
+	}
+
+	update := []map[string]string{
+		{"pricepoint": "105", "amount": "3"},
+		{"pricepoint": "101", "amount": "2"},
+		{"pricepoint": "95", "amount": "0"},
+	}
+
+	res := AggregateDepthUpdate(update, book, "pricepoint", true, &DepthParams{TickSize: big.NewInt(10)})
+	assert.Equal(t, []map[string]string{
+		{"pricepoint": "100", "amount": "5"},
+		{"pricepoint": "90", "amount": "0"},
+	}, res)
+}
+
+func TestAggregateDepthUpdateSnapshot(t *testing.T) {
+	book := []map[string]string{
+		{"pricepoint": "101", "amount": "2"},
+		{"pricepoint": "95", "amount": "1"},
+		{"pricepoint": "85", "amount": "4"},
+	}
+
+	update := []map[string]string{
+		{"pricepoint": "85", "amount": "4"},
+	}
+
+	// the update is outside of the levels, the totals still have to be sent
+	p := &DepthParams{TickSize: big.NewInt(10), Levels: 2, Cumulative: true}
+	res := AggregateDepthUpdate(update, book, "pricepoint", true, p)
+	assert.Equal(t, []map[string]string{
+		{"pricepoint": "100", "amount": "2", "total": "2"},
+		{"pricepoint": "90", "amount": "1", "total": "3"},
+	}, res)
+
+	plain := &DepthParams{TickSize: big.NewInt(10)}
+	assert.NotEqual(t, plain.Key(), (&DepthParams{TickSize: big.NewInt(10), Cumulative: true}).Key())
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"math/big"
+	"time"
+)
+
+// Export formats of the order and trade history
+const (
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatCSV    = "csv"
+)
+
+// OrderCSVHeader is the header of the CSV export of orders
+var OrderCSVHeader = []string{
+	"id", "hash", "userAddress", "exchangeAddress", "pairName", "baseToken", "quoteToken",
+	"side", "type", "status", "pricepoint", "amount", "filledAmount", "nonce", "createdAt", "updatedAt",
+}
+
+// TradeCSVHeader is the header of the CSV export of trades
+var TradeCSVHeader = []string{
+	"id", "hash", "txHash", "pairName", "baseToken", "quoteToken", "maker", "taker",
+	"makerOrderHash", "takerOrderHash", "takerOrderSide", "pricepoint", "amount",
+	"makeFee", "takeFee", "status", "createdAt",
+}
+
+// CSVRecord returns the fields of the order in the order of OrderCSVHeader
+func (o *Order) CSVRecord() []string {
+	return []string{
+		o.ID.Hex(),
+		o.Hash.Hex(),
+		o.UserAddress.Hex(),
+		o.ExchangeAddress.Hex(),
+		o.PairName,
+		o.BaseToken.Hex(),
+		o.QuoteToken.Hex(),
+		o.Side,
+		o.Type,
+		o.Status,
+		csvBigInt(o.PricePoint),
+		csvBigInt(o.Amount),
+		csvBigInt(o.FilledAmount),
+		csvBigInt(o.Nonce),
+		csvTime(o.CreatedAt),
+		csvTime(o.UpdatedAt),
+	}
+}
+
+// CSVRecord returns the fields of the trade in the order of TradeCSVHeader
+func (t *Trade) CSVRecord() []string {
+	return []string{
+		t.ID.Hex(),
+		t.Hash.Hex(),
+		t.TxHash.Hex(),
+		t.PairName,
+		t.BaseToken.Hex(),
+		t.QuoteToken.Hex(),
+		t.Maker.Hex(),
+		t.Taker.Hex(),
+		t.MakerOrderHash.Hex(),
+		t.TakerOrderHash.Hex(),
+		t.TakerOrderSide,
+		csvBigInt(t.PricePoint),
+		csvBigInt(t.Amount),
+		csvBigInt(t.MakeFee),
+		csvBigInt(t.TakeFee),
+		t.Status,
+		csvTime(t.CreatedAt),
+	}
+}
+
+func csvBigInt(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+
+	return n.String()
+}
+
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderCSVRecord(t *testing.T) {
+	o := &Order{
+		ID:           bson.NewObjectId(),
+		UserAddress:  common.HexToAddress("0x1"),
+		PairName:     "TOMO/USDT",
+		Side:         BUY,
+		Status:       OrderStatusOpen,
+		PricePoint:   big.NewInt(100),
+		Amount:       big.NewInt(10),
+		FilledAmount: big.NewInt(0),
+		CreatedAt:    time.Unix(1570000000, 0),
+	}
+
+	r := o.CSVRecord()
+	assert.Equal(t, len(OrderCSVHeader), len(r))
+	assert.Equal(t, o.ID.Hex(), r[0])
+	assert.Equal(t, "100", r[10])
+	assert.Equal(t, "", r[13])
+	assert.Equal(t, "2019-10-02T07:06:40Z", r[14])
+	assert.Equal(t, "", r[15])
+}
+
+func TestTradeCSVRecord(t *testing.T) {
+	tr := &Trade{
+		ID:         bson.NewObjectId(),
+		PairName:   "TOMO/USDT",
+		PricePoint: big.NewInt(100),
+		Amount:     big.NewInt(10),
+	}
+
+	r := tr.CSVRecord()
+	assert.Equal(t, len(TradeCSVHeader), len(r))
+	assert.Equal(t, "TOMO/USDT", r[3])
+	assert.Equal(t, "10", r[12])
+	assert.Equal(t, "", r[13])
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+)
+
+// FixAccount is the account of a FIX session. The orders of the session are signed with the
+// delegated key of the account identified by Address, which is only stored encrypted in the
+// Keystore JSON with the passphrase of the operator.
+type FixAccount struct {
+	ID           bson.ObjectId  `json:"id"`
+	SenderCompID string         `json:"senderCompId"`
+	PasswordHash string         `json:"-"`
+	Address      common.Address `json:"address"`
+	Keystore     string         `json:"-"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+// FixAccountRecord is the bson representation of a FixAccount
+type FixAccountRecord struct {
+	ID           bson.ObjectId `bson:"_id"`
+	SenderCompID string        `bson:"senderCompId"`
+	PasswordHash string        `bson:"passwordHash"`
+	Address      string        `bson:"address"`
+	Keystore     string        `bson:"keystore"`
+	CreatedAt    time.Time     `bson:"createdAt"`
+}
+
+func (a *FixAccount) GetBSON() (interface{}, error) {
+	return FixAccountRecord{
+		ID:           a.ID,
+		SenderCompID: a.SenderCompID,
+		PasswordHash: a.PasswordHash,
+		Address:      a.Address.Hex(),
+		Keystore:     a.Keystore,
+		CreatedAt:    a.CreatedAt,
+	}, nil
+}
+
+func (a *FixAccount) SetBSON(raw bson.Raw) error {
+	decoded := &FixAccountRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	a.ID = decoded.ID
+	a.SenderCompID = decoded.SenderCompID
+	a.PasswordHash = decoded.PasswordHash
+	a.Address = common.HexToAddress(decoded.Address)
+	a.Keystore = decoded.Keystore
+	a.CreatedAt = decoded.CreatedAt
+	return nil
+}
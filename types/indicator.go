@@ -0,0 +1,229 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/tomochain/tomox-sdk/errors"
+)
+
+// Supported technical indicators
+const (
+	IndicatorSMA       = "sma"
+	IndicatorEMA       = "ema"
+	IndicatorRSI       = "rsi"
+	IndicatorMACD      = "macd"
+	IndicatorBollinger = "bb"
+	IndicatorVWAP      = "vwap"
+
+	// maxIndicatorPeriod bounds the number of candles fetched to warm up an indicator
+	maxIndicatorPeriod = 500
+
+	// maxIndicators bounds the number of indicators of a request
+	maxIndicators = 10
+
+	// MaxIndicatorCandles bounds the number of candles of a request, warm-up included
+	MaxIndicatorCandles = 5000
+)
+
+// IndicatorSpec describes an indicator and its parameters, it is parsed from strings of the form
+// sma:20, ema:50, rsi:14, macd:12:26:9, bb:20:2 or vwap
+type IndicatorSpec struct {
+	Name       string   `json:"name"`
+	Period     int      `json:"period,omitempty"`
+	Fast       int      `json:"fast,omitempty"`
+	Slow       int      `json:"slow,omitempty"`
+	Signal     int      `json:"signal,omitempty"`
+	Multiplier *big.Rat `json:"-"`
+}
+
+// ParseIndicatorSpecs parses a comma separated list of indicators
+func ParseIndicatorSpecs(s string) ([]IndicatorSpec, error) {
+	specs := make([]IndicatorSpec, 0)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		spec, err := ParseIndicatorSpec(item)
+		if err != nil {
+			return nil, err
+		}
+
+		specs = append(specs, spec)
+	}
+
+	if len(specs) == 0 {
+		return nil, errors.New("No indicator requested")
+	}
+
+	if len(specs) > maxIndicators {
+		return nil, errors.Errorf("At most %d indicators can be requested", maxIndicators)
+	}
+
+	return specs, nil
+}
+
+// IndicatorsLookback returns the number of candles needed before the first value of all the indicators
+func IndicatorsLookback(specs []IndicatorSpec) int {
+	lookback := 0
+	for _, s := range specs {
+		if l := s.Lookback(); l > lookback {
+			lookback = l
+		}
+	}
+
+	return lookback
+}
+
+// CheckIndicatorRange returns an error if the indicators cannot be computed over the candles of
+// interval seconds between from and to: the range is invalid or needs, with the candles of the
+// warm-up, more than MaxIndicatorCandles candles
+func CheckIndicatorRange(from, to, interval int64, specs []IndicatorSpec) error {
+	if interval <= 0 {
+		return errors.New("Invalid time interval")
+	}
+
+	if from > to {
+		return errors.New("from must not be after to")
+	}
+
+	if (to-from)/interval+int64(IndicatorsLookback(specs)) > MaxIndicatorCandles {
+		return errors.Errorf("At most %d candles, warm-up included, can be requested", MaxIndicatorCandles)
+	}
+
+	return nil
+}
+
+// ParseIndicatorSpec parses a single indicator, using the usual default parameters when omitted
+func ParseIndicatorSpec(s string) (IndicatorSpec, error) {
+	parts := strings.Split(strings.ToLower(s), ":")
+	spec := IndicatorSpec{Name: parts[0]}
+	args := parts[1:]
+
+	ints := make([]int, 0)
+	switch spec.Name {
+	case IndicatorSMA, IndicatorEMA:
+		ints = []int{20}
+	case IndicatorRSI:
+		ints = []int{14}
+	case IndicatorMACD:
+		ints = []int{12, 26, 9}
+	case IndicatorBollinger:
+		ints = []int{20}
+	case IndicatorVWAP:
+	default:
+		return spec, fmt.Errorf("Unknown indicator %s", spec.Name)
+	}
+
+	if spec.Name == IndicatorBollinger {
+		spec.Multiplier = big.NewRat(2, 1)
+		if len(args) > 1 {
+			m, ok := new(big.Rat).SetString(args[1])
+			if !ok || m.Sign() <= 0 {
+				return spec, fmt.Errorf("Invalid multiplier for indicator %s", s)
+			}
+
+			spec.Multiplier = m
+		}
+
+		if len(args) > 2 {
+			return spec, fmt.Errorf("Too many parameters for indicator %s", s)
+		}
+
+		args = args[:minInt(len(args), 1)]
+	}
+
+	if len(args) > len(ints) {
+		return spec, fmt.Errorf("Too many parameters for indicator %s", s)
+	}
+
+	for i, a := range args {
+		v, err := strconv.Atoi(a)
+		if err != nil || v <= 0 || v > maxIndicatorPeriod {
+			return spec, fmt.Errorf("Invalid parameter for indicator %s", s)
+		}
+
+		ints[i] = v
+	}
+
+	switch spec.Name {
+	case IndicatorMACD:
+		spec.Fast, spec.Slow, spec.Signal = ints[0], ints[1], ints[2]
+		if spec.Fast >= spec.Slow {
+			return spec, fmt.Errorf("Fast period must be lower than slow period for indicator %s", s)
+		}
+	case IndicatorVWAP:
+	default:
+		spec.Period = ints[0]
+	}
+
+	return spec, nil
+}
+
+// Lookback returns the number of candles needed before the first value of the indicator is available
+func (s IndicatorSpec) Lookback() int {
+	switch s.Name {
+	case IndicatorMACD:
+		return s.Slow + s.Signal
+	case IndicatorRSI:
+		return s.Period + 1
+	default:
+		return s.Period
+	}
+}
+
+// String returns the canonical representation of the indicator
+func (s IndicatorSpec) String() string {
+	switch s.Name {
+	case IndicatorMACD:
+		return fmt.Sprintf("%s:%d:%d:%d", s.Name, s.Fast, s.Slow, s.Signal)
+	case IndicatorBollinger:
+		return fmt.Sprintf("%s:%d:%s", s.Name, s.Period, s.Multiplier.RatString())
+	case IndicatorVWAP:
+		return s.Name
+	default:
+		return fmt.Sprintf("%s:%d", s.Name, s.Period)
+	}
+}
+
+// IndicatorSpecsKey returns the canonical representation of a list of indicators
+func IndicatorSpecsKey(specs []IndicatorSpec) string {
+	keys := make([]string, len(specs))
+	for i, s := range specs {
+		keys[i] = s.String()
+	}
+
+	return strings.Join(keys, ",")
+}
+
+// IndicatorPoint holds the values of an indicator at the timestamp of a candle.
+// Single line indicators use the "value" key, MACD uses "macd", "signal" and "histogram"
+// and Bollinger bands use "middle", "upper" and "lower".
+type IndicatorPoint struct {
+	Timestamp int64             `json:"timestamp"`
+	Values    map[string]string `json:"values"`
+}
+
+// IndicatorSeries is the result of the computation of an indicator over a candle series
+type IndicatorSeries struct {
+	Indicator string            `json:"indicator"`
+	Points    []*IndicatorPoint `json:"points"`
+}
+
+// OHLCVIndicatorData is sent to subscribers of the ohlcv channels requesting indicators
+type OHLCVIndicatorData struct {
+	Ticks      interface{}        `json:"ticks"`
+	Indicators []*IndicatorSeries `json:"indicators"`
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
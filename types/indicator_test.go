@@ -0,0 +1,40 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIndicatorSpecs(t *testing.T) {
+	specs, err := ParseIndicatorSpecs("sma:10, EMA ,rsi:7,macd:5:10:3,bb:20:2.5,vwap")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 6, len(specs))
+	assert.Equal(t, IndicatorSpec{Name: IndicatorSMA, Period: 10}, specs[0])
+	assert.Equal(t, IndicatorSpec{Name: IndicatorEMA, Period: 20}, specs[1])
+	assert.Equal(t, 8, specs[2].Lookback())
+	assert.Equal(t, 13, specs[3].Lookback())
+	assert.Equal(t, "sma:10,ema:20,rsi:7,macd:5:10:3,bb:20:5/2,vwap", IndicatorSpecsKey(specs))
+}
+
+func TestParseIndicatorSpecsErrors(t *testing.T) {
+	for _, s := range []string{"", "foo", "sma:0", "sma:abc", "sma:10:20", "rsi:501", "macd:26:12:9", "bb:20:-1", "bb:20:2:1"} {
+		_, err := ParseIndicatorSpecs(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestCheckIndicatorRange(t *testing.T) {
+	specs, _ := ParseIndicatorSpecs("rsi:14,macd")
+
+	assert.Nil(t, CheckIndicatorRange(0, 3600*1000, 3600, specs))
+	assert.Error(t, CheckIndicatorRange(0, 3600*5000, 3600, specs))
+	assert.Error(t, CheckIndicatorRange(3600, 0, 3600, specs))
+	assert.Error(t, CheckIndicatorRange(0, 3600, 0, specs))
+
+	_, err := ParseIndicatorSpecs("sma,sma,sma,sma,sma,sma,sma,sma,sma,sma,sma")
+	assert.Error(t, err)
+}
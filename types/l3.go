@@ -0,0 +1,301 @@
+package types
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Level 3 orderbook event types
+const (
+	L3Add    = "ADD"
+	L3Modify = "MODIFY"
+	L3Delete = "DELETE"
+	L3Match  = "MATCH"
+)
+
+// L3Order is the public view of a resting order, it does not carry the user address, the signature
+// or the order hash which are replaced by an anonymized id
+type L3Order struct {
+	ID            string    `json:"id"`
+	Side          string    `json:"side"`
+	PricePoint    *big.Int  `json:"pricepoint"`
+	Amount        *big.Int  `json:"amount"`
+	QueuePosition int       `json:"queuePosition"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// L3Event describes a change of a resting order. Amount is the remaining amount of the order,
+// MatchedAmount the amount filled by a MATCH event. Sequence is the sequence of the order update,
+// shared by its events.
+type L3Event struct {
+	Type          string    `json:"type"`
+	Sequence      uint64    `json:"sequence"`
+	PairName      string    `json:"pairName"`
+	ID            string    `json:"id"`
+	Side          string    `json:"side"`
+	PricePoint    *big.Int  `json:"pricepoint"`
+	Amount        *big.Int  `json:"amount"`
+	MatchedAmount *big.Int  `json:"matchedAmount,omitempty"`
+	QueuePosition int       `json:"queuePosition"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// L3OrderBook is a snapshot of the resting orders of a pair, the events with a sequence
+// number greater than Sequence apply to it
+type L3OrderBook struct {
+	PairName string     `json:"pairName"`
+	Sequence uint64     `json:"sequence"`
+	Bids     []*L3Order `json:"bids"`
+	Asks     []*L3Order `json:"asks"`
+}
+
+type l3Entry struct {
+	hash         common.Hash
+	id           string
+	side         string
+	pricePoint   *big.Int
+	amount       *big.Int
+	filledAmount *big.Int
+	createdAt    time.Time
+}
+
+// L3Book keeps the resting orders of a pair in time priority at each price level
+// and turns order updates into level 3 events. It is not safe for concurrent use.
+type L3Book struct {
+	pairName  string
+	sequence  uint64
+	anonymize func(common.Hash) string
+	orders    map[common.Hash]*l3Entry
+	levels    map[string][]*l3Entry
+}
+
+// NewL3Book returns an empty book, anonymize maps an order hash to its public id
+func NewL3Book(pairName string, anonymize func(common.Hash) string) *L3Book {
+	return &L3Book{
+		pairName:  pairName,
+		anonymize: anonymize,
+		orders:    make(map[common.Hash]*l3Entry),
+		levels:    make(map[string][]*l3Entry),
+	}
+}
+
+// Load adds resting orders to the book without generating events, they include the order
+// updates up to sequence
+func (b *L3Book) Load(orders []*Order, sequence uint64) {
+	if sequence > b.sequence {
+		b.sequence = sequence
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+
+	for _, o := range orders {
+		if isL3Resting(o) {
+			if _, ok := b.orders[o.Hash]; !ok {
+				b.insert(o)
+			}
+		}
+	}
+}
+
+// Apply updates the book with the latest state of an order at sequence and returns the resulting
+// events. The updates older than the book are ignored, the updates without sequence are numbered
+// after the last one.
+func (b *L3Book) Apply(o *Order, sequence uint64) []*L3Event {
+	events := []*L3Event{}
+	if sequence == 0 {
+		sequence = b.sequence + 1
+	}
+
+	if sequence < b.sequence {
+		return events
+	}
+
+	b.sequence = sequence
+	e, known := b.orders[o.Hash]
+
+	if !known {
+		if !isL3Resting(o) {
+			return events
+		}
+
+		e = b.insert(o)
+		return append(events, b.event(L3Add, e, nil, o.UpdatedAt))
+	}
+
+	filled := o.FilledAmount
+	if filled == nil {
+		filled = big.NewInt(0)
+	}
+
+	if filled.Cmp(e.filledAmount) > 0 {
+		matched := new(big.Int).Sub(filled, e.filledAmount)
+		e.filledAmount = new(big.Int).Set(filled)
+		e.amount = remainingAmount(o)
+		events = append(events, b.event(L3Match, e, matched, o.UpdatedAt))
+	}
+
+	if !isL3Resting(o) {
+		events = append(events, b.event(L3Delete, e, nil, o.UpdatedAt))
+		b.remove(e)
+		return events
+	}
+
+	if remaining := remainingAmount(o); remaining.Cmp(e.amount) != 0 {
+		e.amount = remaining
+		events = append(events, b.event(L3Modify, e, nil, o.UpdatedAt))
+	}
+
+	return events
+}
+
+// Snapshot returns the resting orders of the book, bids by descending price and asks by ascending price
+func (b *L3Book) Snapshot() *L3OrderBook {
+	ob := &L3OrderBook{
+		PairName: b.pairName,
+		Sequence: b.sequence,
+		Bids:     []*L3Order{},
+		Asks:     []*L3Order{},
+	}
+
+	for _, level := range b.levels {
+		for i, e := range level {
+			o := &L3Order{
+				ID:            e.id,
+				Side:          e.side,
+				PricePoint:    e.pricePoint,
+				Amount:        e.amount,
+				QueuePosition: i,
+				CreatedAt:     e.createdAt,
+			}
+
+			if e.side == BUY {
+				ob.Bids = append(ob.Bids, o)
+			} else {
+				ob.Asks = append(ob.Asks, o)
+			}
+		}
+	}
+
+	sortL3Orders(ob.Bids, true)
+	sortL3Orders(ob.Asks, false)
+	return ob
+}
+
+func (b *L3Book) insert(o *Order) *l3Entry {
+	filled := big.NewInt(0)
+	if o.FilledAmount != nil {
+		filled = new(big.Int).Set(o.FilledAmount)
+	}
+
+	e := &l3Entry{
+		hash:         o.Hash,
+		id:           b.anonymize(o.Hash),
+		side:         o.Side,
+		pricePoint:   new(big.Int).Set(o.PricePoint),
+		amount:       remainingAmount(o),
+		filledAmount: filled,
+		createdAt:    o.CreatedAt,
+	}
+
+	key := l3LevelKey(e.side, e.pricePoint)
+	level := b.levels[key]
+	i := sort.Search(len(level), func(i int) bool {
+		return level[i].createdAt.After(e.createdAt)
+	})
+
+	level = append(level, nil)
+	copy(level[i+1:], level[i:])
+	level[i] = e
+
+	b.levels[key] = level
+	b.orders[o.Hash] = e
+	return e
+}
+
+func (b *L3Book) remove(e *l3Entry) {
+	key := l3LevelKey(e.side, e.pricePoint)
+	level := b.levels[key]
+	for i, x := range level {
+		if x == e {
+			level = append(level[:i], level[i+1:]...)
+			break
+		}
+	}
+
+	if len(level) == 0 {
+		delete(b.levels, key)
+	} else {
+		b.levels[key] = level
+	}
+
+	delete(b.orders, e.hash)
+}
+
+func (b *L3Book) position(e *l3Entry) int {
+	for i, x := range b.levels[l3LevelKey(e.side, e.pricePoint)] {
+		if x == e {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (b *L3Book) event(t string, e *l3Entry, matched *big.Int, ts time.Time) *L3Event {
+	return &L3Event{
+		Type:          t,
+		Sequence:      b.sequence,
+		PairName:      b.pairName,
+		ID:            e.id,
+		Side:          e.side,
+		PricePoint:    e.pricePoint,
+		Amount:        new(big.Int).Set(e.amount),
+		MatchedAmount: matched,
+		QueuePosition: b.position(e),
+		Timestamp:     ts,
+	}
+}
+
+func isL3Resting(o *Order) bool {
+	if o.PricePoint == nil || o.Amount == nil {
+		return false
+	}
+
+	if o.Status != OrderStatusOpen && o.Status != OrderStatusPartialFilled {
+		return false
+	}
+
+	return remainingAmount(o).Sign() > 0
+}
+
+func remainingAmount(o *Order) *big.Int {
+	if o.FilledAmount == nil {
+		return new(big.Int).Set(o.Amount)
+	}
+
+	return new(big.Int).Sub(o.Amount, o.FilledAmount)
+}
+
+func l3LevelKey(side string, pricePoint *big.Int) string {
+	return side + "::" + pricePoint.String()
+}
+
+func sortL3Orders(orders []*L3Order, descending bool) {
+	sort.SliceStable(orders, func(i, j int) bool {
+		c := orders[i].PricePoint.Cmp(orders[j].PricePoint)
+		if c == 0 {
+			return orders[i].QueuePosition < orders[j].QueuePosition
+		}
+
+		if descending {
+			return c > 0
+		}
+
+		return c < 0
+	})
+}
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/stretchr/testify/assert"
+)
+
+func newL3TestOrder(hash string, side string, price, amount, filled int64, status string, createdAt int64) *Order {
+	return &Order{
+		Hash:         common.HexToHash(hash),
+		Side:         side,
+		PricePoint:   big.NewInt(price),
+		Amount:       big.NewInt(amount),
+		FilledAmount: big.NewInt(filled),
+		Status:       status,
+		UserAddress:  common.HexToAddress("0x7a9f3cd060ab180f36c17fe6bdf9974f577d77aa"),
+		CreatedAt:    time.Unix(createdAt, 0),
+	}
+}
+
+func TestL3Book(t *testing.T) {
+	book := NewL3Book("TOMO/USDT", func(h common.Hash) string { return h.Hex()[62:] })
+	book.Load([]*Order{
+		newL3TestOrder("0x2", BUY, 100, 10, 0, OrderStatusOpen, 2),
+		newL3TestOrder("0x1", BUY, 100, 5, 0, OrderStatusOpen, 1),
+		newL3TestOrder("0x3", SELL, 110, 7, 0, OrderStatusOpen, 3),
+	}, 10)
+
+	ob := book.Snapshot()
+	assert.Equal(t, uint64(10), ob.Sequence)
+	assert.Equal(t, 2, len(ob.Bids))
+	assert.Equal(t, "0001", ob.Bids[0].ID)
+	assert.Equal(t, 0, ob.Bids[0].QueuePosition)
+	assert.Equal(t, 1, ob.Bids[1].QueuePosition)
+	assert.Equal(t, 1, len(ob.Asks))
+
+	events := book.Apply(newL3TestOrder("0x4", BUY, 100, 4, 0, OrderStatusOpen, 4), 11)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, L3Add, events[0].Type)
+	assert.Equal(t, uint64(11), events[0].Sequence)
+	assert.Equal(t, 2, events[0].QueuePosition)
+
+	events = book.Apply(newL3TestOrder("0x1", BUY, 100, 5, 2, OrderStatusPartialFilled, 1), 12)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, L3Match, events[0].Type)
+	assert.Equal(t, big.NewInt(2), events[0].MatchedAmount)
+	assert.Equal(t, big.NewInt(3), events[0].Amount)
+
+	events = book.Apply(newL3TestOrder("0x1", BUY, 100, 5, 5, OrderStatusFilled, 1), 14)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, L3Match, events[0].Type)
+	assert.Equal(t, L3Delete, events[1].Type)
+	// the events of an update share its sequence
+	assert.Equal(t, uint64(14), events[0].Sequence)
+	assert.Equal(t, uint64(14), events[1].Sequence)
+
+	events = book.Apply(newL3TestOrder("0x3", SELL, 110, 7, 0, OrderStatusCancelled, 3), 15)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, L3Delete, events[0].Type)
+
+	// the updates older than the book are ignored
+	events = book.Apply(newL3TestOrder("0x2", BUY, 100, 10, 0, OrderStatusCancelled, 2), 13)
+	assert.Equal(t, 0, len(events))
+
+	events = book.Apply(newL3TestOrder("0x9", SELL, 110, 7, 0, OrderStatusCancelled, 9), 16)
+	assert.Equal(t, 0, len(events))
+
+	ob = book.Snapshot()
+	assert.Equal(t, uint64(16), ob.Sequence)
+	assert.Equal(t, 2, len(ob.Bids))
+	assert.Equal(t, "0002", ob.Bids[0].ID)
+	assert.Equal(t, 0, ob.Bids[0].QueuePosition)
+	assert.Equal(t, 0, len(ob.Asks))
+}
+
+func TestChangeSequence(t *testing.T) {
+	ts := bson.MongoTimestamp(1559642400<<32 | 7)
+	assert.Equal(t, uint64(1559642400000007), ChangeSequence(ts))
+	assert.True(t, ChangeSequence(ts) < ChangeSequence(bson.MongoTimestamp(1559642401<<32|1)))
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/sha3"
 	"github.com/globalsign/mgo/bson"
 	"github.com/tomochain/tomox-sdk/errors"
@@ -47,6 +46,7 @@ type LendingOrder struct {
 	UserAddress     common.Address `bson:"userAddress" json:"userAddress"`
 	RelayerAddress  common.Address `bson:"relayer" json:"relayerAddress"`
 	Signature       *Signature     `bson:"signature" json:"signature"`
+	SignatureType   string         `bson:"-" json:"signatureType,omitempty"`
 	Hash            common.Hash    `bson:"hash" json:"hash"`
 	TxHash          common.Hash    `bson:"txHash" json:"txHash"`
 	Nonce           *big.Int       `bson:"nonce" json:"nonce"`
@@ -63,6 +63,7 @@ type LendingOrder struct {
 type LendingRes struct {
 	Total        int             `json:"total" bson:"total"`
 	LendingItems []*LendingOrder `json:"lendings" bson:"lendings"`
+	NextCursor   string          `json:"nextCursor,omitempty" bson:"-"`
 }
 
 // LendingSpec contains field for filter
@@ -78,6 +79,7 @@ type LendingSpec struct {
 	DateFrom        int64
 	DateTo          int64
 	Hash            string
+	Cursor          *Cursor
 }
 
 // TopupSpec filter topup
@@ -198,23 +200,17 @@ func (o *LendingOrder) ComputeHash() common.Hash {
 	return common.BytesToHash(sha.Sum(nil))
 }
 
-// VerifySignature checks that the orderRequest signature corresponds to the address in the userAddress field
+// VerifySignature checks that the orderRequest signature corresponds to the address in the userAddress field,
+// the order is signed either as a personal message of its hash or as EIP-712 typed data
 func (o *LendingOrder) VerifySignature() (bool, error) {
 	o.Hash = o.ComputeHash()
 
-	message := crypto.Keccak256(
-		[]byte("\x19Ethereum Signed Message:\n32"),
-		o.Hash.Bytes(),
-	)
-
-	address, err := o.Signature.Verify(common.BytesToHash(message))
+	signatureType, err := o.RecoverSignatureType()
 	if err != nil {
 		return false, err
 	}
-	logger.Debug(address.Hex())
-	if address != o.UserAddress {
-		return false, errors.New("Recovered address is incorrect")
-	}
+
+	o.SignatureType = signatureType
 
 	return true, nil
 }
@@ -281,6 +277,10 @@ func (o *LendingOrder) MarshalJSON() ([]byte, error) {
 			"S": o.Signature.S,
 		}
 	}
+
+	if o.SignatureType != "" {
+		lending["signatureType"] = o.SignatureType
+	}
 	return json.Marshal(lending)
 }
 
@@ -361,6 +361,10 @@ func (o *LendingOrder) UnmarshalJSON(b []byte) error {
 		}
 	}
 
+	if lending["signatureType"] != nil {
+		o.SignatureType = lending["signatureType"].(string)
+	}
+
 	if lending["createdAt"] != nil {
 		t, _ := time.Parse(time.RFC3339Nano, lending["createdAt"].(string))
 		o.CreatedAt = t
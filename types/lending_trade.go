@@ -356,12 +356,14 @@ type LendingTradeSpec struct {
 	Status          string
 	DateFrom        int64
 	DateTo          int64
+	Cursor          *Cursor
 }
 
 // LendingTradeRes response api
 type LendingTradeRes struct {
 	Total         int             `json:"total" bson:"total"`
 	LendingTrades []*LendingTrade `json:"trades" bson:"trades"`
+	NextCursor    string          `json:"nextCursor,omitempty" bson:"-"`
 }
 
 // ComputeHash returns hashes the trade
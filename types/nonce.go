@@ -0,0 +1,59 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// NonceKindOrder is the kind of the nonces of the orders
+	NonceKindOrder = "order"
+	// NonceKindLending is the kind of the nonces of the lending orders, topups, repayments and
+	// recalls
+	NonceKindLending = "lending"
+
+	// ReservedNoncePending is the status of a nonce handed out and not used yet
+	ReservedNoncePending = "PENDING"
+	// ReservedNonceConfirmed is the status of a nonce used by an order accepted by the node
+	ReservedNonceConfirmed = "CONFIRMED"
+)
+
+// NonceReservation is a set of nonces handed out to an address, which are kept for it until
+// they are used by its orders or until they expire
+type NonceReservation struct {
+	Address   common.Address `json:"address"`
+	Nonces    []uint64       `json:"nonces"`
+	ExpiresAt time.Time      `json:"expiresAt"`
+}
+
+// ReservedNonce is a nonce of an address held by a reservation, or by the order using it until
+// the node counts it. It is free again once it expires.
+type ReservedNonce struct {
+	ID        string    `json:"id" bson:"_id"`
+	Kind      string    `json:"kind" bson:"kind"`
+	Address   string    `json:"address" bson:"address"`
+	Nonce     int64     `json:"nonce" bson:"nonce"`
+	Status    string    `json:"status" bson:"status"`
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+// ReservedNonceID returns the id of the reserved nonce of a kind of an address
+func ReservedNonceID(kind string, addr common.Address, nonce uint64) string {
+	return fmt.Sprintf("%s:%s:%d", kind, addr.Hex(), nonce)
+}
+
+// FreeNonces returns the n lowest nonces from count which are not taken. The nonces below the
+// count of orders of the address known by the node are used, the gaps left by the nonces
+// released or expired above it are handed out first.
+func FreeNonces(count uint64, taken map[uint64]bool, n int) []uint64 {
+	nonces := make([]uint64, 0, n)
+	for nonce := count; len(nonces) < n; nonce++ {
+		if !taken[nonce] {
+			nonces = append(nonces, nonce)
+		}
+	}
+
+	return nonces
+}
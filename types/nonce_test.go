@@ -0,0 +1,37 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeNonces(t *testing.T) {
+	assert.Equal(t, []uint64{5, 6}, FreeNonces(5, map[uint64]bool{}, 2))
+
+	// the node does not know the pending nonces yet
+	taken := map[uint64]bool{5: true, 6: true}
+	assert.Equal(t, []uint64{7}, FreeNonces(5, taken, 1))
+
+	// the released nonces are handed out first
+	taken = map[uint64]bool{7: true}
+	assert.Equal(t, []uint64{5, 6, 8}, FreeNonces(5, taken, 3))
+}
+
+func TestFreeNoncesResync(t *testing.T) {
+	// orders were sent to the node with nonces which were not reserved
+	taken := map[uint64]bool{5: true, 6: true}
+	assert.Equal(t, []uint64{9}, FreeNonces(9, taken, 1))
+
+	// the nonces 0 and 1 expired while 2 is still pending
+	taken = map[uint64]bool{2: true}
+	assert.Equal(t, []uint64{0, 1}, FreeNonces(0, taken, 2))
+}
+
+func TestReservedNonceID(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+
+	assert.Equal(t, "order:0x0000000000000000000000000000000000000001:5", ReservedNonceID(NonceKindOrder, addr, 5))
+	assert.NotEqual(t, ReservedNonceID(NonceKindOrder, addr, 5), ReservedNonceID(NonceKindLending, addr, 5))
+}
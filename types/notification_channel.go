@@ -0,0 +1,293 @@
+package types
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/utils/notify"
+)
+
+// DefaultDigestInterval is the interval of the digests of the users without preferences, in seconds
+const DefaultDigestInterval = 3600
+
+// ChannelVerificationTTL is the validity of the codes sent to the new channels
+const ChannelVerificationTTL = 24 * time.Hour
+
+var telegramChatID = regexp.MustCompile(`^-?[0-9]+$`)
+
+// NotificationChannel is a target registered by a user to receive its notifications: an
+// email address, a Telegram chat id or a webhook url. The notifications are only sent to the
+// verified channels, a channel is verified with the code sent to its target when it is added.
+type NotificationChannel struct {
+	ID                    bson.ObjectId  `json:"id"`
+	UserAddress           common.Address `json:"userAddress"`
+	Kind                  string         `json:"kind"`
+	Target                string         `json:"target"`
+	Verified              bool           `json:"verified"`
+	VerificationCode      string         `json:"-"`
+	VerificationExpiresAt time.Time      `json:"-"`
+	CreatedAt             time.Time      `json:"createdAt"`
+}
+
+// NotificationChannelRecord is the bson representation of a NotificationChannel
+type NotificationChannelRecord struct {
+	ID                    bson.ObjectId `bson:"_id"`
+	UserAddress           string        `bson:"userAddress"`
+	Kind                  string        `bson:"kind"`
+	Target                string        `bson:"target"`
+	Verified              bool          `bson:"verified"`
+	VerificationCode      string        `bson:"verificationCode,omitempty"`
+	VerificationExpiresAt time.Time     `bson:"verificationExpiresAt,omitempty"`
+	CreatedAt             time.Time     `bson:"createdAt"`
+}
+
+func (c *NotificationChannel) GetBSON() (interface{}, error) {
+	return NotificationChannelRecord{
+		ID:                    c.ID,
+		UserAddress:           c.UserAddress.Hex(),
+		Kind:                  c.Kind,
+		Target:                c.Target,
+		Verified:              c.Verified,
+		VerificationCode:      c.VerificationCode,
+		VerificationExpiresAt: c.VerificationExpiresAt,
+		CreatedAt:             c.CreatedAt,
+	}, nil
+}
+
+func (c *NotificationChannel) SetBSON(raw bson.Raw) error {
+	decoded := &NotificationChannelRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	c.ID = decoded.ID
+	c.UserAddress = common.HexToAddress(decoded.UserAddress)
+	c.Kind = decoded.Kind
+	c.Target = decoded.Target
+	c.Verified = decoded.Verified
+	c.VerificationCode = decoded.VerificationCode
+	c.VerificationExpiresAt = decoded.VerificationExpiresAt
+	c.CreatedAt = decoded.CreatedAt
+	return nil
+}
+
+// Validate checks the target of the channel for its kind
+func (c *NotificationChannel) Validate() error {
+	switch c.Kind {
+	case notify.Email:
+		a, err := mail.ParseAddress(c.Target)
+		if err != nil || a.Address != c.Target {
+			return errors.New("Invalid email address")
+		}
+	case notify.Telegram:
+		if !telegramChatID.MatchString(c.Target) {
+			return errors.New("Invalid Telegram chat id")
+		}
+	case notify.Webhook:
+		u, err := url.Parse(c.Target)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return errors.New("Webhook url must be an https url")
+		}
+	default:
+		return errors.New("Invalid channel kind")
+	}
+
+	return nil
+}
+
+// NewVerificationCode sets a new random verification code on the channel, valid until now+ttl
+func (c *NotificationChannel) NewVerificationCode(now time.Time, ttl time.Duration) error {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		return err
+	}
+
+	c.Verified = false
+	c.VerificationCode = hex.EncodeToString(b)
+	c.VerificationExpiresAt = now.Add(ttl)
+	return nil
+}
+
+// CheckVerificationCode returns an error if code is not the unexpired verification code of the channel
+func (c *NotificationChannel) CheckVerificationCode(code string, now time.Time) error {
+	if c.VerificationCode == "" || !now.Before(c.VerificationExpiresAt) {
+		return errors.New("The verification code expired, the channel must be added again")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(code), []byte(c.VerificationCode)) != 1 {
+		return errors.New("Invalid verification code")
+	}
+
+	return nil
+}
+
+// NotificationRule routes the notifications of a type (ANNOUNCE, ALERT, LOG) and an event
+// (the message type, ORDER_SUCCESS for example). An empty type or event matches any value,
+// empty channels select every channel of the user.
+type NotificationRule struct {
+	Type     string   `json:"type" bson:"type"`
+	Event    string   `json:"event" bson:"event"`
+	Channels []string `json:"channels" bson:"channels"`
+	Mute     bool     `json:"mute" bson:"mute"`
+	Digest   bool     `json:"digest" bson:"digest"`
+}
+
+// NotificationPreferences are the delivery preferences of a user. The notifications
+// routed to the digest are sent together every DigestInterval seconds.
+type NotificationPreferences struct {
+	UserAddress    common.Address     `json:"userAddress"`
+	Locale         string             `json:"locale"`
+	DigestInterval int64              `json:"digestInterval"`
+	Rules          []NotificationRule `json:"rules"`
+	UpdatedAt      time.Time          `json:"updatedAt"`
+}
+
+// NotificationPreferencesRecord is the bson representation of NotificationPreferences
+type NotificationPreferencesRecord struct {
+	UserAddress    string             `bson:"userAddress"`
+	Locale         string             `bson:"locale"`
+	DigestInterval int64              `bson:"digestInterval"`
+	Rules          []NotificationRule `bson:"rules"`
+	UpdatedAt      time.Time          `bson:"updatedAt"`
+}
+
+func (p *NotificationPreferences) GetBSON() (interface{}, error) {
+	return NotificationPreferencesRecord{
+		UserAddress:    p.UserAddress.Hex(),
+		Locale:         p.Locale,
+		DigestInterval: p.DigestInterval,
+		Rules:          p.Rules,
+		UpdatedAt:      p.UpdatedAt,
+	}, nil
+}
+
+func (p *NotificationPreferences) SetBSON(raw bson.Raw) error {
+	decoded := &NotificationPreferencesRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	p.UserAddress = common.HexToAddress(decoded.UserAddress)
+	p.Locale = decoded.Locale
+	p.DigestInterval = decoded.DigestInterval
+	p.Rules = decoded.Rules
+	p.UpdatedAt = decoded.UpdatedAt
+	return nil
+}
+
+// NewNotificationPreferences returns the preferences of a user without preferences, every
+// notification is sent immediately to every channel
+func NewNotificationPreferences(addr common.Address) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserAddress:    addr,
+		Locale:         notify.DefaultLocale,
+		DigestInterval: DefaultDigestInterval,
+	}
+}
+
+// SetDefaults sets the default locale and digest interval of the preferences which have none
+func (p *NotificationPreferences) SetDefaults() {
+	if p.Locale == "" {
+		p.Locale = notify.DefaultLocale
+	}
+
+	if p.DigestInterval == 0 {
+		p.DigestInterval = DefaultDigestInterval
+	}
+}
+
+// Validate checks the rules of the preferences
+func (p *NotificationPreferences) Validate() error {
+	if p.DigestInterval < 60 {
+		return errors.New("The digest interval must be at least 60 seconds")
+	}
+
+	for _, r := range p.Rules {
+		switch r.Type {
+		case "", TypeAnnounce, TypeAlert, TypeLog:
+		default:
+			return errors.New("Invalid notification type " + r.Type)
+		}
+
+		for _, c := range r.Channels {
+			switch c {
+			case notify.Email, notify.Telegram, notify.Webhook:
+			default:
+				return errors.New("Invalid channel kind " + c)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Route returns the rule of a notification: the rule matching its type and event, then its
+// event, then its type, then the rule matching any notification. The notifications without a
+// rule are sent immediately to every channel.
+func (p *NotificationPreferences) Route(n *Notification) NotificationRule {
+	best := -1
+	res := NotificationRule{}
+
+	for _, r := range p.Rules {
+		if (r.Type != "" && r.Type != n.Type) || (r.Event != "" && r.Event != n.Message.MessageType) {
+			continue
+		}
+
+		score := 0
+		if r.Event != "" {
+			score += 2
+		}
+		if r.Type != "" {
+			score++
+		}
+
+		if score > best {
+			best = score
+			res = r
+		}
+	}
+
+	return res
+}
+
+// Selects returns true if the rule sends to the channels of kind
+func (r *NotificationRule) Selects(kind string) bool {
+	if r.Mute {
+		return false
+	}
+
+	if len(r.Channels) == 0 {
+		return true
+	}
+
+	for _, c := range r.Channels {
+		if c == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NotificationDigestItem is a notification waiting for the digest of a channel
+type NotificationDigestItem struct {
+	ID             bson.ObjectId `json:"id" bson:"_id"`
+	UserAddress    string        `json:"userAddress" bson:"userAddress"`
+	ChannelID      bson.ObjectId `json:"channelId" bson:"channelId"`
+	NotificationID bson.ObjectId `json:"notificationId" bson:"notificationId"`
+	Type           string        `json:"type" bson:"type"`
+	Event          string        `json:"event" bson:"event"`
+	Description    string        `json:"description" bson:"description"`
+	CreatedAt      time.Time     `json:"createdAt" bson:"createdAt"`
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomochain/tomox-sdk/utils/notify"
+)
+
+func TestNotificationPreferencesRoute(t *testing.T) {
+	p := NewNotificationPreferences(common.HexToAddress("0x1"))
+	fill := &Notification{Type: TypeLog, Message: Message{MessageType: "ORDER_SUCCESS"}}
+	added := &Notification{Type: TypeLog, Message: Message{MessageType: "ORDER_ADDED"}}
+	alert := &Notification{Type: TypeAlert, Message: Message{MessageType: LENDING_TRADE_LIQUIDATED}}
+
+	rule := p.Route(fill)
+	assert.True(t, rule.Selects(notify.Email))
+	assert.False(t, rule.Digest)
+
+	p.Rules = []NotificationRule{
+		{Mute: true},
+		{Type: TypeLog, Digest: true, Channels: []string{notify.Email}},
+		{Type: TypeLog, Event: "ORDER_SUCCESS", Channels: []string{notify.Telegram}},
+	}
+
+	rule = p.Route(fill)
+	assert.False(t, rule.Digest)
+	assert.True(t, rule.Selects(notify.Telegram))
+	assert.False(t, rule.Selects(notify.Email))
+
+	rule = p.Route(added)
+	assert.True(t, rule.Digest)
+	assert.True(t, rule.Selects(notify.Email))
+
+	rule = p.Route(alert)
+	assert.False(t, rule.Selects(notify.Email))
+}
+
+func TestNotificationChannelValidate(t *testing.T) {
+	valid := []*NotificationChannel{
+		{Kind: notify.Email, Target: "alice@tomox.test"},
+		{Kind: notify.Telegram, Target: "-100123"},
+		{Kind: notify.Webhook, Target: "https://example.com/notify"},
+	}
+	for _, c := range valid {
+		assert.Nil(t, c.Validate(), c.Target)
+	}
+
+	invalid := []*NotificationChannel{
+		{Kind: notify.Email, Target: "Alice <alice@tomox.test>"},
+		{Kind: notify.Telegram, Target: "@alice"},
+		{Kind: notify.Webhook, Target: "http://example.com/notify"},
+		{Kind: "SMS", Target: "+84123456789"},
+	}
+	for _, c := range invalid {
+		assert.NotNil(t, c.Validate(), c.Target)
+	}
+}
+
+func TestNotificationChannelVerificationCode(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := &NotificationChannel{Kind: notify.Webhook, Target: "https://example.com/hook", Verified: true}
+
+	assert.Nil(t, c.NewVerificationCode(now, time.Hour))
+	assert.False(t, c.Verified)
+	assert.Len(t, c.VerificationCode, 16)
+
+	assert.EqualError(t, c.CheckVerificationCode("0000000000000000", now), "Invalid verification code")
+	assert.Nil(t, c.CheckVerificationCode(c.VerificationCode, now))
+	assert.NotNil(t, c.CheckVerificationCode(c.VerificationCode, now.Add(time.Hour)))
+
+	c.VerificationCode = ""
+	assert.NotNil(t, c.CheckVerificationCode("", now))
+}
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/sha3"
 	"github.com/globalsign/mgo/bson"
 	"github.com/tomochain/tomox-sdk/errors"
@@ -41,6 +40,7 @@ type Order struct {
 	Type            string         `json:"type" bson:"type"`
 	Hash            common.Hash    `json:"hash" bson:"hash"`
 	Signature       *Signature     `json:"signature,omitempty" bson:"signature"`
+	SignatureType   string         `json:"signatureType,omitempty" bson:"-"`
 	PricePoint      *big.Int       `json:"pricepoint" bson:"price"`
 	Amount          *big.Int       `json:"amount" bson:"quantity"`
 	FilledAmount    *big.Int       `json:"filledAmount" bson:"filledAmount"`
@@ -57,8 +57,9 @@ type Order struct {
 
 // OrderRes use for api
 type OrderRes struct {
-	Total  int      `json:"total" bson:"total"`
-	Orders []*Order `json:"orders" bson:"orders"`
+	Total      int      `json:"total" bson:"total"`
+	Orders     []*Order `json:"orders" bson:"orders"`
+	NextCursor string   `json:"nextCursor,omitempty" bson:"-"`
 }
 
 // PriceVolume get best order price
@@ -79,6 +80,7 @@ type OrderSpec struct {
 	DateFrom       int64
 	DateTo         int64
 	OrderHash      string
+	Cursor         *Cursor
 }
 
 func (o *Order) String() string {
@@ -160,23 +162,19 @@ func (o *Order) ComputeHash() common.Hash {
 	return common.BytesToHash(sha.Sum(nil))
 }
 
-// VerifySignature checks that the orderRequest signature corresponds to the address in the userAddress field
+// VerifySignature checks that the orderRequest signature corresponds to the address in the userAddress field,
+// the order is signed either as a personal message of its hash or as EIP-712 typed data. The signature is
+// forwarded to the node with tomox_sendOrder, which checks it against the userAddress again, so the orders
+// signed by another key cannot be accepted by the SDK alone.
 func (o *Order) VerifySignature() (bool, error) {
 	o.Hash = o.ComputeHash()
 
-	message := crypto.Keccak256(
-		[]byte("\x19Ethereum Signed Message:\n32"),
-		o.Hash.Bytes(),
-	)
-
-	address, err := o.Signature.Verify(common.BytesToHash(message))
+	signatureType, err := o.RecoverSignatureType()
 	if err != nil {
 		return false, err
 	}
 
-	if address != o.UserAddress {
-		return false, errors.New("Recovered address is incorrect")
-	}
+	o.SignatureType = signatureType
 
 	return true, nil
 }
@@ -405,6 +403,10 @@ func (o *Order) MarshalJSON() ([]byte, error) {
 			"S": o.Signature.S,
 		}
 	}
+
+	if o.SignatureType != "" {
+		order["signatureType"] = o.SignatureType
+	}
 	return json.Marshal(order)
 }
 
@@ -482,6 +484,10 @@ func (o *Order) UnmarshalJSON(b []byte) error {
 		}
 	}
 
+	if order["signatureType"] != nil {
+		o.SignatureType = order["signatureType"].(string)
+	}
+
 	if order["createdAt"] != nil {
 		t, _ := time.Parse(time.RFC3339Nano, order["createdAt"].(string))
 		o.CreatedAt = t
@@ -872,12 +878,21 @@ type evNamespace struct {
 type M bson.M
 
 type OrderChangeEvent struct {
-	ID                interface{} `bson:"_id"`
-	OperationType     string      `bson:"operationType"`
-	FullDocument      *Order      `bson:"fullDocument,omitempty"`
-	Ns                evNamespace `bson:"ns"`
-	DocumentKey       M           `bson:"documentKey"`
-	UpdateDescription *updateDesc `bson:"updateDescription,omitempty"`
+	ID                interface{}         `bson:"_id"`
+	OperationType     string              `bson:"operationType"`
+	FullDocument      *Order              `bson:"fullDocument,omitempty"`
+	Ns                evNamespace         `bson:"ns"`
+	DocumentKey       M                   `bson:"documentKey"`
+	UpdateDescription *updateDesc         `bson:"updateDescription,omitempty"`
+	ClusterTime       bson.MongoTimestamp `bson:"clusterTime"`
+}
+
+// ChangeSequence returns the sequence of a change of the database from its cluster time, the
+// seconds followed by six digits for the increment of the operation within the second. It is the
+// same on every instance, increases with the changes and stays below 2^53 for the JavaScript
+// clients.
+func ChangeSequence(ts bson.MongoTimestamp) uint64 {
+	return uint64(ts>>32)*1000000 + uint64(ts&0xffffffff)
 }
 
 const (
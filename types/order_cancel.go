@@ -28,6 +28,7 @@ type OrderCancel struct {
 	UserAddress     common.Address `json:"userAddress"`
 	ExchangeAddress common.Address `json:"exchangeAddress"`
 	Signature       *Signature     `json:"signature"`
+	SignatureType   string         `json:"-"`
 }
 
 // NewOrderCancel returns a new empty OrderCancel object
@@ -124,26 +125,19 @@ func (oc *OrderCancel) UnmarshalJSON(b []byte) error {
 }
 
 // VerifySignature returns a true value if the OrderCancel object signature
-// corresponds to the Maker of the given order
+// corresponds to the Maker of the given order, as a legacy or an EIP-712 signature
 func (oc *OrderCancel) VerifySignature(o *Order) (bool, error) {
 
 	if o == nil {
 		return false, errors.New("Recovered address is incorrect")
 	}
 
-	message := crypto.Keccak256(
-		[]byte("\x19Ethereum Signed Message:\n32"),
-		oc.Hash.Bytes(),
-	)
-
-	address, err := oc.Signature.Verify(common.BytesToHash(message))
+	typ, err := signatureType(oc.Signature, o.UserAddress, oc.Hash, oc.TypedData())
 	if err != nil {
 		return false, err
 	}
 
-	if address != o.UserAddress {
-		return false, errors.New("Recovered address is incorrect")
-	}
+	oc.SignatureType = typ
 
 	return true, nil
 }
@@ -159,6 +153,13 @@ func (oc *OrderCancel) GetSenderAddress() (common.Address, error) {
 		return common.Address{}, err
 	}
 
+	// the cancels signed as EIP-712 typed data are recognized by their user address
+	if address != oc.UserAddress && TypedDataSignatures() {
+		if typed, err := oc.TypedData().Recover(oc.Signature); err == nil && typed == oc.UserAddress {
+			return typed, nil
+		}
+	}
+
 	return address, nil
 }
 
@@ -291,6 +291,8 @@ type PairData struct {
 	Low                *big.Int   `json:"low,omitempty" bson:"low"`
 	Close              *big.Int   `json:"close,omitempty" bson:"close"`
 	CloseBaseUsd       *big.Float `json:"closeBaseUsd,omitempty" bson:"closeBaseUsd"`
+	CloseBaseFiat      *big.Float `json:"closeBaseFiat,omitempty" bson:"-"`
+	FiatCurrency       string     `json:"fiatCurrency,omitempty" bson:"-"`
 	Volume             *big.Int   `json:"volume,omitempty" bson:"volume"`
 	BaseVolume         *big.Int   `json:"baseVolume,omitempty" bson:"baseVolume"`
 	Change             float32    `json:"change,omitempty" bson:"change"`
@@ -343,6 +345,11 @@ func (p *PairData) MarshalJSON() ([]byte, error) {
 		pairData["closeBaseUsd"] = p.CloseBaseUsd.String()
 	}
 
+	if p.CloseBaseFiat != nil {
+		pairData["closeBaseFiat"] = p.CloseBaseFiat.String()
+		pairData["fiatCurrency"] = p.FiatCurrency
+	}
+
 	if p.Count != nil {
 		pairData["count"] = p.Count.String()
 	}
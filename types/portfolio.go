@@ -0,0 +1,490 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/utils/math"
+)
+
+// Cost basis methods of the portfolios
+const (
+	CostBasisFIFO    = "fifo"
+	CostBasisAverage = "average"
+)
+
+// Lot is an amount of base token bought in a trade, with its cost in quote token fees included
+type Lot struct {
+	Amount *big.Int
+	Cost   *big.Int
+}
+
+// Position is the holding of the base token of a pair built by the trades of a user. The
+// amounts are in base token, the costs and the PnL in quote token, the fees of the trades
+// being paid in quote token.
+//
+// The base tokens sold without having been bought on the exchange, deposited for example, have
+// no cost basis: their proceeds are not counted in the realized PnL.
+type Position struct {
+	PairName   string
+	BaseToken  common.Address
+	QuoteToken common.Address
+	Amount     *big.Int
+	Cost       *big.Int
+	Realized   *big.Int
+	Fees       *big.Int
+	Bought     *big.Int
+	Sold       *big.Int
+
+	// Lots are the bought amounts not sold yet, oldest first, with the FIFO method
+	Lots []*Lot
+
+	// the average cost and the market value of the position as price points, set by Mark
+	AveragePrice *big.Int
+	Price        *big.Int
+	Value        *big.Int
+	Unrealized   *big.Int
+
+	// the USD values, set by the portfolio service when the price of the quote token is known
+	ValueUsd      *big.Float
+	RealizedUsd   *big.Float
+	UnrealizedUsd *big.Float
+}
+
+// NewPosition returns an empty position of a pair
+func NewPosition(pairName string, baseToken, quoteToken common.Address) *Position {
+	return &Position{
+		PairName:   pairName,
+		BaseToken:  baseToken,
+		QuoteToken: quoteToken,
+		Amount:     big.NewInt(0),
+		Cost:       big.NewInt(0),
+		Realized:   big.NewInt(0),
+		Fees:       big.NewInt(0),
+		Bought:     big.NewInt(0),
+		Sold:       big.NewInt(0),
+		Lots:       []*Lot{},
+	}
+}
+
+// Buy adds an amount bought for quote plus fee
+func (p *Position) Buy(method string, amount, quote, fee *big.Int) {
+	cost := math.Add(quote, fee)
+
+	p.Amount = math.Add(p.Amount, amount)
+	p.Cost = math.Add(p.Cost, cost)
+	p.Fees = math.Add(p.Fees, fee)
+	p.Bought = math.Add(p.Bought, amount)
+
+	if method == CostBasisFIFO {
+		p.Lots = append(p.Lots, &Lot{Amount: new(big.Int).Set(amount), Cost: cost})
+	}
+}
+
+// Sell removes an amount sold for quote minus fee and realizes its PnL
+func (p *Position) Sell(method string, amount, quote, fee *big.Int) {
+	p.Fees = math.Add(p.Fees, fee)
+	p.Sold = math.Add(p.Sold, amount)
+
+	held := amount
+	if p.Amount.Cmp(amount) < 0 {
+		held = p.Amount
+	}
+
+	if held.Sign() == 0 {
+		return
+	}
+
+	proceeds := math.Div(math.Mul(math.Sub(quote, fee), held), amount)
+
+	var cost *big.Int
+	if method == CostBasisFIFO {
+		cost = p.sellLots(held)
+	} else {
+		cost = math.Div(math.Mul(p.Cost, held), p.Amount)
+	}
+
+	p.Amount = math.Sub(p.Amount, held)
+	p.Cost = math.Sub(p.Cost, cost)
+	p.Realized = math.Add(p.Realized, math.Sub(proceeds, cost))
+}
+
+// sellLots removes an amount from the oldest lots and returns its cost
+func (p *Position) sellLots(amount *big.Int) *big.Int {
+	cost := big.NewInt(0)
+	left := new(big.Int).Set(amount)
+
+	for len(p.Lots) > 0 && left.Sign() > 0 {
+		l := p.Lots[0]
+
+		if l.Amount.Cmp(left) <= 0 {
+			cost = math.Add(cost, l.Cost)
+			left = math.Sub(left, l.Amount)
+			p.Lots = p.Lots[1:]
+			continue
+		}
+
+		c := math.Div(math.Mul(l.Cost, left), l.Amount)
+		cost = math.Add(cost, c)
+		l.Amount = math.Sub(l.Amount, left)
+		l.Cost = math.Sub(l.Cost, c)
+		left = big.NewInt(0)
+	}
+
+	return cost
+}
+
+// Mark values the position at a price point of its pair, baseMultiplier is 10^decimals of the
+// base token
+func (p *Position) Mark(pricepoint, baseMultiplier *big.Int) {
+	p.AveragePrice = big.NewInt(0)
+	if p.Amount.Sign() > 0 {
+		p.AveragePrice = math.Div(math.Mul(p.Cost, baseMultiplier), p.Amount)
+	}
+
+	p.Price = pricepoint
+	p.Value = math.Div(math.Mul(p.Amount, pricepoint), baseMultiplier)
+	p.Unrealized = math.Sub(p.Value, p.Cost)
+}
+
+// MarshalJSON implements the json.Marshal interface
+func (p *Position) MarshalJSON() ([]byte, error) {
+	res := map[string]interface{}{
+		"pairName":   p.PairName,
+		"baseToken":  p.BaseToken.Hex(),
+		"quoteToken": p.QuoteToken.Hex(),
+		"amount":     p.Amount.String(),
+		"cost":       p.Cost.String(),
+		"realized":   p.Realized.String(),
+		"fees":       p.Fees.String(),
+		"bought":     p.Bought.String(),
+		"sold":       p.Sold.String(),
+	}
+
+	if p.Price != nil {
+		res["averagePrice"] = p.AveragePrice.String()
+		res["price"] = p.Price.String()
+		res["value"] = p.Value.String()
+		res["unrealized"] = p.Unrealized.String()
+	}
+
+	if p.ValueUsd != nil {
+		res["valueUsd"] = p.ValueUsd.String()
+		res["realizedUsd"] = p.RealizedUsd.String()
+		res["unrealizedUsd"] = p.UnrealizedUsd.String()
+	}
+
+	return json.Marshal(res)
+}
+
+// LotRecord is the bson representation of a Lot
+type LotRecord struct {
+	Amount string `bson:"amount"`
+	Cost   string `bson:"cost"`
+}
+
+// PositionRecord is the bson representation of a Position
+type PositionRecord struct {
+	PairName   string      `bson:"pairName"`
+	BaseToken  string      `bson:"baseToken"`
+	QuoteToken string      `bson:"quoteToken"`
+	Amount     string      `bson:"amount"`
+	Cost       string      `bson:"cost"`
+	Realized   string      `bson:"realized"`
+	Fees       string      `bson:"fees"`
+	Bought     string      `bson:"bought"`
+	Sold       string      `bson:"sold"`
+	Lots       []LotRecord `bson:"lots"`
+}
+
+func (p *Position) GetBSON() (interface{}, error) {
+	lots := make([]LotRecord, len(p.Lots))
+	for i, l := range p.Lots {
+		lots[i] = LotRecord{Amount: l.Amount.String(), Cost: l.Cost.String()}
+	}
+
+	return PositionRecord{
+		PairName:   p.PairName,
+		BaseToken:  p.BaseToken.Hex(),
+		QuoteToken: p.QuoteToken.Hex(),
+		Amount:     p.Amount.String(),
+		Cost:       p.Cost.String(),
+		Realized:   p.Realized.String(),
+		Fees:       p.Fees.String(),
+		Bought:     p.Bought.String(),
+		Sold:       p.Sold.String(),
+		Lots:       lots,
+	}, nil
+}
+
+func (p *Position) SetBSON(raw bson.Raw) error {
+	decoded := &PositionRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	p.PairName = decoded.PairName
+	p.BaseToken = common.HexToAddress(decoded.BaseToken)
+	p.QuoteToken = common.HexToAddress(decoded.QuoteToken)
+	p.Amount = math.ToBigInt(decoded.Amount)
+	p.Cost = math.ToBigInt(decoded.Cost)
+	p.Realized = math.ToBigInt(decoded.Realized)
+	p.Fees = math.ToBigInt(decoded.Fees)
+	p.Bought = math.ToBigInt(decoded.Bought)
+	p.Sold = math.ToBigInt(decoded.Sold)
+
+	p.Lots = make([]*Lot, len(decoded.Lots))
+	for i, l := range decoded.Lots {
+		p.Lots[i] = &Lot{Amount: math.ToBigInt(l.Amount), Cost: math.ToBigInt(l.Cost)}
+	}
+
+	return nil
+}
+
+// Portfolio holds the positions of a user under a cost basis method. It is updated
+// incrementally: the trades are applied in their creation order and the last applied trades
+// are recorded so that they are not applied twice.
+type Portfolio struct {
+	UserAddress common.Address `json:"userAddress"`
+	Method      string         `json:"method"`
+	Positions   []*Position    `json:"positions"`
+	TradeCount  int            `json:"tradeCount"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+
+	// LastTradeAt is the creation time of the last applied trades, LastTradeHashes their hashes
+	LastTradeAt     time.Time     `json:"lastTradeAt"`
+	LastTradeHashes []common.Hash `json:"-"`
+
+	// the USD totals of the positions whose quote token has a USD price
+	ValueUsd      *big.Float `json:"valueUsd,omitempty"`
+	RealizedUsd   *big.Float `json:"realizedUsd,omitempty"`
+	UnrealizedUsd *big.Float `json:"unrealizedUsd,omitempty"`
+}
+
+// NewPortfolio returns an empty portfolio of a user
+func NewPortfolio(addr common.Address, method string) (*Portfolio, error) {
+	if method != CostBasisFIFO && method != CostBasisAverage {
+		return nil, errors.New("Cost basis method must be fifo or average")
+	}
+
+	return &Portfolio{
+		UserAddress:     addr,
+		Method:          method,
+		Positions:       []*Position{},
+		LastTradeHashes: []common.Hash{},
+	}, nil
+}
+
+// Applied returns true if a trade was already applied to the portfolio
+func (p *Portfolio) Applied(t *Trade) bool {
+	if t.CreatedAt.Before(p.LastTradeAt) {
+		return true
+	}
+
+	if !t.CreatedAt.Equal(p.LastTradeAt) {
+		return false
+	}
+
+	for _, h := range p.LastTradeHashes {
+		if h == t.Hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ApplyTrade applies a trade of the user, the trades must be applied in their creation order.
+// baseMultiplier is 10^decimals of the base token. It returns false if the trade was already
+// applied.
+func (p *Portfolio) ApplyTrade(t *Trade, baseMultiplier *big.Int) bool {
+	if p.Applied(t) {
+		return false
+	}
+
+	if t.Status != TradeStatusError {
+		pos := p.Position(t.BaseToken, t.QuoteToken)
+		if pos == nil {
+			pos = NewPosition(t.PairName, t.BaseToken, t.QuoteToken)
+			p.Positions = append(p.Positions, pos)
+		}
+
+		quote := math.Div(math.Mul(t.Amount, t.PricePoint), baseMultiplier)
+
+		// a user trading with itself is both the taker and the maker of the trade
+		if t.Taker == p.UserAddress {
+			p.applySide(pos, t.TakerOrderSide, t.Amount, quote, t.TakeFee)
+		}
+
+		if t.Maker == p.UserAddress {
+			makerSide := BUY
+			if t.TakerOrderSide == BUY {
+				makerSide = SELL
+			}
+
+			p.applySide(pos, makerSide, t.Amount, quote, t.MakeFee)
+		}
+
+		p.TradeCount++
+	}
+
+	p.markApplied(t)
+	return true
+}
+
+// SkipTrade marks a trade which can not be applied as applied, without changing the positions.
+// It returns false if the trade was already applied.
+func (p *Portfolio) SkipTrade(t *Trade) bool {
+	if p.Applied(t) {
+		return false
+	}
+
+	p.markApplied(t)
+	return true
+}
+
+func (p *Portfolio) markApplied(t *Trade) {
+	if !t.CreatedAt.Equal(p.LastTradeAt) {
+		p.LastTradeAt = t.CreatedAt
+		p.LastTradeHashes = []common.Hash{}
+	}
+
+	p.LastTradeHashes = append(p.LastTradeHashes, t.Hash)
+}
+
+func (p *Portfolio) applySide(pos *Position, side string, amount, quote, fee *big.Int) {
+	if fee == nil {
+		fee = big.NewInt(0)
+	}
+
+	if side == BUY {
+		pos.Buy(p.Method, amount, quote, fee)
+	} else {
+		pos.Sell(p.Method, amount, quote, fee)
+	}
+}
+
+// Position returns the position of a pair, nil if the user did not trade it
+func (p *Portfolio) Position(baseToken, quoteToken common.Address) *Position {
+	for _, pos := range p.Positions {
+		if pos.BaseToken == baseToken && pos.QuoteToken == quoteToken {
+			return pos
+		}
+	}
+
+	return nil
+}
+
+// PortfolioRecord is the bson representation of a Portfolio
+type PortfolioRecord struct {
+	UserAddress     string      `bson:"userAddress"`
+	Method          string      `bson:"method"`
+	Positions       []*Position `bson:"positions"`
+	TradeCount      int         `bson:"tradeCount"`
+	LastTradeAt     time.Time   `bson:"lastTradeAt"`
+	LastTradeHashes []string    `bson:"lastTradeHashes"`
+	CreatedAt       time.Time   `bson:"createdAt"`
+	UpdatedAt       time.Time   `bson:"updatedAt"`
+}
+
+func (p *Portfolio) GetBSON() (interface{}, error) {
+	hashes := make([]string, len(p.LastTradeHashes))
+	for i, h := range p.LastTradeHashes {
+		hashes[i] = h.Hex()
+	}
+
+	return PortfolioRecord{
+		UserAddress:     p.UserAddress.Hex(),
+		Method:          p.Method,
+		Positions:       p.Positions,
+		TradeCount:      p.TradeCount,
+		LastTradeAt:     p.LastTradeAt,
+		LastTradeHashes: hashes,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}, nil
+}
+
+func (p *Portfolio) SetBSON(raw bson.Raw) error {
+	decoded := &PortfolioRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	p.UserAddress = common.HexToAddress(decoded.UserAddress)
+	p.Method = decoded.Method
+	p.Positions = decoded.Positions
+	p.TradeCount = decoded.TradeCount
+	p.LastTradeAt = decoded.LastTradeAt
+	p.CreatedAt = decoded.CreatedAt
+	p.UpdatedAt = decoded.UpdatedAt
+
+	if p.Positions == nil {
+		p.Positions = []*Position{}
+	}
+
+	p.LastTradeHashes = make([]common.Hash, len(decoded.LastTradeHashes))
+	for i, h := range decoded.LastTradeHashes {
+		p.LastTradeHashes[i] = common.HexToHash(h)
+	}
+
+	return nil
+}
+
+// PortfolioSnapshot is the USD value and PnL of a portfolio at a time, for the equity charts
+type PortfolioSnapshot struct {
+	ID            bson.ObjectId  `json:"-"`
+	UserAddress   common.Address `json:"userAddress"`
+	Method        string         `json:"method"`
+	ValueUsd      string         `json:"valueUsd"`
+	RealizedUsd   string         `json:"realizedUsd"`
+	UnrealizedUsd string         `json:"unrealizedUsd"`
+	Timestamp     int64          `json:"timestamp"`
+}
+
+// PortfolioSnapshotRecord is the bson representation of a PortfolioSnapshot
+type PortfolioSnapshotRecord struct {
+	ID            bson.ObjectId `bson:"_id"`
+	UserAddress   string        `bson:"userAddress"`
+	Method        string        `bson:"method"`
+	ValueUsd      string        `bson:"valueUsd"`
+	RealizedUsd   string        `bson:"realizedUsd"`
+	UnrealizedUsd string        `bson:"unrealizedUsd"`
+	Timestamp     int64         `bson:"timestamp"`
+}
+
+func (s *PortfolioSnapshot) GetBSON() (interface{}, error) {
+	return PortfolioSnapshotRecord{
+		ID:            s.ID,
+		UserAddress:   s.UserAddress.Hex(),
+		Method:        s.Method,
+		ValueUsd:      s.ValueUsd,
+		RealizedUsd:   s.RealizedUsd,
+		UnrealizedUsd: s.UnrealizedUsd,
+		Timestamp:     s.Timestamp,
+	}, nil
+}
+
+func (s *PortfolioSnapshot) SetBSON(raw bson.Raw) error {
+	decoded := &PortfolioSnapshotRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	s.ID = decoded.ID
+	s.UserAddress = common.HexToAddress(decoded.UserAddress)
+	s.Method = decoded.Method
+	s.ValueUsd = decoded.ValueUsd
+	s.RealizedUsd = decoded.RealizedUsd
+	s.UnrealizedUsd = decoded.UnrealizedUsd
+	s.Timestamp = decoded.Timestamp
+	return nil
+}
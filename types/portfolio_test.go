@@ -0,0 +1,115 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func portfolioTrade(user common.Address, side string, amount, price, fee int64, at int64) *Trade {
+	return &Trade{
+		Taker:          user,
+		Maker:          common.HexToAddress("0xb"),
+		BaseToken:      common.HexToAddress("0x1"),
+		QuoteToken:     common.HexToAddress("0x2"),
+		PairName:       "BTC/USDT",
+		Hash:           common.BigToHash(big.NewInt(at)),
+		PricePoint:     big.NewInt(price),
+		Amount:         big.NewInt(amount),
+		TakeFee:        big.NewInt(fee),
+		MakeFee:        big.NewInt(0),
+		TakerOrderSide: side,
+		Status:         TradeStatusSuccess,
+		CreatedAt:      time.Unix(at, 0),
+	}
+}
+
+func TestPortfolioCostBasis(t *testing.T) {
+	user := common.HexToAddress("0xa")
+	one := big.NewInt(1)
+
+	trades := []*Trade{
+		portfolioTrade(user, BUY, 10, 100, 10, 1),
+		portfolioTrade(user, BUY, 10, 200, 0, 2),
+		portfolioTrade(user, SELL, 15, 300, 50, 3),
+	}
+
+	fifo, _ := NewPortfolio(user, CostBasisFIFO)
+	average, _ := NewPortfolio(user, CostBasisAverage)
+	for _, tr := range trades {
+		assert.True(t, fifo.ApplyTrade(tr, one))
+		assert.True(t, average.ApplyTrade(tr, one))
+	}
+
+	// fifo: the 15 sold cost 1010 + 5 * 200, their proceeds are 4500 - 50
+	p := fifo.Position(trades[0].BaseToken, trades[0].QuoteToken)
+	assert.Equal(t, big.NewInt(5), p.Amount)
+	assert.Equal(t, big.NewInt(1000), p.Cost)
+	assert.Equal(t, big.NewInt(2440), p.Realized)
+	assert.Equal(t, big.NewInt(60), p.Fees)
+	assert.Len(t, p.Lots, 1)
+
+	// average: the 20 bought cost 3010, the 15 sold 2257
+	p = average.Position(trades[0].BaseToken, trades[0].QuoteToken)
+	assert.Equal(t, big.NewInt(5), p.Amount)
+	assert.Equal(t, big.NewInt(753), p.Cost)
+	assert.Equal(t, big.NewInt(2193), p.Realized)
+
+	p.Mark(big.NewInt(400), one)
+	assert.Equal(t, big.NewInt(2000), p.Value)
+	assert.Equal(t, big.NewInt(1247), p.Unrealized)
+	assert.Equal(t, big.NewInt(150), p.AveragePrice)
+}
+
+func TestPortfolioApplyTrade(t *testing.T) {
+	user := common.HexToAddress("0xa")
+	one := big.NewInt(1)
+	p, _ := NewPortfolio(user, CostBasisAverage)
+
+	buy := portfolioTrade(user, BUY, 10, 100, 0, 1)
+	assert.True(t, p.ApplyTrade(buy, one))
+	assert.False(t, p.ApplyTrade(buy, one))
+
+	// a trade created at the same time as the last applied trade is not ignored
+	same := portfolioTrade(user, BUY, 10, 100, 0, 1)
+	same.Hash = common.HexToHash("0xff")
+	assert.True(t, p.ApplyTrade(same, one))
+	assert.Equal(t, 2, p.TradeCount)
+
+	// the base tokens sold without a cost basis do not realize a PnL
+	sell := portfolioTrade(user, SELL, 40, 150, 0, 2)
+	assert.True(t, p.ApplyTrade(sell, one))
+	pos := p.Positions[0]
+	assert.Equal(t, "0", pos.Amount.String())
+	assert.Equal(t, big.NewInt(1000), pos.Realized)
+	assert.Equal(t, big.NewInt(40), pos.Sold)
+
+	// a user trading with itself buys and sells the same amount
+	self := portfolioTrade(user, BUY, 10, 100, 1, 3)
+	self.Maker = user
+	self.MakeFee = big.NewInt(1)
+	assert.True(t, p.ApplyTrade(self, one))
+	assert.Equal(t, "0", pos.Amount.String())
+	assert.Equal(t, big.NewInt(998), pos.Realized)
+
+	_, err := NewPortfolio(user, "lifo")
+	assert.Error(t, err)
+}
+
+func TestPortfolioSkipTrade(t *testing.T) {
+	user := common.HexToAddress("0xa")
+	p, _ := NewPortfolio(user, CostBasisFIFO)
+
+	skipped := portfolioTrade(user, BUY, 10, 100, 0, 1)
+	assert.True(t, p.SkipTrade(skipped))
+	assert.False(t, p.SkipTrade(skipped))
+	assert.True(t, p.Applied(skipped))
+	assert.Equal(t, 0, p.TradeCount)
+	assert.Len(t, p.Positions, 0)
+
+	assert.True(t, p.ApplyTrade(portfolioTrade(user, BUY, 10, 100, 0, 2), big.NewInt(1)))
+	assert.Equal(t, 1, p.TradeCount)
+}
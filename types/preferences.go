@@ -0,0 +1,258 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/errors"
+)
+
+// Types of the items of the watchlists
+const (
+	WatchlistSpot    = "SPOT"
+	WatchlistLending = "LENDING"
+)
+
+const (
+	// FavoritesWatchlist is the name of the watchlist migrated from the favorite tokens
+	FavoritesWatchlist = "Favorites"
+
+	maxWatchlists        = 20
+	maxWatchlistItems    = 200
+	maxWatchlistName     = 64
+	maxLayouts           = 20
+	maxLayoutsSize       = 64 << 10
+	defaultChartDuration = 1
+	defaultChartUnit     = "hour"
+)
+
+// ErrPreferencesVersion is returned when the preferences were saved by another device since
+// the version they were read at
+var ErrPreferencesVersion = errors.New("The preferences were updated since this version")
+
+// WatchlistItem is a spot pair, given by its base and quote tokens, or a lending pair, given by
+// its term and lending token
+type WatchlistItem struct {
+	Type         string         `json:"type"`
+	BaseToken    common.Address `json:"baseToken,omitempty"`
+	QuoteToken   common.Address `json:"quoteToken,omitempty"`
+	Term         uint64         `json:"term,omitempty"`
+	LendingToken common.Address `json:"lendingToken,omitempty"`
+}
+
+// WatchlistItemRecord is the bson representation of a WatchlistItem
+type WatchlistItemRecord struct {
+	Type         string `bson:"type"`
+	BaseToken    string `bson:"baseToken,omitempty"`
+	QuoteToken   string `bson:"quoteToken,omitempty"`
+	Term         uint64 `bson:"term,omitempty"`
+	LendingToken string `bson:"lendingToken,omitempty"`
+}
+
+func (i WatchlistItem) GetBSON() (interface{}, error) {
+	r := WatchlistItemRecord{Type: i.Type}
+
+	if i.Type == WatchlistSpot {
+		r.BaseToken = i.BaseToken.Hex()
+		r.QuoteToken = i.QuoteToken.Hex()
+	} else {
+		r.Term = i.Term
+		r.LendingToken = i.LendingToken.Hex()
+	}
+
+	return r, nil
+}
+
+func (i *WatchlistItem) SetBSON(raw bson.Raw) error {
+	decoded := &WatchlistItemRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	i.Type = decoded.Type
+	i.BaseToken = common.HexToAddress(decoded.BaseToken)
+	i.QuoteToken = common.HexToAddress(decoded.QuoteToken)
+	i.Term = decoded.Term
+	i.LendingToken = common.HexToAddress(decoded.LendingToken)
+	return nil
+}
+
+// Validate checks the type and the tokens of the item
+func (i *WatchlistItem) Validate() error {
+	switch i.Type {
+	case WatchlistSpot:
+		if (i.BaseToken == common.Address{} || i.QuoteToken == common.Address{}) {
+			return errors.New("A spot pair needs a base token and a quote token")
+		}
+	case WatchlistLending:
+		if (i.Term == 0 || i.LendingToken == common.Address{}) {
+			return errors.New("A lending pair needs a term and a lending token")
+		}
+	default:
+		return errors.New("Invalid watchlist item type " + i.Type)
+	}
+
+	return nil
+}
+
+// Watchlist is a named list of spot and lending pairs
+type Watchlist struct {
+	Name  string          `json:"name" bson:"name"`
+	Items []WatchlistItem `json:"items" bson:"items"`
+}
+
+// UserPreferences are the settings of a user shared by its devices. Each save increments the
+// version, a save must be made from the current version so that the changes made on another
+// device are not overwritten. The notification settings are the delivery preferences of the
+// notification service, they are not versioned with the other settings.
+type UserPreferences struct {
+	UserAddress     common.Address             `json:"userAddress"`
+	Version         int64                      `json:"version"`
+	Watchlists      []Watchlist                `json:"watchlists"`
+	ChartDuration   int64                      `json:"chartDuration"`
+	ChartUnit       string                     `json:"chartUnit"`
+	DisplayCurrency string                     `json:"displayCurrency"`
+	Layouts         map[string]json.RawMessage `json:"layouts"`
+	Notifications   *NotificationPreferences   `json:"notifications,omitempty"`
+	UpdatedAt       time.Time                  `json:"updatedAt"`
+}
+
+// UserPreferencesRecord is the bson representation of UserPreferences, the layouts are stored
+// as their JSON encoding
+type UserPreferencesRecord struct {
+	UserAddress     string            `bson:"userAddress"`
+	Version         int64             `bson:"version"`
+	Watchlists      []Watchlist       `bson:"watchlists"`
+	ChartDuration   int64             `bson:"chartDuration"`
+	ChartUnit       string            `bson:"chartUnit"`
+	DisplayCurrency string            `bson:"displayCurrency"`
+	Layouts         map[string]string `bson:"layouts"`
+	UpdatedAt       time.Time         `bson:"updatedAt"`
+}
+
+func (p *UserPreferences) GetBSON() (interface{}, error) {
+	layouts := make(map[string]string, len(p.Layouts))
+	for k, v := range p.Layouts {
+		layouts[k] = string(v)
+	}
+
+	return UserPreferencesRecord{
+		UserAddress:     p.UserAddress.Hex(),
+		Version:         p.Version,
+		Watchlists:      p.Watchlists,
+		ChartDuration:   p.ChartDuration,
+		ChartUnit:       p.ChartUnit,
+		DisplayCurrency: p.DisplayCurrency,
+		Layouts:         layouts,
+		UpdatedAt:       p.UpdatedAt,
+	}, nil
+}
+
+func (p *UserPreferences) SetBSON(raw bson.Raw) error {
+	decoded := &UserPreferencesRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	p.UserAddress = common.HexToAddress(decoded.UserAddress)
+	p.Version = decoded.Version
+	p.Watchlists = decoded.Watchlists
+	p.ChartDuration = decoded.ChartDuration
+	p.ChartUnit = decoded.ChartUnit
+	p.DisplayCurrency = decoded.DisplayCurrency
+	p.Layouts = make(map[string]json.RawMessage, len(decoded.Layouts))
+	for k, v := range decoded.Layouts {
+		p.Layouts[k] = json.RawMessage(v)
+	}
+	p.UpdatedAt = decoded.UpdatedAt
+	return nil
+}
+
+// NewUserPreferences returns the default preferences of a user, displayed in currency
+func NewUserPreferences(addr common.Address, currency string) *UserPreferences {
+	return &UserPreferences{
+		UserAddress:     addr,
+		Watchlists:      []Watchlist{},
+		ChartDuration:   defaultChartDuration,
+		ChartUnit:       defaultChartUnit,
+		DisplayCurrency: currency,
+		Layouts:         map[string]json.RawMessage{},
+	}
+}
+
+// Validate checks the watchlists, the layouts and the chart resolution of the preferences
+// against the tick durations, by unit, of the candles
+func (p *UserPreferences) Validate(tickDurations map[string][]int64) error {
+	if len(p.Watchlists) > maxWatchlists {
+		return errors.Errorf("At most %d watchlists are allowed", maxWatchlists)
+	}
+
+	names := map[string]bool{}
+	for _, w := range p.Watchlists {
+		name := strings.TrimSpace(w.Name)
+		if name == "" || len(name) > maxWatchlistName {
+			return errors.Errorf("The watchlist names must have 1 to %d characters", maxWatchlistName)
+		}
+
+		if names[name] {
+			return errors.New("Duplicate watchlist " + name)
+		}
+		names[name] = true
+
+		if len(w.Items) > maxWatchlistItems {
+			return errors.Errorf("At most %d pairs are allowed in a watchlist", maxWatchlistItems)
+		}
+
+		for i := range w.Items {
+			if err := w.Items[i].Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(p.Layouts) > maxLayouts {
+		return errors.Errorf("At most %d layouts are allowed", maxLayouts)
+	}
+
+	size := 0
+	for k, v := range p.Layouts {
+		if !json.Valid(v) {
+			return errors.New("Invalid layout " + k)
+		}
+		size += len(k) + len(v)
+	}
+
+	if size > maxLayoutsSize {
+		return errors.Errorf("The layouts must not exceed %d bytes", maxLayoutsSize)
+	}
+
+	for _, d := range tickDurations[p.ChartUnit] {
+		if d == p.ChartDuration {
+			return nil
+		}
+	}
+
+	return errors.New("Invalid chart resolution")
+}
+
+// FavoritesFromTokens returns the watchlist of the spot pairs of pairs whose base token is one
+// of the favorite tokens
+func FavoritesFromTokens(tokens map[common.Address]bool, pairs []Pair) Watchlist {
+	w := Watchlist{Name: FavoritesWatchlist, Items: []WatchlistItem{}}
+	for _, p := range pairs {
+		if tokens[p.BaseTokenAddress] {
+			w.Items = append(w.Items, WatchlistItem{
+				Type:       WatchlistSpot,
+				BaseToken:  p.BaseTokenAddress,
+				QuoteToken: p.QuoteTokenAddress,
+			})
+		}
+	}
+
+	return w
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTickDurations = map[string][]int64{"min": {1, 5}, "hour": {1, 4}}
+
+func TestUserPreferencesValidate(t *testing.T) {
+	p := NewUserPreferences(common.HexToAddress("0x1"), "USD")
+	assert.Nil(t, p.Validate(testTickDurations))
+
+	p.ChartDuration = 2
+	assert.EqualError(t, p.Validate(testTickDurations), "Invalid chart resolution")
+	p.ChartDuration = 4
+
+	p.Watchlists = []Watchlist{
+		{Name: "Majors", Items: []WatchlistItem{{Type: WatchlistSpot, BaseToken: common.HexToAddress("0x2"), QuoteToken: common.HexToAddress("0x3")}}},
+		{Name: "Majors"},
+	}
+	assert.EqualError(t, p.Validate(testTickDurations), "Duplicate watchlist Majors")
+
+	p.Watchlists[1] = Watchlist{Name: "Loans", Items: []WatchlistItem{{Type: WatchlistLending, LendingToken: common.HexToAddress("0x3")}}}
+	assert.EqualError(t, p.Validate(testTickDurations), "A lending pair needs a term and a lending token")
+
+	p.Watchlists[1].Items[0].Term = 86400
+	assert.Nil(t, p.Validate(testTickDurations))
+
+	p.Layouts["desktop"] = json.RawMessage(`{"panels":`)
+	assert.EqualError(t, p.Validate(testTickDurations), "Invalid layout desktop")
+}
+
+func TestUserPreferencesBSON(t *testing.T) {
+	p := NewUserPreferences(common.HexToAddress("0x1"), "USD")
+	p.Version = 3
+	p.Watchlists = []Watchlist{
+		{Name: "Mixed", Items: []WatchlistItem{
+			{Type: WatchlistSpot, BaseToken: common.HexToAddress("0x2"), QuoteToken: common.HexToAddress("0x3")},
+			{Type: WatchlistLending, Term: 86400, LendingToken: common.HexToAddress("0x3")},
+		}},
+	}
+	p.Layouts["desktop"] = json.RawMessage(`{"panels":["chart"]}`)
+
+	data, err := bson.Marshal(p)
+	assert.Nil(t, err)
+
+	decoded := &UserPreferences{}
+	assert.Nil(t, bson.Unmarshal(data, decoded))
+	assert.Equal(t, p.UserAddress, decoded.UserAddress)
+	assert.Equal(t, p.Watchlists, decoded.Watchlists)
+	assert.Equal(t, `{"panels":["chart"]}`, string(decoded.Layouts["desktop"]))
+	assert.Equal(t, int64(3), decoded.Version)
+}
+
+func TestFavoritesFromTokens(t *testing.T) {
+	tomo, usdt, btc := common.HexToAddress("0x1"), common.HexToAddress("0x2"), common.HexToAddress("0x3")
+	pairs := []Pair{
+		{BaseTokenAddress: tomo, QuoteTokenAddress: usdt},
+		{BaseTokenAddress: btc, QuoteTokenAddress: usdt},
+		{BaseTokenAddress: btc, QuoteTokenAddress: tomo},
+	}
+
+	w := FavoritesFromTokens(map[common.Address]bool{btc: true, tomo: false}, pairs)
+	assert.Equal(t, FavoritesWatchlist, w.Name)
+	assert.Equal(t, 2, len(w.Items))
+	assert.Equal(t, usdt, w.Items[0].QuoteToken)
+	assert.Equal(t, tomo, w.Items[1].QuoteToken)
+}
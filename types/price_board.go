@@ -4,5 +4,7 @@ package types
 type PriceBoardData struct {
 	Ticks          []*Tick `json:"ticks" bson:"ticks"`
 	PriceUSD       string  `json:"usd" bson:"usd"`
+	PriceFiat      string  `json:"price,omitempty" bson:"price"`
+	Currency       string  `json:"currency,omitempty" bson:"currency"`
 	LastTradePrice string  `json:"last_trade_price" bson:"last_trade_price"`
 }
@@ -0,0 +1,301 @@
+package types
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tomochain/tomox-sdk/errors"
+)
+
+var (
+	// ErrInvalidSiweMessage is returned when a login message does not follow EIP-4361
+	ErrInvalidSiweMessage = errors.New("Invalid sign-in message")
+
+	// ErrInvalidSiweDomain is returned when a login message is issued for another domain
+	ErrInvalidSiweDomain = errors.New("The sign-in message is issued for another domain")
+
+	// ErrInvalidSiweChain is returned when a login message is issued for another chain
+	ErrInvalidSiweChain = errors.New("The sign-in message is issued for another chain")
+
+	// ErrSiweExpired is returned when a login message is expired or not valid yet
+	ErrSiweExpired = errors.New("The sign-in message is expired or not valid yet")
+
+	// ErrInvalidAuthNonce is returned when the nonce of a login message was not issued or is already used
+	ErrInvalidAuthNonce = errors.New("Invalid or used nonce")
+
+	// ErrInvalidSession is returned when a session token is malformed, forged or expired
+	ErrInvalidSession = errors.New("Invalid or expired session")
+)
+
+const siwePreamble = " wants you to sign in with your Ethereum account:"
+
+// SiweMessage is a Sign-In with Ethereum message (https://eips.ethereum.org/EIPS/eip-4361).
+// The optional times are zero when they are not set.
+type SiweMessage struct {
+	Domain         string         `json:"domain"`
+	Address        common.Address `json:"address"`
+	Statement      string         `json:"statement"`
+	URI            string         `json:"uri"`
+	Version        string         `json:"version"`
+	ChainID        int64          `json:"chainId"`
+	Nonce          string         `json:"nonce"`
+	IssuedAt       time.Time      `json:"issuedAt"`
+	ExpirationTime time.Time      `json:"expirationTime"`
+	NotBefore      time.Time      `json:"notBefore"`
+	RequestID      string         `json:"requestId"`
+	Resources      []string       `json:"resources"`
+}
+
+// ParseSiweMessage parses the text of a Sign-In with Ethereum message, the URI, version,
+// chain id, nonce and issue time are required
+func ParseSiweMessage(s string) (*SiweMessage, error) {
+	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], siwePreamble) {
+		return nil, ErrInvalidSiweMessage
+	}
+
+	m := &SiweMessage{Domain: strings.TrimSuffix(lines[0], siwePreamble)}
+	if m.Domain == "" || !common.IsHexAddress(lines[1]) {
+		return nil, ErrInvalidSiweMessage
+	}
+
+	m.Address = common.HexToAddress(lines[1])
+
+	fields := map[string]*string{}
+	var chainID, issuedAt, expirationTime, notBefore string
+	fields["URI: "] = &m.URI
+	fields["Version: "] = &m.Version
+	fields["Chain ID: "] = &chainID
+	fields["Nonce: "] = &m.Nonce
+	fields["Issued At: "] = &issuedAt
+	fields["Expiration Time: "] = &expirationTime
+	fields["Not Before: "] = &notBefore
+	fields["Request ID: "] = &m.RequestID
+
+	resources := false
+	for _, l := range lines[2:] {
+		if l == "" {
+			continue
+		}
+
+		if resources {
+			if !strings.HasPrefix(l, "- ") {
+				return nil, ErrInvalidSiweMessage
+			}
+
+			m.Resources = append(m.Resources, strings.TrimPrefix(l, "- "))
+			continue
+		}
+
+		if l == "Resources:" {
+			resources = true
+			continue
+		}
+
+		field := false
+		for prefix, v := range fields {
+			if strings.HasPrefix(l, prefix) {
+				*v = strings.TrimPrefix(l, prefix)
+				field = true
+				break
+			}
+		}
+
+		if field {
+			continue
+		}
+
+		// the statement is the only free line, before the fields
+		if m.Statement != "" || m.URI != "" {
+			return nil, ErrInvalidSiweMessage
+		}
+
+		m.Statement = l
+	}
+
+	if m.URI == "" || m.Version != "1" || len(m.Nonce) < 8 || !isAlphanumeric(m.Nonce) {
+		return nil, ErrInvalidSiweMessage
+	}
+
+	var err error
+	if m.ChainID, err = strconv.ParseInt(chainID, 10, 64); err != nil {
+		return nil, ErrInvalidSiweMessage
+	}
+
+	if m.IssuedAt, err = time.Parse(time.RFC3339, issuedAt); err != nil {
+		return nil, ErrInvalidSiweMessage
+	}
+
+	if expirationTime != "" {
+		if m.ExpirationTime, err = time.Parse(time.RFC3339, expirationTime); err != nil {
+			return nil, ErrInvalidSiweMessage
+		}
+	}
+
+	if notBefore != "" {
+		if m.NotBefore, err = time.Parse(time.RFC3339, notBefore); err != nil {
+			return nil, ErrInvalidSiweMessage
+		}
+	}
+
+	return m, nil
+}
+
+// CheckIssuer returns an error if the message is not issued for domain and for the chain chainID
+func (m *SiweMessage) CheckIssuer(domain string, chainID int64) error {
+	if domain == "" || m.Domain != domain {
+		return ErrInvalidSiweDomain
+	}
+
+	if m.ChainID != chainID {
+		return ErrInvalidSiweChain
+	}
+
+	return nil
+}
+
+// ValidAt returns ErrSiweExpired if the message can not be used at t
+func (m *SiweMessage) ValidAt(t time.Time) error {
+	if !m.ExpirationTime.IsZero() && !t.Before(m.ExpirationTime) {
+		return ErrSiweExpired
+	}
+
+	if !m.NotBefore.IsZero() && t.Before(m.NotBefore) {
+		return ErrSiweExpired
+	}
+
+	return nil
+}
+
+// VerifySiweSignature returns the address which signed the text of a message as a personal
+// message (https://github.com/ethereum/EIPs/issues/191). The recovery id of the 65 bytes
+// signature is 0/1 or 27/28.
+func VerifySiweSignature(message string, signature []byte) (common.Address, error) {
+	if len(signature) != 65 {
+		return common.Address{}, ErrInvalidLoginSignature
+	}
+
+	sig := make([]byte, 65)
+	copy(sig, signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	hash := crypto.Keccak256(
+		[]byte("\x19Ethereum Signed Message:\n"+strconv.Itoa(len(message))),
+		[]byte(message),
+	)
+
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, ErrInvalidLoginSignature
+	}
+
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// AuthNonce is issued to a client before it signs in, it can be used once until it expires
+type AuthNonce struct {
+	Nonce     string    `json:"nonce" bson:"_id"`
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+// NewAuthNonce returns a random nonce valid for ttl
+func NewAuthNonce(ttl time.Duration) (*AuthNonce, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	return &AuthNonce{Nonce: hex.EncodeToString(b), ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+// LoginRequest is the signed Sign-In with Ethereum message sent to open a session
+type LoginRequest struct {
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// AuthSession is returned to a client which signed in, Token is sent in the Authorization
+// header of the private requests as a bearer token
+type AuthSession struct {
+	Token     string         `json:"token"`
+	Address   common.Address `json:"address"`
+	ExpiresAt time.Time      `json:"expiresAt"`
+}
+
+// SessionClaims are the claims of the session tokens, JWTs signed with HS256
+type SessionClaims struct {
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	ID        string `json:"jti"`
+}
+
+var sessionTokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// NewSessionToken returns the JWT of the claims signed with secret
+func NewSessionToken(c *SessionClaims, secret []byte) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := sessionTokenHeader + "." + base64.RawURLEncoding.EncodeToString(b)
+	return unsigned + "." + signSessionToken(unsigned, secret), nil
+}
+
+// ParseSessionToken returns the claims of a JWT signed with secret, ErrInvalidSession if the
+// token is not signed with HS256 and secret or is expired at now
+func ParseSessionToken(token string, secret []byte, now time.Time) (*SessionClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != sessionTokenHeader {
+		return nil, ErrInvalidSession
+	}
+
+	sig := signSessionToken(parts[0]+"."+parts[1], secret)
+	if !hmac.Equal([]byte(sig), []byte(parts[2])) {
+		return nil, ErrInvalidSession
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidSession
+	}
+
+	c := &SessionClaims{}
+	if err := json.Unmarshal(b, c); err != nil || !common.IsHexAddress(c.Subject) {
+		return nil, ErrInvalidSession
+	}
+
+	if now.Unix() >= c.ExpiresAt {
+		return nil, ErrInvalidSession
+	}
+
+	return c, nil
+}
+
+func signSessionToken(unsigned string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func isAlphanumeric(s string) bool {
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const siweTestMessage = `dex.tomochain.com wants you to sign in with your Ethereum account:
+0x8c3D5e5ff4a0B2b41E1bB3D4c8e5b5A6D0a4a1A0
+
+Sign in to TomoX
+
+URI: https://dex.tomochain.com
+Version: 1
+Chain ID: 88
+Nonce: 32891756abcdef12
+Issued At: 2020-01-01T00:00:00Z
+Expiration Time: 2020-01-02T00:00:00Z
+Resources:
+- https://dex.tomochain.com/terms`
+
+func TestParseSiweMessage(t *testing.T) {
+	m, err := ParseSiweMessage(siweTestMessage)
+	assert.Nil(t, err)
+	assert.Equal(t, "dex.tomochain.com", m.Domain)
+	assert.Equal(t, common.HexToAddress("0x8c3D5e5ff4a0B2b41E1bB3D4c8e5b5A6D0a4a1A0"), m.Address)
+	assert.Equal(t, "Sign in to TomoX", m.Statement)
+	assert.Equal(t, int64(88), m.ChainID)
+	assert.Equal(t, "32891756abcdef12", m.Nonce)
+	assert.Equal(t, []string{"https://dex.tomochain.com/terms"}, m.Resources)
+
+	assert.Nil(t, m.CheckIssuer("dex.tomochain.com", 88))
+	assert.Equal(t, ErrInvalidSiweDomain, m.CheckIssuer("phishing.example", 88))
+	assert.Equal(t, ErrInvalidSiweDomain, m.CheckIssuer("", 88))
+	assert.Equal(t, ErrInvalidSiweChain, m.CheckIssuer("dex.tomochain.com", 89))
+
+	assert.Nil(t, m.ValidAt(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ErrSiweExpired, m.ValidAt(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)))
+
+	_, err = ParseSiweMessage("dex.tomochain.com wants you to sign in\n0x1")
+	assert.Equal(t, ErrInvalidSiweMessage, err)
+
+	// the version and the nonce are required
+	_, err = ParseSiweMessage(strings.Replace(siweTestMessage, "Version: 1\n", "", 1))
+	assert.Equal(t, ErrInvalidSiweMessage, err)
+
+	_, err = ParseSiweMessage(strings.Replace(siweTestMessage, "32891756abcdef12", "1234", 1))
+	assert.Equal(t, ErrInvalidSiweMessage, err)
+}
+
+func TestVerifySiweSignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	msg := "a message"
+	hash := crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n" + strconv.Itoa(len(msg)) + msg))
+	sig, _ := crypto.Sign(hash, key)
+
+	signer, err := VerifySiweSignature(msg, sig)
+	assert.Nil(t, err)
+	assert.Equal(t, addr, signer)
+
+	sig[64] += 27
+	signer, err = VerifySiweSignature(msg, sig)
+	assert.Nil(t, err)
+	assert.Equal(t, addr, signer)
+
+	signer, _ = VerifySiweSignature("another message", sig)
+	assert.NotEqual(t, addr, signer)
+
+	_, err = VerifySiweSignature(msg, sig[:64])
+	assert.Equal(t, ErrInvalidLoginSignature, err)
+}
+
+func TestSessionToken(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Unix(1000, 0)
+	c := &SessionClaims{Subject: common.HexToAddress("0x1").Hex(), IssuedAt: 1000, ExpiresAt: 2000, ID: "1"}
+
+	token, err := NewSessionToken(c, secret)
+	assert.Nil(t, err)
+
+	parsed, err := ParseSessionToken(token, secret, now)
+	assert.Nil(t, err)
+	assert.Equal(t, c, parsed)
+
+	_, err = ParseSessionToken(token, []byte("other"), now)
+	assert.Equal(t, ErrInvalidSession, err)
+
+	_, err = ParseSessionToken(token, secret, time.Unix(2000, 0))
+	assert.Equal(t, ErrInvalidSession, err)
+
+	_, err = ParseSessionToken(token+"x", secret, now)
+	assert.Equal(t, ErrInvalidSession, err)
+}
@@ -0,0 +1,329 @@
+package types
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/utils/math"
+)
+
+// Types of the statement lines
+const (
+	StatementTrade          = "TRADE"
+	StatementFee            = "FEE"
+	StatementLend           = "LEND"
+	StatementBorrow         = "BORROW"
+	StatementRepay          = "REPAY"
+	StatementInterestEarned = "INTEREST_EARNED"
+	StatementInterestPaid   = "INTEREST_PAID"
+	StatementLiquidation    = "LIQUIDATION"
+	StatementTopup          = "TOPUP"
+)
+
+const (
+	// LendingInterestBase is the precision of the interest rates of the lending orders and trades
+	LendingInterestBase = 100000000
+
+	lendingYear = 365 * 24 * 60 * 60
+)
+
+// StatementCSVHeader is the header of the CSV export of the statements
+var StatementCSVHeader = []string{
+	"time", "type", "reference", "txHash", "market", "side", "token", "symbol", "amount",
+	"price", "value", "currency",
+}
+
+// StatementLine is a movement of a token of a user, the amount is positive when the user
+// receives the token and negative when it pays it. The price and the value are in the fiat
+// currency of the statement at the time of the movement, they are nil when the token has no
+// known price.
+type StatementLine struct {
+	Time      time.Time      `json:"time"`
+	Type      string         `json:"type"`
+	Reference common.Hash    `json:"reference"`
+	TxHash    common.Hash    `json:"txHash"`
+	Market    string         `json:"market"`
+	Side      string         `json:"side,omitempty"`
+	Token     common.Address `json:"token"`
+	Symbol    string         `json:"symbol"`
+	Decimals  int            `json:"decimals"`
+	Amount    *big.Int       `json:"-"`
+	Quantity  string         `json:"amount"`
+	Price     *big.Float     `json:"price"`
+	Value     *big.Float     `json:"value"`
+}
+
+// CSVRecord returns the fields of the line in the order of StatementCSVHeader
+func (l *StatementLine) CSVRecord(currency string) []string {
+	return []string{
+		csvTime(l.Time),
+		l.Type,
+		l.Reference.Hex(),
+		l.TxHash.Hex(),
+		l.Market,
+		l.Side,
+		l.Token.Hex(),
+		l.Symbol,
+		l.Quantity,
+		csvBigFloat(l.Price),
+		csvBigFloat(l.Value),
+		currency,
+	}
+}
+
+// StatementTotal is the value of the lines of a type
+type StatementTotal struct {
+	Type  string     `json:"type"`
+	Count int        `json:"count"`
+	Value *big.Float `json:"value"`
+}
+
+// Statement is the list of the movements of a user between two dates, with the totals of
+// their values per type
+type Statement struct {
+	UserAddress common.Address    `json:"userAddress"`
+	Currency    string            `json:"currency"`
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Lines       []*StatementLine  `json:"lines"`
+	Totals      []*StatementTotal `json:"totals"`
+}
+
+// Add adds lines to the statement, the lines outside of its dates are ignored
+func (s *Statement) Add(lines ...*StatementLine) {
+	for _, l := range lines {
+		if l.Time.Before(s.From) || !l.Time.Before(s.To) {
+			continue
+		}
+
+		s.Lines = append(s.Lines, l)
+	}
+}
+
+// Close sorts the lines by time and computes the totals
+func (s *Statement) Close() {
+	sort.SliceStable(s.Lines, func(i, j int) bool { return s.Lines[i].Time.Before(s.Lines[j].Time) })
+
+	totals := make(map[string]*StatementTotal)
+	s.Totals = []*StatementTotal{}
+
+	for _, l := range s.Lines {
+		t, ok := totals[l.Type]
+		if !ok {
+			t = &StatementTotal{Type: l.Type, Value: big.NewFloat(0)}
+			totals[l.Type] = t
+			s.Totals = append(s.Totals, t)
+		}
+
+		t.Count++
+		if l.Value != nil {
+			t.Value.Add(t.Value, l.Value)
+		}
+	}
+}
+
+// TradeStatementLines returns the movements of a user in a trade: the base and quote tokens
+// exchanged and the fee paid in quote token. A user trading with itself gets the lines of
+// both sides.
+func TradeStatementLines(t *Trade, user common.Address, baseMultiplier *big.Int) []*StatementLine {
+	quote := math.Div(math.Mul(t.Amount, t.PricePoint), baseMultiplier)
+	lines := []*StatementLine{}
+
+	side := func(side string, fee *big.Int) {
+		base, counter := t.Amount, new(big.Int).Neg(quote)
+		if side == SELL {
+			base, counter = new(big.Int).Neg(t.Amount), quote
+		}
+
+		line := func(typ string, token common.Address, amount *big.Int) *StatementLine {
+			return &StatementLine{
+				Time:      t.CreatedAt,
+				Type:      typ,
+				Reference: t.Hash,
+				TxHash:    t.TxHash,
+				Market:    t.PairName,
+				Side:      side,
+				Token:     token,
+				Amount:    amount,
+			}
+		}
+
+		lines = append(lines, line(StatementTrade, t.BaseToken, base), line(StatementTrade, t.QuoteToken, counter))
+		if fee != nil && fee.Sign() > 0 {
+			lines = append(lines, line(StatementFee, t.QuoteToken, new(big.Int).Neg(fee)))
+		}
+	}
+
+	if t.Taker == user {
+		side(t.TakerOrderSide, t.TakeFee)
+	}
+
+	if t.Maker == user {
+		makerSide := BUY
+		if t.TakerOrderSide == BUY {
+			makerSide = SELL
+		}
+
+		side(makerSide, t.MakeFee)
+	}
+
+	return lines
+}
+
+// LendingTradeStatementLines returns the movements of a user in a lending trade: the loan and
+// its fee when the trade is opened, the repayment and the interest when it is closed, or the
+// collateral when it is liquidated. The trades are closed and liquidated at their update time.
+// lendingSymbol is the symbol of the lending token, which names the lending pair.
+func LendingTradeStatementLines(t *LendingTrade, user common.Address, lendingSymbol string) []*StatementLine {
+	lines := []*StatementLine{}
+	investor := t.Investor == user
+	borrower := t.Borrower == user
+
+	line := func(at time.Time, typ, side string, token common.Address, amount *big.Int) {
+		if amount == nil || amount.Sign() == 0 {
+			return
+		}
+
+		lines = append(lines, &StatementLine{
+			Time:      at,
+			Type:      typ,
+			Reference: t.Hash,
+			TxHash:    t.TxHash,
+			Market:    lendingMarket(t.Term, lendingSymbol),
+			Side:      side,
+			Token:     token,
+			Amount:    amount,
+		})
+	}
+
+	neg := func(n *big.Int) *big.Int {
+		if n == nil {
+			return nil
+		}
+
+		return new(big.Int).Neg(n)
+	}
+
+	if investor {
+		line(t.CreatedAt, StatementLend, LEND, t.LendingToken, neg(t.Amount))
+		line(t.CreatedAt, StatementFee, LEND, t.LendingToken, neg(t.InvestingFee))
+	}
+
+	if borrower {
+		line(t.CreatedAt, StatementBorrow, BORROW, t.LendingToken, t.Amount)
+		line(t.CreatedAt, StatementFee, BORROW, t.LendingToken, neg(t.BorrowingFee))
+	}
+
+	switch t.Status {
+	case TradeStatusClosed:
+		interest := LendingInterest(t.Amount, t.Interest, t.Term, t.LiquidationTime, uint64(t.UpdatedAt.Unix()))
+
+		if investor {
+			line(t.UpdatedAt, StatementRepay, LEND, t.LendingToken, t.Amount)
+			line(t.UpdatedAt, StatementInterestEarned, LEND, t.LendingToken, interest)
+		}
+
+		if borrower {
+			line(t.UpdatedAt, StatementRepay, BORROW, t.LendingToken, neg(t.Amount))
+			line(t.UpdatedAt, StatementInterestPaid, BORROW, t.LendingToken, neg(interest))
+		}
+	case TradeStatusLiquidated:
+		// the collateral of a liquidated loan goes to the investor instead of the repayment
+		if investor {
+			line(t.UpdatedAt, StatementLiquidation, LEND, t.CollateralToken, t.CollateralLockedAmount)
+		}
+
+		if borrower {
+			line(t.UpdatedAt, StatementLiquidation, BORROW, t.CollateralToken, neg(t.CollateralLockedAmount))
+		}
+	}
+
+	return lines
+}
+
+// TopupStatementLine returns the collateral added by a user to a loan
+func TopupStatementLine(o *LendingOrder, lendingSymbol string) *StatementLine {
+	amount := big.NewInt(0)
+	if o.Quantity != nil {
+		amount = new(big.Int).Neg(o.Quantity)
+	}
+
+	return &StatementLine{
+		Time:      o.CreatedAt,
+		Type:      StatementTopup,
+		Reference: o.Hash,
+		TxHash:    o.TxHash,
+		Market:    lendingMarket(o.Term, lendingSymbol),
+		Side:      BORROW,
+		Token:     o.CollateralToken,
+		Amount:    amount,
+	}
+}
+
+// LendingInterest returns the interest of a loan of amount at an annual rate, with the precision
+// LendingInterestBase, repaid at closedAt. The interest runs from the start of the term and
+// at least for half of the term.
+func LendingInterest(amount *big.Int, rate, term, liquidationTime, closedAt uint64) *big.Int {
+	if amount == nil || term == 0 || liquidationTime < term {
+		return big.NewInt(0)
+	}
+
+	start := liquidationTime - term
+	duration := uint64(0)
+	if closedAt > start {
+		duration = closedAt - start
+	}
+
+	if duration < term/2 {
+		duration = term / 2
+	}
+
+	if duration > term {
+		duration = term
+	}
+
+	n := new(big.Int).Mul(amount, new(big.Int).SetUint64(rate))
+	n.Mul(n, new(big.Int).SetUint64(duration))
+
+	d := new(big.Int).Mul(big.NewInt(100*LendingInterestBase), big.NewInt(lendingYear))
+	return n.Div(n, d)
+}
+
+// FormatUnits returns an amount of a token with its decimals, without trailing zeros
+func FormatUnits(amount *big.Int, decimals int) string {
+	if amount == nil {
+		return ""
+	}
+
+	r := new(big.Rat).SetFrac(amount, math.Exp(big.NewInt(10), big.NewInt(int64(decimals))))
+	s := r.FloatString(decimals)
+
+	if decimals > 0 {
+		for s[len(s)-1] == '0' {
+			s = s[:len(s)-1]
+		}
+
+		if s[len(s)-1] == '.' {
+			s = s[:len(s)-1]
+		}
+	}
+
+	return s
+}
+
+// lendingMarket returns the name of a lending pair, as LendingPair.Name
+func lendingMarket(term uint64, lendingSymbol string) string {
+	return strconv.FormatUint(term, 10) + "/" + lendingSymbol
+}
+
+func csvBigFloat(f *big.Float) string {
+	if f == nil {
+		return ""
+	}
+
+	return f.Text('f', 8)
+}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTradeStatementLines(t *testing.T) {
+	user := common.HexToAddress("0xa")
+	tr := portfolioTrade(user, SELL, 10, 100, 5, 1)
+
+	lines := TradeStatementLines(tr, user, big.NewInt(1))
+	assert.Len(t, lines, 3)
+	assert.Equal(t, StatementTrade, lines[0].Type)
+	assert.Equal(t, tr.BaseToken, lines[0].Token)
+	assert.Equal(t, big.NewInt(-10), lines[0].Amount)
+	assert.Equal(t, tr.QuoteToken, lines[1].Token)
+	assert.Equal(t, big.NewInt(1000), lines[1].Amount)
+	assert.Equal(t, StatementFee, lines[2].Type)
+	assert.Equal(t, big.NewInt(-5), lines[2].Amount)
+
+	// the maker side without a fee
+	lines = TradeStatementLines(tr, tr.Maker, big.NewInt(1))
+	assert.Len(t, lines, 2)
+	assert.Equal(t, BUY, lines[0].Side)
+	assert.Equal(t, big.NewInt(10), lines[0].Amount)
+}
+
+func TestLendingTradeStatementLines(t *testing.T) {
+	investor := common.HexToAddress("0xa")
+	borrower := common.HexToAddress("0xb")
+	day := uint64(24 * 60 * 60)
+	created := time.Unix(1000000, 0)
+
+	lt := &LendingTrade{
+		Investor:        investor,
+		Borrower:        borrower,
+		LendingToken:    common.HexToAddress("0x1"),
+		CollateralToken: common.HexToAddress("0x2"),
+		Term:            30 * day,
+		Interest:        10 * LendingInterestBase,
+		Amount:          big.NewInt(365000000),
+		BorrowingFee:    big.NewInt(100),
+		InvestingFee:    big.NewInt(0),
+		LiquidationTime: uint64(created.Unix()) + 30*day,
+		Status:          TradeStatusClosed,
+		CreatedAt:       created,
+		UpdatedAt:       created.Add(20 * 24 * time.Hour),
+	}
+
+	lines := LendingTradeStatementLines(lt, investor, "USDT")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "2592000/USDT", lines[0].Market)
+	assert.Equal(t, StatementLend, lines[0].Type)
+	assert.Equal(t, StatementRepay, lines[1].Type)
+	assert.Equal(t, StatementInterestEarned, lines[2].Type)
+	// 10% a year during 20 days
+	assert.Equal(t, big.NewInt(2000000), lines[2].Amount)
+
+	lines = LendingTradeStatementLines(lt, borrower, "USDT")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, StatementFee, lines[1].Type)
+	assert.Equal(t, big.NewInt(-2000000), lines[3].Amount)
+
+	lt.Status = TradeStatusLiquidated
+	lt.CollateralLockedAmount = big.NewInt(7)
+	lines = LendingTradeStatementLines(lt, borrower, "USDT")
+	assert.Equal(t, StatementLiquidation, lines[2].Type)
+	assert.Equal(t, lt.CollateralToken, lines[2].Token)
+	assert.Equal(t, big.NewInt(-7), lines[2].Amount)
+}
+
+func TestLendingInterest(t *testing.T) {
+	day := uint64(24 * 60 * 60)
+	amount := big.NewInt(365000000)
+
+	// the interest runs at least for half of the term
+	assert.Equal(t, big.NewInt(1500000), LendingInterest(amount, 10*LendingInterestBase, 30*day, 30*day, 1))
+	assert.Equal(t, big.NewInt(3000000), LendingInterest(amount, 10*LendingInterestBase, 30*day, 30*day, 40*day))
+}
+
+func TestStatement(t *testing.T) {
+	s := &Statement{From: time.Unix(10, 0), To: time.Unix(20, 0)}
+	s.Add(
+		&StatementLine{Time: time.Unix(15, 0), Type: StatementFee, Value: big.NewFloat(-1)},
+		&StatementLine{Time: time.Unix(20, 0), Type: StatementFee},
+		&StatementLine{Time: time.Unix(12, 0), Type: StatementFee, Value: big.NewFloat(-2)},
+		&StatementLine{Time: time.Unix(11, 0), Type: StatementTrade},
+	)
+	s.Close()
+
+	assert.Len(t, s.Lines, 3)
+	assert.Equal(t, StatementTrade, s.Lines[0].Type)
+	assert.Equal(t, 2, s.Totals[1].Count)
+	assert.Equal(t, "-3", s.Totals[1].Value.String())
+}
+
+func TestFormatUnits(t *testing.T) {
+	assert.Equal(t, "1.5", FormatUnits(big.NewInt(1500), 3))
+	assert.Equal(t, "-2", FormatUnits(big.NewInt(-2000), 3))
+	assert.Equal(t, "7", FormatUnits(big.NewInt(7), 0))
+}
@@ -56,12 +56,14 @@ type TradeSpec struct {
 	RelayerAddress common.Address
 	DateFrom       int64
 	DateTo         int64
+	Cursor         *Cursor
 }
 
 // TradeRes response api
 type TradeRes struct {
-	Total  int      `json:"total" bson:"total"`
-	Trades []*Trade `json:"trades" bson:"orders"`
+	Total      int      `json:"total" bson:"total"`
+	Trades     []*Trade `json:"trades" bson:"orders"`
+	NextCursor string   `json:"nextCursor,omitempty" bson:"-"`
 }
 type TradeRecord struct {
 	ID             bson.ObjectId `json:"id" bson:"_id"`
@@ -0,0 +1,431 @@
+package types
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/utils/math"
+)
+
+// Types of the signatures of the orders, cancels and lending actions. The legacy signatures
+// sign the hash of the fields as a personal message, the EIP-712 signatures sign their typed data.
+const (
+	SignatureTypeLegacy = ""
+	SignatureTypeEIP712 = "EIP712"
+)
+
+// ErrTypedDataDisabled is returned for the typed data requests when the typed data signatures
+// are not accepted
+var ErrTypedDataDisabled = errors.New("Typed data signatures are not enabled")
+
+// TypedDataSignatures returns true if the typed data signatures are accepted,
+// tomochain.typed_data_signatures. They are off by default since the node recovers the signers
+// over the legacy hashes, it would reject the orders signed as typed data. The flag must only be
+// enabled once the node reads the signatureType field of the order messages and recovers the
+// EIP712 signatures over the typed data hash.
+func TypedDataSignatures() bool {
+	return app.Config.Tomochain["typed_data_signatures"] == "true"
+}
+
+// DefaultChainID is the chain id of the typed data domains and of the sign-in messages when
+// tomochain.chain_id is not set
+const DefaultChainID = 88
+
+// ChainID returns the chain id of the TomoChain network, tomochain.chain_id
+func ChainID() int64 {
+	chainID, err := strconv.ParseInt(app.Config.Tomochain["chain_id"], 10, 64)
+	if err != nil {
+		return DefaultChainID
+	}
+
+	return chainID
+}
+
+// TypedDataField is a member of a struct type of typed data
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedDataDomain is the EIP-712 domain of the typed data
+type TypedDataDomain struct {
+	Name              string         `json:"name"`
+	Version           string         `json:"version"`
+	ChainID           int64          `json:"chainId"`
+	VerifyingContract common.Address `json:"verifyingContract"`
+}
+
+// TypedData is the structure signed by eth_signTypedData_v4 (https://eips.ethereum.org/EIPS/eip-712).
+// The structs are flat and their members are address, bool, string, bytes32 or uint256, the
+// uint256 values of Message are decimal strings.
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      TypedDataDomain             `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+var typedDataDomainType = []TypedDataField{
+	{"name", "string"},
+	{"version", "string"},
+	{"chainId", "uint256"},
+	{"verifyingContract", "address"},
+}
+
+// typedDataTypes are the struct types of the orders, cancels and lending actions
+var typedDataTypes = map[string][]TypedDataField{
+	"Order": {
+		{"exchangeAddress", "address"},
+		{"userAddress", "address"},
+		{"baseToken", "address"},
+		{"quoteToken", "address"},
+		{"amount", "uint256"},
+		{"pricepoint", "uint256"},
+		{"side", "string"},
+		{"type", "string"},
+		{"nonce", "uint256"},
+	},
+	"OrderCancel": {
+		{"orderHash", "bytes32"},
+		{"nonce", "uint256"},
+	},
+	"LendingOrder": {
+		{"relayerAddress", "address"},
+		{"userAddress", "address"},
+		{"collateralToken", "address"},
+		{"lendingToken", "address"},
+		{"quantity", "uint256"},
+		{"term", "uint256"},
+		{"interest", "uint256"},
+		{"side", "string"},
+		{"type", "string"},
+		{"autoTopUp", "bool"},
+		{"nonce", "uint256"},
+	},
+	"LendingCancel": {
+		{"relayerAddress", "address"},
+		{"userAddress", "address"},
+		{"lendingToken", "address"},
+		{"term", "uint256"},
+		{"lendingId", "uint256"},
+		{"nonce", "uint256"},
+	},
+	"LendingRepay": {
+		{"relayerAddress", "address"},
+		{"userAddress", "address"},
+		{"lendingToken", "address"},
+		{"term", "uint256"},
+		{"tradeId", "uint256"},
+		{"nonce", "uint256"},
+	},
+	"LendingTopup": {
+		{"relayerAddress", "address"},
+		{"userAddress", "address"},
+		{"lendingToken", "address"},
+		{"term", "uint256"},
+		{"tradeId", "uint256"},
+		{"quantity", "uint256"},
+		{"nonce", "uint256"},
+	},
+}
+
+// OrderTypedDataDomain returns the domain of the orders and their cancels, verified by the
+// exchange contract
+func OrderTypedDataDomain() TypedDataDomain {
+	return typedDataDomain(app.Config.Tomochain["exchange_contract_address"])
+}
+
+// LendingTypedDataDomain returns the domain of the lending orders and actions, verified by the
+// lending contract
+func LendingTypedDataDomain() TypedDataDomain {
+	return typedDataDomain(app.Config.Tomochain["lending_contract_address"])
+}
+
+func typedDataDomain(contract string) TypedDataDomain {
+	return TypedDataDomain{
+		Name:              "TomoX",
+		Version:           "1",
+		ChainID:           ChainID(),
+		VerifyingContract: common.HexToAddress(contract),
+	}
+}
+
+func newTypedData(primaryType string, domain TypedDataDomain, message map[string]interface{}) *TypedData {
+	return &TypedData{
+		Types: map[string][]TypedDataField{
+			"EIP712Domain": typedDataDomainType,
+			primaryType:    typedDataTypes[primaryType],
+		},
+		PrimaryType: primaryType,
+		Domain:      domain,
+		Message:     message,
+	}
+}
+
+// SignedHash returns the hash signed by the wallets, keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+func (td *TypedData) SignedHash() (common.Hash, error) {
+	domain := map[string]interface{}{
+		"name":              td.Domain.Name,
+		"version":           td.Domain.Version,
+		"chainId":           strconv.FormatInt(td.Domain.ChainID, 10),
+		"verifyingContract": td.Domain.VerifyingContract,
+	}
+
+	domainSeparator, err := hashStruct("EIP712Domain", typedDataDomainType, domain)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	fields, ok := td.Types[td.PrimaryType]
+	if !ok {
+		return common.Hash{}, errors.New("Unknown typed data primary type " + td.PrimaryType)
+	}
+
+	message, err := hashStruct(td.PrimaryType, fields, td.Message)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.BytesToHash(crypto.Keccak256([]byte("\x19\x01"), domainSeparator, message)), nil
+}
+
+// Recover returns the address which signed the typed data
+func (td *TypedData) Recover(s *Signature) (common.Address, error) {
+	if s == nil {
+		return common.Address{}, errors.New("Signature is missing")
+	}
+
+	hash, err := td.SignedHash()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return s.Verify(hash)
+}
+
+// encodeType returns the type string of a struct, as "Order(address userAddress,uint256 amount)"
+func encodeType(name string, fields []TypedDataField) string {
+	members := make([]string, len(fields))
+	for i, f := range fields {
+		members[i] = f.Type + " " + f.Name
+	}
+
+	return name + "(" + strings.Join(members, ",") + ")"
+}
+
+func hashStruct(name string, fields []TypedDataField, data map[string]interface{}) ([]byte, error) {
+	encoded := crypto.Keccak256([]byte(encodeType(name, fields)))
+
+	for _, f := range fields {
+		v, err := encodeTypedValue(f.Type, data[f.Name])
+		if err != nil {
+			return nil, errors.New("Invalid typed data field " + f.Name + ": " + err.Error())
+		}
+
+		encoded = append(encoded, v...)
+	}
+
+	return crypto.Keccak256(encoded), nil
+}
+
+// encodeTypedValue returns the 32 bytes encoding of an atomic value, the strings are hashed
+func encodeTypedValue(typ string, v interface{}) ([]byte, error) {
+	switch typ {
+	case "address":
+		switch a := v.(type) {
+		case common.Address:
+			return common.LeftPadBytes(a.Bytes(), 32), nil
+		case string:
+			if common.IsHexAddress(a) {
+				return common.LeftPadBytes(common.HexToAddress(a).Bytes(), 32), nil
+			}
+		}
+	case "bool":
+		if b, ok := v.(bool); ok {
+			if b {
+				return common.LeftPadBytes([]byte{1}, 32), nil
+			}
+
+			return make([]byte, 32), nil
+		}
+	case "string":
+		if s, ok := v.(string); ok {
+			return crypto.Keccak256([]byte(s)), nil
+		}
+	case "bytes32":
+		switch h := v.(type) {
+		case common.Hash:
+			return h.Bytes(), nil
+		case string:
+			return common.HexToHash(h).Bytes(), nil
+		}
+	case "uint256":
+		var n *big.Int
+		switch i := v.(type) {
+		case *big.Int:
+			n = i
+		case uint64:
+			n = new(big.Int).SetUint64(i)
+		case string:
+			n = math.ToBigInt(i)
+		}
+
+		if n != nil && n.Sign() >= 0 && n.BitLen() <= 256 {
+			return common.LeftPadBytes(n.Bytes(), 32), nil
+		}
+	}
+
+	return nil, errors.New("expected a " + typ)
+}
+
+// Kinds of the typed data requests
+const (
+	TypedDataOrder   = "order"
+	TypedDataCancel  = "cancel"
+	TypedDataLending = "lending"
+)
+
+// TypedDataRequest asks for the typed data to sign for an order, for the cancel of an order
+// given by the hash and the nonce of Order, or for a lending order or action given by the
+// status of LendingOrder
+type TypedDataRequest struct {
+	Kind         string        `json:"kind"`
+	Order        *Order        `json:"order"`
+	LendingOrder *LendingOrder `json:"lendingOrder"`
+}
+
+// TypedData returns the typed data of the request
+func (r *TypedDataRequest) TypedData() (*TypedData, error) {
+	switch r.Kind {
+	case TypedDataOrder:
+		if r.Order != nil {
+			return r.Order.TypedData(), nil
+		}
+	case TypedDataCancel:
+		if r.Order != nil {
+			oc := &OrderCancel{OrderHash: r.Order.Hash, Nonce: r.Order.Nonce}
+			return oc.TypedData(), nil
+		}
+	case TypedDataLending:
+		if r.LendingOrder != nil {
+			return r.LendingOrder.TypedData(), nil
+		}
+	default:
+		return nil, errors.New("Typed data 'kind' should be 'order', 'cancel' or 'lending'")
+	}
+
+	return nil, errors.New("Typed data of kind " + r.Kind + " requires an order")
+}
+
+// decimal returns a uint256 value of a typed data message, zero if it is not set
+func decimal(n *big.Int) string {
+	if n == nil {
+		return "0"
+	}
+
+	return n.String()
+}
+
+// TypedData returns the EIP-712 typed data of the order
+func (o *Order) TypedData() *TypedData {
+	return newTypedData("Order", OrderTypedDataDomain(), map[string]interface{}{
+		"exchangeAddress": o.ExchangeAddress,
+		"userAddress":     o.UserAddress,
+		"baseToken":       o.BaseToken,
+		"quoteToken":      o.QuoteToken,
+		"amount":          decimal(o.Amount),
+		"pricepoint":      decimal(o.PricePoint),
+		"side":            o.Side,
+		"type":            o.Type,
+		"nonce":           decimal(o.Nonce),
+	})
+}
+
+// TypedData returns the EIP-712 typed data of the cancel
+func (oc *OrderCancel) TypedData() *TypedData {
+	return newTypedData("OrderCancel", OrderTypedDataDomain(), map[string]interface{}{
+		"orderHash": oc.OrderHash,
+		"nonce":     decimal(oc.Nonce),
+	})
+}
+
+// TypedData returns the EIP-712 typed data of the lending order, or of the lending action
+// (cancel, repay or topup) given by its status
+func (o *LendingOrder) TypedData() *TypedData {
+	message := map[string]interface{}{
+		"relayerAddress": o.RelayerAddress,
+		"userAddress":    o.UserAddress,
+		"lendingToken":   o.LendingToken,
+		"term":           strconv.FormatUint(o.Term, 10),
+		"nonce":          decimal(o.Nonce),
+	}
+
+	switch o.Status {
+	case LendingStatusCancelled:
+		message["lendingId"] = strconv.FormatUint(o.LendingID, 10)
+		return newTypedData("LendingCancel", LendingTypedDataDomain(), message)
+	case LendingStatusRepay:
+		message["tradeId"] = strconv.FormatUint(o.LendingTradeID, 10)
+		return newTypedData("LendingRepay", LendingTypedDataDomain(), message)
+	case LendingStatusTopup:
+		message["tradeId"] = strconv.FormatUint(o.LendingTradeID, 10)
+		message["quantity"] = decimal(o.Quantity)
+		return newTypedData("LendingTopup", LendingTypedDataDomain(), message)
+	}
+
+	message["collateralToken"] = o.CollateralToken
+	message["quantity"] = decimal(o.Quantity)
+	message["interest"] = strconv.FormatUint(o.Interest, 10)
+	message["side"] = o.Side
+	message["type"] = o.Type
+	message["autoTopUp"] = o.AutoTopUp == 1
+	return newTypedData("LendingOrder", LendingTypedDataDomain(), message)
+}
+
+// signatureType returns the type of a signature by signer of a legacy hash or of typed data, an
+// error if the signature is not made by signer. The typed data is only checked when the typed
+// data signatures are accepted.
+func signatureType(s *Signature, signer common.Address, legacyHash common.Hash, td *TypedData) (string, error) {
+	if s == nil {
+		return "", errors.New("Signature is missing")
+	}
+
+	message := crypto.Keccak256(
+		[]byte("\x19Ethereum Signed Message:\n32"),
+		legacyHash.Bytes(),
+	)
+
+	if address, err := s.Verify(common.BytesToHash(message)); err == nil && address == signer {
+		return SignatureTypeLegacy, nil
+	}
+
+	if !TypedDataSignatures() {
+		return "", errors.New("Recovered address is incorrect")
+	}
+
+	if address, err := td.Recover(s); err == nil && address == signer {
+		return SignatureTypeEIP712, nil
+	}
+
+	return "", errors.New("Recovered address is incorrect")
+}
+
+// RecoverSignatureType returns the type of the signature of the order by its user
+func (o *Order) RecoverSignatureType() (string, error) {
+	return signatureType(o.Signature, o.UserAddress, o.ComputeHash(), o.TypedData())
+}
+
+// RecoverSignatureType returns the type of the signature of the cancel by its user
+func (oc *OrderCancel) RecoverSignatureType() (string, error) {
+	return signatureType(oc.Signature, oc.UserAddress, oc.ComputeHash(), oc.TypedData())
+}
+
+// RecoverSignatureType returns the type of the signature of the lending order or action by its user
+func (o *LendingOrder) RecoverSignatureType() (string, error) {
+	return signatureType(o.Signature, o.UserAddress, o.ComputeHash(), o.TypedData())
+}
@@ -0,0 +1,176 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomochain/tomox-sdk/app"
+)
+
+// enableTypedData accepts the typed data signatures, the returned function restores the config
+func enableTypedData() func() {
+	tomochain := app.Config.Tomochain
+	app.Config.Tomochain = map[string]string{"typed_data_signatures": "true"}
+
+	return func() {
+		app.Config.Tomochain = tomochain
+	}
+}
+
+func TestEncodeType(t *testing.T) {
+	assert.Equal(t, "OrderCancel(bytes32 orderHash,uint256 nonce)", encodeType("OrderCancel", typedDataTypes["OrderCancel"]))
+	assert.Equal(t, "EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)", encodeType("EIP712Domain", typedDataDomainType))
+}
+
+func TestOrderTypedDataSignature(t *testing.T) {
+	defer enableTypedData()()
+
+	key, _ := crypto.GenerateKey()
+
+	o := &Order{
+		UserAddress:     crypto.PubkeyToAddress(key.PublicKey),
+		ExchangeAddress: common.HexToAddress("0x1"),
+		BaseToken:       common.HexToAddress("0x2"),
+		QuoteToken:      common.HexToAddress("0x3"),
+		Amount:          big.NewInt(1000),
+		PricePoint:      big.NewInt(10),
+		Side:            BUY,
+		Type:            TypeLimitOrder,
+		Nonce:           big.NewInt(1),
+	}
+
+	// legacy signature of the order hash
+	var err error
+	o.Signature, err = SignHash(o.ComputeHash(), key)
+	assert.Nil(t, err)
+
+	typ, err := o.RecoverSignatureType()
+	assert.Nil(t, err)
+	assert.Equal(t, SignatureTypeLegacy, typ)
+
+	// signature of the typed data
+	hash, err := o.TypedData().SignedHash()
+	assert.Nil(t, err)
+
+	o.Signature, err = Sign(hash, key)
+	assert.Nil(t, err)
+
+	valid, err := o.VerifySignature()
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	// the type is kept on the order for the node
+	assert.Equal(t, SignatureTypeEIP712, o.SignatureType)
+
+	// the typed data covers the fields of the order
+	o.Amount = big.NewInt(1001)
+	valid, _ = o.VerifySignature()
+	assert.False(t, valid)
+}
+
+func TestOrderCancelTypedDataSignature(t *testing.T) {
+	defer enableTypedData()()
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	oc := &OrderCancel{OrderHash: common.HexToHash("0xa"), Nonce: big.NewInt(2), UserAddress: addr}
+	oc.Hash = oc.ComputeHash()
+
+	hash, err := oc.TypedData().SignedHash()
+	assert.Nil(t, err)
+
+	oc.Signature, _ = Sign(hash, key)
+
+	sender, err := oc.GetSenderAddress()
+	assert.Nil(t, err)
+	assert.Equal(t, addr, sender)
+
+	valid, err := oc.VerifySignature(&Order{UserAddress: addr})
+	assert.True(t, valid)
+	assert.Nil(t, err)
+}
+
+func TestLendingOrderTypedData(t *testing.T) {
+	defer enableTypedData()()
+
+	key, _ := crypto.GenerateKey()
+
+	o := &LendingOrder{
+		UserAddress:     crypto.PubkeyToAddress(key.PublicKey),
+		RelayerAddress:  common.HexToAddress("0x1"),
+		LendingToken:    common.HexToAddress("0x2"),
+		CollateralToken: common.HexToAddress("0x3"),
+		Quantity:        big.NewInt(1000),
+		Term:            86400,
+		Interest:        10,
+		Side:            BORROW,
+		Type:            TypeLimit,
+		AutoTopUp:       1,
+		Nonce:           big.NewInt(1),
+	}
+
+	assert.Equal(t, "LendingOrder", o.TypedData().PrimaryType)
+	assert.Equal(t, true, o.TypedData().Message["autoTopUp"])
+
+	hash, err := o.TypedData().SignedHash()
+	assert.Nil(t, err)
+
+	o.Signature, _ = Sign(hash, key)
+	valid, err := o.VerifySignature()
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	o.Status = LendingStatusRepay
+	o.LendingTradeID = 5
+	td := o.TypedData()
+	assert.Equal(t, "LendingRepay", td.PrimaryType)
+	assert.Equal(t, "5", td.Message["tradeId"])
+
+	// the actions are signed with their own struct type
+	typ, err := o.RecoverSignatureType()
+	assert.NotNil(t, err)
+	assert.Equal(t, "", typ)
+
+	hash, _ = td.SignedHash()
+	o.Signature, _ = Sign(hash, key)
+	typ, err = o.RecoverSignatureType()
+	assert.Nil(t, err)
+	assert.Equal(t, SignatureTypeEIP712, typ)
+}
+
+func TestTypedDataSignaturesDisabled(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	oc := &OrderCancel{OrderHash: common.HexToHash("0xa"), Nonce: big.NewInt(2), UserAddress: addr}
+	oc.Hash = oc.ComputeHash()
+
+	hash, _ := oc.TypedData().SignedHash()
+	oc.Signature, _ = Sign(hash, key)
+
+	// the node would not recover the signer of the typed data
+	valid, err := oc.VerifySignature(&Order{UserAddress: addr})
+	assert.False(t, valid)
+	assert.NotNil(t, err)
+
+	sender, _ := oc.GetSenderAddress()
+	assert.NotEqual(t, addr, sender)
+}
+
+func TestTypedDataRequest(t *testing.T) {
+	req := &TypedDataRequest{Kind: TypedDataCancel, Order: &Order{Hash: common.HexToHash("0xa"), Nonce: big.NewInt(2)}}
+	td, err := req.TypedData()
+	assert.Nil(t, err)
+	assert.Equal(t, "OrderCancel", td.PrimaryType)
+	assert.Equal(t, "2", td.Message["nonce"])
+
+	_, err = (&TypedDataRequest{Kind: TypedDataLending}).TypedData()
+	assert.NotNil(t, err)
+
+	_, err = (&TypedDataRequest{Kind: "trade"}).TypedData()
+	assert.NotNil(t, err)
+}
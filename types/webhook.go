@@ -0,0 +1,272 @@
+package types
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/tomochain/tomox-sdk/errors"
+)
+
+// Events which can be delivered to a webhook. The order events are the statuses of the
+// engine responses, the lending events the statuses of the lending engine responses.
+const (
+	WebhookOrderAdded           = ORDER_ADDED
+	WebhookOrderPartiallyFilled = ORDER_PARTIALLY_FILLED
+	WebhookOrderFilled          = ORDER_FILLED
+	WebhookOrderCancelled       = ORDER_CANCELLED
+	WebhookOrderRejected        = ORDER_REJECTED
+	WebhookTradeAdded           = TradeAdded
+	WebhookLendingTopup         = LENDING_ORDER_TOPUPED
+	WebhookLendingRepay         = LENDING_ORDER_REPAYED
+	WebhookLendingRecall        = LENDING_ORDER_RECALLED
+	WebhookLendingLiquidated    = LENDING_TRADE_LIQUIDATED
+)
+
+// WebhookEvents are the events accepted in the filters of the webhooks
+var WebhookEvents = []string{
+	WebhookOrderAdded,
+	WebhookOrderPartiallyFilled,
+	WebhookOrderFilled,
+	WebhookOrderCancelled,
+	WebhookOrderRejected,
+	WebhookTradeAdded,
+	WebhookLendingTopup,
+	WebhookLendingRepay,
+	WebhookLendingRecall,
+	WebhookLendingLiquidated,
+}
+
+// Statuses of the webhook deliveries
+const (
+	DeliveryStatusPending = "PENDING"
+	DeliveryStatusSuccess = "SUCCESS"
+	DeliveryStatusFailed  = "FAILED"
+)
+
+const (
+	// WebhookMaxAttempts is the number of attempts of a delivery before it is marked as failed
+	WebhookMaxAttempts = 8
+
+	// webhookBaseDelay is the delay before the first retry, it doubles with each attempt
+	webhookBaseDelay = 10 * time.Second
+)
+
+// Headers of the webhook requests
+const (
+	WebhookEventHeader     = "X-Tomox-Event"
+	WebhookDeliveryHeader  = "X-Tomox-Delivery"
+	WebhookTimestampHeader = "X-Tomox-Timestamp"
+	WebhookSignatureHeader = "X-Tomox-Signature"
+)
+
+// Webhook is an HTTPS endpoint receiving the events of the exchange. The events can be
+// filtered by type and by the addresses involved (users, relayers), an empty filter matches
+// every event. The webhooks registered by a user have an owner and only receive the events
+// involving it. The payloads are signed with the secret, which is only returned on creation.
+type Webhook struct {
+	ID        bson.ObjectId    `json:"id"`
+	Owner     common.Address   `json:"owner,omitempty"`
+	URL       string           `json:"url"`
+	Secret    string           `json:"secret,omitempty"`
+	Events    []string         `json:"events"`
+	Addresses []common.Address `json:"addresses"`
+	Active    bool             `json:"active"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+// WebhookRecord is the bson representation of a Webhook
+type WebhookRecord struct {
+	ID        bson.ObjectId `bson:"_id"`
+	Owner     string        `bson:"owner,omitempty"`
+	URL       string        `bson:"url"`
+	Secret    string        `bson:"secret"`
+	Events    []string      `bson:"events"`
+	Addresses []string      `bson:"addresses"`
+	Active    bool          `bson:"active"`
+	CreatedAt time.Time     `bson:"createdAt"`
+	UpdatedAt time.Time     `bson:"updatedAt"`
+}
+
+func (w *Webhook) GetBSON() (interface{}, error) {
+	addresses := make([]string, len(w.Addresses))
+	for i, a := range w.Addresses {
+		addresses[i] = a.Hex()
+	}
+
+	owner := ""
+	if w.Owner != (common.Address{}) {
+		owner = w.Owner.Hex()
+	}
+
+	return WebhookRecord{
+		ID:        w.ID,
+		Owner:     owner,
+		URL:       w.URL,
+		Secret:    w.Secret,
+		Events:    w.Events,
+		Addresses: addresses,
+		Active:    w.Active,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}, nil
+}
+
+func (w *Webhook) SetBSON(raw bson.Raw) error {
+	decoded := &WebhookRecord{}
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	w.ID = decoded.ID
+	if decoded.Owner != "" {
+		w.Owner = common.HexToAddress(decoded.Owner)
+	}
+	w.URL = decoded.URL
+	w.Secret = decoded.Secret
+	w.Events = decoded.Events
+	w.Addresses = make([]common.Address, len(decoded.Addresses))
+	for i, a := range decoded.Addresses {
+		w.Addresses[i] = common.HexToAddress(a)
+	}
+	w.Active = decoded.Active
+	w.CreatedAt = decoded.CreatedAt
+	w.UpdatedAt = decoded.UpdatedAt
+	return nil
+}
+
+// Validate checks the url and the event filter of the webhook
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.New("Webhook url must be an https url")
+	}
+
+	for _, e := range w.Events {
+		valid := false
+		for _, v := range WebhookEvents {
+			if e == v {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return errors.New("Invalid webhook event " + e)
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if the event passes the filters of the webhook
+func (w *Webhook) Matches(event string, addresses []common.Address) bool {
+	if !w.Active {
+		return false
+	}
+
+	if len(w.Events) > 0 {
+		found := false
+		for _, e := range w.Events {
+			if e == event {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	// the webhooks of a user only receive its own events, whatever their addresses
+	filter := w.Addresses
+	if w.Owner != (common.Address{}) {
+		filter = []common.Address{w.Owner}
+	}
+
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, a := range filter {
+		for _, b := range addresses {
+			if a == b {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// WebhookPayload is the body posted to the webhooks
+type WebhookPayload struct {
+	ID        bson.ObjectId `json:"id"`
+	Event     string        `json:"event"`
+	Data      interface{}   `json:"data"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// WebhookDelivery is the log of the delivery of an event to a webhook. The body is stored as
+// it was first sent so that a replay delivers the same payload.
+type WebhookDelivery struct {
+	ID           bson.ObjectId `json:"id" bson:"_id"`
+	WebhookID    bson.ObjectId `json:"webhookId" bson:"webhookId"`
+	Event        string        `json:"event" bson:"event"`
+	Body         string        `json:"body" bson:"body"`
+	Status       string        `json:"status" bson:"status"`
+	Attempts     int           `json:"attempts" bson:"attempts"`
+	ResponseCode int           `json:"responseCode" bson:"responseCode"`
+	Error        string        `json:"error" bson:"error"`
+	NextRetryAt  time.Time     `json:"nextRetryAt" bson:"nextRetryAt"`
+	CreatedAt    time.Time     `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time     `json:"updatedAt" bson:"updatedAt"`
+}
+
+// WebhookDeliverySpec filters the deliveries of a webhook
+type WebhookDeliverySpec struct {
+	WebhookID bson.ObjectId
+	Status    string
+	DateFrom  int64
+	DateTo    int64
+}
+
+// WebhookDeliveryRes is a page of deliveries
+type WebhookDeliveryRes struct {
+	Total      int                `json:"total"`
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+}
+
+// WebhookDeliveryMessage is queued in RabbitMQ for each attempt of a delivery
+type WebhookDeliveryMessage struct {
+	DeliveryID bson.ObjectId `json:"deliveryId"`
+}
+
+// WebhookRetryDelay returns the delay before the retry following the attempt, the delays
+// double from 10 seconds
+func WebhookRetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	return webhookBaseDelay << uint(attempt-1)
+}
+
+// SignWebhookPayload returns the signature of a webhook request, the hex encoded HMAC-SHA256
+// of the timestamp and the body joined with a dot
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookMatches(t *testing.T) {
+	user := common.HexToAddress("0x1")
+	relayer := common.HexToAddress("0x2")
+
+	w := &Webhook{Active: true}
+	assert.True(t, w.Matches(WebhookOrderAdded, []common.Address{user}))
+
+	w.Events = []string{WebhookTradeAdded}
+	assert.False(t, w.Matches(WebhookOrderAdded, []common.Address{user}))
+	assert.True(t, w.Matches(WebhookTradeAdded, []common.Address{user}))
+
+	w.Addresses = []common.Address{relayer}
+	assert.False(t, w.Matches(WebhookTradeAdded, []common.Address{user}))
+	assert.True(t, w.Matches(WebhookTradeAdded, []common.Address{user, relayer}))
+
+	w.Active = false
+	assert.False(t, w.Matches(WebhookTradeAdded, []common.Address{relayer}))
+}
+
+func TestWebhookMatchesOwner(t *testing.T) {
+	user := common.HexToAddress("0x1")
+	relayer := common.HexToAddress("0x2")
+
+	// the webhook of a user only receives its events, whatever its address filter
+	w := &Webhook{Active: true, Owner: user, Addresses: []common.Address{relayer}}
+	assert.True(t, w.Matches(WebhookOrderAdded, []common.Address{user, relayer}))
+	assert.False(t, w.Matches(WebhookOrderAdded, []common.Address{relayer}))
+}
+
+func TestWebhookValidate(t *testing.T) {
+	assert.Nil(t, (&Webhook{URL: "https://example.com/hook", Events: []string{WebhookLendingRepay}}).Validate())
+	assert.NotNil(t, (&Webhook{URL: "http://example.com/hook"}).Validate())
+	assert.NotNil(t, (&Webhook{URL: "https://example.com/hook", Events: []string{"UNKNOWN"}}).Validate())
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, 10*time.Second, WebhookRetryDelay(1))
+	assert.Equal(t, 20*time.Second, WebhookRetryDelay(2))
+	assert.Equal(t, 1280*time.Second, WebhookRetryDelay(WebhookMaxAttempts))
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"event":"TRADE_ADDED"}`)
+	sig := SignWebhookPayload("secret", 1546300800, body)
+
+	assert.Equal(t, sig, SignWebhookPayload("secret", 1546300800, body))
+	assert.NotEqual(t, sig, SignWebhookPayload("other", 1546300800, body))
+	assert.NotEqual(t, sig, SignWebhookPayload("secret", 1546300801, body))
+	assert.Len(t, sig, len("sha256=")+64)
+}
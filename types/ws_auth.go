@@ -0,0 +1,58 @@
+package types
+
+import (
+	"crypto/rand"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tomochain/tomox-sdk/errors"
+)
+
+var (
+	// ErrInvalidLoginSignature is returned when a login does not carry a signature of the challenge by the claimed address
+	ErrInvalidLoginSignature = errors.New("Invalid login signature")
+)
+
+// LoginChallenge is sent by the server, the client logs in by signing Nonce with the key of its wallet
+type LoginChallenge struct {
+	Nonce common.Hash `json:"nonce"`
+}
+
+// LoginPayload is sent by a client to bind its connection to an address
+type LoginPayload struct {
+	Address   common.Address `json:"address"`
+	Signature *Signature     `json:"signature"`
+}
+
+// NewLoginChallenge returns a challenge with a random nonce
+func NewLoginChallenge() (*LoginChallenge, error) {
+	var nonce common.Hash
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+
+	return &LoginChallenge{Nonce: nonce}, nil
+}
+
+// SignedHash returns the hash signed by the wallets, the nonce is signed as a personal message
+// (https://github.com/ethereum/EIPs/issues/191) like with SignHash
+func (ch *LoginChallenge) SignedHash() common.Hash {
+	return common.BytesToHash(crypto.Keccak256(
+		[]byte("\x19Ethereum Signed Message:\n32"),
+		ch.Nonce.Bytes(),
+	))
+}
+
+// Verify checks that the login payload is signed by its address
+func (p *LoginPayload) Verify(ch *LoginChallenge) error {
+	if ch == nil || p.Signature == nil || (p.Address == common.Address{}) {
+		return ErrInvalidLoginSignature
+	}
+
+	addr, err := p.Signature.Verify(ch.SignedHash())
+	if err != nil || addr != p.Address {
+		return ErrInvalidLoginSignature
+	}
+
+	return nil
+}
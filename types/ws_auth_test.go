@@ -0,0 +1,32 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginPayloadVerify(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	ch, err := NewLoginChallenge()
+	assert.Nil(t, err)
+
+	sig, err := SignHash(ch.Nonce, key)
+	assert.Nil(t, err)
+
+	p := &LoginPayload{Address: addr, Signature: sig}
+	assert.Nil(t, p.Verify(ch))
+
+	other, _ := NewLoginChallenge()
+	assert.Equal(t, ErrInvalidLoginSignature, p.Verify(other))
+
+	p = &LoginPayload{Address: common.HexToAddress("0x1"), Signature: sig}
+	assert.Equal(t, ErrInvalidLoginSignature, p.Verify(ch))
+
+	p = &LoginPayload{Address: addr}
+	assert.Equal(t, ErrInvalidLoginSignature, p.Verify(ch))
+}
@@ -24,6 +24,13 @@ const (
 	SUCCESS_EVENT SubscriptionEvent = "SUCCESS"
 	INIT          SubscriptionEvent = "INIT"
 	CANCEL        SubscriptionEvent = "CANCEL"
+	CHALLENGE     SubscriptionEvent = "CHALLENGE"
+	LOGIN         SubscriptionEvent = "LOGIN"
+
+	// acknowledgements of the client messages, they carry the id of the message
+	SUBSCRIBED         SubscriptionEvent = "SUBSCRIBED"
+	UNSUBSCRIBED       SubscriptionEvent = "UNSUBSCRIBED"
+	LIST_SUBSCRIPTIONS SubscriptionEvent = "LIST_SUBSCRIPTIONS"
 
 	// status
 
@@ -55,6 +62,8 @@ const (
 	LENDING_ORDER_TOPUP_REJECTED  = "LENDING_ORDER_TOPUP_REJECTED"
 	LENDING_ORDER_REPAY_REJECTED  = "LENDING_ORDER_REPAY_REJECTED"
 	LENDING_ORDER_RECALL_REJECTED = "LENDING_ORDER_RECALL_REJECTED"
+
+	LENDING_TRADE_LIQUIDATED = "LENDING_TRADE_LIQUIDATED"
 )
 
 type WebsocketMessage struct {
@@ -66,7 +75,10 @@ func (ev *WebsocketMessage) String() string {
 	return fmt.Sprintf("%v/%v", ev.Channel, ev.Event.String())
 }
 
+// WebsocketEvent is the event of a websocket message. ID is set by the client to match
+// the acknowledgement or the error sent in reply to the message.
 type WebsocketEvent struct {
+	ID      string            `json:"id,omitempty"`
 	Type    SubscriptionEvent `json:"type"`
 	Hash    string            `json:"hash,omitempty"`
 	Payload interface{}       `json:"payload"`
@@ -76,6 +88,12 @@ func (ev *WebsocketEvent) String() string {
 	return fmt.Sprintf("%v", ev.Type)
 }
 
+// WebsocketSubscription is a subscription of a connection returned by LIST_SUBSCRIPTIONS
+type WebsocketSubscription struct {
+	Channel string      `json:"channel"`
+	Payload interface{} `json:"payload"`
+}
+
 // Params is a sub document used to pass parameters in Subscription messages
 type Params struct {
 	From     int64  `json:"from"`
@@ -119,6 +137,11 @@ type SubscriptionPayload struct {
 	Units        string         `json:"units"`
 	Term         uint64         `json:"term"`
 	LendingToken common.Address `json:"lendingToken,omitempty"`
+	Currency     string         `json:"currency,omitempty"`
+	Indicator    string         `json:"indicator,omitempty"`
+	TickSize     string         `json:"tickSize,omitempty"`
+	Levels       int            `json:"levels,omitempty"`
+	Cumulative   bool           `json:"cumulative,omitempty"`
 }
 
 /*
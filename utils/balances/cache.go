@@ -0,0 +1,217 @@
+// Package balances keeps the token balances of the accounts up to date with the Transfer
+// events of the tokens, so that they are not read from the chain on every request.
+package balances
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TransferTopic is the topic of the ERC-20 Transfer(address,address,uint256) event
+var TransferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// balanceOfSelector is the selector of the ERC-20 balanceOf(address) method
+var balanceOfSelector = crypto.Keccak256([]byte("balanceOf(address)"))[:4]
+
+// Transfer is a decoded Transfer event
+type Transfer struct {
+	Token   common.Address
+	From    common.Address
+	To      common.Address
+	Value   *big.Int
+	Block   uint64
+	Index   uint
+	Removed bool
+}
+
+// DecodeTransfer decodes a Transfer event log, it returns false if the log is not a Transfer
+// event with indexed from and to addresses
+func DecodeTransfer(l *eth.Log) (*Transfer, bool) {
+	if len(l.Topics) != 3 || l.Topics[0] != TransferTopic || len(l.Data) != 32 {
+		return nil, false
+	}
+
+	return &Transfer{
+		Token:   l.Address,
+		From:    common.BytesToAddress(l.Topics[1].Bytes()),
+		To:      common.BytesToAddress(l.Topics[2].Bytes()),
+		Value:   new(big.Int).SetBytes(l.Data),
+		Block:   l.BlockNumber,
+		Index:   l.Index,
+		Removed: l.Removed,
+	}, true
+}
+
+// BalanceOfData returns the call data of balanceOf(owner)
+func BalanceOfData(owner common.Address) []byte {
+	return append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(owner.Bytes(), 32)...)
+}
+
+// Key identifies the balance of a token of an owner
+type Key struct {
+	Owner common.Address
+	Token common.Address
+}
+
+type entry struct {
+	balance   *big.Int
+	block     uint64
+	fetchedAt time.Time
+	usedAt    time.Time
+}
+
+// Cache holds the balances read at a block and applies the transfers of the following blocks.
+// The position of the cache is the last applied transfer: the transfers at or before it are
+// ignored, so the transfers of a catch up and of a subscription can overlap.
+type Cache struct {
+	entries map[Key]*entry
+	block   uint64
+	index   uint
+	mutex   sync.Mutex
+}
+
+// NewCache returns an empty cache
+func NewCache() *Cache {
+	return &Cache{entries: make(map[Key]*entry)}
+}
+
+// Block returns the block of the position of the cache, the balances added to the cache must
+// be read at this block
+func (c *Cache) Block() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.block
+}
+
+// Advance moves the position to the end of block, once all its transfers are applied
+func (c *Cache) Advance(block uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if block >= c.block {
+		c.block = block
+		c.index = ^uint(0)
+	}
+}
+
+// Get returns a balance, the balances read more than maxAge ago are ignored if maxAge is not 0
+func (c *Cache) Get(k Key, maxAge time.Duration) (*big.Int, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entries[k]
+	if !ok || (maxAge != 0 && time.Since(e.fetchedAt) > maxAge) {
+		return nil, false
+	}
+
+	e.usedAt = time.Now()
+	return new(big.Int).Set(e.balance), true
+}
+
+// Put adds a balance read at block. It returns false if transfers after block were already
+// applied, the balance is then not added since it misses them.
+func (c *Cache) Put(k Key, balance *big.Int, block uint64) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if block < c.block {
+		return false
+	}
+
+	now := time.Now()
+	c.entries[k] = &entry{new(big.Int).Set(balance), block, now, now}
+	return true
+}
+
+// Remove removes a balance
+func (c *Cache) Remove(k Key) {
+	c.mutex.Lock()
+	delete(c.entries, k)
+	c.mutex.Unlock()
+}
+
+// Apply applies a transfer to the balances of its sender and recipient, it returns the keys
+// of the changed balances. The balances of a transfer removed by a reorg are dropped.
+func (c *Cache) Apply(t *Transfer) []Key {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	keys := []Key{{t.From, t.Token}, {t.To, t.Token}}
+
+	if t.Removed {
+		changed := []Key{}
+		for _, k := range keys {
+			if _, ok := c.entries[k]; ok {
+				delete(c.entries, k)
+				changed = append(changed, k)
+			}
+		}
+
+		return changed
+	}
+
+	if t.Block < c.block || (t.Block == c.block && t.Index <= c.index) {
+		return nil
+	}
+
+	c.block = t.Block
+	c.index = t.Index
+
+	changed := []Key{}
+	for i, k := range keys {
+		e, ok := c.entries[k]
+
+		// a balance read at the block of the transfer already includes it
+		if !ok || e.block >= t.Block {
+			continue
+		}
+
+		if i == 0 {
+			e.balance = new(big.Int).Sub(e.balance, t.Value)
+		} else {
+			e.balance = new(big.Int).Add(e.balance, t.Value)
+		}
+
+		// the balance is wrong if the transfers did not add up, it is read again
+		if e.balance.Sign() < 0 {
+			delete(c.entries, k)
+		}
+
+		changed = append(changed, k)
+	}
+
+	return changed
+}
+
+// Keys returns the keys of the balances used since idle or kept, the other balances are removed.
+// keep may be nil.
+func (c *Cache) Keys(idle time.Duration, keep func(Key) bool) []Key {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	keys := []Key{}
+	for k, e := range c.entries {
+		if time.Since(e.usedAt) > idle && (keep == nil || !keep(k)) {
+			delete(c.entries, k)
+			continue
+		}
+
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// Clear removes all the balances, for example when the transfers since the position can not
+// be retrieved
+func (c *Cache) Clear() {
+	c.mutex.Lock()
+	c.entries = make(map[Key]*entry)
+	c.mutex.Unlock()
+}
@@ -0,0 +1,103 @@
+package balances
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	token = common.HexToAddress("0x1")
+	alice = common.HexToAddress("0xa")
+	bob   = common.HexToAddress("0xb")
+)
+
+func transferLog(from, to common.Address, value int64, block uint64, index uint) *eth.Log {
+	return &eth.Log{
+		Address:     token,
+		Topics:      []common.Hash{TransferTopic, from.Hash(), to.Hash()},
+		Data:        common.LeftPadBytes(big.NewInt(value).Bytes(), 32),
+		BlockNumber: block,
+		Index:       index,
+	}
+}
+
+func TestDecodeTransfer(t *testing.T) {
+	tr, ok := DecodeTransfer(transferLog(alice, bob, 5, 10, 2))
+	assert.True(t, ok)
+	assert.Equal(t, token, tr.Token)
+	assert.Equal(t, alice, tr.From)
+	assert.Equal(t, bob, tr.To)
+	assert.Equal(t, big.NewInt(5), tr.Value)
+	assert.Equal(t, uint64(10), tr.Block)
+
+	approval := transferLog(alice, bob, 5, 10, 2)
+	approval.Topics[0] = common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
+	_, ok = DecodeTransfer(approval)
+	assert.False(t, ok)
+}
+
+func TestBalanceOfData(t *testing.T) {
+	assert.Equal(t, "70a08231000000000000000000000000000000000000000000000000000000000000000a", common.Bytes2Hex(BalanceOfData(alice)))
+}
+
+func TestCacheApply(t *testing.T) {
+	c := NewCache()
+	c.Advance(10)
+
+	assert.True(t, c.Put(Key{alice, token}, big.NewInt(100), 10))
+	assert.True(t, c.Put(Key{bob, token}, big.NewInt(0), 10))
+
+	tr, _ := DecodeTransfer(transferLog(alice, bob, 30, 11, 0))
+	assert.Len(t, c.Apply(tr), 2)
+
+	b, _ := c.Get(Key{alice, token}, 0)
+	assert.Equal(t, big.NewInt(70), b)
+	b, _ = c.Get(Key{bob, token}, 0)
+	assert.Equal(t, big.NewInt(30), b)
+
+	// the transfers of a catch up overlapping the subscription are applied once
+	assert.Nil(t, c.Apply(tr))
+	b, _ = c.Get(Key{alice, token}, 0)
+	assert.Equal(t, big.NewInt(70), b)
+
+	// a balance read before the position misses the applied transfers
+	assert.False(t, c.Put(Key{alice, token}, big.NewInt(100), 10))
+
+	// a balance read at the block of a transfer already includes it
+	assert.True(t, c.Put(Key{alice, token}, big.NewInt(40), 12))
+	tr, _ = DecodeTransfer(transferLog(alice, bob, 30, 12, 1))
+	assert.Equal(t, []Key{{bob, token}}, c.Apply(tr))
+	b, _ = c.Get(Key{alice, token}, 0)
+	assert.Equal(t, big.NewInt(40), b)
+
+	// the balances of a transfer removed by a reorg are read again
+	tr.Removed = true
+	assert.Len(t, c.Apply(tr), 2)
+	_, ok := c.Get(Key{alice, token}, 0)
+	assert.False(t, ok)
+}
+
+func TestCacheExpiry(t *testing.T) {
+	c := NewCache()
+	c.Put(Key{alice, token}, big.NewInt(1), 0)
+
+	_, ok := c.Get(Key{alice, token}, time.Nanosecond)
+	assert.False(t, ok)
+
+	_, ok = c.Get(Key{alice, token}, time.Hour)
+	assert.True(t, ok)
+
+	c.Put(Key{bob, token}, big.NewInt(1), 0)
+	time.Sleep(time.Millisecond)
+
+	keep := func(k Key) bool { return k.Owner == bob }
+	assert.Equal(t, []Key{{bob, token}}, c.Keys(time.Nanosecond, keep))
+	assert.Empty(t, c.Keys(time.Nanosecond, nil))
+	_, ok = c.Get(Key{alice, token}, 0)
+	assert.False(t, ok)
+}
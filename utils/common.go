@@ -66,6 +66,16 @@ func GetLendingOhlcvChannelID(term uint64, lendingToken common.Address, unit str
 	return fmt.Sprintf("%d::%s::%d::%s", term, lendingToken.Hex(), duration, unit)
 }
 
+// GetIndicatorChannelID suffixes an ohlcv channel id with the indicators requested by the subscriber
+func GetIndicatorChannelID(id string, indicators string) string {
+	return fmt.Sprintf("%s::%s", id, strings.ToLower(indicators))
+}
+
+// GetDepthChannelID suffixes an orderbook channel id with the aggregation requested by the subscriber
+func GetDepthChannelID(id string, params string) string {
+	return fmt.Sprintf("%s::depth::%s", id, params)
+}
+
 func GetOrderBookChannelID(bt, qt common.Address) string {
 	return strings.ToLower(fmt.Sprintf("%s::%s", bt.Hex(), qt.Hex()))
 }
@@ -79,6 +89,16 @@ func GetPriceBoardChannelID(bt, qt common.Address) string {
 func GetMarketsChannelID(channel string) string {
 	return strings.ToLower(channel)
 }
+
+// GetFiatChannelID suffixes a channel id with the fiat currency its values are expressed in.
+// The default currency keeps the bare channel id so existing subscribers are unaffected.
+func GetFiatChannelID(id string, currency string) string {
+	if currency == "" || strings.ToUpper(currency) == "USD" {
+		return id
+	}
+
+	return fmt.Sprintf("%s::%s", id, strings.ToLower(currency))
+}
 func GetLendingPairName(term uint64, lendingTokenName string) string {
 	return strings.ToUpper(fmt.Sprintf("%s::%s", strconv.FormatUint(term, 10), lendingTokenName))
 }
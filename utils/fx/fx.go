@@ -0,0 +1,120 @@
+package fx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// BaseCurrency is the currency every rate returned by a Source is quoted against.
+// Token prices are computed against USDT on the exchange, so USD is the natural base.
+const BaseCurrency = "USD"
+
+// Source is implemented by every provider of foreign exchange rates.
+// Rates returns the amount of each currency that one unit of BaseCurrency buys,
+// keyed by upper-case ISO 4217 code.
+type Source interface {
+	Rates() (map[string]float64, error)
+}
+
+// StaticSource returns a fixed set of rates, typically loaded from the config file
+type StaticSource struct {
+	rates map[string]float64
+}
+
+// NewStaticSource returns a source serving the given rates
+func NewStaticSource(rates map[string]float64) *StaticSource {
+	r := make(map[string]float64)
+	for k, v := range rates {
+		r[strings.ToUpper(k)] = v
+	}
+
+	r[BaseCurrency] = 1
+	return &StaticSource{rates: r}
+}
+
+// Rates returns a copy of the configured rates
+func (s *StaticSource) Rates() (map[string]float64, error) {
+	res := make(map[string]float64)
+	for k, v := range s.rates {
+		res[k] = v
+	}
+
+	return res, nil
+}
+
+// HTTPSource fetches rates from an HTTP API answering with a document of the form
+// {"base": "USD", "rates": {"EUR": 0.91, "JPY": 149.2}}
+type HTTPSource struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSource returns a source querying the given url
+func NewHTTPSource(url string) *HTTPSource {
+	return &HTTPSource{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Rates fetches the latest rates from the remote API
+func (s *HTTPSource) Rates() (map[string]float64, error) {
+	res, err := s.client.Get(s.url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fx source returned status %d", res.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := struct {
+		Base  string             `json:"base"`
+		Rates map[string]float64 `json:"rates"`
+	}{}
+
+	err = json.Unmarshal(body, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret.Base != "" && strings.ToUpper(ret.Base) != BaseCurrency {
+		return nil, fmt.Errorf("fx source base currency %s is not %s", ret.Base, BaseCurrency)
+	}
+
+	rates := make(map[string]float64)
+	for k, v := range ret.Rates {
+		rates[strings.ToUpper(k)] = v
+	}
+
+	rates[BaseCurrency] = 1
+	return rates, nil
+}
+
+// NewSource returns the source described by the config map.
+// Supported types are "static" (default) and "http".
+func NewSource(config map[string]string, rates map[string]float64) (Source, error) {
+	switch config["type"] {
+	case "", "static":
+		return NewStaticSource(rates), nil
+	case "http":
+		if config["url"] == "" {
+			return nil, fmt.Errorf("fx source url is required")
+		}
+
+		return NewHTTPSource(config["url"]), nil
+	default:
+		return nil, fmt.Errorf("unknown fx source type %s", config["type"])
+	}
+}
@@ -0,0 +1,62 @@
+package fx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStaticSource(t *testing.T) {
+	s := NewStaticSource(map[string]float64{"eur": 0.9, "JPY": 150})
+
+	rates, err := s.Rates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rates["EUR"] != 0.9 || rates["JPY"] != 150 || rates[BaseCurrency] != 1 {
+		t.Errorf("Unexpected rates: %v", rates)
+	}
+}
+
+func TestHTTPSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"base":"USD","rates":{"vnd":24000}}`))
+	}))
+	defer srv.Close()
+
+	rates, err := NewHTTPSource(srv.URL).Rates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rates["VND"] != 24000 || rates[BaseCurrency] != 1 {
+		t.Errorf("Unexpected rates: %v", rates)
+	}
+}
+
+func TestHTTPSourceWrongBase(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"base":"EUR","rates":{"USD":1.1}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewHTTPSource(srv.URL).Rates()
+	if err == nil {
+		t.Error("Expected an error for a non USD base")
+	}
+}
+
+func TestNewSource(t *testing.T) {
+	if _, err := NewSource(map[string]string{"type": "http"}, nil); err == nil {
+		t.Error("Expected an error when the url is missing")
+	}
+
+	if _, err := NewSource(map[string]string{"type": "unknown"}, nil); err == nil {
+		t.Error("Expected an error for an unknown source type")
+	}
+
+	if _, err := NewSource(nil, map[string]float64{"EUR": 0.9}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,269 @@
+package indicators
+
+import (
+	"math/big"
+)
+
+// precision is the mantissa precision used for the square roots of the Bollinger bands,
+// every other computation is rational arithmetic
+const precision = 512
+
+// stepScale is the precision, 10^-18, to which the recursive indicators (EMA, RSI) are rounded
+// at each step, their exact rationals would otherwise grow with every candle
+var stepScale = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// Candle is the input of the indicators, prices and volume are expressed in the same
+// integer units as the ticks they are built from
+type Candle struct {
+	Timestamp int64
+	High      *big.Rat
+	Low       *big.Rat
+	Close     *big.Rat
+	Volume    *big.Rat
+}
+
+// NewCandle builds a candle from big integer values
+func NewCandle(timestamp int64, high, low, close, volume *big.Int) Candle {
+	return Candle{
+		Timestamp: timestamp,
+		High:      ratFromInt(high),
+		Low:       ratFromInt(low),
+		Close:     ratFromInt(close),
+		Volume:    ratFromInt(volume),
+	}
+}
+
+func ratFromInt(x *big.Int) *big.Rat {
+	if x == nil {
+		return new(big.Rat)
+	}
+
+	return new(big.Rat).SetInt(x)
+}
+
+// Closes returns the close prices of the candles
+func Closes(candles []Candle) []*big.Rat {
+	res := make([]*big.Rat, len(candles))
+	for i, c := range candles {
+		res[i] = c.Close
+	}
+
+	return res
+}
+
+// SMA returns the simple moving average of the values.
+// The first period-1 entries are nil as there is not enough data to compute them.
+func SMA(values []*big.Rat, period int) []*big.Rat {
+	res := make([]*big.Rat, len(values))
+	if period <= 0 {
+		return res
+	}
+
+	sum := new(big.Rat)
+	n := new(big.Rat).SetInt64(int64(period))
+	for i, v := range values {
+		sum.Add(sum, v)
+		if i >= period {
+			sum.Sub(sum, values[i-period])
+		}
+
+		if i >= period-1 {
+			res[i] = new(big.Rat).Quo(sum, n)
+		}
+	}
+
+	return res
+}
+
+// EMA returns the exponential moving average of the values, seeded with the simple
+// moving average of the first period values. Nil entries of the input are skipped.
+func EMA(values []*big.Rat, period int) []*big.Rat {
+	res := make([]*big.Rat, len(values))
+	if period <= 0 {
+		return res
+	}
+
+	k := big.NewRat(2, int64(period+1))
+	oneMinusK := new(big.Rat).Sub(big.NewRat(1, 1), k)
+
+	var prev *big.Rat
+	seed := new(big.Rat)
+	count := 0
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+
+		if prev == nil {
+			seed.Add(seed, v)
+			count++
+			if count == period {
+				prev = round(new(big.Rat).Quo(seed, new(big.Rat).SetInt64(int64(period))))
+				res[i] = prev
+			}
+
+			continue
+		}
+
+		next := new(big.Rat).Mul(v, k)
+		next.Add(next, new(big.Rat).Mul(prev, oneMinusK))
+		round(next)
+		res[i] = next
+		prev = next
+	}
+
+	return res
+}
+
+// RSI returns the relative strength index of the values using Wilder's smoothing.
+// The first period entries are nil.
+func RSI(values []*big.Rat, period int) []*big.Rat {
+	res := make([]*big.Rat, len(values))
+	if period <= 0 || len(values) <= period {
+		return res
+	}
+
+	hundred := big.NewRat(100, 1)
+	n := new(big.Rat).SetInt64(int64(period))
+	nMinusOne := new(big.Rat).SetInt64(int64(period - 1))
+
+	avgGain := new(big.Rat)
+	avgLoss := new(big.Rat)
+	for i := 1; i < len(values); i++ {
+		change := new(big.Rat).Sub(values[i], values[i-1])
+		gain := new(big.Rat)
+		loss := new(big.Rat)
+		if change.Sign() > 0 {
+			gain = change
+		} else {
+			loss = change.Neg(change)
+		}
+
+		if i <= period {
+			avgGain.Add(avgGain, gain)
+			avgLoss.Add(avgLoss, loss)
+			if i < period {
+				continue
+			}
+
+			round(avgGain.Quo(avgGain, n))
+			round(avgLoss.Quo(avgLoss, n))
+		} else {
+			round(avgGain.Quo(avgGain.Add(avgGain.Mul(avgGain, nMinusOne), gain), n))
+			round(avgLoss.Quo(avgLoss.Add(avgLoss.Mul(avgLoss, nMinusOne), loss), n))
+		}
+
+		if avgLoss.Sign() == 0 {
+			res[i] = new(big.Rat).Set(hundred)
+			continue
+		}
+
+		// RSI = 100 - 100 / (1 + avgGain / avgLoss)
+		rs := new(big.Rat).Quo(avgGain, avgLoss)
+		rs.Add(rs, big.NewRat(1, 1))
+		res[i] = new(big.Rat).Sub(hundred, new(big.Rat).Quo(hundred, rs))
+	}
+
+	return res
+}
+
+// MACD returns the MACD line, the signal line and the histogram of the values
+func MACD(values []*big.Rat, fast, slow, signal int) ([]*big.Rat, []*big.Rat, []*big.Rat) {
+	fastEMA := EMA(values, fast)
+	slowEMA := EMA(values, slow)
+
+	line := make([]*big.Rat, len(values))
+	for i := range values {
+		if fastEMA[i] != nil && slowEMA[i] != nil {
+			line[i] = new(big.Rat).Sub(fastEMA[i], slowEMA[i])
+		}
+	}
+
+	signalLine := EMA(line, signal)
+	histogram := make([]*big.Rat, len(values))
+	for i := range values {
+		if line[i] != nil && signalLine[i] != nil {
+			histogram[i] = new(big.Rat).Sub(line[i], signalLine[i])
+		}
+	}
+
+	return line, signalLine, histogram
+}
+
+// BollingerBands returns the middle, upper and lower bands of the values, the bands being
+// multiplier population standard deviations away from the simple moving average
+func BollingerBands(values []*big.Rat, period int, multiplier *big.Rat) ([]*big.Rat, []*big.Rat, []*big.Rat) {
+	middle := SMA(values, period)
+	upper := make([]*big.Rat, len(values))
+	lower := make([]*big.Rat, len(values))
+
+	n := new(big.Rat).SetInt64(int64(period))
+	for i, m := range middle {
+		if m == nil {
+			continue
+		}
+
+		variance := new(big.Rat)
+		for _, v := range values[i-period+1 : i+1] {
+			d := new(big.Rat).Sub(v, m)
+			variance.Add(variance, d.Mul(d, d))
+		}
+
+		variance.Quo(variance, n)
+		deviation := sqrt(variance)
+		deviation.Mul(deviation, multiplier)
+
+		upper[i] = new(big.Rat).Add(m, deviation)
+		lower[i] = new(big.Rat).Sub(m, deviation)
+	}
+
+	return middle, upper, lower
+}
+
+// VWAP returns the cumulative volume weighted average of the typical price (high + low + close) / 3
+func VWAP(candles []Candle) []*big.Rat {
+	res := make([]*big.Rat, len(candles))
+	three := big.NewRat(3, 1)
+
+	cumulativePV := new(big.Rat)
+	cumulativeVolume := new(big.Rat)
+	for i, c := range candles {
+		typical := new(big.Rat).Add(c.High, c.Low)
+		typical.Add(typical, c.Close)
+		typical.Quo(typical, three)
+
+		cumulativePV.Add(cumulativePV, typical.Mul(typical, c.Volume))
+		cumulativeVolume.Add(cumulativeVolume, c.Volume)
+
+		if cumulativeVolume.Sign() != 0 {
+			res[i] = new(big.Rat).Quo(cumulativePV, cumulativeVolume)
+		}
+	}
+
+	return res
+}
+
+// round rounds x half away from zero to a multiple of 1/stepScale, it returns x
+func round(x *big.Rat) *big.Rat {
+	n := new(big.Int).Mul(x.Num(), stepScale)
+	q, m := new(big.Int).QuoRem(n, x.Denom(), new(big.Int))
+
+	m.Abs(m).Lsh(m, 1)
+	if m.Cmp(x.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(n.Sign())))
+	}
+
+	return x.SetFrac(q, stepScale)
+}
+
+func sqrt(x *big.Rat) *big.Rat {
+	if x.Sign() == 0 {
+		return new(big.Rat)
+	}
+
+	f := new(big.Float).SetPrec(precision).SetRat(x)
+	f.Sqrt(f)
+
+	res, _ := f.Rat(nil)
+	return res
+}
@@ -0,0 +1,109 @@
+package indicators
+
+import (
+	"math/big"
+	"testing"
+)
+
+func rats(values ...int64) []*big.Rat {
+	res := make([]*big.Rat, len(values))
+	for i, v := range values {
+		res[i] = big.NewRat(v, 1)
+	}
+
+	return res
+}
+
+func assertSeries(t *testing.T, name string, got []*big.Rat, expected []string) {
+	if len(got) != len(expected) {
+		t.Fatalf("%s: expected %d values, got %d", name, len(expected), len(got))
+	}
+
+	for i, e := range expected {
+		if e == "" {
+			if got[i] != nil {
+				t.Errorf("%s[%d]: expected nil, got %s", name, i, got[i].FloatString(4))
+			}
+
+			continue
+		}
+
+		if got[i] == nil || got[i].FloatString(4) != e {
+			t.Errorf("%s[%d]: expected %s, got %v", name, i, e, got[i])
+		}
+	}
+}
+
+func TestSMA(t *testing.T) {
+	assertSeries(t, "sma", SMA(rats(1, 2, 3, 4, 5), 3), []string{"", "", "2.0000", "3.0000", "4.0000"})
+}
+
+func TestEMA(t *testing.T) {
+	// k = 2/(3+1) = 0.5, seeded with SMA(1,2,3) = 2
+	assertSeries(t, "ema", EMA(rats(1, 2, 3, 4, 5), 3), []string{"", "", "2.0000", "3.0000", "4.0000"})
+	assertSeries(t, "ema", EMA(rats(1, 2, 3, 10), 3), []string{"", "", "2.0000", "6.0000"})
+}
+
+func TestRSI(t *testing.T) {
+	assertSeries(t, "rsi", RSI(rats(1, 2, 3, 4), 2), []string{"", "", "100.0000", "100.0000"})
+
+	// first averages are gain 1 and loss 0.5, then after a loss of 2:
+	// avgGain = (1*1+0)/2 = 0.5, avgLoss = (0.5*1+2)/2 = 1.25, RSI = 100 - 100/1.4
+	assertSeries(t, "rsi", RSI(rats(10, 12, 11, 9), 2), []string{"", "", "66.6667", "28.5714"})
+}
+
+func TestMACD(t *testing.T) {
+	line, signal, histogram := MACD(rats(1, 2, 3, 4, 5, 6), 1, 2, 2)
+
+	// fast EMA(1) is the value itself, slow EMA(2) lags by 0.5 once seeded
+	assertSeries(t, "macd", line, []string{"", "0.5000", "0.5000", "0.5000", "0.5000", "0.5000"})
+	assertSeries(t, "signal", signal, []string{"", "", "0.5000", "0.5000", "0.5000", "0.5000"})
+	assertSeries(t, "histogram", histogram, []string{"", "", "0.0000", "0.0000", "0.0000", "0.0000"})
+}
+
+func TestBollingerBands(t *testing.T) {
+	// 2, 4, 4, 4, 5, 5, 7, 9 has a mean of 5 and a population standard deviation of 2
+	middle, upper, lower := BollingerBands(rats(2, 4, 4, 4, 5, 5, 7, 9), 8, big.NewRat(2, 1))
+
+	assertSeries(t, "middle", middle[7:], []string{"5.0000"})
+	assertSeries(t, "upper", upper[7:], []string{"9.0000"})
+	assertSeries(t, "lower", lower[7:], []string{"1.0000"})
+	assertSeries(t, "upper", upper[:7], []string{"", "", "", "", "", "", ""})
+}
+
+func TestVWAP(t *testing.T) {
+	candles := []Candle{
+		NewCandle(1, big.NewInt(12), big.NewInt(9), big.NewInt(9), big.NewInt(1)),
+		NewCandle(2, big.NewInt(21), big.NewInt(18), big.NewInt(21), big.NewInt(3)),
+		NewCandle(3, big.NewInt(1), big.NewInt(1), big.NewInt(1), big.NewInt(0)),
+	}
+
+	// typical prices 10 and 20, weighted 1 and 3
+	assertSeries(t, "vwap", VWAP(candles), []string{"10.0000", "17.5000", "17.5000"})
+}
+
+func TestRecursiveIndicatorsPrecision(t *testing.T) {
+	values := make([]*big.Rat, 6000)
+	for i := range values {
+		values[i] = big.NewRat(int64(1000000+(i*7919)%1000), 1)
+	}
+
+	for name, series := range map[string][]*big.Rat{"ema": EMA(values, 50), "rsi": RSI(values, 14)} {
+		last := series[len(series)-1]
+		if last.Denom().BitLen() > 64 {
+			t.Errorf("%s: the denominator grew to %d bits", name, last.Denom().BitLen())
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	x := big.NewRat(2, 3)
+	if round(x).FloatString(18) != "0.666666666666666667" {
+		t.Errorf("unexpected rounding %s", x.FloatString(20))
+	}
+
+	y := big.NewRat(-2, 3)
+	if round(y).FloatString(18) != "-0.666666666666666667" {
+		t.Errorf("unexpected rounding %s", y.FloatString(20))
+	}
+}
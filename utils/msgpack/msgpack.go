@@ -0,0 +1,408 @@
+// Package msgpack encodes and decodes the websocket messages in the MessagePack format.
+//
+// Values are converted through their JSON representation so that the custom MarshalJSON and
+// UnmarshalJSON methods of the types (big.Int amounts, addresses, dates) are honored and a
+// message has the same content in both encodings. The integers which do not fit in 64 bits
+// are encoded as strings.
+package msgpack
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Marshal returns the MessagePack encoding of v
+func Marshal(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var value interface{}
+	if err := d.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := encode(buf, value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes the MessagePack data into v
+func Unmarshal(data []byte, v interface{}) error {
+	d := &decoder{data: data}
+	value, err := d.decode()
+	if err != nil {
+		return err
+	}
+
+	if d.pos != len(data) {
+		return fmt.Errorf("msgpack: %d bytes remaining after the value", len(data)-d.pos)
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+func encode(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if v {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case string:
+		encodeString(buf, v)
+	case json.Number:
+		encodeNumber(buf, v)
+	case []interface{}:
+		encodeLength(buf, len(v), 0x90, 0xdc, 0xdd)
+		for _, e := range v {
+			if err := encode(buf, e); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		encodeLength(buf, len(v), 0x80, 0xde, 0xdf)
+		for _, k := range keys {
+			encodeString(buf, k)
+			if err := encode(buf, v[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("msgpack: unsupported type %T", v)
+	}
+
+	return nil
+}
+
+func encodeString(buf *bytes.Buffer, s string) {
+	n := len(s)
+	switch {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(0xd9)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xda)
+		writeUint16(buf, uint16(n))
+	default:
+		buf.WriteByte(0xdb)
+		writeUint32(buf, uint32(n))
+	}
+
+	buf.WriteString(s)
+}
+
+// encodeLength writes the header of an array or a map
+func encodeLength(buf *bytes.Buffer, n int, fix, b16, b32 byte) {
+	switch {
+	case n < 16:
+		buf.WriteByte(fix | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(b16)
+		writeUint16(buf, uint16(n))
+	default:
+		buf.WriteByte(b32)
+		writeUint32(buf, uint32(n))
+	}
+}
+
+func encodeNumber(buf *bytes.Buffer, n json.Number) {
+	s := n.String()
+	if strings.ContainsAny(s, ".eE") {
+		f, err := n.Float64()
+		if err == nil {
+			buf.WriteByte(0xcb)
+			writeUint64(buf, math.Float64bits(f))
+			return
+		}
+	}
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		encodeInt(buf, i)
+		return
+	}
+
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		buf.WriteByte(0xcf)
+		writeUint64(buf, u)
+		return
+	}
+
+	// big integers keep their exact value as strings
+	encodeString(buf, s)
+}
+
+func encodeInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0 && i <= 127:
+		buf.WriteByte(byte(i))
+	case i >= -32 && i < 0:
+		buf.WriteByte(byte(0xe0 | (i + 32)))
+	case i >= 0 && i <= math.MaxUint8:
+		buf.WriteByte(0xcc)
+		buf.WriteByte(byte(i))
+	case i >= 0 && i <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		writeUint16(buf, uint16(i))
+	case i >= 0 && i <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		writeUint32(buf, uint32(i))
+	case i >= 0:
+		buf.WriteByte(0xcf)
+		writeUint64(buf, uint64(i))
+	case i >= math.MinInt8:
+		buf.WriteByte(0xd0)
+		buf.WriteByte(byte(int8(i)))
+	case i >= math.MinInt16:
+		buf.WriteByte(0xd1)
+		writeUint16(buf, uint16(int16(i)))
+	case i >= math.MinInt32:
+		buf.WriteByte(0xd2)
+		writeUint32(buf, uint32(int32(i)))
+	default:
+		buf.WriteByte(0xd3)
+		writeUint64(buf, uint64(i))
+	}
+}
+
+func writeUint16(buf *bytes.Buffer, v uint16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	buf.Write(b[:])
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	buf.Write(b[:])
+}
+
+func writeUint64(buf *bytes.Buffer, v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	buf.Write(b[:])
+}
+
+// maxDepth is the maximum nesting of the arrays and maps of the decoded values, deeper values
+// are refused instead of exhausting the stack
+const maxDepth = 100
+
+type decoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// enter records that an array or a map is being decoded, it returns an error if the values are
+// nested deeper than maxDepth
+func (d *decoder) enter() error {
+	d.depth++
+	if d.depth > maxDepth {
+		return fmt.Errorf("msgpack: values nested deeper than %d levels", maxDepth)
+	}
+
+	return nil
+}
+
+func (d *decoder) next(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.data) {
+		return nil, fmt.Errorf("msgpack: unexpected end of data")
+	}
+
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) uint(n int) (uint64, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+func (d *decoder) decode() (interface{}, error) {
+	b, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+
+	c := b[0]
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xe0 == 0xa0:
+		return d.string(int(c & 0x1f))
+	case c&0xf0 == 0x90:
+		return d.array(int(c & 0x0f))
+	case c&0xf0 == 0x80:
+		return d.mapping(int(c & 0x0f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xca:
+		u, err := d.uint(4)
+		return float64(math.Float32frombits(uint32(u))), err
+	case 0xcb:
+		u, err := d.uint(8)
+		return math.Float64frombits(u), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return d.uint(1 << (c - 0xcc))
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		n := 1 << (c - 0xd0)
+		u, err := d.uint(n)
+		if err != nil {
+			return nil, err
+		}
+
+		switch n {
+		case 1:
+			return int64(int8(u)), nil
+		case 2:
+			return int64(int16(u)), nil
+		case 4:
+			return int64(int32(u)), nil
+		default:
+			return int64(u), nil
+		}
+	case 0xc4, 0xc5, 0xc6, 0xd9, 0xda, 0xdb:
+		// bin and str have the same layout, bin is decoded as a string
+		var size byte
+		if c <= 0xc6 {
+			size = c - 0xc4
+		} else {
+			size = c - 0xd9
+		}
+
+		n, err := d.uint(1 << size)
+		if err != nil {
+			return nil, err
+		}
+
+		return d.string(int(n))
+	case 0xdc, 0xdd:
+		n, err := d.uint(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+
+		return d.array(int(n))
+	case 0xde, 0xdf:
+		n, err := d.uint(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+
+		return d.mapping(int(n))
+	}
+
+	return nil, fmt.Errorf("msgpack: unsupported format 0x%x", c)
+}
+
+func (d *decoder) string(n int) (interface{}, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (d *decoder) array(n int) (interface{}, error) {
+	if n > len(d.data)-d.pos {
+		return nil, fmt.Errorf("msgpack: unexpected end of data")
+	}
+
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { d.depth-- }()
+
+	a := make([]interface{}, n)
+	for i := range a {
+		v, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+
+		a[i] = v
+	}
+
+	return a, nil
+}
+
+func (d *decoder) mapping(n int) (interface{}, error) {
+	if n > len(d.data)-d.pos {
+		return nil, fmt.Errorf("msgpack: unexpected end of data")
+	}
+
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { d.depth-- }()
+
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+
+		m[fmt.Sprint(k)] = v
+	}
+
+	return m, nil
+}
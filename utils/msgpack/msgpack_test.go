@@ -0,0 +1,149 @@
+package msgpack
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+func TestMarshal(t *testing.T) {
+	b, err := Marshal(map[string]interface{}{"a": 1, "b": []interface{}{true, nil, -1, "x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'b', 0x94, 0xc3, 0xc0, 0xff, 0xa1, 'x'}
+	assert.Equal(t, expected, b)
+}
+
+func TestMarshalNumbers(t *testing.T) {
+	cases := map[string][]byte{
+		"200":                  {0xcc, 0xc8},
+		"-200":                 {0xd1, 0xff, 0x38},
+		"70000":                {0xce, 0x00, 0x01, 0x11, 0x70},
+		"18446744073709551615": {0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"1.5":                  {0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0},
+	}
+
+	for n, expected := range cases {
+		b, err := Marshal(json.Number(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, expected, b, n)
+	}
+}
+
+func TestMarshalBigInt(t *testing.T) {
+	// big.Int is marshalled to a JSON number which does not fit in 64 bits
+	amount, _ := new(big.Int).SetString("1000000000000000000000000", 10)
+
+	b, err := Marshal(map[string]*big.Int{"amount": amount})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]string
+	err = Unmarshal(b, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, amount.String(), decoded["amount"])
+}
+
+func TestMarshalOrder(t *testing.T) {
+	amount, _ := new(big.Int).SetString("123456789000000000000000000", 10)
+	o := &types.Order{
+		UserAddress: common.HexToAddress("0x1"),
+		BaseToken:   common.HexToAddress("0x2"),
+		QuoteToken:  common.HexToAddress("0x3"),
+		Amount:      amount,
+		PricePoint:  big.NewInt(1000),
+		Hash:        common.HexToHash("0x4"),
+		Side:        types.BUY,
+		Status:      types.OrderStatusOpen,
+	}
+
+	b, err := Marshal(&types.WebsocketMessage{
+		Channel: "orders",
+		Event:   types.WebsocketEvent{Type: types.UPDATE, Payload: o},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &struct {
+		Event struct {
+			Payload *types.Order `json:"payload"`
+		} `json:"event"`
+	}{}
+
+	err = Unmarshal(b, decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, o.Amount, decoded.Event.Payload.Amount)
+	assert.Equal(t, o.PricePoint, decoded.Event.Payload.PricePoint)
+	assert.Equal(t, o.UserAddress, decoded.Event.Payload.UserAddress)
+	assert.Equal(t, o.Hash, decoded.Event.Payload.Hash)
+}
+
+func TestRoundTrip(t *testing.T) {
+	type message struct {
+		Channel string                 `json:"channel"`
+		Event   map[string]interface{} `json:"event"`
+		Values  []float64              `json:"values"`
+	}
+
+	m := &message{
+		Channel: "orderbook",
+		Event: map[string]interface{}{
+			"type": "UPDATE",
+			"long": strings.Repeat("x", 300),
+		},
+		Values: make([]float64, 20),
+	}
+	m.Values[3] = 0.25
+
+	b, err := Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &message{}
+	err = Unmarshal(b, decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, m, decoded)
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var v interface{}
+	assert.Error(t, Unmarshal([]byte{0x92, 0x01}, &v))
+	assert.Error(t, Unmarshal([]byte{0x01, 0x02}, &v))
+	assert.Error(t, Unmarshal([]byte{0xc1}, &v))
+}
+
+func TestUnmarshalNesting(t *testing.T) {
+	var v interface{}
+
+	nested := append(bytes.Repeat([]byte{0x91}, maxDepth-1), 0x90)
+	assert.Nil(t, Unmarshal(nested, &v))
+
+	deep := append(bytes.Repeat([]byte{0x91}, 8<<20), 0x90)
+	assert.EqualError(t, Unmarshal(deep, &v), "msgpack: values nested deeper than 100 levels")
+
+	deepMap := append(bytes.Repeat([]byte{0x81, 0x01}, maxDepth+1), 0x80)
+	assert.Error(t, Unmarshal(deepMap, &v))
+}
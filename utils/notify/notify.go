@@ -0,0 +1,247 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Kinds of the transports, a user registers its targets (an email address, a Telegram chat,
+// a webhook url) for each kind
+const (
+	Email    = "EMAIL"
+	Telegram = "TELEGRAM"
+	Webhook  = "WEBHOOK"
+)
+
+const defaultTelegramURL = "https://api.telegram.org"
+
+// Message is a rendered notification
+type Message struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+}
+
+// Transport is implemented by every delivery channel of the notifications
+type Transport interface {
+	Send(target string, m *Message) error
+}
+
+// SMTPTransport sends the notifications as plain text emails
+type SMTPTransport struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPTransport returns a transport sending through the SMTP server at addr (host:port),
+// the server is used without authentication if username is empty
+func NewSMTPTransport(addr, username, password, from string) *SMTPTransport {
+	var auth smtp.Auth
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPTransport{addr, from, auth}
+}
+
+// Send sends the message to the email address target
+func (t *SMTPTransport) Send(target string, m *Message) error {
+	if strings.ContainsAny(target, "\r\n") {
+		return fmt.Errorf("invalid email address %q", target)
+	}
+
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "From: %s\r\n", t.from)
+	fmt.Fprintf(b, "To: %s\r\n", target)
+	fmt.Fprintf(b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.Replace(m.Text, "\n", "\r\n", -1))
+
+	return smtp.SendMail(t.addr, t.auth, t.from, []string{target}, b.Bytes())
+}
+
+// TelegramTransport sends the notifications with a Telegram bot
+type TelegramTransport struct {
+	url    string
+	client *http.Client
+}
+
+// NewTelegramTransport returns a transport sending with the bot of token through the Bot API
+// at apiURL, the public API if it is empty
+func NewTelegramTransport(apiURL, token string) *TelegramTransport {
+	if apiURL == "" {
+		apiURL = defaultTelegramURL
+	}
+
+	return &TelegramTransport{
+		url:    strings.TrimRight(apiURL, "/") + "/bot" + token + "/sendMessage",
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send sends the message to the chat id target
+func (t *TelegramTransport) Send(target string, m *Message) error {
+	text := m.Text
+	if m.Subject != "" {
+		text = m.Subject + "\n\n" + m.Text
+	}
+
+	return postJSON(t.client, t.url, map[string]string{
+		"chat_id": target,
+		"text":    text,
+	})
+}
+
+// WebhookTransport posts the notifications as json to a url. The urls are registered by the
+// users, the transport only connects to public addresses and does not follow redirects so that
+// they cannot reach the internal network of the server.
+type WebhookTransport struct {
+	client *http.Client
+}
+
+// NewWebhookTransport returns a new instance of WebhookTransport
+func NewWebhookTransport() *WebhookTransport {
+	return &WebhookTransport{client: newWebhookClient(IsPublicIP)}
+}
+
+// newWebhookClient returns a client connecting only to the addresses allowed by allow, the
+// addresses are checked once resolved, when the connection is made
+func newWebhookClient(allow func(net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || !allow(ip) {
+				return fmt.Errorf("notification target address %s is not allowed", host)
+			}
+
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return fmt.Errorf("notification target redirected to %s", req.URL.Host)
+		},
+	}
+}
+
+// reservedNetworks are the networks, besides the loopback, link-local and multicast ones, which
+// are not reachable on the internet
+var reservedNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+	"64:ff9b::/96",
+	"fc00::/7",
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	res := make([]*net.IPNet, len(cidrs))
+	for i, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+
+		res[i] = n
+	}
+
+	return res
+}
+
+// IsPublicIP returns true if ip is a public unicast address. The loopback, private, link-local
+// (the cloud metadata services among them) and reserved addresses are not public.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsUnspecified() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, n := range reservedNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Send posts the message to the url target
+func (t *WebhookTransport) Send(target string, m *Message) error {
+	return postJSON(t.client, target, m)
+}
+
+func postJSON(client *http.Client, url string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("notification target returned status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// NewTransports returns the transports enabled by the config map: the email transport if
+// smtp_host is set, the Telegram transport if telegram_token is set and the webhook transport.
+func NewTransports(config map[string]string) map[string]Transport {
+	transports := map[string]Transport{
+		Webhook: NewWebhookTransport(),
+	}
+
+	if config["smtp_host"] != "" {
+		port := config["smtp_port"]
+		if port == "" {
+			port = "25"
+		}
+
+		transports[Email] = NewSMTPTransport(
+			net.JoinHostPort(config["smtp_host"], port),
+			config["smtp_username"],
+			config["smtp_password"],
+			config["smtp_from"],
+		)
+	}
+
+	if config["telegram_token"] != "" {
+		transports[Telegram] = NewTelegramTransport(config["telegram_api_url"], config["telegram_token"])
+	}
+
+	return transports
+}
@@ -0,0 +1,153 @@
+package notify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomochain/tomox-sdk/utils/notify/smtptest"
+)
+
+func TestSMTPTransport(t *testing.T) {
+	srv, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	tr := NewSMTPTransport(srv.Addr, "", "", "noreply@tomox.test")
+	err = tr.Send("alice@tomox.test", &Message{Subject: "Lệnh đã khớp", Text: "line 1\nline 2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mails := srv.Mails()
+	assert.Len(t, mails, 1)
+	assert.Equal(t, "noreply@tomox.test", mails[0].From)
+	assert.Equal(t, []string{"alice@tomox.test"}, mails[0].To)
+	assert.Contains(t, mails[0].Data, "Subject: =?utf-8?q?")
+	assert.Contains(t, mails[0].Data, "line 1\r\nline 2")
+
+	assert.NotNil(t, tr.Send("bob@tomox.test\r\nBcc: eve@tomox.test", &Message{}))
+}
+
+func TestTelegramTransport(t *testing.T) {
+	var path string
+	body := map[string]string{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer srv.Close()
+
+	err := NewTelegramTransport(srv.URL, "123:abc").Send("42", &Message{Subject: "Filled", Text: "Your order was filled"})
+	assert.Nil(t, err)
+	assert.Equal(t, "/bot123:abc/sendMessage", path)
+	assert.Equal(t, "42", body["chat_id"])
+	assert.Equal(t, "Filled\n\nYour order was filled", body["text"])
+}
+
+func TestWebhookTransportStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	tr := &WebhookTransport{client: newWebhookClient(func(net.IP) bool { return true })}
+	assert.NotNil(t, tr.Send(srv.URL, &Message{}))
+}
+
+func TestWebhookTransportAddresses(t *testing.T) {
+	var posted bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted = true
+	}))
+	defer srv.Close()
+
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, srv.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirect.Close()
+
+	// the test servers listen on the loopback address
+	err := NewWebhookTransport().Send(srv.URL, &Message{})
+	assert.Contains(t, err.Error(), "is not allowed")
+	assert.False(t, posted)
+
+	tr := &WebhookTransport{client: newWebhookClient(func(net.IP) bool { return true })}
+	err = tr.Send(redirect.URL, &Message{})
+	assert.Contains(t, err.Error(), "redirected")
+	assert.False(t, posted)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fe80::1", "fd00:ec2::254", "::ffff:127.0.0.1"} {
+		assert.False(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+
+	for _, ip := range []string{"8.8.8.8", "1.1.1.1", "2606:4700:4700::1111"} {
+		assert.True(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestTemplatesRender(t *testing.T) {
+	tmpl := NewTemplates()
+	data := &Data{Event: "ORDER_SUCCESS", Description: "0xabc", CreatedAt: time.Unix(0, 0)}
+
+	m, err := tmpl.Render("vi", "ORDER_SUCCESS", data)
+	assert.Nil(t, err)
+	assert.Equal(t, "TomoX: lệnh của bạn đã được khớp", m.Subject)
+
+	// unknown locales and events fall back to the default locale and template
+	m, err = tmpl.Render("fr", "ORDER_SUCCESS", data)
+	assert.Nil(t, err)
+	assert.Equal(t, "TomoX: your order was filled", m.Subject)
+
+	m, err = tmpl.Render("fr", "ORDER_ADDED", &Data{Event: "ORDER_ADDED", Description: "0xdef"})
+	assert.Nil(t, err)
+	assert.Equal(t, "TomoX: ORDER_ADDED", m.Subject)
+	assert.True(t, strings.HasPrefix(m.Text, "ORDER_ADDED 0xdef"))
+
+	m, err = tmpl.Render("en", DigestTemplate, &Data{Items: []Data{*data, *data}})
+	assert.Nil(t, err)
+	assert.Equal(t, "TomoX: 2 new notifications", m.Subject)
+	assert.Equal(t, 2, strings.Count(m.Text, "ORDER_SUCCESS 0xabc"))
+}
+
+func TestTemplatesLoadDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Mkdir(filepath.Join(dir, "fr"), 0755)
+	err = ioutil.WriteFile(
+		filepath.Join(dir, "fr", "ORDER_SUCCESS.tmpl"),
+		[]byte(`{{define "subject"}}Ordre exécuté{{end}}{{define "body"}}{{.Description}}{{end}}`),
+		0644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := NewTemplatesFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := tmpl.Render("fr", "ORDER_SUCCESS", &Data{Description: "0xabc"})
+	assert.Nil(t, err)
+	assert.Equal(t, &Message{Subject: "Ordre exécuté", Text: "0xabc"}, m)
+
+	assert.NotNil(t, tmpl.Add("fr", "ORDER_ADDED", `{{define "subject"}}Ordre{{end}}`))
+}
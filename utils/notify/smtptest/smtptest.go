@@ -0,0 +1,126 @@
+// Package smtptest provides a local SMTP server recording the emails it receives, to test
+// the email notifications without a mail server
+package smtptest
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Mail is an email received by the server
+type Mail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// Server is a minimal SMTP server listening on the loopback interface
+type Server struct {
+	Addr string
+
+	listener net.Listener
+	mails    []Mail
+	mutex    sync.Mutex
+}
+
+// NewServer starts a server on a random port, it has to be closed by the caller
+func NewServer() (*Server, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{Addr: l.Addr().String(), listener: l}
+	go s.serve()
+	return s, nil
+}
+
+// Close stops the server
+func (s *Server) Close() error {
+	return s.listener.Close()
+}
+
+// Mails returns the emails received so far
+func (s *Server) Mails() []Mail {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]Mail{}, s.mails...)
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost SMTP stand-in")
+
+	mail := Mail{}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			mail = Mail{From: strings.Trim(line[len("MAIL FROM:"):], "<> ")}
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			mail.To = append(mail.To, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+
+			data := &strings.Builder{}
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if l == ".\r\n" {
+					break
+				}
+
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+
+			mail.Data = data.String()
+
+			s.mutex.Lock()
+			s.mails = append(s.mails, mail)
+			s.mutex.Unlock()
+
+			reply("250 OK")
+		case cmd == "RSET", cmd == "NOOP":
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// DefaultLocale is used when a user has no locale or its locale has no template
+const DefaultLocale = "en"
+
+// Names of the templates which are not notification events
+const (
+	// DefaultTemplate renders the events without a template of their own
+	DefaultTemplate = "DEFAULT"
+
+	// DigestTemplate renders the batched notifications
+	DigestTemplate = "DIGEST"
+
+	// VerificationTemplate renders the verification code of a new channel, its description
+	VerificationTemplate = "VERIFY_CHANNEL"
+)
+
+// Data is the data of the templates
+type Data struct {
+	Recipient   string
+	Type        string
+	Event       string
+	Description string
+	CreatedAt   time.Time
+
+	// Items are the notifications of a digest
+	Items []Data
+}
+
+// builtinTemplates are the default templates, keyed by locale and name. A template defines
+// a "subject" and a "body".
+var builtinTemplates = map[string]map[string]string{
+	"en": {
+		DefaultTemplate: `{{define "subject"}}TomoX: {{.Event}}{{end}}` +
+			`{{define "body"}}{{.Event}} {{.Description}}
+{{.CreatedAt.UTC.Format "2006-01-02 15:04:05 MST"}}{{end}}`,
+		"ORDER_SUCCESS": `{{define "subject"}}TomoX: your order was filled{{end}}` +
+			`{{define "body"}}Your order was matched by the trade {{.Description}}.
+{{.CreatedAt.UTC.Format "2006-01-02 15:04:05 MST"}}{{end}}`,
+		"ORDER_CANCELLED": `{{define "subject"}}TomoX: your order was cancelled{{end}}` +
+			`{{define "body"}}Your order {{.Description}} was cancelled.{{end}}`,
+		"ORDER_REJECTED": `{{define "subject"}}TomoX: your order was rejected{{end}}` +
+			`{{define "body"}}Your order {{.Description}} was rejected.{{end}}`,
+		"LENDING_ORDER_SUCCESS": `{{define "subject"}}TomoX: your lending order was filled{{end}}` +
+			`{{define "body"}}Your lending order was matched by the loan {{.Description}}.{{end}}`,
+		"LENDING_TRADE_LIQUIDATED": `{{define "subject"}}TomoX: your loan was liquidated{{end}}` +
+			`{{define "body"}}The collateral of your loan {{.Description}} was liquidated.
+{{.CreatedAt.UTC.Format "2006-01-02 15:04:05 MST"}}{{end}}`,
+		VerificationTemplate: `{{define "subject"}}TomoX: verify your notification channel{{end}}` +
+			`{{define "body"}}Your verification code is {{.Description}}. Ignore this message if you did not add this channel.{{end}}`,
+		DigestTemplate: `{{define "subject"}}TomoX: {{len .Items}} new notifications{{end}}` +
+			`{{define "body"}}{{range .Items}}{{.CreatedAt.UTC.Format "2006-01-02 15:04"}} {{.Event}} {{.Description}}
+{{end}}{{end}}`,
+	},
+	"vi": {
+		DefaultTemplate: `{{define "subject"}}TomoX: {{.Event}}{{end}}` +
+			`{{define "body"}}{{.Event}} {{.Description}}
+{{.CreatedAt.UTC.Format "02/01/2006 15:04:05 MST"}}{{end}}`,
+		"ORDER_SUCCESS": `{{define "subject"}}TomoX: lệnh của bạn đã được khớp{{end}}` +
+			`{{define "body"}}Lệnh của bạn đã được khớp trong giao dịch {{.Description}}.
+{{.CreatedAt.UTC.Format "02/01/2006 15:04:05 MST"}}{{end}}`,
+		"LENDING_TRADE_LIQUIDATED": `{{define "subject"}}TomoX: khoản vay của bạn đã bị thanh lý{{end}}` +
+			`{{define "body"}}Tài sản thế chấp của khoản vay {{.Description}} đã bị thanh lý.{{end}}`,
+		VerificationTemplate: `{{define "subject"}}TomoX: xác minh kênh thông báo của bạn{{end}}` +
+			`{{define "body"}}Mã xác minh của bạn là {{.Description}}. Hãy bỏ qua tin nhắn này nếu bạn không thêm kênh này.{{end}}`,
+		DigestTemplate: `{{define "subject"}}TomoX: {{len .Items}} thông báo mới{{end}}` +
+			`{{define "body"}}{{range .Items}}{{.CreatedAt.UTC.Format "02/01/2006 15:04"}} {{.Event}} {{.Description}}
+{{end}}{{end}}`,
+	},
+}
+
+// Templates renders the notifications with the template of their event in the locale of the
+// user, falling back to the default locale then to the default template
+type Templates struct {
+	templates map[string]*template.Template
+	mutex     sync.RWMutex
+}
+
+// NewTemplates returns the builtin templates
+func NewTemplates() *Templates {
+	t := &Templates{templates: make(map[string]*template.Template)}
+	for locale, templates := range builtinTemplates {
+		for name, text := range templates {
+			t.templates[key(locale, name)] = template.Must(template.New(name).Parse(text))
+		}
+	}
+
+	return t
+}
+
+// Add parses a template, replacing the template of the same locale and name
+func (t *Templates) Add(locale, name, text string) error {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	if tmpl.Lookup("subject") == nil || tmpl.Lookup("body") == nil {
+		return fmt.Errorf("template %s/%s must define a subject and a body", locale, name)
+	}
+
+	t.mutex.Lock()
+	t.templates[key(locale, name)] = tmpl
+	t.mutex.Unlock()
+	return nil
+}
+
+// LoadDir adds the templates of a directory, stored as <locale>/<name>.tmpl
+func (t *Templates) LoadDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*", "*.tmpl"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		locale := filepath.Base(filepath.Dir(path))
+		name := strings.TrimSuffix(filepath.Base(path), ".tmpl")
+
+		err = t.Add(locale, name, string(b))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Render renders the template name in locale
+func (t *Templates) Render(locale, name string, data *Data) (*Message, error) {
+	tmpl := t.lookup(locale, name)
+	if tmpl == nil {
+		return nil, fmt.Errorf("no template %s", name)
+	}
+
+	subject := &bytes.Buffer{}
+	err := tmpl.ExecuteTemplate(subject, "subject", data)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &bytes.Buffer{}
+	err = tmpl.ExecuteTemplate(body, "body", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(body.String()),
+	}, nil
+}
+
+func (t *Templates) lookup(locale, name string) *template.Template {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	candidates := []string{
+		key(locale, name),
+		key(DefaultLocale, name),
+		key(locale, DefaultTemplate),
+		key(DefaultLocale, DefaultTemplate),
+	}
+
+	for _, k := range candidates {
+		if tmpl, ok := t.templates[k]; ok {
+			return tmpl
+		}
+	}
+
+	return nil
+}
+
+func key(locale, name string) string {
+	return strings.ToLower(locale) + "/" + name
+}
+
+// NewTemplatesFromDir returns the builtin templates overridden by the templates of dir, if it
+// is not empty
+func NewTemplatesFromDir(dir string) (*Templates, error) {
+	t := NewTemplates()
+	if dir == "" {
+		return t, nil
+	}
+
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+
+	err := t.LoadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
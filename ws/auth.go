@@ -0,0 +1,104 @@
+package ws
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+// Address returns the address the connection is logged in with
+func (c *Client) Address() (common.Address, bool) {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.address, c.address != common.Address{}
+}
+
+// IsAuthenticated returns true if the connection is logged in with the given address.
+// Private channels (orders, lending orders, notifications, deposits, balances) must check it
+// before registering the connection for an address.
+func (c *Client) IsAuthenticated(a common.Address) bool {
+	addr, ok := c.Address()
+	return ok && addr == a
+}
+
+// SendChallenge generates a new login challenge and sends it to the client,
+// the previous challenge can not be used anymore
+func (c *Client) SendChallenge() {
+	ch, err := types.NewLoginChallenge()
+	if err != nil {
+		logger.Error(err)
+		c.SendError(AuthChannel, "", err)
+		return
+	}
+
+	c.authMu.Lock()
+	c.challenge = ch
+	c.authMu.Unlock()
+
+	c.SendMessage(AuthChannel, types.CHALLENGE, ch)
+}
+
+// login binds the connection to the address of the payload if it signed the pending challenge.
+// A challenge is used once whatever the result and a connection can only be bound to one address.
+func (c *Client) login(p *types.LoginPayload) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	ch := c.challenge
+	c.challenge = nil
+
+	if err := p.Verify(ch); err != nil {
+		return err
+	}
+
+	if (c.address != common.Address{}) && c.address != p.Address {
+		return types.ErrInvalidLoginSignature
+	}
+
+	c.address = p.Address
+	return nil
+}
+
+// handleAuthMessage handles the login handshake, CHALLENGE requests a new challenge
+// and LOGIN answers the pending one
+func handleAuthMessage(input interface{}, c *Client) error {
+	b, _ := json.Marshal(input)
+	var ev *types.WebsocketEvent
+	if err := json.Unmarshal(b, &ev); err != nil || ev == nil {
+		return errors.InvalidPayload("empty event")
+	}
+
+	switch ev.Type {
+	case types.CHALLENGE:
+		c.SendChallenge()
+	case types.LOGIN:
+		b, _ = json.Marshal(ev.Payload)
+		var p *types.LoginPayload
+		if err := json.Unmarshal(b, &p); err != nil || p == nil {
+			c.SendChallenge()
+			return errors.InvalidPayload("login payload must have an address and a signature")
+		}
+
+		if err := c.login(p); err != nil {
+			c.SendChallenge()
+			return errors.Unauthorized(err.Error())
+		}
+
+		c.SendReply(AuthChannel, ev.ID, types.SUCCESS_EVENT, map[string]string{"address": p.Address.Hex()})
+	default:
+		return errors.InvalidEvent(AuthChannel, ev.Type)
+	}
+
+	return nil
+}
+
+// Authorize returns an error if the connection is not logged in with the address
+func (c *Client) Authorize(channel string, a common.Address) error {
+	if c.IsAuthenticated(a) {
+		return nil
+	}
+
+	return errors.LoginRequired(channel, a.Hex())
+}
@@ -0,0 +1,133 @@
+package ws
+
+import (
+	"sync"
+
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+var balanceSocket *BalanceSocket
+
+// BalanceSocket holds the map of connections subscribed to the balances of an address, the
+// channel id is the hex address
+type BalanceSocket struct {
+	subscriptions     map[string]map[*Client]bool
+	subscriptionsList map[*Client][]string
+	subsMutex         sync.RWMutex
+	subsListMutex     sync.RWMutex
+}
+
+func NewBalanceSocket() *BalanceSocket {
+	return &BalanceSocket{
+		subscriptions:     make(map[string]map[*Client]bool),
+		subscriptionsList: make(map[*Client][]string),
+	}
+}
+
+// GetBalanceSocket return singleton instance of BalanceSocket type struct
+func GetBalanceSocket() *BalanceSocket {
+	if balanceSocket == nil {
+		balanceSocket = NewBalanceSocket()
+	}
+
+	return balanceSocket
+}
+
+// Subscribe registers a new websocket connection to the balance updates of an address
+func (s *BalanceSocket) Subscribe(channelID string, c *Client) error {
+	s.subsMutex.Lock()
+	s.subsListMutex.Lock()
+	defer s.subsMutex.Unlock()
+	defer s.subsListMutex.Unlock()
+
+	if c == nil {
+		return errors.New("No connection found")
+	}
+
+	if s.subscriptions[channelID] == nil {
+		s.subscriptions[channelID] = make(map[*Client]bool)
+	}
+
+	s.subscriptions[channelID][c] = true
+
+	if s.subscriptionsList[c] == nil {
+		s.subscriptionsList[c] = []string{}
+	}
+
+	s.subscriptionsList[c] = append(s.subscriptionsList[c], channelID)
+	return nil
+}
+
+// UnsubscribeChannelHandler unsubscribes a connection from the balances of an address
+func (s *BalanceSocket) UnsubscribeChannelHandler(channelID string) func(c *Client) {
+	return func(c *Client) {
+		s.UnsubscribeChannel(channelID, c)
+	}
+}
+
+// UnsubscribeChannel removes a websocket connection from the balance updates of an address
+func (s *BalanceSocket) UnsubscribeChannel(channelID string, c *Client) {
+	s.subsMutex.Lock()
+	defer s.subsMutex.Unlock()
+
+	delete(s.subscriptions[channelID], c)
+	if len(s.subscriptions[channelID]) == 0 {
+		delete(s.subscriptions, channelID)
+	}
+}
+
+// Unsubscribe removes a websocket connection from all the balance updates
+func (s *BalanceSocket) Unsubscribe(c *Client) {
+	s.subsListMutex.Lock()
+	channelIDs := s.subscriptionsList[c]
+	delete(s.subscriptionsList, c)
+	s.subsListMutex.Unlock()
+
+	for _, id := range channelIDs {
+		s.UnsubscribeChannel(id, c)
+	}
+}
+
+// HasSubscriptions returns true if a connection of this instance is subscribed to the channel
+func (s *BalanceSocket) HasSubscriptions(channelID string) bool {
+	s.subsMutex.RLock()
+	defer s.subsMutex.RUnlock()
+	return len(s.subscriptions[channelID]) > 0
+}
+
+// Watched returns true if the updates of the channel may have subscribers, on this instance or
+// on another instance of the cluster
+func (s *BalanceSocket) Watched(channelID string) bool {
+	return relayEnabled() || s.HasSubscriptions(channelID)
+}
+
+// BroadcastMessage sends the balances to the connections subscribed to the address, on every
+// instance of the cluster
+func (s *BalanceSocket) BroadcastMessage(channelID string, p interface{}) {
+	if relayed(BalanceChannel, channelID, types.UPDATE, p) {
+		return
+	}
+
+	s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the balances to the connections of this instance subscribed to the address
+func (s *BalanceSocket) DeliverMessage(channelID string, p interface{}) {
+	s.subsMutex.RLock()
+	defer s.subsMutex.RUnlock()
+
+	for c := range s.subscriptions[channelID] {
+		s.SendUpdateMessage(c, p)
+	}
+}
+
+// SendInitMessage sends the balances of an address on subscription
+func (s *BalanceSocket) SendInitMessage(c *Client, p interface{}) {
+	c.SendMessage(BalanceChannel, types.INIT, p)
+}
+
+// SendUpdateMessage sends the changed balances of an address
+func (s *BalanceSocket) SendUpdateMessage(c *Client, p interface{}) {
+	c.SendMessage(BalanceChannel, types.UPDATE, p)
+}
@@ -4,19 +4,24 @@ import (
 	"fmt"
 
 	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/types"
 )
 
 const (
+	AuthChannel         = "auth"
 	TradeChannel        = "trades"
 	RawOrderBookChannel = "raw_orderbook"
 	OrderChannel        = "orders"
 	OrderBookChannel    = "orderbook"
+	L3OrderBookChannel  = "l3_orderbook"
 	TokenChannel        = "tokens"
 	OHLCVChannel        = "ohlcv"
 	PriceBoardChannel   = "price_board"
 	DepositChannel      = "deposit"
 	MarketsChannel      = "markets"
 	NotificationChannel = "notification"
+	BalanceChannel      = "balances"
+	PreferencesChannel  = "preferences"
 
 	// Lending channel
 	LendingOrderChannel        = "lending_orders"
@@ -28,9 +33,14 @@ const (
 	LendingPriceBoardChannel   = "lending_price_board"
 )
 
-var socketChannels map[string]func(interface{}, *Client)
+// Handler handles the events received on a channel. The error returned is sent back to the client
+// in an ERROR event, otherwise the SUBSCRIBE and UNSUBSCRIBE events are acknowledged with
+// SUBSCRIBED and UNSUBSCRIBED. Both replies carry the id of the event.
+type Handler func(interface{}, *Client) error
 
-func RegisterChannel(channel string, fn func(interface{}, *Client)) error {
+var socketChannels map[string]Handler
+
+func RegisterChannel(channel string, fn Handler) error {
 	if channel == "" {
 		return errors.New("Channel can not be an empty string")
 	}
@@ -48,10 +58,46 @@ func RegisterChannel(channel string, fn func(interface{}, *Client)) error {
 	return nil
 }
 
-func getChannels() map[string]func(interface{}, *Client) {
+func getChannels() map[string]Handler {
 	if socketChannels == nil {
-		socketChannels = make(map[string]func(interface{}, *Client))
+		socketChannels = make(map[string]Handler)
 	}
 
 	return socketChannels
 }
+
+// handleMessage dispatches a message to the handler of its channel and replies to the client
+func handleMessage(msg types.WebsocketMessage, c *Client) {
+	ev := msg.Event
+
+	if ev.Type == types.LIST_SUBSCRIPTIONS {
+		c.SendReply(msg.Channel, ev.ID, types.LIST_SUBSCRIPTIONS, c.Subscriptions())
+		return
+	}
+
+	var fn Handler
+	if msg.Channel == AuthChannel {
+		fn = handleAuthMessage
+	} else {
+		fn = socketChannels[msg.Channel]
+	}
+
+	if fn == nil {
+		c.SendError(msg.Channel, ev.ID, errors.InvalidChannel(msg.Channel))
+		return
+	}
+
+	if err := fn(ev, c); err != nil {
+		c.SendError(msg.Channel, ev.ID, err)
+		return
+	}
+
+	switch ev.Type {
+	case types.SUBSCRIBE:
+		c.addSubscription(msg.Channel, ev.Payload)
+		c.SendReply(msg.Channel, ev.ID, types.SUBSCRIBED, ev.Payload)
+	case types.UNSUBSCRIBE:
+		c.removeSubscription(msg.Channel, ev.Payload)
+		c.SendReply(msg.Channel, ev.ID, types.UNSUBSCRIBED, ev.Payload)
+	}
+}
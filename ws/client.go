@@ -1,24 +1,49 @@
 package ws
 
 import (
+	"encoding/json"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/websocket"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/types"
 )
 
+// Client is a websocket connection. Messages are queued in send and written by the writer
+// goroutine of the connection so that a slow client never blocks the broadcasts.
 type Client struct {
 	*websocket.Conn
-	mu   sync.Mutex
-	send chan types.WebsocketMessage
+	send      chan types.WebsocketMessage
+	done      chan struct{}
+	closeOnce sync.Once
+	evicted   int32
+	encoding  string
+
+	// address is the address the connection is logged in with, private channels are
+	// restricted to it. challenge is the pending login challenge.
+	authMu    sync.RWMutex
+	address   common.Address
+	challenge *types.LoginChallenge
+
+	// subscriptions are the acknowledged SUBSCRIBE payloads of each channel keyed by their json
+	subsMu        sync.Mutex
+	subscriptions map[string]map[string]interface{}
 }
 
 var unsubscribeHandlers map[*Client][]func(*Client)
 
 func NewClient(c *websocket.Conn) *Client {
-	conn := &Client{Conn: c, mu: sync.Mutex{}, send: make(chan types.WebsocketMessage)}
+	conn := &Client{
+		Conn:          c,
+		send:          make(chan types.WebsocketMessage, sendQueueSize),
+		done:          make(chan struct{}),
+		encoding:      EncodingJSON,
+		subscriptions: make(map[string]map[string]interface{}),
+	}
 
 	if unsubscribeHandlers == nil {
 		unsubscribeHandlers = make(map[*Client][]func(*Client))
@@ -31,15 +56,61 @@ func NewClient(c *websocket.Conn) *Client {
 	return conn
 }
 
+// NewLocalClient returns a client without connection, the messages sent to it are read from
+// Messages. It lets the other APIs subscribe to the channels of the sockets.
+func NewLocalClient() *Client {
+	return NewClient(nil)
+}
+
+// Messages returns the queue of the messages of a local client
+func (c *Client) Messages() <-chan types.WebsocketMessage {
+	return c.send
+}
+
+// Done is closed once the client is closed, a local client is closed when it is a slow consumer
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Disconnect closes the client and runs its unsubscribe handlers
+func (c *Client) Disconnect() {
+	c.closeConnection()
+}
+
+// writeMessage queues a message without blocking. A client whose queue is full does not read
+// its messages fast enough, it is disconnected with a policy violation close code.
 func (c *Client) writeMessage(m types.WebsocketMessage) {
-	c.SetWriteDeadline(time.Now().Add(writeWait))
-	err := c.WriteJSON(m)
-	if err != nil {
-		logger.Info("writeMessage closing connection:", err)
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+
+	select {
+	case c.send <- m:
+	default:
+		if atomic.CompareAndSwapInt32(&c.evicted, 0, 1) {
+			logger.Warning("Slow consumer, closing connection")
+			go c.evict()
+		}
+	}
+}
+
+// evict closes the connection of a slow consumer. The close frame is sent as a control message
+// which gorilla allows concurrently with the writer goroutine.
+func (c *Client) evict() {
+	if c.Conn == nil {
 		c.closeConnection()
 		return
 	}
 
+	msg := websocket.FormatCloseMessage(closeSlowConsumer, "Slow consumer")
+	err := c.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+	if err != nil {
+		logger.Error(err)
+	}
+
+	c.closeConnection()
 }
 
 // SendMessage constructs the message with proper structure to be sent over websocket
@@ -60,26 +131,110 @@ func (c *Client) SendMessage(channel string, msgType types.SubscriptionEvent, pa
 		Event:   e,
 	}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.writeMessage(m)
+}
+
+// SendReply sends the reply to a client message with the id of the message
+func (c *Client) SendReply(channel, id string, msgType types.SubscriptionEvent, payload interface{}) {
+	m := types.WebsocketMessage{
+		Channel: channel,
+		Event: types.WebsocketEvent{
+			ID:      id,
+			Type:    msgType,
+			Payload: payload,
+		},
+	}
 
 	c.writeMessage(m)
 }
 
-// SendPingMessage check conntection
+// SendError sends an ERROR event with the id of the client message, the payload is an API error
+// with a code defined in the errors file
+func (c *Client) SendError(channel, id string, err error) {
+	apiErr, ok := err.(*errors.APIError)
+	if !ok {
+		apiErr = errors.InternalServerError(err)
+	}
+
+	c.SendReply(channel, id, types.ERROR, apiErr)
+}
+
+// Subscriptions returns the subscriptions of the connection sorted by channel
+func (c *Client) Subscriptions() []types.WebsocketSubscription {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	channels := make([]string, 0, len(c.subscriptions))
+	for ch := range c.subscriptions {
+		channels = append(channels, ch)
+	}
+	sort.Strings(channels)
+
+	subs := []types.WebsocketSubscription{}
+	for _, ch := range channels {
+		keys := make([]string, 0, len(c.subscriptions[ch]))
+		for k := range c.subscriptions[ch] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			subs = append(subs, types.WebsocketSubscription{Channel: ch, Payload: c.subscriptions[ch][k]})
+		}
+	}
+
+	return subs
+}
+
+func (c *Client) addSubscription(channel string, payload interface{}) {
+	b, _ := json.Marshal(payload)
+
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.subscriptions[channel] == nil {
+		c.subscriptions[channel] = make(map[string]interface{})
+	}
+	c.subscriptions[channel][string(b)] = payload
+}
+
+// removeSubscription removes the subscription with the same payload, all the subscriptions
+// of the channel are removed if the payload is empty
+func (c *Client) removeSubscription(channel string, payload interface{}) {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if payload == nil {
+		delete(c.subscriptions, channel)
+		return
+	}
+
+	b, _ := json.Marshal(payload)
+	delete(c.subscriptions[channel], string(b))
+	if len(c.subscriptions[channel]) == 0 {
+		delete(c.subscriptions, channel)
+	}
+}
+
+// SendPingMessage check conntection, it must only be called by the writer goroutine
 func (c *Client) SendPingMessage() error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
 	c.SetWriteDeadline(time.Now().Add(writeWait))
 	return c.WriteMessage(websocket.PingMessage, nil)
 }
 
+// closeConnection stops the writer goroutine, runs the unsubscribe handlers and closes
+// the connection. It can be called several times.
 func (c *Client) closeConnection() {
-	for _, unsub := range unsubscribeHandlers[c] {
-		unsub(c)
-	}
+	c.closeOnce.Do(func() {
+		close(c.done)
+		for _, unsub := range unsubscribeHandlers[c] {
+			unsub(c)
+		}
 
-	c.Close()
+		if c.Conn != nil {
+			c.Close()
+		}
+	})
 }
 
 func (c *Client) SendOrderErrorMessage(err error, h common.Hash) {
@@ -98,8 +253,6 @@ func (c *Client) SendOrderErrorMessage(err error, h common.Hash) {
 		Event:   e,
 	}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
 	c.writeMessage(m)
 }
 
@@ -120,7 +273,5 @@ func (c *Client) SendLendingOrderErrorMessage(err error, h common.Hash) {
 		Event:   e,
 	}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
 	c.writeMessage(m)
 }
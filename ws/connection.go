@@ -7,20 +7,43 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/tomochain/tomox-sdk/app"
+	"github.com/tomochain/tomox-sdk/errors"
 	"github.com/tomochain/tomox-sdk/types"
+	"github.com/tomochain/tomox-sdk/utils/msgpack"
 )
 
 const (
 	writeWait  = 30 * time.Second
 	pongWait   = 30 * time.Second
 	pingPeriod = (pongWait * 9) / 10
+
+	// sendQueueSize is the number of messages queued for a client before it is considered
+	// as a slow consumer and disconnected
+	sendQueueSize = 256
+
+	// maxMessageSize is the maximum size of the messages read from the clients, larger messages
+	// close the connection
+	maxMessageSize = 256 << 10
+
+	// closeSlowConsumer is the close code sent to the clients disconnected for not reading fast enough
+	closeSlowConsumer = websocket.ClosePolicyViolation
+)
+
+// Encodings of the messages, selected with the encoding query parameter of the socket endpoint.
+// JSON messages are sent in text frames and MessagePack messages in binary frames.
+const (
+	EncodingJSON    = "json"
+	EncodingMsgpack = "msgpack"
 )
 
 var logger = NewWebsocketLogger()
 
+// upgrader negotiates permessage-deflate with the clients supporting it, the snapshots of the
+// orderbooks and candles compress well
 var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
+	ReadBufferSize:    4096,
+	WriteBufferSize:   16384,
+	EnableCompression: true,
 	CheckOrigin: func(r *http.Request) bool {
 		return true
 	},
@@ -30,17 +53,33 @@ var upgrader = websocket.Upgrader{
 // It handles incoming websocket messages and routes the message according to
 // channel parameter in channelMessage
 func ConnectionEndpoint(w http.ResponseWriter, r *http.Request) {
+	encoding := r.URL.Query().Get("encoding")
+	if encoding == "" {
+		encoding = EncodingJSON
+	}
+
+	if encoding != EncodingJSON && encoding != EncodingMsgpack {
+		http.Error(w, "Invalid encoding", http.StatusBadRequest)
+		return
+	}
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		logger.Error(err)
 		return
 	}
 
+	conn.SetReadLimit(maxMessageSize)
+
 	c := NewClient(conn)
+	c.encoding = encoding
 	c.SetCloseHandler(closeHandler(c))
 
 	go readHandler(c)
-	go pingHandler(c)
+	go writeHandler(c)
+
+	// private channels require the client to log in by signing this challenge
+	c.SendChallenge()
 }
 
 func readHandler(c *Client) {
@@ -65,15 +104,12 @@ func readHandler(c *Client) {
 			return
 		}
 
-		if msgType != 1 {
-			return
-		}
-
-		msg := types.WebsocketMessage{}
-		if err := json.Unmarshal(payload, &msg); err != nil {
+		// a bad message is answered with an error, it does not close the connection
+		msg, err := c.decodeMessage(msgType, payload)
+		if err != nil {
 			logger.Error(err)
-			c.SendMessage(msg.Channel, types.ERROR, err.Error())
-			return
+			c.SendError(msg.Channel, "", errors.InvalidMessage(err.Error()))
+			continue
 		}
 
 		// Only log WS messages in local environment
@@ -83,16 +119,13 @@ func readHandler(c *Client) {
 
 		logger.Infof("%v", msg.String())
 
-		if socketChannels[msg.Channel] == nil {
-			c.SendMessage(msg.Channel, types.ERROR, "INVALID_CHANNEL")
-			return
-		}
-
-		go socketChannels[msg.Channel](msg.Event, c)
+		go handleMessage(msg, c)
 	}
 }
 
-func pingHandler(c *Client) {
+// writeHandler writes the queued messages and the pings of a connection, it is the only
+// goroutine writing data frames to the connection
+func writeHandler(c *Client) {
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
 		logger.Info("Closing connection")
@@ -102,15 +135,54 @@ func pingHandler(c *Client) {
 
 	for {
 		select {
+		case m := <-c.send:
+			c.SetWriteDeadline(time.Now().Add(writeWait))
+			err := c.writeEncoded(m)
+			if err != nil {
+				logger.Info("writeMessage closing connection:", err)
+				return
+			}
 		case <-ticker.C:
 			err := c.SendPingMessage()
 			if err != nil {
 				logger.Error(err)
 				return
 			}
+		case <-c.done:
+			return
 		}
 	}
+}
+
+// decodeMessage decodes a client message, text frames are always JSON and binary frames are
+// accepted from the MessagePack connections
+func (c *Client) decodeMessage(msgType int, payload []byte) (types.WebsocketMessage, error) {
+	msg := types.WebsocketMessage{}
+
+	switch {
+	case msgType == websocket.TextMessage:
+		err := json.Unmarshal(payload, &msg)
+		return msg, err
+	case msgType == websocket.BinaryMessage && c.encoding == EncodingMsgpack:
+		err := msgpack.Unmarshal(payload, &msg)
+		return msg, err
+	default:
+		return msg, errors.New("binary messages require the msgpack encoding")
+	}
+}
+
+// writeEncoded writes a message in the encoding of the connection
+func (c *Client) writeEncoded(m types.WebsocketMessage) error {
+	if c.encoding != EncodingMsgpack {
+		return c.WriteJSON(m)
+	}
+
+	b, err := msgpack.Marshal(m)
+	if err != nil {
+		return err
+	}
 
+	return c.WriteMessage(websocket.BinaryMessage, b)
 }
 
 func closeHandler(c *Client) func(code int, text string) error {
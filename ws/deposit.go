@@ -49,7 +49,11 @@ func DepositSocketUnsubscribeHandler(a common.Address) func(client *Client) {
 
 // RegisterDepositConnection registers a connection with and depositID.
 // It is called whenever a message is recieved over deposit channel
-func RegisterDepositConnection(a common.Address, c *Client) {
+func RegisterDepositConnection(a common.Address, c *Client) error {
+	if err := c.Authorize(DepositChannel, a); err != nil {
+		return err
+	}
+
 	logger.Info("Registering new deposit connection")
 
 	if depositConnections == nil {
@@ -71,9 +75,19 @@ func RegisterDepositConnection(a common.Address, c *Client) {
 			logger.Info("Number of connections for this address: %v", len(depositConnections))
 		}
 	}
+
+	return nil
 }
 
 func SendDepositMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
+	if relayed(DepositChannel, a.Hex(), msgType, payload) {
+		return
+	}
+
+	deliverDepositMessage(msgType, a, payload)
+}
+
+func deliverDepositMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
 	conn := GetDepositConnections(a)
 	if conn == nil {
 		logger.Infof("No connection found")
@@ -0,0 +1,139 @@
+package ws
+
+import (
+	"sync"
+
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+var l3OrderBookSocket *L3OrderBookSocket
+
+// L3OrderBookSocket holds the map of subscriptions subscribed to the per order orderbook channels
+// corresponding to the key/event they have subscribed to.
+type L3OrderBookSocket struct {
+	subscriptions     map[string]map[*Client]bool
+	subscriptionsList map[*Client][]string
+	subsMutex         sync.RWMutex
+	subsListMutex     sync.RWMutex
+}
+
+func NewL3OrderBookSocket() *L3OrderBookSocket {
+	return &L3OrderBookSocket{
+		subscriptions:     make(map[string]map[*Client]bool),
+		subscriptionsList: make(map[*Client][]string),
+	}
+}
+
+// GetL3OrderBookSocket return singleton instance of L3OrderBookSocket type struct
+func GetL3OrderBookSocket() *L3OrderBookSocket {
+	if l3OrderBookSocket == nil {
+		l3OrderBookSocket = NewL3OrderBookSocket()
+	}
+
+	return l3OrderBookSocket
+}
+
+// Subscribe handles the subscription of connection to get
+// streaming data over the socker for any pair.
+// pair := utils.GetPairKey(bt, qt)
+func (s *L3OrderBookSocket) Subscribe(channelID string, c *Client) error {
+	s.subsMutex.Lock()
+	s.subsListMutex.Lock()
+	defer s.subsMutex.Unlock()
+	defer s.subsListMutex.Unlock()
+
+	if c == nil {
+		return errors.New("No connection found")
+	}
+
+	if s.subscriptions[channelID] == nil {
+		s.subscriptions[channelID] = make(map[*Client]bool)
+	}
+
+	s.subscriptions[channelID][c] = true
+
+	if s.subscriptionsList[c] == nil {
+		s.subscriptionsList[c] = []string{}
+	}
+
+	s.subscriptionsList[c] = append(s.subscriptionsList[c], channelID)
+
+	return nil
+}
+
+// UnsubscribeHandler unsubscribes a connection from a certain l3 orderbook channel id
+func (s *L3OrderBookSocket) UnsubscribeChannelHandler(channelID string) func(c *Client) {
+	return func(c *Client) {
+		s.UnsubscribeChannel(channelID, c)
+	}
+}
+
+func (s *L3OrderBookSocket) UnsubscribeHandler() func(c *Client) {
+	return func(c *Client) {
+		s.Unsubscribe(c)
+	}
+}
+
+// UnsubscribeChannel removes a websocket connection from the l3 orderbook channel updates
+func (s *L3OrderBookSocket) UnsubscribeChannel(channelID string, c *Client) {
+	s.subsMutex.Lock()
+	defer s.subsMutex.Unlock()
+	if s.subscriptions[channelID][c] {
+		s.subscriptions[channelID][c] = false
+		delete(s.subscriptions[channelID], c)
+	}
+}
+
+func (s *L3OrderBookSocket) Unsubscribe(c *Client) {
+	s.subsListMutex.RLock()
+	defer s.subsListMutex.RUnlock()
+	channelIDs := s.subscriptionsList[c]
+	if channelIDs == nil {
+		return
+	}
+
+	for _, id := range s.subscriptionsList[c] {
+		s.UnsubscribeChannel(id, c)
+	}
+}
+
+// HasSubscriptions returns true if at least one connection is subscribed to the channel
+func (s *L3OrderBookSocket) HasSubscriptions(channelID string) bool {
+	s.subsMutex.RLock()
+	defer s.subsMutex.RUnlock()
+	return len(s.subscriptions[channelID]) > 0
+}
+
+// BroadcastMessage streams message to all the subscribtions subscribed to the pair
+func (s *L3OrderBookSocket) BroadcastMessage(channelID string, p interface{}) error {
+	s.subsMutex.RLock()
+	defer s.subsMutex.RUnlock()
+	for c, status := range s.subscriptions[channelID] {
+		if status {
+			s.SendUpdateMessage(c, p)
+		}
+	}
+
+	return nil
+}
+
+// SendMessage sends a websocket message on the l3 orderbook channel
+func (s *L3OrderBookSocket) SendMessage(c *Client, msgType types.SubscriptionEvent, p interface{}) {
+	c.SendMessage(L3OrderBookChannel, msgType, p)
+}
+
+// SendInitMessage sends INIT message on l3 orderbook channel on subscription event
+func (s *L3OrderBookSocket) SendInitMessage(c *Client, data interface{}) {
+	c.SendMessage(L3OrderBookChannel, types.INIT, data)
+}
+
+// SendUpdateMessage sends UPDATE message on l3 orderbook channel as new data is created
+func (s *L3OrderBookSocket) SendUpdateMessage(c *Client, data interface{}) {
+	c.SendMessage(L3OrderBookChannel, types.UPDATE, data)
+}
+
+// SendErrorMessage sends error message on l3 orderbook channel
+func (s *L3OrderBookSocket) SendErrorMessage(c *Client, data interface{}) {
+	c.SendMessage(L3OrderBookChannel, types.ERROR, data)
+}
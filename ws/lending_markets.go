@@ -106,6 +106,15 @@ func (s *LendingMarketsSocket) getSubscriptions() map[string]map[*Client]bool {
 
 // BroadcastMessage streams message to all the subscriptions subscribed to the pair
 func (s *LendingMarketsSocket) BroadcastMessage(channelID string, p interface{}) error {
+	if relayed(LendingMarketsChannel, channelID, types.UPDATE, p) {
+		return nil
+	}
+
+	return s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the message to the connections of this instance subscribed to the channel
+func (s *LendingMarketsSocket) DeliverMessage(channelID string, p interface{}) error {
 	subs := s.getSubscriptions()
 	for c, status := range subs[channelID] {
 		if status {
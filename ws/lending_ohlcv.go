@@ -100,8 +100,24 @@ func (s *LendingOhlcvSocket) Unsubscribe(c *Client) {
 	}
 }
 
+// HasSubscriptions returns true if at least one connection is subscribed to the channel
+func (s *LendingOhlcvSocket) HasSubscriptions(channelID string) bool {
+	s.subsMutex.RLock()
+	defer s.subsMutex.RUnlock()
+	return len(s.subscriptions[channelID]) > 0
+}
+
 // BroadcastLendingOhlcv Message streams message to all the subscriptions subscribed to the pair
 func (s *LendingOhlcvSocket) BroadcastLendingOhlcv(channelID string, p interface{}) error {
+	if relayed(LendingOhlcvChannel, channelID, types.UPDATE, p) {
+		return nil
+	}
+
+	return s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the message to the connections of this instance subscribed to the channel
+func (s *LendingOhlcvSocket) DeliverMessage(channelID string, p interface{}) error {
 	s.subsMutex.RLock()
 	defer s.subsMutex.RUnlock()
 	for c, status := range s.subscriptions[channelID] {
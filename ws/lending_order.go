@@ -84,6 +84,14 @@ func RegisterLendingOrderConnection(a common.Address, c *Client) {
 
 // SendLendingOrderMessage send lending order message
 func SendLendingOrderMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
+	if relayed(LendingOrderChannel, a.Hex(), msgType, payload) {
+		return
+	}
+
+	deliverLendingOrderMessage(msgType, a, payload)
+}
+
+func deliverLendingOrderMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
 	conn := GetLendingOrderConnections(a)
 	if conn == nil {
 		return
@@ -107,8 +107,24 @@ func (s *LendingOrderBookSocket) getSubscriptions() map[string]map[*Client]bool
 	return s.subscriptions
 }
 
+// HasSubscriptions returns true if at least one connection is subscribed to the channel
+func (s *LendingOrderBookSocket) HasSubscriptions(channelID string) bool {
+	s.subsMutex.RLock()
+	defer s.subsMutex.RUnlock()
+	return len(s.subscriptions[channelID]) > 0
+}
+
 // BroadcastMessage streams message to all the subscribtions subscribed to the pair
 func (s *LendingOrderBookSocket) BroadcastMessage(channelID string, p interface{}) error {
+	if relayed(LendingOrderBookChannel, channelID, types.UPDATE, p) {
+		return nil
+	}
+
+	return s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the message to the connections of this instance subscribed to the channel
+func (s *LendingOrderBookSocket) DeliverMessage(channelID string, p interface{}) error {
 	subs := s.getSubscriptions()
 	for c, status := range subs[channelID] {
 		if status {
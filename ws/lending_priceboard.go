@@ -102,6 +102,15 @@ func (s *LendingPriceBoardSocket) getSubscriptions() map[string]map[*Client]bool
 
 // BroadcastMessage streams message to all the subscriptions subscribed to the pair
 func (s *LendingPriceBoardSocket) BroadcastMessage(channelID string, p interface{}) error {
+	if relayed(LendingPriceBoardChannel, channelID, types.UPDATE, p) {
+		return nil
+	}
+
+	return s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the message to the connections of this instance subscribed to the channel
+func (s *LendingPriceBoardSocket) DeliverMessage(channelID string, p interface{}) error {
 	subs := s.getSubscriptions()
 	for c, status := range subs[channelID] {
 		if status {
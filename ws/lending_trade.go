@@ -105,6 +105,15 @@ func (s *LendingTradeSocket) getSubscriptions() map[string]map[*Client]bool {
 
 // BroadcastMessage broadcasts trade message to all subscribed sockets
 func (s *LendingTradeSocket) BroadcastMessage(channelID string, p interface{}) {
+	if relayed(LendingTradeChannel, channelID, types.UPDATE, p) {
+		return
+	}
+
+	s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the message to the connections of this instance subscribed to the channel
+func (s *LendingTradeSocket) DeliverMessage(channelID string, p interface{}) {
 	go func() {
 		subs := s.getSubscriptions()
 		for conn, active := range subs[channelID] {
@@ -83,6 +83,14 @@ func RegisterNotificationConnection(a common.Address, c *Client) {
 }
 
 func SendNotificationMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
+	if relayed(NotificationChannel, a.Hex(), msgType, payload) {
+		return
+	}
+
+	deliverNotificationMessage(msgType, a, payload)
+}
+
+func deliverNotificationMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
 	conn := GetNotificationConnections(a)
 	if conn == nil {
 		return
@@ -98,8 +98,24 @@ func (s *OHLCVSocket) Unsubscribe(c *Client) {
 	}
 }
 
+// HasSubscriptions returns true if at least one connection is subscribed to the channel
+func (s *OHLCVSocket) HasSubscriptions(channelID string) bool {
+	s.subsMutex.RLock()
+	defer s.subsMutex.RUnlock()
+	return len(s.subscriptions[channelID]) > 0
+}
+
 // BroadcastOHLCV Message streams message to all the subscriptions subscribed to the pair
 func (s *OHLCVSocket) BroadcastOHLCV(channelID string, p interface{}) error {
+	if relayed(OHLCVChannel, channelID, types.UPDATE, p) {
+		return nil
+	}
+
+	return s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the message to the connections of this instance subscribed to the channel
+func (s *OHLCVSocket) DeliverMessage(channelID string, p interface{}) error {
 	s.subsMutex.RLock()
 	defer s.subsMutex.RUnlock()
 	for c, status := range s.subscriptions[channelID] {
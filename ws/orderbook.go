@@ -104,8 +104,24 @@ func (s *OrderBookSocket) getSubscriptions() map[string]map[*Client]bool {
 	return s.subscriptions
 }
 
+// HasSubscriptions returns true if at least one connection is subscribed to the channel
+func (s *OrderBookSocket) HasSubscriptions(channelID string) bool {
+	s.subsMutex.RLock()
+	defer s.subsMutex.RUnlock()
+	return len(s.subscriptions[channelID]) > 0
+}
+
 // BroadcastMessage streams message to all the subscribtions subscribed to the pair
 func (s *OrderBookSocket) BroadcastMessage(channelID string, p interface{}) error {
+	if relayed(OrderBookChannel, channelID, types.UPDATE, p) {
+		return nil
+	}
+
+	return s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the message to the connections of this instance subscribed to the channel
+func (s *OrderBookSocket) DeliverMessage(channelID string, p interface{}) error {
 	subs := s.getSubscriptions()
 	for c, status := range subs[channelID] {
 		if status {
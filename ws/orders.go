@@ -83,6 +83,14 @@ func RegisterOrderConnection(a common.Address, c *Client) {
 }
 
 func SendOrderMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
+	if relayed(OrderChannel, a.Hex(), msgType, payload) {
+		return
+	}
+
+	deliverOrderMessage(msgType, a, payload)
+}
+
+func deliverOrderMessage(msgType types.SubscriptionEvent, a common.Address, payload interface{}) {
 	conn := GetOrderConnections(a)
 	if conn == nil {
 		return
@@ -0,0 +1,121 @@
+package ws
+
+import (
+	"sync"
+
+	"github.com/tomochain/tomox-sdk/errors"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+var preferencesSocket *PreferencesSocket
+
+// PreferencesSocket holds the map of connections subscribed to the preferences of an address,
+// the channel id is the hex address. The devices of a user are notified of the preferences saved
+// by the others.
+type PreferencesSocket struct {
+	subscriptions     map[string]map[*Client]bool
+	subscriptionsList map[*Client][]string
+	subsMutex         sync.RWMutex
+	subsListMutex     sync.RWMutex
+}
+
+func NewPreferencesSocket() *PreferencesSocket {
+	return &PreferencesSocket{
+		subscriptions:     make(map[string]map[*Client]bool),
+		subscriptionsList: make(map[*Client][]string),
+	}
+}
+
+// GetPreferencesSocket return singleton instance of PreferencesSocket type struct
+func GetPreferencesSocket() *PreferencesSocket {
+	if preferencesSocket == nil {
+		preferencesSocket = NewPreferencesSocket()
+	}
+
+	return preferencesSocket
+}
+
+// Subscribe registers a new websocket connection to the preferences updates of an address
+func (s *PreferencesSocket) Subscribe(channelID string, c *Client) error {
+	s.subsMutex.Lock()
+	s.subsListMutex.Lock()
+	defer s.subsMutex.Unlock()
+	defer s.subsListMutex.Unlock()
+
+	if c == nil {
+		return errors.New("No connection found")
+	}
+
+	if s.subscriptions[channelID] == nil {
+		s.subscriptions[channelID] = make(map[*Client]bool)
+	}
+
+	s.subscriptions[channelID][c] = true
+
+	if s.subscriptionsList[c] == nil {
+		s.subscriptionsList[c] = []string{}
+	}
+
+	s.subscriptionsList[c] = append(s.subscriptionsList[c], channelID)
+	return nil
+}
+
+// UnsubscribeChannelHandler unsubscribes a connection from the preferences of an address
+func (s *PreferencesSocket) UnsubscribeChannelHandler(channelID string) func(c *Client) {
+	return func(c *Client) {
+		s.UnsubscribeChannel(channelID, c)
+	}
+}
+
+// UnsubscribeChannel removes a websocket connection from the preferences updates of an address
+func (s *PreferencesSocket) UnsubscribeChannel(channelID string, c *Client) {
+	s.subsMutex.Lock()
+	defer s.subsMutex.Unlock()
+
+	delete(s.subscriptions[channelID], c)
+	if len(s.subscriptions[channelID]) == 0 {
+		delete(s.subscriptions, channelID)
+	}
+}
+
+// Unsubscribe removes a websocket connection from all the preferences updates
+func (s *PreferencesSocket) Unsubscribe(c *Client) {
+	s.subsListMutex.Lock()
+	channelIDs := s.subscriptionsList[c]
+	delete(s.subscriptionsList, c)
+	s.subsListMutex.Unlock()
+
+	for _, id := range channelIDs {
+		s.UnsubscribeChannel(id, c)
+	}
+}
+
+// BroadcastMessage sends the preferences to the connections subscribed to the address, on every
+// instance of the cluster
+func (s *PreferencesSocket) BroadcastMessage(channelID string, p interface{}) {
+	if relayed(PreferencesChannel, channelID, types.UPDATE, p) {
+		return
+	}
+
+	s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the preferences to the connections of this instance subscribed to the address
+func (s *PreferencesSocket) DeliverMessage(channelID string, p interface{}) {
+	s.subsMutex.RLock()
+	defer s.subsMutex.RUnlock()
+
+	for c := range s.subscriptions[channelID] {
+		s.SendUpdateMessage(c, p)
+	}
+}
+
+// SendInitMessage sends the preferences of an address on subscription
+func (s *PreferencesSocket) SendInitMessage(c *Client, p interface{}) {
+	c.SendMessage(PreferencesChannel, types.INIT, p)
+}
+
+// SendUpdateMessage sends the saved preferences of an address
+func (s *PreferencesSocket) SendUpdateMessage(c *Client, p interface{}) {
+	c.SendMessage(PreferencesChannel, types.UPDATE, p)
+}
@@ -103,6 +103,15 @@ func (s *PriceBoardSocket) getSubscriptions() map[string]map[*Client]bool {
 
 // BroadcastMessage streams message to all the subscriptions subscribed to the pair
 func (s *PriceBoardSocket) BroadcastMessage(channelID string, p interface{}) error {
+	if relayed(PriceBoardChannel, channelID, types.UPDATE, p) {
+		return nil
+	}
+
+	return s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the message to the connections of this instance subscribed to the channel
+func (s *PriceBoardSocket) DeliverMessage(channelID string, p interface{}) error {
 	subs := s.getSubscriptions()
 	for c, status := range subs[channelID] {
 		if status {
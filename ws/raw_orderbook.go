@@ -90,6 +90,15 @@ func (s *RawOrderBookSocket) Unsubscribe(c *Client) {
 
 // BroadcastMessage streams message to all the subscribtions subscribed to the pair
 func (s *RawOrderBookSocket) BroadcastMessage(channelID string, p interface{}) error {
+	if relayed(RawOrderBookChannel, channelID, types.UPDATE, p) {
+		return nil
+	}
+
+	return s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the message to the connections of this instance subscribed to the channel
+func (s *RawOrderBookSocket) DeliverMessage(channelID string, p interface{}) error {
 	for c, status := range s.subscriptions[channelID] {
 		if status {
 			s.SendUpdateMessage(c, p)
@@ -0,0 +1,110 @@
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tomochain/tomox-sdk/types"
+)
+
+var (
+	relayPublisher func(*types.RelayMessage) error
+	relayMutex     sync.RWMutex
+)
+
+// EnableRelay routes the broadcasts of the sockets and the messages of the private channels through
+// publish so that they reach the clients connected to every instance of a cluster. The messages
+// published by the instances, including this one, must be passed to HandleRelayMessage.
+func EnableRelay(publish func(*types.RelayMessage) error) {
+	relayMutex.Lock()
+	defer relayMutex.Unlock()
+	relayPublisher = publish
+}
+
+// relayEnabled returns true if the messages are relayed to the instances of a cluster
+func relayEnabled() bool {
+	relayMutex.RLock()
+	defer relayMutex.RUnlock()
+	return relayPublisher != nil
+}
+
+// relayed publishes a message to the cluster and returns true when the relay is enabled,
+// otherwise the message has to be delivered to the local connections
+func relayed(channel, channelID string, msgType types.SubscriptionEvent, p interface{}) bool {
+	relayMutex.RLock()
+	publish := relayPublisher
+	relayMutex.RUnlock()
+
+	if publish == nil {
+		return false
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		logger.Error(err)
+		return false
+	}
+
+	m := &types.RelayMessage{
+		Channel:   channel,
+		ChannelID: channelID,
+		Type:      msgType,
+		Payload:   json.RawMessage(b),
+	}
+
+	// the message is delivered locally if it can not be published
+	if err := publish(m); err != nil {
+		logger.Error(err)
+		return false
+	}
+
+	return true
+}
+
+// HandleRelayMessage delivers a message published by an instance of the cluster to the
+// connections of this instance. The payload is forwarded as is to the clients.
+func HandleRelayMessage(m *types.RelayMessage) error {
+	p := m.Payload
+
+	switch m.Channel {
+	case TradeChannel:
+		GetTradeSocket().DeliverMessage(m.ChannelID, p)
+	case RawOrderBookChannel:
+		GetRawOrderBookSocket().DeliverMessage(m.ChannelID, p)
+	case OrderBookChannel:
+		GetOrderBookSocket().DeliverMessage(m.ChannelID, p)
+	case OHLCVChannel:
+		GetOHLCVSocket().DeliverMessage(m.ChannelID, p)
+	case PriceBoardChannel:
+		GetPriceBoardSocket().DeliverMessage(m.ChannelID, p)
+	case MarketsChannel:
+		GetMarketSocket().DeliverMessage(m.ChannelID, p)
+	case LendingTradeChannel:
+		GetLendingTradeSocket().DeliverMessage(m.ChannelID, p)
+	case LendingOrderBookChannel:
+		GetLendingOrderBookSocket().DeliverMessage(m.ChannelID, p)
+	case LendingOhlcvChannel:
+		GetLendingOhlcvSocket().DeliverMessage(m.ChannelID, p)
+	case LendingMarketsChannel:
+		GetLendingMarketSocket().DeliverMessage(m.ChannelID, p)
+	case LendingPriceBoardChannel:
+		GetLendingPriceBoardSocket().DeliverMessage(m.ChannelID, p)
+	case OrderChannel:
+		deliverOrderMessage(m.Type, common.HexToAddress(m.ChannelID), p)
+	case LendingOrderChannel:
+		deliverLendingOrderMessage(m.Type, common.HexToAddress(m.ChannelID), p)
+	case NotificationChannel:
+		deliverNotificationMessage(m.Type, common.HexToAddress(m.ChannelID), p)
+	case DepositChannel:
+		deliverDepositMessage(m.Type, common.HexToAddress(m.ChannelID), p)
+	case BalanceChannel:
+		GetBalanceSocket().DeliverMessage(m.ChannelID, p)
+	case PreferencesChannel:
+		GetPreferencesSocket().DeliverMessage(m.ChannelID, p)
+	default:
+		logger.Warning("Unknown relay channel ", m.Channel)
+	}
+
+	return nil
+}
@@ -101,6 +101,15 @@ func (s *TradeSocket) getSubscriptions() map[string]map[*Client]bool {
 
 // BroadcastMessage broadcasts trade message to all subscribed sockets
 func (s *TradeSocket) BroadcastMessage(channelID string, p interface{}) {
+	if relayed(TradeChannel, channelID, types.UPDATE, p) {
+		return
+	}
+
+	s.DeliverMessage(channelID, p)
+}
+
+// DeliverMessage sends the message to the connections of this instance subscribed to the channel
+func (s *TradeSocket) DeliverMessage(channelID string, p interface{}) {
 	go func() {
 		subs := s.getSubscriptions()
 		for conn, active := range subs[channelID] {